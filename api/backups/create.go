@@ -10,10 +10,18 @@ import (
 )
 
 // Create sends a request to create a backup of juju's state.  It
-// returns the metadata associated with the resulting backup.
-func (c *Client) Create(notes string) (*params.BackupsMetadataResult, error) {
+// returns the metadata associated with the resulting backup. If
+// encryptionKey is not empty, the backup is encrypted with it rather
+// than with the controller's default key.
+func (c *Client) Create(notes, encryptionKey string) (*params.BackupsMetadataResult, error) {
+	if encryptionKey != "" && c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("encrypting backups with this version of Juju")
+	}
 	var result params.BackupsMetadataResult
-	args := params.BackupsCreateArgs{Notes: notes}
+	args := params.BackupsCreateArgs{
+		Notes:         notes,
+		EncryptionKey: encryptionKey,
+	}
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
 		return nil, errors.Trace(err)
 	}
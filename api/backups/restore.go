@@ -67,8 +67,12 @@ func prepareRestore(newClient ClientConnection) error {
 	return errors.Annotatef(err, "could not start restore process: %v", remoteError)
 }
 
-// RestoreReader restores the contents of backupFile as backup.
-func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient ClientConnection) error {
+// RestoreReader restores the contents of backupFile as backup. The
+// decryption key is needed if the backup is encrypted.
+func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, decryptionKey string, newClient ClientConnection) error {
+	if err := c.checkDecryptionKey(decryptionKey); err != nil {
+		return errors.Trace(err)
+	}
 	if err := prepareRestore(newClient); err != nil {
 		return errors.Trace(err)
 	}
@@ -81,16 +85,29 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 		logger.Errorf("could not clean up after failed backup upload: %v", finishErr)
 		return errors.Annotatef(err, "cannot upload backup file")
 	}
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, decryptionKey, newClient)
 }
 
 // Restore performs restore using a backup id corresponding to a backup stored in the server.
-func (c *Client) Restore(backupId string, newClient ClientConnection) error {
+// The decryption key is needed if the backup is encrypted.
+func (c *Client) Restore(backupId, decryptionKey string, newClient ClientConnection) error {
+	if err := c.checkDecryptionKey(decryptionKey); err != nil {
+		return errors.Trace(err)
+	}
 	if err := prepareRestore(newClient); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Debugf("Server in 'about to restore' mode")
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, decryptionKey, newClient)
+}
+
+// checkDecryptionKey ensures that the server can decrypt backups, if
+// a decryption key is given.
+func (c *Client) checkDecryptionKey(decryptionKey string) error {
+	if decryptionKey != "" && c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("restoring encrypted backups with this version of Juju")
+	}
+	return nil
 }
 
 func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, error) {
@@ -103,15 +120,17 @@ func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, erro
 // restore is responsible for triggering the whole restore process in a remote
 // machine. The backup information for the process should already be in the
 // server and loaded in the backup storage under the backupId id.
-// It takes backupId as the identifier for the remote backup file and a
-// client connection factory newClient (newClient should no longer be
+// It takes backupId as the identifier for the remote backup file, the
+// key with which to decrypt it if it is encrypted, and a client
+// connection factory newClient (newClient should no longer be
 // necessary when lp:1399722 is sorted out).
-func (c *Client) restore(backupId string, newClient ClientConnection) error {
+func (c *Client) restore(backupId, decryptionKey string, newClient ClientConnection) error {
 	var err, remoteError error
 
 	// Restore
 	restoreArgs := params.RestoreArgs{
-		BackupId: backupId,
+		BackupId:      backupId,
+		DecryptionKey: decryptionKey,
 	}
 
 	cleanExit := false
@@ -0,0 +1,28 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Verify asks the controller to check that the stored backup is
+// complete and restorable. The decryption key is only needed to verify
+// the contents of encrypted backups.
+func (c *Client) Verify(id, decryptionKey string) (*params.BackupsVerifyResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("verifying backups with this version of Juju")
+	}
+	var result params.BackupsVerifyResult
+	args := params.BackupsVerifyArgs{
+		ID:            id,
+		DecryptionKey: decryptionKey,
+	}
+	if err := c.facade.FacadeCall("Verify", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
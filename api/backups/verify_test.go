@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type verifySuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) TestVerify(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Verify")
+
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsVerifyArgs{})
+			p := paramsIn.(params.BackupsVerifyArgs)
+			c.Check(p.ID, gc.Equals, "spam")
+			c.Check(p.DecryptionKey, gc.Equals, "secret:<key>")
+
+			if result, ok := resp.(*params.BackupsVerifyResult); ok {
+				result.ID = p.ID
+				result.Problems = []string{"archive has no juju-backup/root.tar"}
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.Verify("spam", "secret:<key>")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, &params.BackupsVerifyResult{
+		ID:       "spam",
+		Problems: []string{"archive has no juju-backup/root.tar"},
+	})
+}
@@ -53,6 +53,18 @@ func (APICallerFunc) ConnectControllerStream(path string, attrs url.Values, head
 	return nil, errors.NotImplementedf("controller stream connection")
 }
 
+// BestVersionCaller is an APICallerFunc that reports a particular
+// best facade version, for testing version-dependent client behaviour.
+type BestVersionCaller struct {
+	APICallerFunc
+	BestVersion int
+}
+
+// BestFacadeVersion is part of the base.APICaller interface.
+func (c BestVersionCaller) BestFacadeVersion(facade string) int {
+	return c.BestVersion
+}
+
 // CheckArgs holds the possible arguments to CheckingAPICaller(). Any
 // fields non empty fields will be checked to match the arguments
 // recieved by the APICall() method of the returned APICallerFunc. If
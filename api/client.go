@@ -48,6 +48,34 @@ func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
 	return &result, nil
 }
 
+// FilteredStatus returns the status of the juju model, limited to the
+// entities matching the patterns and any of the status filter
+// expressions, such as "workload=blocked|error,agent=idle".
+func (c *Client) FilteredStatus(patterns, filters []string) (*params.FullStatus, error) {
+	if len(filters) > 0 && c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("status filters with this version of Juju")
+	}
+	var result params.FullStatus
+	p := params.StatusParams{Patterns: patterns, Filters: filters}
+	if err := c.facade.FacadeCall("FullStatus", p, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ModelStatusHistory returns the status history of the units and
+// machines in the model selected by the request, oldest entry first.
+func (c *Client) ModelStatusHistory(args params.ModelStatusHistoryRequest) ([]params.ModelStatusHistoryEntry, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("model status history with this version of Juju")
+	}
+	var result params.ModelStatusHistoryResult
+	if err := c.facade.FacadeCall("ModelStatusHistory", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Statuses, nil
+}
+
 // StatusHistory retrieves the last <size> results of
 // <kind:combined|agent|workload|machine|machineinstance|container|containerinstance> status
 // for <name> unit
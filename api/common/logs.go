@@ -34,6 +34,14 @@ type DebugLogParams struct {
 	// ExcludeModule lists logging modules to exclude from the resposne. If a
 	// module is specified, all the submodules are also excluded.
 	ExcludeModule []string
+	// IncludeMessage lists regular expressions matched against log
+	// messages. If any are set, only messages matching at least one
+	// of them are included in the response.
+	IncludeMessage []string
+	// ExcludeMessage lists regular expressions matched against log
+	// messages. Messages matching any of them are excluded from the
+	// response.
+	ExcludeMessage []string
 	// Limit defines the maximum number of lines to return. Once this many
 	// have been sent, the socket is closed.  If zero, all filtered lines are
 	// sent down the connection until the client closes the connection.
@@ -53,6 +61,10 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, means only records with a log time before
+	// EndTime will be returned. The server does not wait for new
+	// logs when EndTime is set.
+	EndTime time.Time
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -62,6 +74,12 @@ func (args DebugLogParams) URLQuery() url.Values {
 		"excludeEntity": args.ExcludeEntity,
 		"excludeModule": args.ExcludeModule,
 	}
+	if len(args.IncludeMessage) > 0 {
+		attrs["includeMessage"] = args.IncludeMessage
+	}
+	if len(args.ExcludeMessage) > 0 {
+		attrs["excludeMessage"] = args.ExcludeMessage
+	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
 	}
@@ -80,6 +98,9 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
 	return attrs
 }
 
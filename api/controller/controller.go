@@ -194,6 +194,46 @@ func (c *Client) modifyControllerUser(action params.ControllerAction, user, acce
 	return result.Combine()
 }
 
+// GrantControllerToGroup grants a local group of users access to the
+// controller.
+func (c *Client) GrantControllerToGroup(group, access string) error {
+	return c.modifyControllerGroup(params.GrantControllerAccess, group, access)
+}
+
+// RevokeControllerFromGroup revokes a local group's access to the
+// controller.
+func (c *Client) RevokeControllerFromGroup(group, access string) error {
+	return c.modifyControllerGroup(params.RevokeControllerAccess, group, access)
+}
+
+func (c *Client) modifyControllerGroup(action params.ControllerAction, group, access string) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("group access with this version of Juju")
+	}
+	var args params.ModifyControllerAccessRequest
+
+	if !names.IsValidUserName(group) {
+		return errors.Errorf("invalid group name: %q", group)
+	}
+
+	args.Changes = []params.ModifyControllerAccess{{
+		Group:  group,
+		Action: action,
+		Access: access,
+	}}
+
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("ModifyControllerAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Changes) {
+		return errors.Errorf("expected %d results, got %d", len(args.Changes), len(result.Results))
+	}
+
+	return result.Combine()
+}
+
 // GetControllerAccess returns the access level the user has on the controller.
 func (c *Client) GetControllerAccess(user string) (permission.Access, error) {
 	if !names.IsValidUser(user) {
@@ -297,6 +337,21 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 	return result.MigrationId, nil
 }
 
+// AuditLog returns the entries in the controller's audit log matching
+// the query, oldest first. The controller limits the number of entries
+// returned by a single call; use the query's Offset to page through
+// the rest.
+func (c *Client) AuditLog(query params.AuditLogQuery) ([]params.AuditLogEntry, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("querying the audit log with this version of Juju")
+	}
+	var result params.AuditLogResults
+	if err := c.facade.FacadeCall("AuditLog", query, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
+
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
 	if len(macs) == 0 {
 		return "", nil
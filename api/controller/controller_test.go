@@ -229,3 +229,89 @@ func makeSpec() controller.MigrationSpec {
 func randomUUID() string {
 	return utils.MustNewUUID().String()
 }
+
+func (s *Suite) TestAuditLog(c *gc.C) {
+	var stub jujutesting.Stub
+	entries := []params.AuditLogEntry{{
+		ModelUUID:  randomUUID(),
+		OriginName: "user-bob",
+		Operation:  "ApplicationDestroy",
+	}}
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				stub.AddCall(objType+"."+request, arg)
+				*(result.(*params.AuditLogResults)) = params.AuditLogResults{Entries: entries}
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := controller.NewClient(apiCaller)
+
+	query := params.AuditLogQuery{OriginName: "user-bob", Limit: 10}
+	result, err := client.AuditLog(query)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, entries)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.AuditLog", []interface{}{query}},
+	})
+}
+
+func (s *Suite) TestAuditLogNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(
+			func(string, int, string, string, interface{}, interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.AuditLog(params.AuditLogQuery{})
+	c.Assert(err, gc.ErrorMatches, "querying the audit log with this version of Juju not supported")
+}
+
+func (s *Suite) TestGrantControllerToGroup(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				stub.AddCall(objType+"."+request, arg)
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.GrantControllerToGroup("ops", "add-model")
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.ModifyControllerAccess", []interface{}{params.ModifyControllerAccessRequest{
+			Changes: []params.ModifyControllerAccess{{
+				Group:  "ops",
+				Action: params.GrantControllerAccess,
+				Access: "add-model",
+			}},
+		}}},
+	})
+}
+
+func (s *Suite) TestGrantControllerToGroupNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(
+			func(string, int, string, string, interface{}, interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.GrantControllerToGroup("ops", "add-model")
+	c.Assert(err, gc.ErrorMatches, "group access with this version of Juju not supported")
+}
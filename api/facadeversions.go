@@ -12,6 +12,7 @@ package api
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
 	"Action":                       2,
+	"ActionPruner":                 1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
@@ -19,15 +20,15 @@ var facadeVersions = map[string]int{
 	"Annotations":                  2,
 	"Application":                  4,
 	"ApplicationScaler":            1,
-	"Backups":                      1,
+	"Backups":                      3,
 	"Block":                        2,
-	"Bundle":                       1,
+	"Bundle":                       2,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       1,
+	"Client":                       3,
 	"Cloud":                        1,
-	"Controller":                   3,
+	"Controller":                   5,
 	"CrossModelRelations":          1,
 	"Deployer":                     1,
 	"DiscoverSpaces":               2,
@@ -58,9 +59,9 @@ var facadeVersions = map[string]int{
 	"MigrationMaster":              1,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              2,
 	"ModelConfig":                  1,
-	"ModelManager":                 2,
+	"ModelManager":                 5,
 	"NotifyWatcher":                1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
@@ -85,9 +86,9 @@ var facadeVersions = map[string]int{
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       4,
+	"Uniter":                       5,
 	"Upgrader":                     1,
-	"UserManager":                  1,
+	"UserManager":                  3,
 	"VolumeAttachmentsWatcher":     2,
 }
 
@@ -49,6 +49,30 @@ func (s *LogReaderSuite) TestOpenFullConfig(c *gc.C) {
 	})
 }
 
+func (s *LogReaderSuite) TestOpenFilterConfig(c *gc.C) {
+	cUUID := "feebdaed-2f18-4fd2-967d-db9663db7bea"
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	stream := mockStream{stub: stub}
+	conn.ReturnConnectStream = stream
+	cfg := params.LogStreamConfig{
+		Sink:          "spam",
+		Level:         "INFO",
+		IncludeEntity: []string{"unit-mysql-*", "machine-0"},
+		ExcludeModule: []string{"juju.worker.uniter"},
+	}
+
+	_, err := logstream.Open(conn, cfg, cUUID)
+	c.Assert(err, gc.IsNil)
+
+	stub.CheckCall(c, 0, "ConnectStream", `/logstream`, url.Values{
+		"sink":          []string{"spam"},
+		"level":         []string{"INFO"},
+		"includeEntity": []string{"unit-mysql-*", "machine-0"},
+		"excludeModule": []string{"juju.worker.uniter"},
+	})
+}
+
 func (s *LogReaderSuite) TestOpenError(c *gc.C) {
 	cUUID := "feebdaed-2f18-4fd2-967d-db9663db7bea"
 	stub := &testing.Stub{}
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/watcher"
 )
@@ -148,6 +149,7 @@ func (c *Client) ModelInfo() (migration.ModelInfo, error) {
 		Owner:                  owner,
 		AgentVersion:           info.AgentVersion,
 		ControllerAgentVersion: info.ControllerAgentVersion,
+		Quotas:                 convertQuotas(info.Quotas),
 	}, nil
 }
 
@@ -188,6 +190,7 @@ func (c *Client) Export() (migration.SerializedModel, error) {
 		Charms:    serialized.Charms,
 		Tools:     tools,
 		Resources: resources,
+		Quotas:    convertQuotas(serialized.Quotas),
 	}, nil
 }
 
@@ -366,3 +369,16 @@ func convertResourceRevision(app, name string, rev params.SerializedModelResourc
 		Timestamp:     rev.Timestamp,
 	}, nil
 }
+
+func convertQuotas(in *params.ModelQuotas) quota.Resources {
+	if in == nil {
+		return quota.Resources{}
+	}
+	return quota.Resources{
+		Machines: in.Machines,
+		Units:    in.Units,
+		Cores:    in.Cores,
+		Memory:   in.Memory,
+		Storage:  in.Storage,
+	}
+}
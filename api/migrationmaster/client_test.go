@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/api/migrationmaster"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/watcher"
 )
@@ -180,6 +181,7 @@ func (s *ClientSuite) TestModelInfo(c *gc.C) {
 			OwnerTag:               owner.String(),
 			AgentVersion:           version.MustParse("1.2.3"),
 			ControllerAgentVersion: version.MustParse("1.2.4"),
+			Quotas:                 &params.ModelQuotas{Machines: 5, Memory: 8192},
 		}
 		return nil
 	})
@@ -195,6 +197,7 @@ func (s *ClientSuite) TestModelInfo(c *gc.C) {
 		Owner:                  owner,
 		AgentVersion:           version.MustParse("1.2.3"),
 		ControllerAgentVersion: version.MustParse("1.2.4"),
+		Quotas:                 quota.Resources{Machines: 5, Memory: 8192},
 	})
 }
 
@@ -273,6 +276,7 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 					},
 				},
 			}},
+			Quotas: &params.ModelQuotas{Units: 10},
 		}
 		return nil
 	})
@@ -343,6 +347,7 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 				},
 			},
 		}},
+		Quotas: quota.Resources{Units: 10},
 	})
 }
 
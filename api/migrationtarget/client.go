@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/tools"
 	jujuversion "github.com/juju/juju/version"
@@ -42,7 +43,13 @@ type Client struct {
 	httpClientFactory func() (*httprequest.Client, error)
 }
 
+// Prechecks checks that the target controller is ready to accept
+// the model. It fails without asking the target controller if the
+// model has quotas and the controller cannot hold them.
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
+	if model.Quotas != (quota.Resources{}) && c.caller.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("migrating a model with quotas to this controller")
+	}
 	args := params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
@@ -50,6 +57,9 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
 	}
+	if model.Quotas != (quota.Resources{}) {
+		args.Quotas = paramsModelQuotas(model.Quotas)
+	}
 	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
@@ -60,6 +70,19 @@ func (c *Client) Import(bytes []byte) error {
 	return c.caller.FacadeCall("Import", serialized, nil)
 }
 
+// SetQuotas sets the quotas on the resources that a previously
+// imported model may use.
+func (c *Client) SetQuotas(modelUUID string, quotas quota.Resources) error {
+	if c.caller.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("model quotas with this version of Juju")
+	}
+	args := params.SetModelQuotas{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		Quotas:   *paramsModelQuotas(quotas),
+	}
+	return c.caller.FacadeCall("SetQuotas", args, nil)
+}
+
 // Abort removes all data relating to a previously imported model.
 func (c *Client) Abort(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
@@ -220,3 +243,13 @@ func (c *Client) AdoptResources(modelUUID string) error {
 	}
 	return errors.Trace(c.caller.FacadeCall("AdoptResources", args, nil))
 }
+
+func paramsModelQuotas(r quota.Resources) *params.ModelQuotas {
+	return &params.ModelQuotas{
+		Machines: r.Machines,
+		Units:    r.Units,
+		Cores:    r.Cores,
+		Memory:   r.Memory,
+		Storage:  r.Storage,
+	}
+}
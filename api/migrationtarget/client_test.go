@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/tools"
 	jujuversion "github.com/juju/juju/version"
@@ -77,6 +78,83 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) getVersionedClientAndStub(c *gc.C, bestVersion int) (*migrationtarget.Client, *jujutesting.Stub) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return errors.New("boom")
+		}),
+		BestVersion: bestVersion,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+	return client, &stub
+}
+
+func (s *ClientSuite) TestPrechecksQuotas(c *gc.C) {
+	client, stub := s.getVersionedClientAndStub(c, 2)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	err := client.Prechecks(coremigration.ModelInfo{
+		UUID:         "uuid",
+		Owner:        ownerTag,
+		Name:         "name",
+		AgentVersion: vers,
+		Quotas:       quota.Resources{Units: 10},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	expectedArg := params.MigrationModelInfo{
+		UUID:         "uuid",
+		Name:         "name",
+		OwnerTag:     ownerTag.String(),
+		AgentVersion: vers,
+		Quotas:       &params.ModelQuotas{Units: 10},
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.Prechecks", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestPrechecksQuotasNotSupported(c *gc.C) {
+	client, stub := s.getVersionedClientAndStub(c, 1)
+
+	err := client.Prechecks(coremigration.ModelInfo{
+		UUID:         "uuid",
+		Owner:        names.NewUserTag("owner"),
+		Name:         "name",
+		AgentVersion: version.MustParse("1.2.3"),
+		Quotas:       quota.Resources{Units: 10},
+	})
+	c.Assert(err, gc.ErrorMatches, "migrating a model with quotas to this controller not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestSetQuotas(c *gc.C) {
+	client, stub := s.getVersionedClientAndStub(c, 2)
+
+	err := client.SetQuotas("uuid", quota.Resources{Machines: 5, Memory: 8192})
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	expectedArg := params.SetModelQuotas{
+		ModelTag: names.NewModelTag("uuid").String(),
+		Quotas:   params.ModelQuotas{Machines: 5, Memory: 8192},
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.SetQuotas", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestSetQuotasNotSupported(c *gc.C) {
+	client, stub := s.getVersionedClientAndStub(c, 1)
+
+	err := client.SetQuotas("uuid", quota.Resources{Units: 1})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -190,3 +190,75 @@ func (s *accessSuite) TestInvalidResultCount(c *gc.C) {
 	err := client.GrantModel("bob", "write", someModelUUID, someModelUUID)
 	c.Assert(err, gc.ErrorMatches, "expected 2 results, got 0")
 }
+
+func (s *accessSuite) TestGrantApplication(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				checkCall(c, objType, id, request)
+
+				req := assertRequest(c, a)
+				c.Assert(req.Changes, jc.DeepEquals, []params.ModifyModelAccess{{
+					UserTag:     names.NewUserTag("bob").String(),
+					Action:      params.GrantModelAccess,
+					Access:      params.ModelWriteAccess,
+					ModelTag:    someModelTag,
+					Application: "mysql",
+				}, {
+					UserTag:     names.NewUserTag("bob").String(),
+					Action:      params.GrantModelAccess,
+					Access:      params.ModelWriteAccess,
+					ModelTag:    someModelTag,
+					Application: "pgsql",
+				}})
+
+				resp := assertResponse(c, result)
+				*resp = params.ErrorResults{Results: []params.ErrorResult{{}, {}}}
+				return nil
+			}),
+		BestVersion: 4,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.GrantApplication("bob", someModelUUID, "mysql", "pgsql")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *accessSuite) TestRevokeApplicationFromGroup(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				checkCall(c, objType, id, request)
+
+				req := assertRequest(c, a)
+				c.Assert(req.Changes, jc.DeepEquals, []params.ModifyModelAccess{{
+					Group:       "dba",
+					Action:      params.RevokeModelAccess,
+					Access:      params.ModelWriteAccess,
+					ModelTag:    someModelTag,
+					Application: "mysql",
+				}})
+
+				resp := assertResponse(c, result)
+				*resp = params.ErrorResults{Results: []params.ErrorResult{{}}}
+				return nil
+			}),
+		BestVersion: 4,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.RevokeApplicationFromGroup("dba", someModelUUID, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *accessSuite) TestGrantApplicationNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			}),
+		BestVersion: 3,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.GrantApplication("bob", someModelUUID, "mysql")
+	c.Assert(err, gc.ErrorMatches, "application access with this version of Juju not supported")
+}
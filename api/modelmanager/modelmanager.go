@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/permission"
@@ -38,13 +39,18 @@ func NewClient(st base.APICallCloser) *Client {
 }
 
 // CreateModel creates a new model using the model config,
-// cloud region and credential specified in the args.
+// cloud region and credential specified in the args. If quotas
+// is nil, the controller's default model quotas are used.
 func (c *Client) CreateModel(
 	name, owner, cloud, cloudRegion string,
 	cloudCredential names.CloudCredentialTag,
 	config map[string]interface{},
+	quotas *quota.Resources,
 ) (base.ModelInfo, error) {
 	var result base.ModelInfo
+	if quotas != nil && c.BestAPIVersion() < 5 {
+		return result, errors.NotSupportedf("model quotas with this version of Juju")
+	}
 	if !names.IsValidUser(owner) {
 		return result, errors.Errorf("invalid owner name %q", owner)
 	}
@@ -67,6 +73,9 @@ func (c *Client) CreateModel(
 		CloudRegion:        cloudRegion,
 		CloudCredentialTag: cloudCredentialTag,
 	}
+	if quotas != nil {
+		createArgs.Quotas = paramsModelQuotas(*quotas)
+	}
 	var modelInfo params.ModelInfo
 	err := c.facade.FacadeCall("CreateModel", createArgs, &modelInfo)
 	if err != nil {
@@ -310,6 +319,170 @@ func (c *Client) modifyModelUser(action params.ModelAction, user, access string,
 	return result.Combine()
 }
 
+// GrantModelToGroup grants a local group of users access to the
+// specified models.
+func (c *Client) GrantModelToGroup(group, access string, modelUUIDs ...string) error {
+	return c.modifyModelGroup(params.GrantModelAccess, group, access, modelUUIDs)
+}
+
+// RevokeModelFromGroup revokes a local group's access to the specified
+// models.
+func (c *Client) RevokeModelFromGroup(group, access string, modelUUIDs ...string) error {
+	return c.modifyModelGroup(params.RevokeModelAccess, group, access, modelUUIDs)
+}
+
+func (c *Client) modifyModelGroup(action params.ModelAction, group, access string, modelUUIDs []string) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("group access with this version of Juju")
+	}
+	var args params.ModifyModelAccessRequest
+
+	if !names.IsValidUserName(group) {
+		return errors.Errorf("invalid group name: %q", group)
+	}
+
+	modelAccess := permission.Access(access)
+	if err := permission.ValidateModelAccess(modelAccess); err != nil {
+		return errors.Trace(err)
+	}
+	for _, model := range modelUUIDs {
+		if !names.IsValidModel(model) {
+			return errors.Errorf("invalid model: %q", model)
+		}
+		modelTag := names.NewModelTag(model)
+		args.Changes = append(args.Changes, params.ModifyModelAccess{
+			Group:    group,
+			Action:   action,
+			Access:   params.UserAccessPermission(modelAccess),
+			ModelTag: modelTag.String(),
+		})
+	}
+
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("ModifyModelAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Changes) {
+		return errors.Errorf("expected %d results, got %d", len(args.Changes), len(result.Results))
+	}
+	return result.Combine()
+}
+
+// GrantApplication grants a user write access to the specified
+// applications in a model.
+func (c *Client) GrantApplication(user, modelUUID string, applications ...string) error {
+	if !names.IsValidUser(user) {
+		return errors.Errorf("invalid username: %q", user)
+	}
+	change := params.ModifyModelAccess{
+		UserTag: names.NewUserTag(user).String(),
+		Action:  params.GrantModelAccess,
+	}
+	return c.modifyApplicationAccess(change, modelUUID, applications)
+}
+
+// RevokeApplication revokes a user's access to the specified
+// applications in a model.
+func (c *Client) RevokeApplication(user, modelUUID string, applications ...string) error {
+	if !names.IsValidUser(user) {
+		return errors.Errorf("invalid username: %q", user)
+	}
+	change := params.ModifyModelAccess{
+		UserTag: names.NewUserTag(user).String(),
+		Action:  params.RevokeModelAccess,
+	}
+	return c.modifyApplicationAccess(change, modelUUID, applications)
+}
+
+// GrantApplicationToGroup grants a local group of users write access to
+// the specified applications in a model.
+func (c *Client) GrantApplicationToGroup(group, modelUUID string, applications ...string) error {
+	if !names.IsValidUserName(group) {
+		return errors.Errorf("invalid group name: %q", group)
+	}
+	change := params.ModifyModelAccess{
+		Group:  group,
+		Action: params.GrantModelAccess,
+	}
+	return c.modifyApplicationAccess(change, modelUUID, applications)
+}
+
+// RevokeApplicationFromGroup revokes a local group's access to the
+// specified applications in a model.
+func (c *Client) RevokeApplicationFromGroup(group, modelUUID string, applications ...string) error {
+	if !names.IsValidUserName(group) {
+		return errors.Errorf("invalid group name: %q", group)
+	}
+	change := params.ModifyModelAccess{
+		Group:  group,
+		Action: params.RevokeModelAccess,
+	}
+	return c.modifyApplicationAccess(change, modelUUID, applications)
+}
+
+func (c *Client) modifyApplicationAccess(change params.ModifyModelAccess, modelUUID string, applications []string) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("application access with this version of Juju")
+	}
+	if !names.IsValidModel(modelUUID) {
+		return errors.Errorf("invalid model: %q", modelUUID)
+	}
+	var args params.ModifyModelAccessRequest
+	for _, application := range applications {
+		if !names.IsValidApplication(application) {
+			return errors.Errorf("invalid application: %q", application)
+		}
+		change.Access = params.ModelWriteAccess
+		change.ModelTag = names.NewModelTag(modelUUID).String()
+		change.Application = application
+		args.Changes = append(args.Changes, change)
+	}
+
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("ModifyModelAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Changes) {
+		return errors.Errorf("expected %d results, got %d", len(args.Changes), len(result.Results))
+	}
+	return result.Combine()
+}
+
+// SetModelQuotas replaces the quotas on the resources that the model
+// may use. Zero quotas are not limited.
+func (c *Client) SetModelQuotas(modelUUID string, quotas quota.Resources) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("model quotas with this version of Juju")
+	}
+	if !names.IsValidModel(modelUUID) {
+		return errors.Errorf("invalid model: %q", modelUUID)
+	}
+	args := params.SetModelQuotasArgs{
+		Args: []params.SetModelQuotas{{
+			ModelTag: names.NewModelTag(modelUUID).String(),
+			Quotas:   *paramsModelQuotas(quotas),
+		}},
+	}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("SetModelQuotas", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+func paramsModelQuotas(r quota.Resources) *params.ModelQuotas {
+	return &params.ModelQuotas{
+		Machines: r.Machines,
+		Units:    r.Units,
+		Cores:    r.Cores,
+		Memory:   r.Memory,
+		Storage:  r.Storage,
+	}
+}
+
 // ModelDefaults returns the default values for various sources used when
 // creating a new model.
 func (c *Client) ModelDefaults() (config.ModelDefaultAttributes, error) {
@@ -36,7 +36,7 @@ func (s *modelmanagerSuite) OpenAPI(c *gc.C) *modelmanager.Client {
 func (s *modelmanagerSuite) TestCreateModelBadUser(c *gc.C) {
 	modelManager := s.OpenAPI(c)
 	defer modelManager.Close()
-	_, err := modelManager.CreateModel("mymodel", "not a user", "", "", names.CloudCredentialTag{}, nil)
+	_, err := modelManager.CreateModel("mymodel", "not a user", "", "", names.CloudCredentialTag{}, nil, nil)
 	c.Assert(err, gc.ErrorMatches, `invalid owner name "not a user"`)
 }
 
@@ -61,7 +61,7 @@ func (s *modelmanagerSuite) testCreateModel(c *gc.C, cloud, region string) {
 		"authorized-keys": "ssh-key",
 		// dummy needs controller
 		"controller": false,
-	})
+	}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(newModel.Name, gc.Equals, "new-model")
 	c.Assert(newModel.Owner, gc.Equals, user.String())
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/quota"
+	"github.com/juju/juju/testing"
+)
+
+type quotaSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&quotaSuite{})
+
+func (s *quotaSuite) TestSetModelQuotas(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "SetModelQuotas")
+				c.Check(a, jc.DeepEquals, params.SetModelQuotasArgs{
+					Args: []params.SetModelQuotas{{
+						ModelTag: someModelTag,
+						Quotas:   params.ModelQuotas{Machines: 10, Memory: 65536},
+					}},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+				}
+				return nil
+			}),
+		BestVersion: 5,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.SetModelQuotas(someModelUUID, quota.Resources{Machines: 10, Memory: 65536})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *quotaSuite) TestSetModelQuotasNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			}),
+		BestVersion: 4,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.SetModelQuotas(someModelUUID, quota.Resources{Machines: 10})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	_, err = client.CreateModel("foo", "bob", "", "", names.CloudCredentialTag{}, nil, &quota.Resources{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *quotaSuite) TestCreateModelQuotas(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(request, gc.Equals, "CreateModel")
+				args, ok := a.(params.ModelCreateArgs)
+				c.Assert(ok, jc.IsTrue)
+				c.Check(args.Quotas, jc.DeepEquals, &params.ModelQuotas{Units: 20})
+				return errors.New("stop here")
+			}),
+		BestVersion: 5,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.CreateModel("foo", "bob", "", "", names.CloudCredentialTag{}, nil, &quota.Resources{Units: 20})
+	c.Assert(err, gc.ErrorMatches, "stop here")
+}
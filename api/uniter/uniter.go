@@ -275,6 +275,34 @@ func (st *State) ActionFinish(tag names.ActionTag, status string, results map[st
 	return nil
 }
 
+// ActionStatus returns the current status of an action. The uniter
+// uses it to learn that an action it is running has been cancelled.
+func (st *State) ActionStatus(tag names.ActionTag) (string, error) {
+	if st.facade.BestAPIVersion() < 5 {
+		return "", errors.NotImplementedf("ActionStatus")
+	}
+	var outcome params.StringResults
+
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: tag.String()},
+		},
+	}
+
+	err := st.facade.FacadeCall("ActionStatus", args, &outcome)
+	if err != nil {
+		return "", err
+	}
+	if len(outcome.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(outcome.Results))
+	}
+	result := outcome.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
 // RelationById returns the existing relation with the given id.
 func (st *State) RelationById(id int) (*Relation, error) {
 	var results params.RelationResults
@@ -6,6 +6,7 @@ package usermanager
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -180,3 +181,151 @@ func (c *Client) SetPassword(username, password string) error {
 	}
 	return results.OneError()
 }
+
+// groupCall calls a method taking the names of groups, which require
+// UserManager facade version 2 or later.
+func (c *Client) groupCall(names []string, methodCall string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("user groups with this version of Juju")
+	}
+	var results params.ErrorResults
+	args := params.UserGroupNames{Names: names}
+	err := c.facade.FacadeCall(methodCall, args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
+// AddGroups creates new local groups of users in the controller.
+func (c *Client) AddGroups(names ...string) error {
+	return c.groupCall(names, "AddGroups")
+}
+
+// RemoveGroups removes local groups of users from the controller, along
+// with all the access granted to them.
+func (c *Client) RemoveGroups(names ...string) error {
+	return c.groupCall(names, "RemoveGroups")
+}
+
+// GroupInfo returns information about the specified groups. If no groups
+// are specified, all groups are returned.
+func (c *Client) GroupInfo(groupNames []string) ([]params.UserGroup, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("user groups with this version of Juju")
+	}
+	var results params.UserGroupResults
+	args := params.UserGroupNames{Names: groupNames}
+	err := c.facade.FacadeCall("GroupInfo", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := make([]params.UserGroup, len(results.Results))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return nil, errors.Trace(result.Error)
+		}
+		if result.Result == nil {
+			return nil, errors.Errorf("unexpected nil result at position %d", i)
+		}
+		info[i] = *result.Result
+	}
+	return info, nil
+}
+
+func (c *Client) groupMembersCall(group string, usernames []string, methodCall string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("user groups with this version of Juju")
+	}
+	members := params.UserGroupMembers{Group: group}
+	for _, username := range usernames {
+		if !names.IsValidUser(username) {
+			return errors.Errorf("%q is not a valid username", username)
+		}
+		members.UserTags = append(members.UserTags, names.NewUserTag(username).String())
+	}
+	var results params.ErrorResults
+	args := params.ModifyUserGroupMembers{
+		Changes: []params.UserGroupMembers{members},
+	}
+	err := c.facade.FacadeCall(methodCall, args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// AddGroupMembers adds local users to a group. Users who are already
+// members are ignored.
+func (c *Client) AddGroupMembers(group string, usernames ...string) error {
+	return c.groupMembersCall(group, usernames, "AddGroupMembers")
+}
+
+// RemoveGroupMembers removes users from a group. Users who are not
+// members are ignored.
+func (c *Client) RemoveGroupMembers(group string, usernames ...string) error {
+	return c.groupMembersCall(group, usernames, "RemoveGroupMembers")
+}
+
+// AddToken creates an API token for the logged in user, and returns the
+// secret token. The token grants at most the given model access to the
+// models with the given UUIDs. It never expires if expires is zero.
+func (c *Client) AddToken(name, access string, modelUUIDs []string, expires time.Time) (string, error) {
+	if c.BestAPIVersion() < 3 {
+		return "", errors.NotSupportedf("API tokens with this version of Juju")
+	}
+	token := params.AddAPIToken{
+		Name:   name,
+		Access: access,
+	}
+	for _, uuid := range modelUUIDs {
+		if !names.IsValidModel(uuid) {
+			return "", errors.NotValidf("model %q", uuid)
+		}
+		token.ModelTags = append(token.ModelTags, names.NewModelTag(uuid).String())
+	}
+	if !expires.IsZero() {
+		token.Expires = &expires
+	}
+	var results params.AddAPITokenResults
+	args := params.AddAPITokens{Tokens: []params.AddAPIToken{token}}
+	err := c.facade.FacadeCall("AddTokens", args, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if count := len(results.Results); count != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", count)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", errors.Trace(err)
+	}
+	return results.Results[0].Token, nil
+}
+
+// ListTokens returns information about the API tokens of the logged in
+// user.
+func (c *Client) ListTokens() ([]params.APIToken, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("API tokens with this version of Juju")
+	}
+	var results params.APITokenResults
+	err := c.facade.FacadeCall("ListTokens", nil, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
+
+// RevokeTokens removes the named API tokens of the logged in user.
+func (c *Client) RevokeTokens(tokenNames ...string) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("API tokens with this version of Juju")
+	}
+	var results params.ErrorResults
+	args := params.APITokenNames{Names: tokenNames}
+	err := c.facade.FacadeCall("RevokeTokens", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
@@ -4,6 +4,8 @@
 package usermanager_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -215,3 +217,33 @@ func (s *usermanagerSuite) TestSetUserPasswordBadName(c *gc.C) {
 	err := s.usermanager.SetPassword("not!good", "new-password")
 	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
 }
+
+func (s *usermanagerSuite) TestAddListRevokeTokens(c *gc.C) {
+	token, err := s.usermanager.AddToken("ci", "write", []string{s.State.ModelUUID()}, time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token, gc.Not(gc.Equals), "")
+
+	tokens, err := s.usermanager.ListTokens()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, gc.HasLen, 1)
+	c.Assert(tokens[0].Name, gc.Equals, "ci")
+	c.Assert(tokens[0].Access, gc.Equals, "write")
+	c.Assert(tokens[0].Models, jc.DeepEquals, []string{"admin/controller"})
+	c.Assert(tokens[0].Expires, gc.IsNil)
+
+	err = s.usermanager.RevokeTokens("ci")
+	c.Assert(err, jc.ErrorIsNil)
+	tokens, err = s.usermanager.ListTokens()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, gc.HasLen, 0)
+}
+
+func (s *usermanagerSuite) TestAddTokenBadModel(c *gc.C) {
+	_, err := s.usermanager.AddToken("ci", "write", []string{"not-a-uuid"}, time.Time{})
+	c.Assert(err, gc.ErrorMatches, `model "not-a-uuid" not valid`)
+}
+
+func (s *usermanagerSuite) TestRevokeTokenNotFound(c *gc.C) {
+	err := s.usermanager.RevokeTokens("ci")
+	c.Assert(err, gc.ErrorMatches, `token "ci" not found`)
+}
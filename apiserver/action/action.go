@@ -62,6 +62,38 @@ func (a *ActionAPI) checkCanWrite() error {
 	return nil
 }
 
+// checkCanWriteApplication returns an error unless the user has write
+// access to the model, or has been granted write access to the named
+// application alone.
+func (a *ActionAPI) checkCanWriteApplication(name string) error {
+	if err := a.checkCanWrite(); err != common.ErrPerm {
+		return err
+	}
+	canWrite, err := a.authorizer.HasPermission(permission.WriteAccess, names.NewApplicationTag(name))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canWrite {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// checkCanWriteReceiver returns an error unless the user may run and
+// cancel actions on the receiver. Actions on a unit may be run by
+// users with write access to its application.
+func (a *ActionAPI) checkCanWriteReceiver(tag names.Tag) error {
+	unitTag, ok := tag.(names.UnitTag)
+	if !ok {
+		return a.checkCanWrite()
+	}
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return a.checkCanWriteApplication(appName)
+}
+
 func (a *ActionAPI) checkCanAdmin() error {
 	canAdmin, err := a.authorizer.HasPermission(permission.AdminAccess, a.state.ModelTag())
 	if err != nil {
@@ -160,7 +192,7 @@ func (a *ActionAPI) FindActionsByNames(arg params.FindActionsByNames) (params.Ac
 // enqueued Action, or an error if there was a problem enqueueing the
 // Action.
 func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
-	if err := a.checkCanWrite(); err != nil {
+	if err := a.checkCanRead(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
 	}
 
@@ -177,6 +209,10 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
+		if err := a.checkCanWriteReceiver(receiver.Tag()); err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
 		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
@@ -232,9 +268,10 @@ func (a *ActionAPI) ListCompleted(arg params.Entities) (params.ActionsByReceiver
 	return a.internalList(arg, completedActions)
 }
 
-// Cancel attempts to cancel enqueued Actions from running.
+// Cancel attempts to cancel enqueued Actions from running, and asks
+// the receivers of running Actions to abort them.
 func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
-	if err := a.checkCanWrite(); err != nil {
+	if err := a.checkCanRead(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
 	}
 
@@ -260,12 +297,16 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		result, err := action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
+		receiverTag, err := names.ActionReceiverTag(action.Receiver())
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		receiverTag, err := names.ActionReceiverTag(result.Receiver())
+		if err := a.checkCanWriteReceiver(receiverTag); err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		result, err := action.Cancel("action cancelled via the API")
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -280,7 +321,7 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 // services.
 func (a *ActionAPI) ApplicationsCharmsActions(args params.Entities) (params.ApplicationsCharmActionsResults, error) {
 	result := params.ApplicationsCharmActionsResults{Results: make([]params.ApplicationCharmActionsResult, len(args.Entities))}
-	if err := a.checkCanWrite(); err != nil {
+	if err := a.checkCanRead(); err != nil {
 		return result, errors.Trace(err)
 	}
 
@@ -292,6 +333,10 @@ func (a *ActionAPI) ApplicationsCharmsActions(args params.Entities) (params.Appl
 			continue
 		}
 		currentResult.ApplicationTag = svcTag.String()
+		if err := a.checkCanWriteApplication(svcTag.Id()); err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
 		svc, err := a.state.Application(svcTag.Id())
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
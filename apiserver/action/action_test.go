@@ -312,6 +312,28 @@ var testCases = []testCase{{
 	},
 }}
 
+func (s *actionSuite) TestEnqueueApplicationWriteAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("writeapplication-mysql")
+	api, err := action.NewActionAPI(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	arg := params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.mysqlUnit.Tag().String(), Name: "fakeaction"},
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+		},
+	}
+	res, err := api.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 2)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[1].Error, gc.ErrorMatches, "permission denied")
+
+	actions, err := s.wordpressUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 0)
+}
+
 func (s *actionSuite) TestListAll(c *gc.C) {
 	for _, testCase := range testCases {
 		// set up query args
@@ -630,6 +652,26 @@ func (s *actionSuite) TestCancel(c *gc.C) {
 	c.Assert(myActions[1].Status, gc.Equals, params.ActionCancelled)
 }
 
+func (s *actionSuite) TestCancelRunning(c *gc.C) {
+	added, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = added.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.Cancel(params.Entities{
+		Entities: []params.Entity{{Tag: added.ActionTag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Status, gc.Equals, params.ActionAborting)
+
+	// The action is left for the unit to abort.
+	action, err := s.State.Action(added.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Status(), gc.Equals, state.ActionAborting)
+}
+
 func (s *actionSuite) TestApplicationsCharmsActions(c *gc.C) {
 	actionSchemas := map[string]map[string]interface{}{
 		"snapshot": {
@@ -187,6 +187,21 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 			// currently being moved to another controller.
 			apiRoot = restrictRoot(apiRoot, migrationClientMethodsOnly)
 		}
+
+		// Users whose passwords have expired may only set a new one.
+		expired, err := a.passwordExpired(entity)
+		if err != nil {
+			return fail, errors.Trace(err)
+		}
+		if expired {
+			apiRoot = restrictRoot(apiRoot, passwordChangeMethodsOnly)
+		}
+
+		// Users logged in with an API token cannot use it to gain
+		// more access than it grants.
+		if _, ok := entity.(*authentication.TokenEntity); ok {
+			apiRoot = restrictRoot(apiRoot, tokenMethodsOnly)
+		}
 	}
 
 	loginResult := params.LoginResult{
@@ -229,8 +244,12 @@ func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin
 		everyoneGroupAccess = everyoneGroupUser.Access
 	}
 
+	// Access granted to the user's groups counts as well as their own,
+	// limited by the API token used to log in, if any.
+	userAccess := a.root.userAccess()
+
 	controllerAccess := permission.NoAccess
-	if controllerUser, err := state.ControllerAccess(a.root.state, userTag); err == nil {
+	if controllerUser, err := userAccess(userTag, a.root.state.ControllerTag()); err == nil {
 		controllerAccess = controllerUser.Access
 	} else if errors.IsNotFound(err) {
 		controllerAccess = everyoneGroupAccess
@@ -243,7 +262,7 @@ func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin
 		// no authorisation to access this model, unless the user is controller
 		// admin.
 
-		modelUser, err := a.root.state.UserAccess(userTag, a.root.state.ModelTag())
+		modelUser, err := userAccess(userTag, a.root.state.ModelTag())
 		if err != nil && controllerAccess != permission.SuperuserAccess {
 			return nil, errors.Wrap(err, common.ErrPerm)
 		}
@@ -280,6 +299,21 @@ func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin
 	}, nil
 }
 
+// passwordExpired reports whether the entity is a local user whose
+// password is older than the controller's password policy allows.
+func (a *admin) passwordExpired(entity state.Entity) (bool, error) {
+	user, ok := entity.(passwordChangedEntity)
+	if !ok {
+		return false, nil
+	}
+	controllerCfg, err := a.root.state.ControllerConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	policy := controllerCfg.PasswordPolicy()
+	return policy.Expired(user.PasswordChanged(), a.srv.clock.Now()), nil
+}
+
 func filterFacades(allowFacade func(name string) bool) []params.FacadeVersions {
 	allFacades := DescribeFacades()
 	out := make([]params.FacadeVersions, 0, len(allFacades))
@@ -355,9 +389,14 @@ func checkCreds(st *state.State, req params.LoginRequest, lookForModelUser bool,
 		return nil, nil, errors.Trace(err)
 	}
 
-	// For user logins, update the last login time.
+	// For user logins, update the last login time. Logins with an API
+	// token count as logins of the token's owner.
 	var lastLogin *time.Time
-	if entity, ok := entity.(loginEntity); ok {
+	loggedIn := entity
+	if token, ok := entity.(*authentication.TokenEntity); ok {
+		loggedIn = token.Entity
+	}
+	if entity, ok := loggedIn.(loginEntity); ok {
 		userLastLogin, err := entity.LastLogin()
 		if err != nil && !state.IsNeverLoggedInError(err) {
 			return nil, nil, errors.Trace(err)
@@ -399,6 +438,13 @@ type loginEntity interface {
 	UpdateLastLogin() error
 }
 
+// passwordChangedEntity is implemented by entities that record when
+// their password was last set. Notable implementations are *state.User
+// and *modelUserEntity.
+type passwordChangedEntity interface {
+	PasswordChanged() time.Time
+}
+
 // modelUserEntityFinder implements EntityFinder by returning a
 // loginEntity value for users, ensuring that the user exists in the
 // state's current model as well as retrieving more global
@@ -473,6 +519,50 @@ func (u *modelUserEntity) PasswordValid(pass string) bool {
 	return u.user.PasswordValid(pass)
 }
 
+// PasswordChanged implements passwordChangedEntity.PasswordChanged.
+// External users have no password, so it is never known when it was
+// changed.
+func (u *modelUserEntity) PasswordChanged() time.Time {
+	if u.user == nil {
+		return time.Time{}
+	}
+	return u.user.PasswordChanged()
+}
+
+// RecordFailedLogin records a failed password login for the local user.
+func (u *modelUserEntity) RecordFailedLogin(maxAttempts int, lockout time.Duration) error {
+	if u.user == nil {
+		return nil
+	}
+	return u.user.RecordFailedLogin(maxAttempts, lockout)
+}
+
+// ResetFailedLogins forgets the failed password logins of the local user.
+func (u *modelUserEntity) ResetFailedLogins() error {
+	if u.user == nil {
+		return nil
+	}
+	return u.user.ResetFailedLogins()
+}
+
+// APITokenValid returns the API token presented as credentials for the
+// local user, and whether it is valid. External users have no tokens.
+func (u *modelUserEntity) APITokenValid(credentials string) (*state.APIToken, bool) {
+	if u.user == nil {
+		return nil, false
+	}
+	return u.user.APITokenValid(credentials)
+}
+
+// LockedOutUntil returns the time until which the local user is locked
+// out after too many failed logins. External users are never locked out.
+func (u *modelUserEntity) LockedOutUntil() (time.Time, error) {
+	if u.user == nil {
+		return time.Time{}, nil
+	}
+	return u.user.LockedOutUntil()
+}
+
 // Tag implements state.Entity.Tag.
 func (u *modelUserEntity) Tag() names.Tag {
 	if u.user != nil {
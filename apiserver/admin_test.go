@@ -472,6 +472,63 @@ func (s *loginSuite) TestNonModelUserLoginFails(c *gc.C) {
 	assertInvalidEntityPassword(c, err)
 }
 
+func (s *loginSuite) TestLoginWithAPIToken(c *gc.C) {
+	info, srv := newServer(c, s.State)
+	defer assertStop(c, srv)
+	info.ModelTag = s.State.ModelTag()
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "dummy-password"})
+	_, token, err := s.State.AddAPIToken(state.AddAPITokenArgs{
+		Owner:      user.UserTag(),
+		Name:       "ci",
+		Access:     permission.ReadAccess,
+		ModelUUIDs: []string{s.State.ModelUUID()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.openAPIWithoutLogin(c, info)
+	err = st.Login(user.UserTag(), token, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st.AuthTag(), gc.Equals, user.UserTag())
+	c.Assert(st.ControllerAccess(), gc.Equals, "login")
+}
+
+func (s *loginSuite) TestLoginWithAPITokenOtherModelFails(c *gc.C) {
+	info, srv := newServer(c, s.State)
+	defer assertStop(c, srv)
+	info.ModelTag = s.State.ModelTag()
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "dummy-password"})
+	_, token, err := s.State.AddAPIToken(state.AddAPITokenArgs{
+		Owner:  user.UserTag(),
+		Name:   "ci",
+		Access: permission.ReadAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.openAPIWithoutLogin(c, info)
+	err = st.Login(user.UserTag(), token, "", nil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *loginSuite) TestLoginWithRevokedAPITokenFails(c *gc.C) {
+	info, srv := newServer(c, s.State)
+	defer assertStop(c, srv)
+	info.ModelTag = s.State.ModelTag()
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "dummy-password"})
+	_, token, err := s.State.AddAPIToken(state.AddAPITokenArgs{
+		Owner:      user.UserTag(),
+		Name:       "ci",
+		Access:     permission.ReadAccess,
+		ModelUUIDs: []string{s.State.ModelUUID()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveAPIToken(user.UserTag(), "ci")
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.openAPIWithoutLogin(c, info)
+	err = st.Login(user.UserTag(), token, "", nil)
+	assertInvalidEntityPassword(c, err)
+}
+
 func (s *loginSuite) TestLoginValidationSuccess(c *gc.C) {
 	validator := func(params.LoginRequest) error {
 		return nil
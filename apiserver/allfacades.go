@@ -11,6 +11,7 @@ package apiserver
 // place, not scattering it across packages and depending on magic import lists.
 import (
 	_ "github.com/juju/juju/apiserver/action" // ModelUser Write
+	_ "github.com/juju/juju/apiserver/actionpruner"
 	_ "github.com/juju/juju/apiserver/agent"
 	_ "github.com/juju/juju/apiserver/agenttools"
 	_ "github.com/juju/juju/apiserver/annotations" // ModelUser Write
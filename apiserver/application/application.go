@@ -133,6 +133,23 @@ func (api *API) checkCanWrite() error {
 	return nil
 }
 
+// checkCanWriteApplication returns an error unless the user has write
+// access to the model, or has been granted write access to the named
+// application alone.
+func (api *API) checkCanWriteApplication(name string) error {
+	if err := api.checkCanWrite(); err != common.ErrPerm {
+		return err
+	}
+	canWrite, err := api.authorizer.HasPermission(permission.WriteAccess, names.NewApplicationTag(name))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canWrite {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 // SetMetricCredentials sets credentials on the application.
 func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error) {
 	if err := api.checkCanWrite(); err != nil {
@@ -293,9 +310,16 @@ func parseSettingsCompatible(charmConfig *charm.Config, settings map[string]stri
 // minimum number of units, settings and constraints.
 // All parameters in params.ApplicationUpdate except the application name are optional.
 func (api *API) Update(args params.ApplicationUpdate) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(args.ApplicationName); err != nil {
 		return err
 	}
+	// Changing the charm may change the application's relations and
+	// storage, so it requires write access to the model.
+	if args.CharmURL != "" {
+		if err := api.checkCanWrite(); err != nil {
+			return err
+		}
+	}
 	if !args.ForceCharmURL {
 		if err := api.check.ChangeAllowed(); err != nil {
 			return errors.Trace(err)
@@ -494,7 +518,7 @@ func applicationSetSettingsYAML(appName string, application Application, setting
 // GetCharmURL returns the charm URL the given application is
 // running at present.
 func (api *API) GetCharmURL(args params.ApplicationGet) (params.StringResult, error) {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(args.ApplicationName); err != nil {
 		return params.StringResult{}, errors.Trace(err)
 	}
 	application, err := api.backend.Application(args.ApplicationName)
@@ -509,7 +533,7 @@ func (api *API) GetCharmURL(args params.ApplicationGet) (params.StringResult, er
 // It does not unset values that are set to an empty string.
 // Unset should be used for that.
 func (api *API) Set(p params.ApplicationSet) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(p.ApplicationName); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -535,7 +559,7 @@ func (api *API) Set(p params.ApplicationSet) error {
 
 // Unset implements the server side of Client.Unset.
 func (api *API) Unset(p params.ApplicationUnset) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(p.ApplicationName); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -620,7 +644,7 @@ func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]*s
 
 // AddUnits adds a given number of units to an application.
 func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplicationUnitsResults, error) {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(args.ApplicationName); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
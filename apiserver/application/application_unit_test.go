@@ -37,9 +37,6 @@ var _ = gc.Suite(&ApplicationSuite{})
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
-	s.authorizer = apiservertesting.FakeAuthorizer{
-		Tag: names.NewUserTag("admin"),
-	}
 	s.application = mockApplication{
 		units: []mockUnit{{
 			tag: names.NewUnitTag("foo/0"),
@@ -81,6 +78,13 @@ func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 		},
 	}
 	s.blockChecker = mockBlockChecker{}
+	s.setAPIUser(c, names.NewUserTag("admin"))
+}
+
+func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: user,
+	}
 	resources := common.NewResources()
 	resources.RegisterNamed("dataDir", common.StringResource(c.MkDir()))
 	api, err := application.NewAPI(
@@ -163,6 +167,34 @@ postgresql:
 	})
 }
 
+func (s *ApplicationSuite) TestApplicationWriteAccess(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("writeapplication-foo"))
+	err := s.api.Unset(params.ApplicationUnset{
+		ApplicationName: "foo",
+		Options:         []string{"stringOption"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.application.CheckCall(c, 0, "UpdateConfigSettings", charm.Settings{"stringOption": nil})
+
+	// Write access to the application does not extend to other
+	// applications, or to changes to the model.
+	err = s.api.Unset(params.ApplicationUnset{
+		ApplicationName: "bar",
+		Options:         []string{"stringOption"},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	err = s.api.Update(params.ApplicationUpdate{
+		ApplicationName: "foo",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	_, err = s.api.DestroyApplication(params.Entities{
+		Entities: []params.Entity{{Tag: "application-foo"}},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.application.CheckCallNames(c, "UpdateConfigSettings")
+}
+
 func (s *ApplicationSuite) TestDestroyRelation(c *gc.C) {
 	err := s.api.DestroyRelation(params.DestroyRelation{Endpoints: []string{"a", "b"}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -375,6 +407,11 @@ func (a *mockApplication) SetCharm(cfg state.SetCharmConfig) error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) UpdateConfigSettings(changes charm.Settings) error {
+	a.MethodCall(a, "UpdateConfigSettings", changes)
+	return a.NextErr()
+}
+
 func (a *mockApplication) Destroy() error {
 	a.MethodCall(a, "Destroy")
 	return a.NextErr()
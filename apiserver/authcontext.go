@@ -172,7 +172,11 @@ func (a authenticator) authenticatorForTag(tag names.Tag) (authentication.Entity
 	case names.UnitTagKind, names.MachineTagKind:
 		return &a.ctxt.agentAuth, nil
 	case names.UserTagKind:
-		return a.localUserAuth(), nil
+		auth, err := a.localUserAuth()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return auth, nil
 	default:
 		return nil, errors.Annotatef(common.ErrBadRequest, "unexpected login entity tag")
 	}
@@ -180,7 +184,11 @@ func (a authenticator) authenticatorForTag(tag names.Tag) (authentication.Entity
 
 // localUserAuth returns an authenticator that can authenticate logins for
 // local users with either passwords or macaroons.
-func (a authenticator) localUserAuth() *authentication.UserAuthenticator {
+func (a authenticator) localUserAuth() (*authentication.UserAuthenticator, error) {
+	controllerCfg, err := a.ctxt.st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get controller config")
+	}
 	localUserIdentityLocation := url.URL{
 		Scheme: "https",
 		Host:   a.serverHost,
@@ -190,7 +198,9 @@ func (a authenticator) localUserAuth() *authentication.UserAuthenticator {
 		Service: a.ctxt.localUserBakeryService,
 		Clock:   a.ctxt.clock,
 		LocalUserIdentityLocation: localUserIdentityLocation.String(),
-	}
+		MaxFailedLogins:           controllerCfg.LoginMaxFailedAttempts(),
+		LockoutDuration:           controllerCfg.LoginLockoutDuration(),
+	}, nil
 }
 
 // externalMacaroonAuth returns an authenticator that can authenticate macaroon-based
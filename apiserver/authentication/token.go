@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// TokenEntity is the entity authenticated by an API token. It acts as
+// the local user that owns the token, with access limited by the token.
+type TokenEntity struct {
+	// Entity holds the owner of the token, as found by the
+	// EntityFinder used to authenticate it.
+	Entity state.Entity
+
+	// Token holds the token that was presented.
+	Token *state.APIToken
+}
+
+// Tag implements state.Entity.Tag by returning the tag of the token's
+// owner.
+func (e *TokenEntity) Tag() names.Tag {
+	return e.Entity.Tag()
+}
+
+// tokenOwner is implemented by entities that may own API tokens. Notable
+// implementations are *state.User and the model user entity of the
+// apiserver.
+type tokenOwner interface {
+	APITokenValid(credentials string) (*state.APIToken, bool)
+}
+
+// authenticateToken authenticates the found entity with the API token
+// presented as its credentials.
+func authenticateToken(entity state.Entity, credentials string) (state.Entity, error) {
+	owner, ok := entity.(tokenOwner)
+	if !ok {
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	token, ok := owner.APITokenValid(credentials)
+	if !ok {
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	return &TokenEntity{Entity: entity, Token: token}, nil
+}
@@ -38,6 +38,15 @@ type UserAuthenticator struct {
 	// to for local users. This always points at the same controller
 	// agent that is servicing the authorisation request.
 	LocalUserIdentityLocation string
+
+	// MaxFailedLogins holds the number of failed password logins in a
+	// row after which a local user is locked out. Users are never
+	// locked out if it is zero.
+	MaxFailedLogins int
+
+	// LockoutDuration holds how long a local user is locked out for
+	// after too many failed password logins.
+	LockoutDuration time.Duration
 }
 
 const (
@@ -74,9 +83,72 @@ func (u *UserAuthenticator) Authenticate(
 	if req.Credentials == "" && userTag.IsLocal() {
 		return u.authenticateMacaroons(entityFinder, userTag, req)
 	}
+	if userTag.IsLocal() {
+		return u.authenticatePassword(entityFinder, userTag, req)
+	}
 	return u.AgentAuthenticator.Authenticate(entityFinder, tag, req)
 }
 
+// lockableEntity is implemented by entities that record failed
+// password logins, and may be locked out after too many of them.
+type lockableEntity interface {
+	RecordFailedLogin(maxAttempts int, lockout time.Duration) error
+	ResetFailedLogins() error
+	LockedOutUntil() (time.Time, error)
+}
+
+// authenticatePassword authenticates a local user with a password or an
+// API token. Users that record their failed logins are refused while they
+// are locked out after too many failed password logins.
+func (u *UserAuthenticator) authenticatePassword(
+	entityFinder EntityFinder, tag names.UserTag, req params.LoginRequest,
+) (state.Entity, error) {
+	entity, err := entityFinder.FindEntity(tag)
+	if errors.IsNotFound(err) {
+		return nil, errors.Trace(common.ErrBadCreds)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if state.IsAPIToken(req.Credentials) {
+		// API tokens are random, so guessing them is not a concern
+		// and they are usable even while their owner is locked out.
+		return authenticateToken(entity, req.Credentials)
+	}
+	authenticator, ok := entity.(taggedAuthenticator)
+	if !ok {
+		return nil, errors.Trace(common.ErrBadRequest)
+	}
+	lockable, ok := entity.(lockableEntity)
+	if !ok {
+		if !authenticator.PasswordValid(req.Credentials) {
+			return nil, errors.Trace(common.ErrBadCreds)
+		}
+		return entity, nil
+	}
+
+	lockedUntil, err := lockable.LockedOutUntil()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !lockedUntil.IsZero() {
+		// The password is not checked while the user is locked out,
+		// and the lockout is not revealed, so that it gives away
+		// neither the password nor the account's existence.
+		logger.Debugf("user %s is locked out until %v", tag.Id(), lockedUntil)
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if !authenticator.PasswordValid(req.Credentials) {
+		if err := lockable.RecordFailedLogin(u.MaxFailedLogins, u.LockoutDuration); err != nil {
+			logger.Errorf("cannot record failed login for %s: %v", tag.Id(), err)
+		}
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if err := lockable.ResetFailedLogins(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return entity, nil
+}
+
 // CreateLocalLoginMacaroon creates a macaroon that may be provided to a
 // user as proof that they have logged in with a valid username and password.
 // This macaroon may then be used to obtain a discharge macaroon so that
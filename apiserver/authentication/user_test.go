@@ -120,6 +120,53 @@ func (s *userAuthenticatorSuite) TestUserLoginWrongPassword(c *gc.C) {
 
 }
 
+func (s *userAuthenticatorSuite) TestUserLoginLockedOut(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:     "bobbrown",
+		Password: "password",
+	})
+
+	authenticator := &authentication.UserAuthenticator{
+		MaxFailedLogins: 2,
+		LockoutDuration: time.Hour,
+	}
+	for i := 0; i < 2; i++ {
+		_, err := authenticator.Authenticate(s.State, user.Tag(), params.LoginRequest{
+			Credentials: "wrongpassword",
+		})
+		c.Assert(err, gc.ErrorMatches, "invalid entity name or password")
+	}
+
+	// The right password is refused while the user is locked out,
+	// just as a wrong one is.
+	_, err := authenticator.Authenticate(s.State, user.Tag(), params.LoginRequest{
+		Credentials: "password",
+	})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrBadCreds)
+}
+
+func (s *userAuthenticatorSuite) TestUserLoginResetsFailedLogins(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:     "bobbrown",
+		Password: "password",
+	})
+
+	authenticator := &authentication.UserAuthenticator{
+		MaxFailedLogins: 2,
+		LockoutDuration: time.Hour,
+	}
+	login := func(password string) error {
+		_, err := authenticator.Authenticate(s.State, user.Tag(), params.LoginRequest{
+			Credentials: password,
+		})
+		return err
+	}
+	c.Assert(login("wrongpassword"), gc.ErrorMatches, "invalid entity name or password")
+	c.Assert(login("password"), jc.ErrorIsNil)
+	c.Assert(login("wrongpassword"), gc.ErrorMatches, "invalid entity name or password")
+	c.Assert(login("password"), jc.ErrorIsNil)
+}
+
 func (s *userAuthenticatorSuite) TestInvalidRelationLogin(c *gc.C) {
 
 	// add relation
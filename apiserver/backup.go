@@ -19,9 +19,12 @@ import (
 	"github.com/juju/juju/state/backups"
 )
 
-var newBackups = func(st *state.State) (backups.Backups, io.Closer) {
-	stor := backups.NewStorage(st)
-	return backups.NewBackups(stor), stor
+var newBackups = func(st *state.State) (backups.Backups, io.Closer, error) {
+	stor, err := backups.NewControllerStorage(st)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return backups.NewBackups(stor), stor, nil
 }
 
 // backupHandler handles backup requests.
@@ -39,7 +42,11 @@ func (h *backupHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	}
 	defer releaser()
 
-	backups, closer := newBackups(st)
+	backups, closer, err := newBackups(st)
+	if err != nil {
+		h.sendError(resp, err)
+		return
+	}
 	defer closer.Close()
 
 	switch req.Method {
@@ -105,9 +105,12 @@ func extractResourceValue(resources facade.Resources, key string) (string, error
 	return strRes.String(), nil
 }
 
-var newBackups = func(backend Backend) (backups.Backups, io.Closer) {
-	stor := backups.NewStorage(backend)
-	return backups.NewBackups(stor), stor
+var newBackups = func(backend Backend) (backups.Backups, io.Closer, error) {
+	stor, err := backups.NewControllerStorage(backend)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return backups.NewBackups(stor), stor, nil
 }
 
 // ResultFromMetadata updates the result with the information in the
@@ -129,6 +132,8 @@ func ResultFromMetadata(meta *backups.Metadata) params.BackupsMetadataResult {
 		result.Finished = *meta.Finished
 	}
 	result.Notes = meta.Notes
+	result.Scheduled = meta.Scheduled
+	result.KeyFingerprint = meta.KeyFingerprint
 
 	result.Model = meta.Origin.Model
 	result.Machine = meta.Origin.Machine
@@ -163,6 +168,7 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 	meta.Origin.Version = result.Version
 	meta.Origin.Series = result.Series
 	meta.Notes = result.Notes
+	meta.KeyFingerprint = result.KeyFingerprint
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
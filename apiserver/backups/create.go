@@ -8,6 +8,7 @@ import (
 	"github.com/juju/replicaset"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/backupcrypt"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/backups"
 )
@@ -17,7 +18,10 @@ var waitUntilReady = replicaset.WaitUntilReady
 // Create is the API method that requests juju to create a new backup
 // of its state.  It returns the metadata for that backup.
 func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataResult, err error) {
-	backupsMethods, closer := newBackups(a.backend)
+	backupsMethods, closer, err := newBackups(a.backend)
+	if err != nil {
+		return p, errors.Trace(err)
+	}
 	defer closer.Close()
 
 	session := a.backend.MongoSession().Copy()
@@ -52,11 +56,45 @@ func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataRes
 		return p, errors.Trace(err)
 	}
 	meta.Notes = args.Notes
+	key, err := a.encryptionKey(args.EncryptionKey)
+	if err != nil {
+		return p, errors.Trace(err)
+	}
 
-	err = backupsMethods.Create(meta, a.paths, dbInfo)
+	err = backupsMethods.Create(meta, a.paths, dbInfo, key)
 	if err != nil {
 		return p, errors.Trace(err)
 	}
 
-	return ResultFromMetadata(meta), nil
+	result := ResultFromMetadata(meta)
+	if key != nil {
+		// The CA private key must not leave the controller except
+		// inside the encrypted archive.
+		result.CAPrivateKey = ""
+	}
+	return result, nil
+}
+
+// encryptionKey returns the key with which to encrypt a new backup:
+// the given key if there is one, or the controller's default key
+// otherwise. It returns nil if the backup should not be encrypted.
+func (a *API) encryptionKey(requested string) (*backupcrypt.Key, error) {
+	if requested == "" {
+		cfg, err := a.backend.ControllerConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		requested = cfg.BackupEncryptionKey()
+	}
+	if requested == "" {
+		return nil, nil
+	}
+	key, err := backupcrypt.ParseKey(requested)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid encryption key")
+	}
+	if key.Type() == backupcrypt.PrivateKey {
+		return nil, errors.New("invalid encryption key: expected a secret or public key, got a private key")
+	}
+	return key, nil
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/backupcrypt"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -42,6 +43,40 @@ func (s *backupsSuite) TestCreateNotes(c *gc.C) {
 	c.Check(result, gc.DeepEquals, expected)
 }
 
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	s.meta.CAPrivateKey = "ca-private-key"
+	fake := s.setBackups(c, s.meta, "")
+	_, key, err := backupcrypt.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.BackupsCreateArgs{
+		EncryptionKey: key.String(),
+	}
+	result, err := s.api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(fake.KeyArg, gc.NotNil)
+	c.Check(fake.KeyArg.String(), gc.Equals, key.String())
+	c.Check(result.CAPrivateKey, gc.Equals, "")
+}
+
+func (s *backupsSuite) TestCreateEncryptedPrivateKey(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+	key, _, err := backupcrypt.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.BackupsCreateArgs{
+		EncryptionKey: key.String(),
+	}
+	_, err = s.api.Create(args)
+	c.Check(err, gc.ErrorMatches, "invalid encryption key: expected a secret or public key, got a private key")
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
+
 func (s *backupsSuite) TestCreateError(c *gc.C) {
 	s.setBackups(c, nil, "failed!")
 	s.PatchValue(backups.WaitUntilReady,
@@ -7,16 +7,20 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
 )
 
 // List provides the implementation of the API method.
 func (a *API) List(args params.BackupsListArgs) (params.BackupsListResult, error) {
 	var result params.BackupsListResult
 
-	backups, closer := newBackups(a.backend)
+	backupsMethods, closer, err := newBackups(a.backend)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
 	defer closer.Close()
 
-	metaList, err := backups.List()
+	metaList, err := backupsMethods.List()
 	if err != nil {
 		return result, errors.Trace(err)
 	}
@@ -26,5 +30,19 @@ func (a *API) List(args params.BackupsListArgs) (params.BackupsListResult, error
 		result.List[i] = ResultFromMetadata(meta)
 	}
 
+	status, err := backups.GetScheduleStatus(a.backend)
+	if errors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Schedule = &params.BackupsScheduleResult{
+		Schedule:     status.Schedule,
+		LastRun:      status.LastRun,
+		LastBackupID: status.LastBackupID,
+		LastError:    status.LastError,
+		NextRun:      status.NextRun,
+	}
+
 	return result, nil
 }
@@ -6,12 +6,14 @@ package backups_test
 import (
 	"bytes"
 	"io/ioutil"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 func (s *backupsSuite) TestListOkay(c *gc.C) {
@@ -29,6 +31,27 @@ func (s *backupsSuite) TestListOkay(c *gc.C) {
 	c.Check(result, gc.DeepEquals, expected)
 }
 
+func (s *backupsSuite) TestListSchedule(c *gc.C) {
+	s.setBackups(c, s.meta, "")
+	lastRun := time.Date(2017, 3, 10, 2, 30, 0, 0, time.UTC)
+	err := statebackups.SetScheduleStatus(s.State, statebackups.ScheduleStatus{
+		Schedule:  "30 2 * * *",
+		LastRun:   lastRun,
+		LastError: "boom",
+		NextRun:   lastRun.Add(24 * time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.List(params.BackupsListArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Schedule, jc.DeepEquals, &params.BackupsScheduleResult{
+		Schedule:  "30 2 * * *",
+		LastRun:   lastRun,
+		LastError: "boom",
+		NextRun:   lastRun.Add(24 * time.Hour),
+	})
+}
+
 func (s *backupsSuite) TestListError(c *gc.C) {
 	s.setBackups(c, nil, "failed!")
 	args := params.BackupsListArgs{}
@@ -11,6 +11,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/backupcrypt"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
@@ -24,8 +25,20 @@ var bootstrapNode = names.NewMachineTag("0")
 func (a *API) Restore(p params.RestoreArgs) error {
 	logger.Infof("Starting server side restore")
 
+	var key *backupcrypt.Key
+	if p.DecryptionKey != "" {
+		var err error
+		key, err = backupcrypt.ParseKey(p.DecryptionKey)
+		if err != nil {
+			return errors.Annotate(err, "invalid decryption key")
+		}
+	}
+
 	// Get hold of a backup file Reader
-	backup, closer := newBackups(a.backend)
+	backup, closer, err := newBackups(a.backend)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	defer closer.Close()
 
 	// Obtain the address of current machine, where we will be performing restore.
@@ -69,6 +82,7 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		NewInstId:      instanceId,
 		NewInstTag:     machine.Tag(),
 		NewInstSeries:  machine.Series(),
+		DecryptionKey:  key,
 	}
 
 	session := a.backend.MongoSession().Copy()
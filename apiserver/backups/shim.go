@@ -18,6 +18,12 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Backups", 1, newAPI)
+
+	// Facade version 2 adds encryption keys to Create and Restore.
+	common.RegisterStandardFacade("Backups", 2, newAPI)
+
+	// Facade version 3 adds Verify.
+	common.RegisterStandardFacade("Backups", 3, newAPI)
 }
 
 type stateShim struct {
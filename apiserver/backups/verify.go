@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/backupcrypt"
+	"github.com/juju/juju/state/backups"
+)
+
+var verifyArchive = backups.VerifyArchive
+
+// Verify checks that the stored backup is complete and restorable,
+// without restoring it.
+func (a *API) Verify(args params.BackupsVerifyArgs) (params.BackupsVerifyResult, error) {
+	var key *backupcrypt.Key
+	if args.DecryptionKey != "" {
+		var err error
+		key, err = backupcrypt.ParseKey(args.DecryptionKey)
+		if err != nil {
+			return params.BackupsVerifyResult{}, errors.Annotate(err, "invalid decryption key")
+		}
+	}
+
+	backupsMethods, closer, err := newBackups(a.backend)
+	if err != nil {
+		return params.BackupsVerifyResult{}, errors.Trace(err)
+	}
+	defer closer.Close()
+
+	meta, archive, err := backupsMethods.Get(args.ID)
+	if err != nil {
+		return params.BackupsVerifyResult{}, errors.Trace(err)
+	}
+	defer archive.Close()
+
+	result, err := verifyArchive(meta, archive, key)
+	if err != nil {
+		return params.BackupsVerifyResult{}, errors.Trace(err)
+	}
+	return VerifyResultFromVerification(meta.ID(), result), nil
+}
+
+// VerifyResultFromVerification returns the API result describing the
+// outcome of verifying the identified backup.
+func VerifyResultFromVerification(id string, result *backups.VerifyResult) params.BackupsVerifyResult {
+	return params.BackupsVerifyResult{
+		ID:               id,
+		Checksum:         result.Checksum,
+		Size:             result.Size,
+		ContentsVerified: result.ContentsVerified,
+		Collections:      result.Collections,
+		Documents:        result.Documents,
+		Version:          result.Version,
+		Compatible:       result.Compatible,
+		Problems:         result.Problems,
+	}
+}
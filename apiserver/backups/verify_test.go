@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	backupsAPI "github.com/juju/juju/apiserver/backups"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/backupcrypt"
+	"github.com/juju/juju/state/backups"
+)
+
+func (s *backupsSuite) TestVerify(c *gc.C) {
+	impl := s.setBackups(c, s.meta, "")
+	impl.Archive = ioutil.NopCloser(bytes.NewBufferString("spamspamspam"))
+	key, err := backupcrypt.GenerateSecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var gotKey *backupcrypt.Key
+	s.PatchValue(backupsAPI.VerifyArchive,
+		func(meta *backups.Metadata, archive io.Reader, key *backupcrypt.Key) (*backups.VerifyResult, error) {
+			c.Check(meta, gc.Equals, s.meta)
+			gotKey = key
+			return &backups.VerifyResult{
+				Checksum:         "checksum",
+				Size:             12,
+				ContentsVerified: true,
+				Collections:      2,
+				Documents:        3,
+				Version:          s.meta.Origin.Version,
+				Compatible:       true,
+			}, nil
+		},
+	)
+
+	result, err := s.api.Verify(params.BackupsVerifyArgs{
+		ID:            "some-id",
+		DecryptionKey: key.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.BackupsVerifyResult{
+		ID:               s.meta.ID(),
+		Checksum:         "checksum",
+		Size:             12,
+		ContentsVerified: true,
+		Collections:      2,
+		Documents:        3,
+		Version:          s.meta.Origin.Version,
+		Compatible:       true,
+	})
+	c.Check(gotKey.String(), gc.Equals, key.String())
+}
+
+func (s *backupsSuite) TestVerifyCorrupt(c *gc.C) {
+	impl := s.setBackups(c, s.meta, "")
+	impl.Archive = ioutil.NopCloser(bytes.NewBufferString("spamspamspam"))
+
+	result, err := s.api.Verify(params.BackupsVerifyArgs{ID: "some-id"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ContentsVerified, jc.IsFalse)
+	c.Check(result.Size, gc.Equals, int64(12))
+	c.Check(result.Problems, gc.Not(gc.HasLen), 0)
+	c.Check(result.Problems[0], gc.Matches, "uncompressing archive: .*")
+}
+
+func (s *backupsSuite) TestVerifyInvalidKey(c *gc.C) {
+	s.setBackups(c, s.meta, "")
+	_, err := s.api.Verify(params.BackupsVerifyArgs{
+		ID:            "some-id",
+		DecryptionKey: "spam",
+	})
+	c.Check(err, gc.ErrorMatches, "invalid decryption key: backup key without type not valid")
+}
+
+func (s *backupsSuite) TestVerifyError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+	_, err := s.api.Verify(params.BackupsVerifyArgs{ID: "some-id"})
+	c.Check(err, gc.ErrorMatches, "failed!")
+}
@@ -5,47 +5,87 @@
 package bundle
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/juju/bundlechanges"
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 )
 
 // init registers the Bundle facade.
 func init() {
-	common.RegisterStandardFacade("Bundle", 1, newFacade)
+	common.RegisterStandardFacade("Bundle", 1, newFacadeV1)
+
+	// Facade version 2 adds the ExportBundle() method.
+	common.RegisterStandardFacade("Bundle", 2, newFacade)
+}
+
+func newFacadeV1(st *state.State, _ facade.Resources, auth facade.Authorizer) (BundleV1, error) {
+	return NewFacade(auth, st)
 }
 
-func newFacade(_ *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
-	return NewFacade(auth)
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
+	return NewFacade(auth, st)
+}
+
+// Backend defines the state functionality required by the Bundle facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ModelConfig() (*config.Config, error)
+	AllApplications() ([]*state.Application, error)
+	AllMachines() ([]*state.Machine, error)
+	AllRelations() ([]*state.Relation, error)
 }
 
 // NewFacade creates and returns a new Bundle API facade.
-func NewFacade(auth facade.Authorizer) (Bundle, error) {
+func NewFacade(auth facade.Authorizer, backend Backend) (Bundle, error) {
 	if !auth.AuthClient() {
 		return nil, common.ErrPerm
 	}
-	return &bundleAPI{}, nil
+	return &bundleAPI{
+		backend:    backend,
+		authorizer: auth,
+	}, nil
 }
 
-// Bundle defines the API endpoint used to retrieve bundle changes.
-type Bundle interface {
+// BundleV1 defines version 1 of the API endpoint used to retrieve bundle
+// changes.
+type BundleV1 interface {
 	// GetChanges returns the list of changes required to deploy the given
 	// bundle data.
 	GetChanges(params.BundleChangesParams) (params.BundleChangesResults, error)
 }
 
+// Bundle defines the API endpoint used to retrieve bundle changes and to
+// export the current model as a bundle.
+type Bundle interface {
+	BundleV1
+
+	// ExportBundle returns the YAML representation of a bundle which,
+	// when deployed, reproduces the current model.
+	ExportBundle() (params.StringResult, error)
+}
+
 // bundleAPI implements the Bundle interface and is the concrete implementation
 // of the API end point.
-type bundleAPI struct{}
+type bundleAPI struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
 
 // GetChanges returns the list of changes required to deploy the given bundle
 // data. The changes are sorted by requirements, so that they can be applied in
@@ -56,14 +96,6 @@ func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 	if err != nil {
 		return results, errors.Annotate(err, "cannot read bundle YAML")
 	}
-	verifyConstraints := func(s string) error {
-		_, err := constraints.Parse(s)
-		return err
-	}
-	verifyStorage := func(s string) error {
-		_, err := storage.ParseConstraints(s)
-		return err
-	}
 	if err := data.Verify(verifyConstraints, verifyStorage); err != nil {
 		if err, ok := err.(*charm.VerificationError); ok {
 			results.Errors = make([]string, len(err.Errors))
@@ -87,3 +119,263 @@ func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 	}
 	return results, nil
 }
+
+// ExportBundle returns the YAML representation of a bundle which, when
+// deployed, reproduces the applications, machine placement and relations
+// of the current model.
+func (b *bundleAPI) ExportBundle() (params.StringResult, error) {
+	if err := b.checkCanRead(); err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	data, err := b.exportBundleData()
+	if err != nil {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	return params.StringResult{Result: string(out)}, nil
+}
+
+func (b *bundleAPI) checkCanRead() error {
+	canRead, err := b.authorizer.HasPermission(permission.ReadAccess, b.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// exportBundleData walks the model and builds the equivalent bundle data.
+// The resulting bundle is verified before it is returned, so that a bundle
+// which could not be deployed is never handed out.
+func (b *bundleAPI) exportBundleData() (*charm.BundleData, error) {
+	cfg, err := b.backend.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data := &charm.BundleData{
+		Applications: make(map[string]*charm.ApplicationSpec),
+		Machines:     make(map[string]*charm.MachineSpec),
+	}
+	if series, ok := cfg.DefaultSeries(); ok {
+		data.Series = series
+	}
+
+	applications, err := b.backend.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	usedMachines := make(map[string]bool)
+	for _, application := range applications {
+		spec, machineIds, err := exportApplication(application, data.Series)
+		if err != nil {
+			return nil, errors.Annotatef(err, "exporting application %q", application.Name())
+		}
+		data.Applications[application.Name()] = spec
+		for _, id := range machineIds {
+			usedMachines[id] = true
+		}
+	}
+
+	machines, err := b.backend.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, machine := range machines {
+		if !usedMachines[machine.Id()] {
+			continue
+		}
+		spec := &charm.MachineSpec{}
+		if machine.Series() != data.Series {
+			spec.Series = machine.Series()
+		}
+		cons, err := machine.Constraints()
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "reading constraints for machine %q", machine.Id())
+		}
+		spec.Constraints = cons.String()
+		data.Machines[machine.Id()] = spec
+	}
+
+	relations, err := b.backend.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, relation := range relations {
+		endpoints := relation.Endpoints()
+		if len(endpoints) != 2 {
+			// Peer relations are established automatically.
+			continue
+		}
+		if data.Applications[endpoints[0].ApplicationName] == nil ||
+			data.Applications[endpoints[1].ApplicationName] == nil {
+			// Relations with remote applications cannot be
+			// expressed in a bundle.
+			continue
+		}
+		data.Relations = append(data.Relations, []string{
+			endpoints[0].String(),
+			endpoints[1].String(),
+		})
+	}
+
+	if err := data.Verify(verifyConstraints, verifyStorage); err != nil {
+		return nil, errors.Annotate(err, "exported bundle is not valid")
+	}
+	return data, nil
+}
+
+// exportApplication returns the bundle specification of the given
+// application, along with the ids of the top level machines hosting its
+// units.
+func exportApplication(application *state.Application, defaultSeries string) (*charm.ApplicationSpec, []string, error) {
+	curl, _ := application.CharmURL()
+	if curl.Schema == "local" {
+		// Local charms are not available to the deploying client,
+		// so a bundle referring to them cannot be deployed.
+		return nil, nil, errors.NotSupportedf("exporting local charm %q", curl)
+	}
+	spec := &charm.ApplicationSpec{
+		Charm:  curl.String(),
+		Expose: application.IsExposed(),
+	}
+	if series := application.Series(); series != defaultSeries && curl.Series == "" {
+		spec.Series = series
+	}
+
+	settings, err := application.ConfigSettings()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if len(settings) > 0 {
+		spec.Options = map[string]interface{}(settings)
+	}
+
+	cons, err := application.Constraints()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, nil, errors.Trace(err)
+	}
+	spec.Constraints = cons.String()
+
+	bindings, err := application.EndpointBindings()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if spec.EndpointBindings == nil {
+			spec.EndpointBindings = make(map[string]string)
+		}
+		spec.EndpointBindings[endpoint] = space
+	}
+
+	storageCons, err := application.StorageConstraints()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for name, cons := range storageCons {
+		if spec.Storage == nil {
+			spec.Storage = make(map[string]string)
+		}
+		spec.Storage[name] = formatStorageConstraints(cons)
+	}
+
+	if !application.IsPrincipal() {
+		// Subordinate units follow their principals.
+		return spec, nil, nil
+	}
+	units, err := application.AllUnits()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	spec.NumUnits = len(units)
+
+	placements := make(map[int]string)
+	var machineIds []string
+	for _, unit := range units {
+		number, err := unitNumber(unit.Name())
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			// Unassigned units are deployed to new machines, rather
+			// than repeating the previous unit's placement.
+			placements[number] = "new"
+			continue
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		placement, topLevelId, err := bundlePlacement(machineId)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "unit %q", unit.Name())
+		}
+		placements[number] = placement
+		machineIds = append(machineIds, topLevelId)
+	}
+	if len(machineIds) == 0 {
+		// None of the units are placed, so leave the
+		// placement to the deployment.
+		return spec, nil, nil
+	}
+	numbers := make([]int, 0, len(placements))
+	for number := range placements {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	for _, number := range numbers {
+		spec.To = append(spec.To, placements[number])
+	}
+	return spec, machineIds, nil
+}
+
+// bundlePlacement returns the bundle placement directive for a unit
+// assigned to the given machine, along with the id of the top level
+// machine the directive refers to. Units in containers are placed in a
+// new container of the same type on the same host. Bundles cannot
+// express nested containers, so units in them cannot be placed.
+func bundlePlacement(machineId string) (string, string, error) {
+	parts := strings.Split(machineId, "/")
+	switch len(parts) {
+	case 1:
+		return machineId, machineId, nil
+	case 3:
+		return fmt.Sprintf("%s:%s", parts[1], parts[0]), parts[0], nil
+	}
+	return "", "", errors.NotSupportedf("placement in nested container %q", machineId)
+}
+
+// unitNumber returns the sequence number of the named unit.
+func unitNumber(unitName string) (int, error) {
+	if !names.IsValidUnit(unitName) {
+		return 0, errors.NotValidf("unit name %q", unitName)
+	}
+	number := unitName[strings.LastIndex(unitName, "/")+1:]
+	return strconv.Atoi(number)
+}
+
+// formatStorageConstraints returns the bundle storage directive
+// corresponding to the given storage constraints.
+func formatStorageConstraints(cons state.StorageConstraints) string {
+	directive := fmt.Sprintf("%d,%dM", cons.Count, cons.Size)
+	if cons.Pool == "" {
+		return directive
+	}
+	return cons.Pool + "," + directive
+}
+
+func verifyConstraints(s string) error {
+	_, err := constraints.Parse(s)
+	return err
+}
+
+func verifyStorage(s string) error {
+	_, err := storage.ParseConstraints(s)
+	return err
+}
@@ -0,0 +1,198 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/bundle"
+	"github.com/juju/juju/apiserver/common"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type exportBundleSuite struct {
+	jujutesting.JujuConnSuite
+	facade bundle.Bundle
+}
+
+var _ = gc.Suite(&exportBundleSuite{})
+
+func (s *exportBundleSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	facade, err := bundle.NewFacade(auth, s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *exportBundleSuite) exportedBundle(c *gc.C) *charm.BundleData {
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func (s *exportBundleSuite) TestExportBundleEmptyModel(c *gc.C) {
+	data := s.exportedBundle(c)
+	c.Assert(data.Applications, gc.HasLen, 0)
+	c.Assert(data.Machines, gc.HasLen, 0)
+	c.Assert(data.Relations, gc.HasLen, 0)
+}
+
+func (s *exportBundleSuite) TestExportBundle(c *gc.C) {
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:        "mysql",
+		Charm:       s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql", URL: "cs:quantal/mysql-1"}),
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "wordpress",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress", URL: "cs:quantal/wordpress-2"}),
+	})
+	err := wordpress.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Series:      "quantal",
+		Constraints: constraints.MustParse("cores=2"),
+	})
+	container := s.Factory.MakeMachineNested(c, machine.Id(), nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql, Machine: machine})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress, Machine: container})
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data := s.exportedBundle(c)
+	c.Assert(data.Applications, gc.HasLen, 2)
+
+	mysqlSpec := data.Applications["mysql"]
+	c.Assert(mysqlSpec.Charm, gc.Equals, "cs:quantal/mysql-1")
+	c.Assert(mysqlSpec.NumUnits, gc.Equals, 1)
+	c.Assert(mysqlSpec.To, jc.DeepEquals, []string{machine.Id()})
+	c.Assert(mysqlSpec.Constraints, gc.Equals, "mem=4096M")
+	c.Assert(mysqlSpec.Expose, jc.IsFalse)
+
+	wordpressSpec := data.Applications["wordpress"]
+	c.Assert(wordpressSpec.Charm, gc.Equals, "cs:quantal/wordpress-2")
+	c.Assert(wordpressSpec.NumUnits, gc.Equals, 1)
+	c.Assert(wordpressSpec.To, jc.DeepEquals, []string{"lxd:" + machine.Id()})
+	c.Assert(wordpressSpec.Expose, jc.IsTrue)
+
+	c.Assert(data.Machines, gc.HasLen, 1)
+	c.Assert(data.Machines[machine.Id()].Constraints, gc.Equals, "cores=2")
+	c.Assert(data.Relations, jc.DeepEquals, [][]string{
+		{"wordpress:db", "mysql:server"},
+	})
+}
+
+func (s *exportBundleSuite) TestExportBundleUnassignedUnits(c *gc.C) {
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql", URL: "cs:quantal/mysql-1"}),
+	})
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql, Machine: machine})
+	_, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "wordpress",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress", URL: "cs:quantal/wordpress-2"}),
+	})
+	_, err = wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data := s.exportedBundle(c)
+	c.Assert(data.Applications["mysql"].NumUnits, gc.Equals, 2)
+	c.Assert(data.Applications["mysql"].To, jc.DeepEquals, []string{machine.Id(), "new"})
+	c.Assert(data.Applications["wordpress"].NumUnits, gc.Equals, 1)
+	c.Assert(data.Applications["wordpress"].To, gc.HasLen, 0)
+}
+
+func (s *exportBundleSuite) TestExportBundleNestedContainer(c *gc.C) {
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql", URL: "cs:quantal/mysql-1"}),
+	})
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	container := s.Factory.MakeMachineNested(c, machine.Id(), nil)
+	nested := s.Factory.MakeMachineNested(c, container.Id(), nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql, Machine: nested})
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `exporting application "mysql": unit "mysql/0": placement in nested container ".*" not supported`)
+}
+
+func (s *exportBundleSuite) TestExportBundleLocalCharm(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql", URL: "local:quantal/mysql-1"}),
+	})
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `exporting application "mysql": exporting local charm "local:quantal/mysql-1" not supported`)
+}
+
+func (s *exportBundleSuite) TestExportBundleStorage(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	s.AddTestingServiceWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": {Pool: "loop", Size: 1024, Count: 1},
+	})
+
+	data := s.exportedBundle(c)
+	c.Assert(data.Applications["storage-block"].Storage["data"], gc.Equals, "loop,1,1024M")
+}
+
+func (s *exportBundleSuite) TestFormatStorageConstraints(c *gc.C) {
+	c.Check(bundle.FormatStorageConstraints(state.StorageConstraints{
+		Pool: "ebs", Size: 2048, Count: 3,
+	}), gc.Equals, "ebs,3,2048M")
+
+	// A directive without a pool uses the default pool.
+	c.Check(bundle.FormatStorageConstraints(state.StorageConstraints{
+		Size: 1024, Count: 1,
+	}), gc.Equals, "1,1024M")
+}
+
+func (s *exportBundleSuite) TestExportBundleNotInVersion1(c *gc.C) {
+	for version, found := range map[int]bool{1: false, 2: true} {
+		facadeType, err := common.Facades.GetType("Bundle", version)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = rpcreflect.ObjTypeOf(facadeType).Method("ExportBundle")
+		if found {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.Equals, rpcreflect.ErrMethodNotFound)
+		}
+	}
+}
+
+func (s *exportBundleSuite) TestExportBundlePermissionDenied(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("someoneelse"),
+	}
+	facade, err := bundle.NewFacade(auth, s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = facade.ExportBundle()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
@@ -64,6 +64,7 @@ type Backend interface {
 	ModelConfig() (*config.Config, error)
 	ModelConfigValues() (config.ConfigValues, error)
 	ModelConstraints() (constraints.Value, error)
+	ModelStatusHistory(state.ModelStatusHistoryFilter) ([]state.ModelStatusHistoryEntry, error)
 	ModelTag() names.ModelTag
 	ModelUUID() string
 	RemoveUserAccess(names.UserTag, names.Tag) error
@@ -31,6 +31,12 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Client", 1, newClient)
+
+	// Facade version 2 adds filters to FullStatus.
+	common.RegisterStandardFacade("Client", 2, newClient)
+
+	// Facade version 3 adds ModelStatusHistory.
+	common.RegisterStandardFacade("Client", 3, newClient)
 }
 
 var logger = loggo.GetLogger("juju.apiserver.client")
@@ -96,6 +102,27 @@ func (c *Client) checkCanWrite() error {
 	return nil
 }
 
+// checkCanWriteUnit returns an error unless the user has write access
+// to the model, or has been granted write access to the application of
+// the named unit.
+func (c *Client) checkCanWriteUnit(unitName string) error {
+	if err := c.checkCanWrite(); err != common.ErrPerm {
+		return err
+	}
+	appName, err := names.UnitApplication(unitName)
+	if err != nil {
+		return common.ErrPerm
+	}
+	canWrite, err := c.api.auth.HasPermission(permission.WriteAccess, names.NewApplicationTag(appName))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canWrite {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func newClient(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*Client, error) {
 	urlGetter := common.NewToolsURLGetter(st.ModelUUID(), st)
 	configGetter := stateenvirons.EnvironConfigGetter{st}
@@ -162,7 +189,7 @@ func (c *Client) WatchAll() (params.AllWatcherId, error) {
 
 // Resolved implements the server side of Client.Resolved.
 func (c *Client) Resolved(p params.Resolved) error {
-	if err := c.checkCanWrite(); err != nil {
+	if err := c.checkCanWriteUnit(p.UnitName); err != nil {
 		return err
 	}
 	if err := c.check.ChangeAllowed(); err != nil {
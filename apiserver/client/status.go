@@ -163,6 +163,42 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 	return results
 }
 
+// ModelStatusHistory returns the status history of the units and
+// machines in the model, oldest entry first.
+func (c *Client) ModelStatusHistory(args params.ModelStatusHistoryRequest) (params.ModelStatusHistoryResult, error) {
+	if err := c.checkCanRead(); err != nil {
+		return params.ModelStatusHistoryResult{}, err
+	}
+	filter := state.ModelStatusHistoryFilter{
+		Applications: args.Applications,
+		Since:        args.Since,
+		Until:        args.Until,
+		Size:         args.Size,
+		Exclude:      set.NewStrings(args.Exclude...),
+	}
+	for _, kind := range args.Kinds {
+		filter.Kinds = append(filter.Kinds, status.HistoryKind(kind))
+	}
+	history, err := c.api.stateAccessor.ModelStatusHistory(filter)
+	if err != nil {
+		return params.ModelStatusHistoryResult{}, errors.Annotate(err, "fetching model status history")
+	}
+	result := params.ModelStatusHistoryResult{
+		Statuses: make([]params.ModelStatusHistoryEntry, len(history)),
+	}
+	for i, entry := range history {
+		result.Statuses[i] = params.ModelStatusHistoryEntry{
+			Tag:    entry.Entity.String(),
+			Kind:   string(entry.Kind),
+			Status: string(entry.Status),
+			Info:   entry.Message,
+			Data:   entry.Data,
+			Since:  entry.Since,
+		}
+	}
+	return result, nil
+}
+
 // FullStatus gives the information needed for juju status over the api
 func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error) {
 	if err := c.checkCanRead(); err != nil {
@@ -170,8 +206,11 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	}
 
 	var noStatus params.FullStatus
+	filters, err := parseStatusFilters(args.Filters)
+	if err != nil {
+		return noStatus, errors.Trace(err)
+	}
 	var context statusContext
-	var err error
 	if context.applications, context.units, context.latestCharms, err =
 		fetchAllApplicationsAndUnits(c.api.stateAccessor, len(args.Patterns) <= 0); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch applications and units")
@@ -290,6 +329,12 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		}
 	}
 
+	if len(filters) > 0 {
+		if err := context.applyStatusFilters(filters); err != nil {
+			return noStatus, errors.Annotate(err, "could not apply status filters")
+		}
+	}
+
 	modelStatus, err := c.modelStatus()
 	if err != nil {
 		return noStatus, errors.Annotate(err, "cannot determine model status")
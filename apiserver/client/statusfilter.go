@@ -0,0 +1,316 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// The keys which may be used in status filter terms, and the entities
+// which have a value for each. Units have a value for every key: the
+// application and charm are those of the unit's application, and the
+// machine is the status of the machine the unit is assigned to.
+var (
+	unitFilterKeys        = set.NewStrings("application", "charm", "workload", "agent", "machine")
+	applicationFilterKeys = set.NewStrings("application", "charm")
+	machineFilterKeys     = set.NewStrings("machine")
+)
+
+// filterTerm is a single key=value or key!=value term of a status
+// filter. The value may list alternatives separated by '|', and each
+// alternative may be a glob pattern.
+type filterTerm struct {
+	key    string
+	values []string
+	negate bool
+}
+
+// matches reports whether any of the given values of the term's key
+// satisfy the term.
+func (t filterTerm) matches(values []string) bool {
+	for _, pattern := range t.values {
+		for _, value := range values {
+			// Patterns are validated when parsed, so errors
+			// cannot happen here.
+			if ok, _ := path.Match(pattern, value); ok {
+				return !t.negate
+			}
+		}
+	}
+	return t.negate
+}
+
+// statusFilter is a parsed status filter expression. An entity matches
+// the filter if it satisfies every term.
+type statusFilter []filterTerm
+
+// appliesTo reports whether every term in the filter uses one of the
+// given keys.
+func (f statusFilter) appliesTo(keys set.Strings) bool {
+	for _, term := range f {
+		if !keys.Contains(term.key) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether an entity with the given values for each
+// key satisfies every term. A term whose key has no value never
+// matches, even if it is negated.
+func (f statusFilter) matches(values map[string][]string) bool {
+	for _, term := range f {
+		termValues, ok := values[term.key]
+		if !ok || !term.matches(termValues) {
+			return false
+		}
+	}
+	return true
+}
+
+// statusFilters holds the filters passed to FullStatus. An entity
+// matches if it matches any of them.
+type statusFilters []statusFilter
+
+// keys returns the keys used by any of the filters.
+func (fs statusFilters) keys() set.Strings {
+	keys := set.NewStrings()
+	for _, f := range fs {
+		for _, term := range f {
+			keys.Add(term.key)
+		}
+	}
+	return keys
+}
+
+// matches reports whether an entity with the given values matches any
+// of the filters. Filters using keys other than those the kind of
+// entity has values for are skipped.
+func (fs statusFilters) matches(keys set.Strings, values map[string][]string) bool {
+	for _, f := range fs {
+		if f.appliesTo(keys) && f.matches(values) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStatusFilters parses status filter expressions, such as
+// "workload=blocked|error,agent!=idle". Each expression is made of
+// terms separated by commas, all of which must be satisfied.
+func parseStatusFilters(exprs []string) (statusFilters, error) {
+	filters := make(statusFilters, len(exprs))
+	for i, expr := range exprs {
+		filter, err := parseStatusFilter(expr)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid filter %q", expr)
+		}
+		filters[i] = filter
+	}
+	return filters, nil
+}
+
+func parseStatusFilter(expr string) (statusFilter, error) {
+	var filter statusFilter
+	for _, part := range strings.Split(expr, ",") {
+		var term filterTerm
+		var value string
+		if i := strings.Index(part, "!="); i >= 0 {
+			term.key, value, term.negate = part[:i], part[i+2:], true
+		} else if i := strings.Index(part, "="); i >= 0 {
+			term.key, value = part[:i], part[i+1:]
+		} else {
+			return nil, errors.Errorf("expected key=value or key!=value, got %q", part)
+		}
+		term.key = strings.TrimSpace(term.key)
+		if !unitFilterKeys.Contains(term.key) {
+			return nil, errors.Errorf(
+				"unknown key %q, expected one of %s",
+				term.key, strings.Join(unitFilterKeys.SortedValues(), ", "),
+			)
+		}
+		for _, v := range strings.Split(value, "|") {
+			v = strings.TrimSpace(v)
+			if v == "" {
+				return nil, errors.Errorf("missing value for %q", term.key)
+			}
+			if _, err := path.Match(v, ""); err != nil {
+				return nil, errors.Errorf("invalid pattern %q for %q", v, term.key)
+			}
+			term.values = append(term.values, v)
+		}
+		filter = append(filter, term)
+	}
+	return filter, nil
+}
+
+// applyStatusFilters removes from the context every unit, application
+// and machine which does not match one of the filters, and is not
+// needed to show one which does: applications and machines are kept if
+// they have a matching unit, and host machines if they have a matching
+// container.
+func (context *statusContext) applyStatusFilters(filters statusFilters) error {
+	keys := filters.keys()
+	machines := make(map[string]*state.Machine)
+	for _, machineList := range context.machines {
+		for _, m := range machineList {
+			machines[m.Id()] = m
+		}
+	}
+
+	// Machine statuses are needed to filter both machines and
+	// units, so are only looked up once.
+	machineStatuses := make(map[string]string)
+	if keys.Contains("machine") {
+		for id, m := range machines {
+			statusInfo, err := common.MachineStatus(m)
+			if err != nil {
+				return errors.Annotatef(err, "could not get status of machine %s", id)
+			}
+			machineStatuses[id] = statusInfo.Status.String()
+		}
+	}
+
+	unitValues := func(unit *state.Unit) (map[string][]string, error) {
+		values := map[string][]string{
+			"application": {unit.ApplicationName()},
+		}
+		if keys.Contains("charm") {
+			application := context.applications[unit.ApplicationName()]
+			if application == nil {
+				var err error
+				if application, err = unit.Application(); err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+			values["charm"] = applicationCharmValues(application)
+		}
+		if keys.Contains("workload") || keys.Contains("agent") {
+			agent, workload := common.UnitStatus(unit)
+			if agent.Err != nil {
+				return nil, errors.Trace(agent.Err)
+			}
+			if workload.Err != nil {
+				return nil, errors.Trace(workload.Err)
+			}
+			values["agent"] = []string{agent.Status.Status.String()}
+			values["workload"] = []string{workload.Status.Status.String()}
+		}
+		if keys.Contains("machine") {
+			if machineId, err := unit.AssignedMachineId(); err == nil {
+				if machineStatus, ok := machineStatuses[machineId]; ok {
+					values["machine"] = []string{machineStatus}
+				}
+			}
+		}
+		return values, nil
+	}
+	unitMatches := func(unit *state.Unit) (bool, error) {
+		values, err := unitValues(unit)
+		if err != nil {
+			return false, errors.Annotatef(err, "could not filter unit %s", unit.Name())
+		}
+		return filters.matches(unitFilterKeys, values), nil
+	}
+
+	// Filter units. A principal is kept if it or any of its
+	// subordinates match; if only subordinates match, the other
+	// subordinates are removed.
+	matchedApplications := make(set.Strings)
+	matchedMachines := make(set.Strings)
+	for _, unitMap := range context.units {
+		for name, unit := range unitMap {
+			if !unit.IsPrincipal() {
+				continue
+			}
+			matches, err := unitMatches(unit)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			subMatches := false
+			for _, subName := range unit.SubordinateNames() {
+				subUnit := context.unitByName(subName)
+				if subUnit == nil {
+					continue
+				}
+				if !matches {
+					ok, err := unitMatches(subUnit)
+					if err != nil {
+						return errors.Trace(err)
+					}
+					if !ok {
+						delete(context.units[subUnit.ApplicationName()], subName)
+						continue
+					}
+					subMatches = true
+				}
+				matchedApplications.Add(subUnit.ApplicationName())
+			}
+			if !matches && !subMatches {
+				delete(unitMap, name)
+				continue
+			}
+			matchedApplications.Add(unit.ApplicationName())
+			if machineId, err := unit.AssignedMachineId(); err == nil {
+				matchedMachines.Add(machineId)
+			}
+		}
+	}
+
+	// Filter applications.
+	for name, application := range context.applications {
+		if matchedApplications.Contains(name) {
+			continue
+		}
+		values := map[string][]string{
+			"application": {name},
+			"charm":       applicationCharmValues(application),
+		}
+		if !filters.matches(applicationFilterKeys, values) {
+			delete(context.applications, name)
+		}
+	}
+
+	// Filter machines.
+	for id, m := range machines {
+		values := make(map[string][]string)
+		if machineStatus, ok := machineStatuses[id]; ok {
+			values["machine"] = []string{machineStatus}
+		}
+		if filters.matches(machineFilterKeys, values) {
+			matchedMachines.Add(id)
+		}
+	}
+	for topId, machineList := range context.machines {
+		matched := make([]*state.Machine, 0, len(machineList))
+		for _, m := range machineList {
+			containers, err := m.Containers()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if matchedMachines.Contains(m.Id()) || !matchedMachines.Intersection(set.NewStrings(containers...)).IsEmpty() {
+				matched = append(matched, m)
+			}
+		}
+		context.machines[topId] = matched
+	}
+	return nil
+}
+
+// applicationCharmValues returns the values an application has for the
+// "charm" filter key: the name of its charm, and the charm's full URL.
+func applicationCharmValues(application *state.Application) []string {
+	curl, _ := application.CharmURL()
+	if curl == nil {
+		return nil
+	}
+	return []string{curl.Name, curl.String()}
+}
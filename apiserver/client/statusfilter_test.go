@@ -0,0 +1,173 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing/factory"
+)
+
+type statusFilterSuite struct {
+	baseSuite
+
+	idleMachine *state.Machine
+	mysql0      *state.Unit
+	mysql1      *state.Unit
+	wordpress0  *state.Unit
+}
+
+var _ = gc.Suite(&statusFilterSuite{})
+
+func (s *statusFilterSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	s.mysql0 = s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: mysql,
+		Status:      &status.StatusInfo{Status: status.Active},
+	})
+	s.mysql1 = s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: mysql,
+		Status:      &status.StatusInfo{Status: status.Blocked, Message: "need a relation"},
+	})
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "wordpress",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	s.wordpress0 = s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: wordpress,
+		Status:      &status.StatusInfo{Status: status.Active},
+	})
+
+	// Machines hosting units are started, leaving only the idle
+	// machine pending.
+	for _, unit := range []*state.Unit{s.mysql0, s.mysql1, s.wordpress0} {
+		machineId, err := unit.AssignedMachineId()
+		c.Assert(err, jc.ErrorIsNil)
+		machine, err := s.State.Machine(machineId)
+		c.Assert(err, jc.ErrorIsNil)
+		now := time.Now()
+		err = machine.SetStatus(status.StatusInfo{Status: status.Started, Since: &now})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.idleMachine = s.Factory.MakeMachine(c, nil)
+}
+
+func (s *statusFilterSuite) filteredStatus(c *gc.C, filters ...string) *params.FullStatus {
+	status, err := s.APIState.Client().FilteredStatus(nil, filters)
+	c.Assert(err, jc.ErrorIsNil)
+	return status
+}
+
+func (s *statusFilterSuite) unitMachineId(c *gc.C, unit *state.Unit) string {
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	return machineId
+}
+
+func (s *statusFilterSuite) checkUnits(c *gc.C, status *params.FullStatus, expected map[string][]string) {
+	c.Assert(status.Applications, gc.HasLen, len(expected))
+	for name, units := range expected {
+		app, ok := status.Applications[name]
+		c.Assert(ok, jc.IsTrue, gc.Commentf("application %q missing", name))
+		c.Check(app.Units, gc.HasLen, len(units))
+		for _, unit := range units {
+			_, ok := app.Units[unit]
+			c.Check(ok, jc.IsTrue, gc.Commentf("unit %q missing", unit))
+		}
+	}
+}
+
+func (s *statusFilterSuite) checkMachines(c *gc.C, status *params.FullStatus, expected ...string) {
+	c.Assert(status.Machines, gc.HasLen, len(expected))
+	for _, id := range expected {
+		_, ok := status.Machines[id]
+		c.Check(ok, jc.IsTrue, gc.Commentf("machine %q missing", id))
+	}
+}
+
+func (s *statusFilterSuite) TestWorkload(c *gc.C) {
+	status := s.filteredStatus(c, "workload=blocked|error")
+	s.checkUnits(c, status, map[string][]string{
+		"mysql": {"mysql/1"},
+	})
+	s.checkMachines(c, status, s.unitMachineId(c, s.mysql1))
+}
+
+func (s *statusFilterSuite) TestCharm(c *gc.C) {
+	status := s.filteredStatus(c, "charm=mysql")
+	s.checkUnits(c, status, map[string][]string{
+		"mysql": {"mysql/0", "mysql/1"},
+	})
+	s.checkMachines(c, status, s.unitMachineId(c, s.mysql0), s.unitMachineId(c, s.mysql1))
+
+	status = s.filteredStatus(c, "charm=cs:quantal/word*")
+	s.checkUnits(c, status, map[string][]string{
+		"wordpress": {"wordpress/0"},
+	})
+}
+
+func (s *statusFilterSuite) TestAllTermsMustMatch(c *gc.C) {
+	status := s.filteredStatus(c, "workload=active,application!=wordpress")
+	s.checkUnits(c, status, map[string][]string{
+		"mysql": {"mysql/0"},
+	})
+	s.checkMachines(c, status, s.unitMachineId(c, s.mysql0))
+}
+
+func (s *statusFilterSuite) TestAnyFilterMayMatch(c *gc.C) {
+	status := s.filteredStatus(c, "workload=blocked", "application=wordpress")
+	s.checkUnits(c, status, map[string][]string{
+		"mysql":     {"mysql/1"},
+		"wordpress": {"wordpress/0"},
+	})
+	s.checkMachines(c, status, s.unitMachineId(c, s.mysql1), s.unitMachineId(c, s.wordpress0))
+}
+
+func (s *statusFilterSuite) TestMachine(c *gc.C) {
+	status := s.filteredStatus(c, "machine=pending")
+	s.checkUnits(c, status, nil)
+	s.checkMachines(c, status, s.idleMachine.Id())
+}
+
+func (s *statusFilterSuite) TestWithPatterns(c *gc.C) {
+	status, err := s.APIState.Client().FilteredStatus([]string{"mysql"}, []string{"workload=active"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkUnits(c, status, map[string][]string{
+		"mysql": {"mysql/0"},
+	})
+}
+
+func (s *statusFilterSuite) TestInvalidFilters(c *gc.C) {
+	for i, test := range []struct {
+		filter string
+		err    string
+	}{{
+		filter: "workload",
+		err:    `invalid filter "workload": expected key=value or key!=value, got "workload"`,
+	}, {
+		filter: "colour=red",
+		err:    `invalid filter "colour=red": unknown key "colour", expected one of agent, application, charm, machine, workload`,
+	}, {
+		filter: "workload=active|",
+		err:    `invalid filter "workload=active\|": missing value for "workload"`,
+	}, {
+		filter: "application=[my",
+		err:    `invalid filter "application=\[my": invalid pattern "\[my" for "application"`,
+	}} {
+		c.Logf("test %d: %s", i, test.filter)
+		_, err := s.APIState.Client().FilteredStatus(nil, []string{test.filter})
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
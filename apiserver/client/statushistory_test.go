@@ -8,12 +8,14 @@ import (
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/client"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing"
 )
@@ -218,10 +220,78 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestModelStatusHistory(c *gc.C) {
+	since := time.Unix(1000, 0)
+	until := time.Unix(2000, 0)
+	at := time.Unix(1500, 0)
+	s.st.modelHistory = []state.ModelStatusHistoryEntry{{
+		StatusInfo: status.StatusInfo{
+			Status:  status.Blocked,
+			Message: "waiting",
+			Since:   &at,
+		},
+		Entity: names.NewUnitTag("unit/0"),
+		Kind:   status.KindWorkload,
+	}, {
+		StatusInfo: status.StatusInfo{
+			Status: status.Started,
+			Since:  &at,
+		},
+		Entity: names.NewMachineTag("0"),
+		Kind:   status.KindMachine,
+	}}
+	result, err := s.api.ModelStatusHistory(params.ModelStatusHistoryRequest{
+		Kinds:        []string{"workload", "juju-machine"},
+		Applications: []string{"unit"},
+		Since:        &since,
+		Until:        &until,
+		Size:         10,
+		Exclude:      []string{"running update-status hook"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.st.modelHistoryFilter, jc.DeepEquals, state.ModelStatusHistoryFilter{
+		Kinds:        []status.HistoryKind{status.KindWorkload, status.KindMachine},
+		Applications: []string{"unit"},
+		Since:        &since,
+		Until:        &until,
+		Size:         10,
+		Exclude:      set.NewStrings("running update-status hook"),
+	})
+	c.Check(result, jc.DeepEquals, params.ModelStatusHistoryResult{
+		Statuses: []params.ModelStatusHistoryEntry{{
+			Tag:    "unit-unit-0",
+			Kind:   "workload",
+			Status: "blocked",
+			Info:   "waiting",
+			Since:  &at,
+		}, {
+			Tag:    "machine-0",
+			Kind:   "juju-machine",
+			Status: "started",
+			Since:  &at,
+		}},
+	})
+}
+
+func (s *statusHistoryTestSuite) TestModelStatusHistoryError(c *gc.C) {
+	s.st.modelHistoryErr = errors.New("boom")
+	_, err := s.api.ModelStatusHistory(params.ModelStatusHistoryRequest{})
+	c.Assert(err, gc.ErrorMatches, "fetching model status history: boom")
+}
+
 type mockState struct {
 	client.Backend
 	unitHistory  []status.StatusInfo
 	agentHistory []status.StatusInfo
+
+	modelHistory       []state.ModelStatusHistoryEntry
+	modelHistoryFilter state.ModelStatusHistoryFilter
+	modelHistoryErr    error
+}
+
+func (m *mockState) ModelStatusHistory(filter state.ModelStatusHistoryFilter) ([]state.ModelStatusHistoryEntry, error) {
+	m.modelHistoryFilter = filter
+	return m.modelHistory, m.modelHistoryErr
 }
 
 func (m *mockState) ModelUUID() string {
@@ -27,6 +27,8 @@ func ParamsActionExecutionResultsToStateActionResults(arg params.ActionExecution
 		status = state.ActionFailed
 	case params.ActionPending:
 		status = state.ActionPending
+	case params.ActionAborted:
+		status = state.ActionAborted
 	default:
 		return state.ActionResults{}, errors.Errorf("unrecognized action status '%s'", arg.Status)
 	}
@@ -159,6 +161,26 @@ func Actions(args params.Entities, actionFn func(string) (state.Action, error))
 	return results
 }
 
+// ActionStatuses returns the current status of each of the given
+// Actions. It needs an actionFn that can fetch an action from state
+// using its id, which is usually created by AuthAndActionFromTagFn.
+func ActionStatuses(args params.Entities, actionFn func(string) (state.Action, error)) params.StringResults {
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+
+	for i, arg := range args.Entities {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+		results.Results[i].Result = string(action.Status())
+	}
+
+	return results
+}
+
 // WatchOneActionReceiverNotifications to create a watcher for one receiver.
 // It needs a tagToActionReceiver function and a registerFunc to register
 // resources.
@@ -5,6 +5,7 @@ package common
 
 import (
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 )
 
@@ -21,13 +22,22 @@ func NewControllerConfig(st state.ControllerAccessor) *ControllerConfigAPI {
 	}
 }
 
-// ControllerConfig returns the controller's configuration.
+// ControllerConfig returns the controller's configuration, without
+// any secret attributes.
 func (s *ControllerConfigAPI) ControllerConfig() (params.ControllerConfigResult, error) {
 	result := params.ControllerConfigResult{}
 	config, err := s.st.ControllerConfig()
 	if err != nil {
 		return result, err
 	}
-	result.Config = params.ControllerConfig(config)
+	result.Config = make(params.ControllerConfig)
+	for name, value := range config {
+		result.Config[name] = value
+	}
+	// Secrets are only used by the controller itself, which reads
+	// them from state, so they are never handed out.
+	for _, name := range controller.SecretConfigAttributes {
+		delete(result.Config, name)
+	}
 	return result, nil
 }
@@ -26,18 +26,23 @@ var _ = gc.Suite(&controllerConfigSuite{})
 
 type fakeControllerAccessor struct {
 	controllerConfigError error
+	extra                 map[string]interface{}
 }
 
 func (f *fakeControllerAccessor) ControllerConfig() (controller.Config, error) {
 	if f.controllerConfigError != nil {
 		return nil, f.controllerConfigError
 	}
-	return map[string]interface{}{
+	cfg := map[string]interface{}{
 		controller.ControllerUUIDKey: testing.ControllerTag.Id(),
 		controller.CACertKey:         testing.CACert,
 		controller.APIPort:           4321,
 		controller.StatePort:         1234,
-	}, nil
+	}
+	for k, v := range f.extra {
+		cfg[k] = v
+	}
+	return cfg, nil
 }
 
 func (s *controllerConfigSuite) TearDownTest(c *gc.C) {
@@ -59,6 +64,25 @@ func (*controllerConfigSuite) TestControllerConfigSuccess(c *gc.C) {
 	})
 }
 
+func (*controllerConfigSuite) TestControllerConfigOmitsSecrets(c *gc.C) {
+	cc := common.NewControllerConfig(
+		&fakeControllerAccessor{extra: map[string]interface{}{
+			controller.AuditSyslogHost:         "syslog.example.com:6514",
+			controller.AuditSyslogClientKey:    "private key",
+			controller.BackupEncryptionKey:     "secret:key",
+			controller.BackupTargetS3AccessKey: "access key",
+			controller.BackupTargetS3SecretKey: "secret key",
+		}},
+	)
+	result, err := cc.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Config[controller.AuditSyslogHost], gc.Equals, "syslog.example.com:6514")
+	for _, name := range controller.SecretConfigAttributes {
+		_, ok := result.Config[name]
+		c.Check(ok, jc.IsFalse, gc.Commentf("%s", name))
+	}
+}
+
 func (*controllerConfigSuite) TestControllerConfigFetchError(c *gc.C) {
 	cc := common.NewControllerConfig(
 		&fakeControllerAccessor{
@@ -91,6 +91,7 @@ var (
 	ErrBadCreds           = errors.New("invalid entity name or password")
 	ErrNoCreds            = errors.New("no credentials provided")
 	ErrLoginExpired       = errors.New("login expired")
+	ErrPasswordExpired    = errors.New("password expired, use juju change-user-password to set a new one")
 	ErrPerm               = errors.New("permission denied")
 	ErrNotLoggedIn        = errors.New("not logged in")
 	ErrUnknownWatcher     = errors.New("unknown watcher id")
@@ -125,6 +126,7 @@ var singletonErrorCodes = map[error]string{
 	ErrBadCreds:                  params.CodeUnauthorized,
 	ErrNoCreds:                   params.CodeNoCreds,
 	ErrLoginExpired:              params.CodeLoginExpired,
+	ErrPasswordExpired:           params.CodeUnauthorized,
 	ErrPerm:                      params.CodeUnauthorized,
 	ErrNotLoggedIn:               params.CodeUnauthorized,
 	ErrUnknownWatcher:            params.CodeNotFound,
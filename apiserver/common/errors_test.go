@@ -88,6 +88,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeUnauthorized,
 	status:     http.StatusUnauthorized,
 	helperFunc: params.IsCodeUnauthorized,
+}, {
+	err:        common.ErrPasswordExpired,
+	code:       params.CodeUnauthorized,
+	status:     http.StatusUnauthorized,
+	helperFunc: params.IsCodeUnauthorized,
 }, {
 	err:        common.ErrPerm,
 	code:       params.CodeUnauthorized,
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/apiserver/metricsender"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
@@ -56,8 +57,13 @@ type ModelManagerBackend interface {
 	ControllerTag() names.ControllerTag
 	Export() (description.Model, error)
 	SetUserAccess(subject names.UserTag, target names.Tag, access permission.Access) (permission.UserAccess, error)
+	UserGroupAccess(group string, target names.Tag) (permission.Access, error)
+	UserAccessFromGroups(user names.UserTag, target names.Tag) (permission.Access, error)
+	SetUserGroupAccess(group string, target names.Tag, access permission.Access) error
+	RemoveUserGroupAccess(group string, target names.Tag) error
 	LastModelConnection(user names.UserTag) (time.Time, error)
 	LatestMigration() (state.ModelMigration, error)
+	ModelUsage() (quota.Resources, error)
 	DumpAll() (map[string]interface{}, error)
 	Close() error
 }
@@ -77,6 +83,8 @@ type Model interface {
 	Users() ([]permission.UserAccess, error)
 	Destroy() error
 	DestroyIncludingHosted() error
+	Quotas() quota.Resources
+	SetQuotas(quota.Resources) error
 }
 
 var _ ModelManagerBackend = (*modelManagerStateShim)(nil)
@@ -6,6 +6,7 @@ package common
 import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
@@ -52,13 +53,19 @@ func (m *ModelWatcher) WatchForModelConfigChanges() (params.NotifyWatchResult, e
 	return result, nil
 }
 
-// ModelConfig returns the current environment's configuration.
+// ModelConfig returns the current environment's configuration. Secret
+// attributes are only returned to controller agents.
 func (m *ModelWatcher) ModelConfig() (params.ModelConfigResult, error) {
 	result := params.ModelConfigResult{}
-	config, err := m.st.ModelConfig()
+	cfg, err := m.st.ModelConfig()
 	if err != nil {
 		return result, err
 	}
-	result.Config = config.AllAttrs()
+	result.Config = cfg.AllAttrs()
+	if !m.authorizer.AuthController() {
+		for _, name := range config.SecretAttributes {
+			delete(result.Config, name)
+		}
+	}
 	return result, nil
 }
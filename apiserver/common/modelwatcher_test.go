@@ -83,6 +83,41 @@ func (*environWatcherSuite) TestModelConfigSuccess(c *gc.C) {
 	c.Check(map[string]interface{}(result.Config), jc.DeepEquals, testingEnvConfig.AllAttrs())
 }
 
+func (*environWatcherSuite) TestModelConfigOmitsSecretsForOtherAgents(c *gc.C) {
+	cfg, err := testingEnvConfig(c).Apply(map[string]interface{}{
+		config.LogFwdElasticsearchURL:      "https://es.example.com:9200",
+		config.LogFwdElasticsearchUsername: "juju",
+		config.LogFwdElasticsearchPassword: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for i, test := range []struct {
+		controller bool
+		expected   bool
+	}{{
+		controller: true,
+		expected:   true,
+	}, {
+		controller: false,
+		expected:   false,
+	}} {
+		c.Logf("test %d", i)
+		authorizer := apiservertesting.FakeAuthorizer{
+			Tag:        names.NewMachineTag("0"),
+			Controller: test.controller,
+		}
+		e := common.NewModelWatcher(
+			&fakeModelAccessor{modelConfig: cfg},
+			nil,
+			authorizer,
+		)
+		result, err := e.ModelConfig()
+		c.Assert(err, jc.ErrorIsNil)
+		_, ok := result.Config[config.LogFwdElasticsearchPassword]
+		c.Check(ok, gc.Equals, test.expected)
+		c.Check(result.Config[config.LogFwdElasticsearchUsername], gc.Equals, "juju")
+	}
+}
+
 func (*environWatcherSuite) TestModelConfigFetchError(c *gc.C) {
 	authorizer := apiservertesting.FakeAuthorizer{
 		Tag:        names.NewMachineTag("0"),
@@ -23,11 +23,13 @@ const EveryoneTagName = "everyone@external"
 func UserAccess(st *state.State, utag names.UserTag) (modelUser, controllerUser permission.UserAccess, err error) {
 	var none permission.UserAccess
 	modelUser, err = st.UserAccess(utag, st.ModelTag())
+	modelUser, err = useUserGroupAccess(st, modelUser, err, utag, st.ModelTag())
 	if err != nil && !errors.IsNotFound(err) {
 		return none, none, errors.Trace(err)
 	}
 
 	controllerUser, err = state.ControllerAccess(st, utag)
+	controllerUser, err = useUserGroupAccess(st, controllerUser, err, utag, st.ControllerTag())
 	if err != nil && !errors.IsNotFound(err) {
 		return none, none, errors.Trace(err)
 	}
@@ -61,7 +63,9 @@ func HasPermission(userGetter userAccessFunc, utag names.Tag,
 	case permission.LoginAccess, permission.AddModelAccess, permission.SuperuserAccess:
 		validForKind = target.Kind() == names.ControllerTagKind
 	case permission.ReadAccess, permission.WriteAccess, permission.AdminAccess:
-		validForKind = target.Kind() == names.ModelTagKind
+		// Access granted on an application is checked on its own; it
+		// does not include the access granted on the model.
+		validForKind = target.Kind() == names.ModelTagKind || target.Kind() == names.ApplicationTagKind
 	}
 
 	if !validForKind {
@@ -102,7 +106,7 @@ func HasPermission(userGetter userAccessFunc, utag names.Tag,
 	if errors.IsNotFound(err) {
 		return false, nil
 	}
-	modelPermission := user.Access.EqualOrGreaterModelAccessThan(requestedPermission) && target.Kind() == names.ModelTagKind
+	modelPermission := user.Access.EqualOrGreaterModelAccessThan(requestedPermission) && target.Kind() != names.ControllerTagKind
 	controllerPermission := user.Access.EqualOrGreaterControllerAccessThan(requestedPermission) && target.Kind() == names.ControllerTagKind
 	if !controllerPermission && !modelPermission {
 		return false, nil
@@ -144,6 +148,99 @@ func maybeUseGroupPermission(
 
 type userAccessFunc func(names.UserTag, names.Tag) (permission.UserAccess, error)
 
+// UserAccessBackend provides the access granted to users and to the
+// local groups they are members of.
+type UserAccessBackend interface {
+	UserAccess(names.UserTag, names.Tag) (permission.UserAccess, error)
+	UserAccessFromGroups(names.UserTag, names.Tag) (permission.Access, error)
+}
+
+// EffectiveUserAccess returns a function that reports the access a user
+// has on a target, as the union of the access granted to the user and
+// to the local groups the user is a member of. It is suitable for use
+// with HasPermission.
+func EffectiveUserAccess(st UserAccessBackend) func(names.UserTag, names.Tag) (permission.UserAccess, error) {
+	return func(userTag names.UserTag, target names.Tag) (permission.UserAccess, error) {
+		userAccess, err := st.UserAccess(userTag, target)
+		return useUserGroupAccess(st, userAccess, err, userTag, target)
+	}
+}
+
+// AccessScope limits the access that a connection may use, whatever the
+// access of the user it is authenticated as.
+type AccessScope interface {
+	// MaxAccess returns the most access allowed on the target.
+	MaxAccess(target names.Tag) permission.Access
+}
+
+// ScopedUserAccess returns a function that reports the access a user has
+// on a target as userAccess does, limited by the scope. Targets outside
+// the scope are reported as not found.
+func ScopedUserAccess(
+	userAccess func(names.UserTag, names.Tag) (permission.UserAccess, error),
+	scope AccessScope,
+) func(names.UserTag, names.Tag) (permission.UserAccess, error) {
+	return func(userTag names.UserTag, target names.Tag) (permission.UserAccess, error) {
+		maxAccess := scope.MaxAccess(target)
+		if maxAccess == permission.NoAccess {
+			return permission.UserAccess{}, errors.NotFoundf("%s access for %q", target.Kind(), userTag.Id())
+		}
+		access, err := userAccess(userTag, target)
+		if err != nil {
+			return access, err
+		}
+		limited := access.Access.EqualOrGreaterModelAccessThan(maxAccess)
+		if target.Kind() == names.ControllerTagKind {
+			limited = access.Access.EqualOrGreaterControllerAccessThan(maxAccess)
+		}
+		if limited {
+			access.Access = maxAccess
+		}
+		return access, nil
+	}
+}
+
+// useUserGroupAccess returns the passed permission.UserAccess updated
+// with the access granted to the user's groups on target if higher than
+// current. The error is that of looking up the user's own access; if
+// the user lacks access of their own but their groups do not, a stand-in
+// will be created to hold the group access.
+func useUserGroupAccess(
+	st UserAccessBackend,
+	userAccess permission.UserAccess,
+	err error,
+	userTag names.UserTag,
+	target names.Tag,
+) (permission.UserAccess, error) {
+	if err != nil && !errors.IsNotFound(err) {
+		return userAccess, err
+	}
+	groupAccess, groupErr := st.UserAccessFromGroups(userTag, target)
+	if groupErr != nil {
+		return permission.UserAccess{}, errors.Annotate(groupErr, "obtaining group access")
+	}
+	if groupAccess == permission.NoAccess {
+		return userAccess, err
+	}
+	if permission.IsEmptyUserAccess(userAccess) {
+		return permission.UserAccess{
+			UserID:   strings.ToLower(userTag.Id()),
+			UserTag:  userTag,
+			Object:   target,
+			Access:   groupAccess,
+			UserName: userTag.Id(),
+		}, nil
+	}
+	greater := groupAccess.EqualOrGreaterModelAccessThan(userAccess.Access)
+	if target.Kind() == names.ControllerTagKind {
+		greater = groupAccess.EqualOrGreaterControllerAccessThan(userAccess.Access)
+	}
+	if greater {
+		userAccess.Access = groupAccess
+	}
+	return userAccess, nil
+}
+
 // newControllerUserFromGroup returns a permission.UserAccess that serves
 // as a stand-in for a user that has group access but no explicit user
 // access.
@@ -98,6 +98,22 @@ func (r *PermissionSuite) TestHasPermission(c *gc.C) {
 			access:           permission.AddModelAccess,
 			expected:         true,
 		},
+		{
+			title:            "application permissions also work",
+			userGetterAccess: permission.WriteAccess,
+			user:             names.NewUserTag("validuser"),
+			target:           names.NewApplicationTag("mysql"),
+			access:           permission.WriteAccess,
+			expected:         true,
+		},
+		{
+			title:            "user requests controller permission on application",
+			userGetterAccess: permission.WriteAccess,
+			user:             names.NewUserTag("validuser"),
+			target:           names.NewApplicationTag("mysql"),
+			access:           permission.AddModelAccess,
+			expected:         false,
+		},
 	}
 	for i, t := range testCases {
 		userGetter := &fakeUserAccess{
@@ -22,7 +22,9 @@ import (
 	"github.com/juju/juju/apiserver/common/cloudspec"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
@@ -33,6 +35,12 @@ var logger = loggo.GetLogger("juju.apiserver.controller")
 
 func init() {
 	common.RegisterStandardFacade("Controller", 3, NewControllerAPI)
+
+	// Facade version 4 adds the AuditLog() method.
+	common.RegisterStandardFacade("Controller", 4, NewControllerAPI)
+
+	// Facade version 5 adds group access to ModifyControllerAccess.
+	common.RegisterStandardFacade("Controller", 5, NewControllerAPI)
 }
 
 // Controller defines the methods on the controller API end point.
@@ -49,6 +57,7 @@ type Controller interface {
 	ModelStatus(params.Entities) (params.ModelStatusResults, error)
 	InitiateMigration(params.InitiateMigrationArgs) (params.InitiateMigrationResults, error)
 	ModifyControllerAccess(params.ModifyControllerAccessRequest) (params.ErrorResults, error)
+	AuditLog(params.AuditLogQuery) (params.AuditLogResults, error)
 }
 
 // ControllerAPI implements the environment manager interface and is
@@ -233,6 +242,9 @@ func (s *ControllerAPI) ModelConfig() (params.ModelConfigResults, error) {
 			Value: val,
 		}
 	}
+	for _, name := range config.SecretAttributes {
+		delete(result.Config, name)
+	}
 	return result, nil
 }
 
@@ -352,7 +364,7 @@ func (c *ControllerAPI) GetControllerAccess(req params.Entities) (params.UserAcc
 			results.Results[i].Error = common.ServerError(common.ErrPerm)
 			continue
 		}
-		accessInfo, err := c.state.UserAccess(userTag, c.state.ControllerTag())
+		accessInfo, err := common.EffectiveUserAccess(c.state)(userTag, c.state.ControllerTag())
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
@@ -476,6 +488,12 @@ func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAcces
 			continue
 		}
 
+		if arg.Group != "" {
+			result.Results[i].Error = common.ServerError(
+				changeControllerGroupAccess(c.state, arg.Group, arg.Action, controllerAccess))
+			continue
+		}
+
 		targetUserTag, err := names.ParseUserTag(arg.UserTag)
 		if err != nil {
 			result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify controller access"))
@@ -488,6 +506,56 @@ func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAcces
 	return result, nil
 }
 
+// maxAuditLogEntries is the maximum number of audit log entries
+// returned by a single AuditLog call.
+const maxAuditLogEntries = 1000
+
+// AuditLog returns the entries in the controller's audit log matching
+// the query, oldest first. At most maxAuditLogEntries entries are
+// returned; callers should use the query's offset to page through
+// larger result sets.
+func (c *ControllerAPI) AuditLog(args params.AuditLogQuery) (params.AuditLogResults, error) {
+	result := params.AuditLogResults{}
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	query := audit.Query{
+		ModelUUID:  args.ModelUUID,
+		OriginName: args.OriginName,
+		Operation:  args.Operation,
+		Offset:     args.Offset,
+		Limit:      args.Limit,
+	}
+	if args.After != nil {
+		query.After = *args.After
+	}
+	if args.Before != nil {
+		query.Before = *args.Before
+	}
+	if query.Limit == 0 || query.Limit > maxAuditLogEntries {
+		query.Limit = maxAuditLogEntries
+	}
+	entries, err := c.state.AuditEntries(query)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Entries = make([]params.AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditLogEntry{
+			JujuServerVersion: entry.JujuServerVersion.String(),
+			ModelUUID:         entry.ModelUUID,
+			Timestamp:         entry.Timestamp,
+			RemoteAddress:     entry.RemoteAddress,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			Operation:         entry.Operation,
+			Data:              entry.Data,
+		}
+	}
+	return result, nil
+}
+
 var runMigrationPrechecks = func(st *state.State, targetInfo coremigration.TargetInfo) error {
 	// Check model and source controller.
 	backend, err := migration.PrecheckShim(st)
@@ -633,6 +701,50 @@ func ChangeControllerAccess(accessor *state.State, apiUser, targetUserTag names.
 	}
 }
 
+// changeControllerGroupAccess performs the requested access grant or
+// revoke action for the specified local group on the controller.
+func changeControllerGroupAccess(accessor *state.State, group string, action params.ControllerAction, access permission.Access) error {
+	controllerTag := accessor.ControllerTag()
+	current, err := accessor.UserGroupAccess(group, controllerTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "could not look up controller access for group")
+	}
+	hasAccess := err == nil
+
+	switch action {
+	case params.GrantControllerAccess:
+		// Only set access if greater access is being granted.
+		if hasAccess && current.EqualOrGreaterControllerAccessThan(access) {
+			err = errors.Errorf("group already has %q access or greater", access)
+		} else {
+			err = accessor.SetUserGroupAccess(group, controllerTag, access)
+		}
+		return errors.Annotate(err, "could not grant controller access")
+	case params.RevokeControllerAccess:
+		if !hasAccess {
+			return errors.Errorf("group %q has no access to controller", group)
+		}
+		switch access {
+		case permission.LoginAccess:
+			// Revoking login access removes all access.
+			err := accessor.RemoveUserGroupAccess(group, controllerTag)
+			return errors.Annotate(err, "could not revoke controller access")
+		case permission.AddModelAccess:
+			// Revoking add-model access sets login.
+			err := accessor.SetUserGroupAccess(group, controllerTag, permission.LoginAccess)
+			return errors.Annotate(err, "could not set controller access to read-only")
+		case permission.SuperuserAccess:
+			// Revoking superuser sets add-model.
+			err := accessor.SetUserGroupAccess(group, controllerTag, permission.AddModelAccess)
+			return errors.Annotate(err, "could not set controller access to add-model")
+		default:
+			return errors.Errorf("don't know how to revoke %q access", access)
+		}
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
+
 func (o orderedBlockInfo) Swap(i, j int) {
 	o[i], o[j] = o[j], o[i]
 }
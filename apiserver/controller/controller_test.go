@@ -12,6 +12,7 @@ import (
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
@@ -22,6 +23,7 @@ import (
 	"github.com/juju/juju/apiserver/facade/facadetest"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -690,6 +692,56 @@ func (s *controllerSuite) TestGrantControllerAddRemoteUser(c *gc.C) {
 	c.Assert(controllerUser.Access, gc.Equals, permission.AddModelAccess)
 }
 
+func (s *controllerSuite) modifyControllerGroupAccess(c *gc.C, group string, action params.ControllerAction, access string) error {
+	args := params.ModifyControllerAccessRequest{
+		Changes: []params.ModifyControllerAccess{{
+			Group:  group,
+			Action: action,
+			Access: access,
+		}}}
+	result, err := s.controller.ModifyControllerAccess(args)
+	c.Assert(err, jc.ErrorIsNil)
+	return result.OneError()
+}
+
+func (s *controllerSuite) TestGrantControllerToGroup(c *gc.C) {
+	_, err := s.State.AddUserGroup("ops", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyControllerGroupAccess(c, "ops", params.GrantControllerAccess, string(permission.AddModelAccess))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.modifyControllerGroupAccess(c, "ops", params.GrantControllerAccess, string(permission.LoginAccess))
+	c.Assert(err, gc.ErrorMatches, `could not grant controller access: group already has "login" access or greater`)
+
+	access, err := s.State.UserGroupAccess("ops", s.State.ControllerTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AddModelAccess)
+
+	err = s.modifyControllerGroupAccess(c, "dev", params.GrantControllerAccess, string(permission.LoginAccess))
+	c.Assert(err, gc.ErrorMatches, `could not grant controller access: group "dev" not found`)
+}
+
+func (s *controllerSuite) TestRevokeControllerFromGroup(c *gc.C) {
+	_, err := s.State.AddUserGroup("ops", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUserGroupAccess("ops", s.State.ControllerTag(), permission.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyControllerGroupAccess(c, "ops", params.RevokeControllerAccess, string(permission.SuperuserAccess))
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.UserGroupAccess("ops", s.State.ControllerTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AddModelAccess)
+
+	err = s.modifyControllerGroupAccess(c, "ops", params.RevokeControllerAccess, string(permission.LoginAccess))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserGroupAccess("ops", s.State.ControllerTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.modifyControllerGroupAccess(c, "ops", params.RevokeControllerAccess, string(permission.LoginAccess))
+	c.Assert(err, gc.ErrorMatches, `group "ops" has no access to controller`)
+}
+
 func (s *controllerSuite) TestGrantControllerInvalidUserTag(c *gc.C) {
 	for _, testParam := range []struct {
 		tag      string
@@ -804,6 +856,26 @@ func (s *controllerSuite) TestGetControllerAccess(c *gc.C) {
 		}}})
 }
 
+func (s *controllerSuite) TestGetControllerAccessFromGroup(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	group, err := s.State.AddUserGroup("ops", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMembers(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUserGroupAccess("ops", s.State.ControllerTag(), permission.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.controller.GetControllerAccess(params.Entities{
+		Entities: []params.Entity{{Tag: user.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.DeepEquals, []params.UserAccessResult{{
+		Result: &params.UserAccess{
+			Access:  "superuser",
+			UserTag: user.Tag().String(),
+		}}})
+}
+
 func (s *controllerSuite) TestGetControllerAccessPermissions(c *gc.C) {
 	// Set up the user making the call.
 	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
@@ -843,3 +915,66 @@ func (s *controllerSuite) TestGetControllerAccessPermissions(c *gc.C) {
 		Message: "permission denied", Code: "unauthorized access",
 	})
 }
+
+func (s *controllerSuite) TestAuditLog(c *gc.C) {
+	put := s.State.PutAuditEntryFn()
+	start := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i, op := range []string{"ApplicationDeploy", "ApplicationDestroy", "ApplicationDestroy"} {
+		err := put(audit.AuditEntry{
+			JujuServerVersion: version.MustParse("2.2.0"),
+			ModelUUID:         s.State.ModelUUID(),
+			Timestamp:         start.Add(time.Duration(i) * time.Hour),
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "user",
+			OriginName:        "user-bob",
+			Operation:         op,
+			Data:              map[string]interface{}{"application": "mysql"},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	after := start.Add(time.Minute)
+	results, err := s.controller.AuditLog(params.AuditLogQuery{
+		ModelUUID: s.State.ModelUUID(),
+		Operation: "ApplicationDestroy",
+		After:     &after,
+		Limit:     1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Entries, jc.DeepEquals, []params.AuditLogEntry{{
+		JujuServerVersion: "2.2.0",
+		ModelUUID:         s.State.ModelUUID(),
+		Timestamp:         start.Add(time.Hour),
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "user",
+		OriginName:        "user-bob",
+		Operation:         "ApplicationDestroy",
+		Data:              map[string]interface{}{"application": "mysql"},
+	}})
+
+	results, err = s.controller.AuditLog(params.AuditLogQuery{Offset: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Entries, gc.HasLen, 2)
+	c.Assert(results.Entries[0].Timestamp, gc.Equals, start.Add(time.Hour))
+}
+
+func (s *controllerSuite) TestAuditLogInvalidQuery(c *gc.C) {
+	_, err := s.controller.AuditLog(params.AuditLogQuery{ModelUUID: "foo"})
+	c.Assert(err, gc.ErrorMatches, `ModelUUID "foo" not valid`)
+}
+
+func (s *controllerSuite) TestAuditLogRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPI(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.AuditLog(params.AuditLogQuery{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -59,6 +60,11 @@ func newDebugLogHandler(
 //   excludeEntity -> []string - lists entity tags to exclude from the response
 //      - as with include, it may finish with a '*'
 //   excludeModule -> []string - lists logging modules to exclude from the response
+//   includeMessage -> []string - lists regular expressions matching messages to
+//      include in the response
+//      - if none are set, then all lines are considered included
+//   excludeMessage -> []string - lists regular expressions matching messages to
+//      exclude from the response
 //   limit -> uint - show *at most* this many lines
 //   backlog -> uint
 //      - go back this many lines from the end before starting to filter
@@ -67,6 +73,9 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - RFC3339 time, only logs recorded at or after it are sent
+//   endTime -> string - RFC3339 time, only logs recorded before it are sent
+//      - implies noTail, as no new logs can match
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler := func(conn *websocket.Conn) {
 		socket := &debugLogSocketImpl{conn}
@@ -144,16 +153,19 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	startTime     time.Time
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
+	startTime      time.Time
+	endTime        time.Time
+	maxLines       uint
+	fromTheStart   bool
+	noTail         bool
+	backlog        uint
+	filterLevel    loggo.Level
+	includeEntity  []string
+	excludeEntity  []string
+	includeModule  []string
+	excludeModule  []string
+	includeMessage []string
+	excludeMessage []string
 }
 
 func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
@@ -209,10 +221,31 @@ func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		if !endTime.After(params.startTime) {
+			return nil, errors.Errorf("end time %q is not after start time", value)
+		}
+		params.endTime = endTime
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
 
+	for _, key := range []string{"includeMessage", "excludeMessage"} {
+		for _, value := range queryMap[key] {
+			if _, err := regexp.Compile(value); err != nil {
+				return nil, errors.Errorf("%s value %q is not a valid regular expression", key, value)
+			}
+		}
+	}
+	params.includeMessage = queryMap["includeMessage"]
+	params.excludeMessage = queryMap["excludeMessage"]
+
 	return params, nil
 }
@@ -30,6 +30,21 @@ func (s *debugLogDBSuite) TestBadParams(c *gc.C) {
 	assertWebsocketClosed(c, reader)
 }
 
+func (s *debugLogDBSuite) TestBadMessageRegex(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"includeMessage": {"foo("}})
+	assertJSONError(c, reader, `includeMessage value "foo\(" is not a valid regular expression`)
+	assertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogDBSuite) TestEndTimeBeforeStartTime(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{
+		"startTime": {"2016-11-30T11:00:00Z"},
+		"endTime":   {"2016-11-30T10:00:00Z"},
+	})
+	assertJSONError(c, reader, `end time "2016-11-30T10:00:00Z" is not after start time`)
+	assertWebsocketClosed(c, reader)
+}
+
 func (s *debugLogDBSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL(c, "http", nil).String()
 	s.sendRequest(c, httpRequestParams{
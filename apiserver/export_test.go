@@ -126,6 +126,20 @@ func TestingMigratingRoot(st *state.State) rpc.Root {
 	return restrictRoot(r, migrationClientMethodsOnly)
 }
 
+// TestingPasswordExpiredRoot returns a restricted srvRoot as if
+// logged in as a user whose password has expired.
+func TestingPasswordExpiredRoot(st *state.State) rpc.Root {
+	r := TestingAPIRoot(st)
+	return restrictRoot(r, passwordChangeMethodsOnly)
+}
+
+// TestingTokenRoot returns a restricted srvRoot as if logged in with
+// an API token.
+func TestingTokenRoot(st *state.State) rpc.Root {
+	r := TestingAPIRoot(st)
+	return restrictRoot(r, tokenMethodsOnly)
+}
+
 // TestingControllerOnlyRoot returns a restricted srvRoot as if
 // logged in to the root of the API path.
 func TestingControllerOnlyRoot() rpc.Root {
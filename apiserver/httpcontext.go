@@ -14,8 +14,10 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 
+	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
 
@@ -122,6 +124,12 @@ func (ctxt *httpContext) stateForRequestAuthenticated(r *http.Request) (
 		// "unauthorized".
 		return nil, nil, nil, errors.Trace(errors.NewUnauthorized(err, ""))
 	}
+	// API tokens only grant access to their own models.
+	if token, ok := entity.(*authentication.TokenEntity); ok {
+		if token.Token.MaxAccess(st.ModelTag()) == permission.NoAccess {
+			return nil, nil, nil, errors.NewUnauthorized(common.ErrPerm, "")
+		}
+	}
 	return st, releaser, entity, nil
 }
 
@@ -158,7 +166,12 @@ func (ctxt *httpContext) stateForMigration(r *http.Request, requiredMode state.M
 	if !st.IsController() {
 		return nil, nil, errors.BadRequestf("model is not controller model")
 	}
-	admin, err := st.IsControllerAdmin(user.Tag().(names.UserTag))
+	admin, err := common.HasPermission(
+		entityUserAccess(st, user),
+		user.Tag(),
+		permission.SuperuserAccess,
+		st.ControllerTag(),
+	)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -40,10 +40,11 @@ func (h introspectionHandler) checkAuth(r *http.Request) error {
 
 	// Users with "superuser" access on the controller,
 	// or "read" access on the controller model, can
-	// access these endpoints.
+	// access these endpoints. Access is limited by the
+	// API token used to authenticate, if any.
 
 	ok, err := common.HasPermission(
-		st.UserAccess,
+		entityUserAccess(st, entity),
 		entity.Tag(),
 		permission.SuperuserAccess,
 		st.ControllerTag(),
@@ -60,7 +61,7 @@ func (h introspectionHandler) checkAuth(r *http.Request) error {
 		return errors.Trace(err)
 	}
 	ok, err = common.HasPermission(
-		st.UserAccess,
+		entityUserAccess(st, entity),
 		entity.Tag(),
 		permission.ReadAccess,
 		controllerModel.ModelTag(),
@@ -82,7 +82,7 @@ func (api *KeyManagerAPI) checkCanRead(sshUser string) error {
 		return common.ErrPerm
 	}
 	ok, err := common.HasPermission(
-		api.state.UserAccess,
+		common.EffectiveUserAccess(api.state),
 		*api.apiUser,
 		permission.ReadAccess,
 		api.state.ModelTag(),
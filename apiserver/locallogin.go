@@ -16,6 +16,7 @@ import (
 	macaroon "gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 )
@@ -65,10 +66,7 @@ func (h *localLoginHandlers) serveLoginPost(p httprequest.Params) (interface{},
 		return nil, errors.NotValidf("non-local username %q", username)
 	}
 
-	authenticator := h.authCtxt.authenticator(p.Request.Host)
-	if _, err := authenticator.Authenticate(h.state, userTag, params.LoginRequest{
-		Credentials: password,
-	}); err != nil {
+	if err := h.authenticate(p.Request.Host, userTag, password); err != nil {
 		// Mark the interaction as done (but failed),
 		// unblocking a pending "/auth/wait" request.
 		if err := h.authCtxt.localUserInteractions.Done(waitId, userTag, err); err != nil {
@@ -108,6 +106,20 @@ func (h *localLoginHandlers) serveLoginPost(p httprequest.Params) (interface{},
 	return nil, nil
 }
 
+// authenticate checks the password of the local user logging in.
+// API tokens are refused: the macaroon issued on login carries the
+// full access of the user, which the token may not have.
+func (h *localLoginHandlers) authenticate(host string, userTag names.UserTag, password string) error {
+	if state.IsAPIToken(password) {
+		return errors.Trace(common.ErrBadCreds)
+	}
+	authenticator := h.authCtxt.authenticator(host)
+	_, err := authenticator.Authenticate(h.state, userTag, params.LoginRequest{
+		Credentials: password,
+	})
+	return errors.Trace(err)
+}
+
 func (h *localLoginHandlers) serveLoginGet(p httprequest.Params) (interface{}, error) {
 	if p.Request.Header.Get("Accept") == "application/json" {
 		// The application/json content-type is used to
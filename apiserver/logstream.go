@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/schema"
 	"github.com/gorilla/websocket"
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/featureflag"
 
@@ -54,6 +55,9 @@ func newLogStreamEndpointHandler(ctxt httpContext) *logStreamEndpointHandler {
 // Args for the HTTP request are as follows:
 //   all -> string - one of [true, false], if true, include records from all models
 //   sink -> string - the name of the the log forwarding target
+//   level -> string - the minimum level of records to stream
+//   includeEntity, excludeEntity -> []string - filter records by entity
+//   includeModule, excludeModule -> []string - filter records by module
 func (h *logStreamEndpointHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger.Infof("log stream request handler starting")
 	handler := func(conn *websocket.Conn) {
@@ -127,9 +131,21 @@ func (h *logStreamEndpointHandler) newTailer(source logStreamSource, cfg params.
 	}
 
 	tailerArgs := &state.LogTailerParams{
-		StartTime:    start,
-		InitialLines: cfg.MaxLookbackRecords,
-		AllModels:    cfg.AllModels,
+		StartTime:     start,
+		InitialLines:  cfg.MaxLookbackRecords,
+		AllModels:     cfg.AllModels,
+		IncludeEntity: cfg.IncludeEntity,
+		ExcludeEntity: cfg.ExcludeEntity,
+		IncludeModule: cfg.IncludeModule,
+		ExcludeModule: cfg.ExcludeModule,
+	}
+	if cfg.Level != "" {
+		level, ok := loggo.ParseLevel(cfg.Level)
+		if !ok || level < loggo.TRACE || level > loggo.ERROR {
+			return nil, errors.Errorf("level value %q is not one of %q, %q, %q, %q, %q",
+				cfg.Level, loggo.TRACE, loggo.DEBUG, loggo.INFO, loggo.WARNING, loggo.ERROR)
+		}
+		tailerArgs.MinLevel = level
 	}
 	tailer, err := source.newTailer(tailerArgs)
 	if err != nil {
@@ -61,6 +61,59 @@ func (s *LogStreamIntSuite) TestParamConversion(c *gc.C) {
 	})
 }
 
+func (s *LogStreamIntSuite) TestParamConversionFilters(c *gc.C) {
+	cfg := params.LogStreamConfig{
+		AllModels:     true,
+		Sink:          "spam",
+		Level:         "INFO",
+		IncludeEntity: []string{"unit-mysql-*", "machine-0"},
+		ExcludeEntity: []string{"unit-mysql-1"},
+		IncludeModule: []string{"juju.worker"},
+		ExcludeModule: []string{"juju.worker.uniter"},
+	}
+	req := s.newReq(c, cfg)
+
+	stub := &testing.Stub{}
+	source := &stubSource{stub: stub}
+	source.ReturnGetStart = 10
+	handler := logStreamEndpointHandler{
+		stopCh:    nil,
+		newSource: source.newSource,
+	}
+
+	_, err := handler.newLogStreamRequestHandler(nil, req, clock.WallClock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCallNames(c, "newSource", "getStart", "newTailer")
+	stub.CheckCall(c, 2, "newTailer", &state.LogTailerParams{
+		StartTime:     time.Unix(10, 0),
+		AllModels:     true,
+		MinLevel:      loggo.INFO,
+		IncludeEntity: []string{"unit-mysql-*", "machine-0"},
+		ExcludeEntity: []string{"unit-mysql-1"},
+		IncludeModule: []string{"juju.worker"},
+		ExcludeModule: []string{"juju.worker.uniter"},
+	})
+}
+
+func (s *LogStreamIntSuite) TestParamBadLevel(c *gc.C) {
+	cfg := params.LogStreamConfig{
+		Sink:  "spam",
+		Level: "CHATTY",
+	}
+	req := s.newReq(c, cfg)
+
+	stub := &testing.Stub{}
+	source := &stubSource{stub: stub}
+	handler := logStreamEndpointHandler{
+		stopCh:    nil,
+		newSource: source.newSource,
+	}
+
+	_, err := handler.newLogStreamRequestHandler(nil, req, clock.WallClock)
+	c.Assert(err, gc.ErrorMatches, `creating new tailer: level value "CHATTY" is not one of "TRACE", "DEBUG", "INFO", "WARNING", "ERROR"`)
+}
+
 type mockClock struct {
 	clock.Clock
 	now time.Time
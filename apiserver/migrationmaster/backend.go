@@ -7,6 +7,7 @@ import (
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
@@ -20,6 +21,7 @@ type Backend interface {
 	ModelName() (string, error)
 	ModelOwner() (names.UserTag, error)
 	AgentVersion() (version.Number, error)
+	ModelQuotas() (quota.Resources, error)
 	RemoveExportingModelDocs() error
 
 	migration.StateExporter
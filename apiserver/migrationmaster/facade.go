@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state/watcher"
 )
@@ -128,11 +129,17 @@ func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
 		return empty, errors.Annotate(err, "retrieving agent version")
 	}
 
+	quotas, err := api.backend.ModelQuotas()
+	if err != nil {
+		return empty, errors.Annotate(err, "retrieving model quotas")
+	}
+
 	return params.MigrationModelInfo{
 		UUID:         api.backend.ModelUUID(),
 		Name:         name,
 		OwnerTag:     owner.String(),
 		AgentVersion: vers,
+		Quotas:       paramsModelQuotas(quotas),
 	}, nil
 }
 
@@ -189,6 +196,14 @@ func (api *API) Export() (params.SerializedModel, error) {
 	serialized.Charms = getUsedCharms(model)
 	serialized.Tools = getUsedTools(model)
 	serialized.Resources = getUsedResources(model)
+
+	// The model description has no place for the model's quotas,
+	// so they are sent alongside it.
+	quotas, err := api.backend.ModelQuotas()
+	if err != nil {
+		return serialized, errors.Annotate(err, "retrieving model quotas")
+	}
+	serialized.Quotas = paramsModelQuotas(quotas)
 	return serialized, nil
 }
 
@@ -362,3 +377,18 @@ func revisionToSerialized(rr description.ResourceRevision) params.SerializedMode
 		Username:       rr.Username(),
 	}
 }
+
+// paramsModelQuotas returns the quotas to send for a model, or nil if
+// the model has none.
+func paramsModelQuotas(r quota.Resources) *params.ModelQuotas {
+	if r == (quota.Resources{}) {
+		return nil
+	}
+	return &params.ModelQuotas{
+		Machines: r.Machines,
+		Units:    r.Units,
+		Cores:    r.Cores,
+		Memory:   r.Memory,
+		Storage:  r.Storage,
+	}
+}
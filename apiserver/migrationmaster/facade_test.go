@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
@@ -129,6 +130,15 @@ func (s *Suite) TestModelInfo(c *gc.C) {
 	c.Assert(model.Name, gc.Equals, "model-name")
 	c.Assert(model.OwnerTag, gc.Equals, names.NewUserTag("owner").String())
 	c.Assert(model.AgentVersion, gc.Equals, version.MustParse("1.2.3"))
+	c.Assert(model.Quotas, gc.IsNil)
+}
+
+func (s *Suite) TestModelInfoQuotas(c *gc.C) {
+	s.backend.quotas = quota.Resources{Machines: 5, Memory: 8192}
+	api := s.mustMakeAPI(c)
+	model, err := api.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Quotas, gc.DeepEquals, &params.ModelQuotas{Machines: 5, Memory: 8192})
 }
 
 func (s *Suite) TestSetPhase(c *gc.C) {
@@ -262,6 +272,7 @@ func (s *Suite) TestExport(c *gc.C) {
 	c.Check(string(serialized.Bytes), jc.Contains, jujuversion.Current.String())
 
 	c.Check(serialized.Charms, gc.DeepEquals, []string{"cs:foo-0"})
+	c.Check(serialized.Quotas, gc.IsNil)
 	c.Check(serialized.Tools, jc.SameContents, []params.SerializedModelTools{
 		{tools0, "/tools/" + tools0},
 		{tools1, "/tools/" + tools1},
@@ -308,6 +319,14 @@ func (s *Suite) TestExport(c *gc.C) {
 
 }
 
+func (s *Suite) TestExportQuotas(c *gc.C) {
+	s.backend.quotas = quota.Resources{Units: 10}
+	api := s.mustMakeAPI(c)
+	serialized, err := api.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serialized.Quotas, gc.DeepEquals, &params.ModelQuotas{Units: 10})
+}
+
 func (s *Suite) TestReap(c *gc.C) {
 	api := s.mustMakeAPI(c)
 
@@ -413,6 +432,7 @@ type stubBackend struct {
 	removeErr error
 	migration *stubMigration
 	model     description.Model
+	quotas    quota.Resources
 }
 
 func (b *stubBackend) WatchForMigration() state.NotifyWatcher {
@@ -444,6 +464,10 @@ func (b *stubBackend) AgentVersion() (version.Number, error) {
 	return version.MustParse("1.2.3"), nil
 }
 
+func (b *stubBackend) ModelQuotas() (quota.Resources, error) {
+	return b.quotas, nil
+}
+
 func (b *stubBackend) RemoveExportingModelDocs() error {
 	b.stub.AddCall("RemoveExportingModelDocs")
 	return b.removeErr
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
@@ -63,3 +64,12 @@ func (s *backendShim) AgentVersion() (version.Number, error) {
 	}
 	return vers, nil
 }
+
+// ModelQuotas implements Backend.
+func (s *backendShim) ModelQuotas() (quota.Resources, error) {
+	model, err := s.Model()
+	if err != nil {
+		return quota.Resources{}, errors.Trace(err)
+	}
+	return model.Quotas(), nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/permission"
@@ -22,6 +23,9 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("MigrationTarget", 1, newAPIWithRealEnviron)
+
+	// Facade version 2 adds SetQuotas.
+	common.RegisterStandardFacade("MigrationTarget", 2, newAPIWithRealEnviron)
 }
 
 // API implements the API required for the model migration
@@ -161,6 +165,24 @@ func (api *API) Activate(args params.ModelArgs) error {
 	return model.SetMigrationMode(state.MigrationModeNone)
 }
 
+// SetQuotas sets the quotas on the resources that an imported model
+// may use, which the model description has no place for. It is an
+// error to set the quotas of a model that has a migration mode other
+// than importing.
+func (api *API) SetQuotas(args params.SetModelQuotas) error {
+	model, err := api.getImportingModel(params.ModelArgs{ModelTag: args.ModelTag})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(model.SetQuotas(quota.Resources{
+		Machines: args.Quotas.Machines,
+		Units:    args.Quotas.Units,
+		Cores:    args.Quotas.Cores,
+		Memory:   args.Quotas.Memory,
+		Storage:  args.Quotas.Storage,
+	}))
+}
+
 // LatestLogTime returns the time of the most recent log record
 // received by the logtransfer endpoint. This can be used as the start
 // point for streaming logs from the source if the transfer was
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
@@ -197,6 +198,35 @@ func (s *Suite) TestActivateNotImportingEnv(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
 }
 
+func (s *Suite) TestSetQuotas(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+
+	err := api.SetQuotas(params.SetModelQuotas{
+		ModelTag: tag.String(),
+		Quotas:   params.ModelQuotas{Machines: 5, Memory: 8192},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.GetModel(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Quotas(), gc.Equals, quota.Resources{Machines: 5, Memory: 8192})
+}
+
+func (s *Suite) TestSetQuotasNotImportingEnv(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.mustNewAPI(c)
+	err = api.SetQuotas(params.SetModelQuotas{
+		ModelTag: model.ModelTag().String(),
+		Quotas:   params.ModelQuotas{Units: 1},
+	})
+	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
+}
+
 func (s *Suite) TestLatestLogTime(c *gc.C) {
 	st := s.Factory.MakeModel(c, nil)
 	defer st.Close()
@@ -90,6 +90,11 @@ func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
 			Source: val.Source,
 		}
 	}
+	// Secrets are only needed by controller agents, to
+	// forward logs, so they are never returned to users.
+	for _, name := range config.SecretAttributes {
+		delete(result.Config, name)
+	}
 	return result, nil
 }
 
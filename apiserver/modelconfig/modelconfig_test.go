@@ -58,6 +58,18 @@ func (s *modelconfigSuite) TestModelGet(c *gc.C) {
 	})
 }
 
+func (s *modelconfigSuite) TestModelGetOmitsSecrets(c *gc.C) {
+	s.backend.cfg[config.LogFwdElasticsearchPassword] = config.ConfigValue{"secret", "model"}
+	s.backend.cfg[config.LogFwdGELFClientKey] = config.ConfigValue{"private key", "model"}
+	result, err := s.api.ModelGet()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, name := range config.SecretAttributes {
+		_, ok := result.Config[name]
+		c.Check(ok, jc.IsFalse, gc.Commentf("%s", name))
+	}
+	c.Assert(result.Config["ftp-proxy"], jc.DeepEquals, params.ConfigValue{"http://proxy", "model"})
+}
+
 func (s *modelconfigSuite) assertConfigValue(c *gc.C, key string, expected interface{}) {
 	value, found := s.backend.cfg[key]
 	c.Assert(found, jc.IsTrue)
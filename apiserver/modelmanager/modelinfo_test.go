@@ -22,6 +22,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
@@ -145,6 +146,8 @@ func (s *modelInfoSuite) setAPIUser(c *gc.C, user names.UserTag) {
 }
 
 func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
+	s.st.model.quotas = quota.Resources{Machines: 10, Memory: 4096}
+	s.st.usage = quota.Resources{Machines: 1, Cores: 1}
 	info := s.getModelInfo(c)
 	c.Assert(info, jc.DeepEquals, params.ModelInfo{
 		Name:               "testenv",
@@ -187,6 +190,8 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 		}, {
 			Id: "2",
 		}},
+		Quotas: &params.ModelQuotas{Machines: 10, Memory: 4096},
+		Usage:  &params.ModelQuotas{Machines: 1, Cores: 1},
 	})
 	s.st.CheckCalls(c, []gitjujutesting.StubCall{
 		{"ControllerTag", nil},
@@ -199,6 +204,7 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 		{"LastModelConnection", []interface{}{names.NewLocalUserTag("charlotte")}},
 		{"LastModelConnection", []interface{}{names.NewLocalUserTag("mary")}},
 		{"AllMachines", nil},
+		{"ModelUsage", nil},
 		{"LatestMigration", nil},
 		{"Close", nil},
 	})
@@ -215,6 +221,7 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 		{"Cloud", nil},
 		{"CloudRegion", nil},
 		{"CloudCredential", nil},
+		{"Quotas", nil},
 	})
 }
 
@@ -241,6 +248,17 @@ func (s *modelInfoSuite) TestModelInfoNonOwner(c *gc.C) {
 	c.Assert(info.Users, gc.HasLen, 1)
 	c.Assert(info.Users[0].UserName, gc.Equals, "charlotte")
 	c.Assert(info.Machines, gc.HasLen, 0)
+	c.Assert(info.Usage, gc.IsNil)
+}
+
+func (s *modelInfoSuite) TestModelInfoWithoutQuotas(c *gc.C) {
+	info := s.getModelInfo(c)
+	c.Assert(info.Machines, gc.HasLen, 2)
+	c.Assert(info.Quotas, gc.IsNil)
+	c.Assert(info.Usage, gc.IsNil)
+	for _, call := range s.st.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "ModelUsage")
+	}
 }
 
 func (s *modelInfoSuite) getModelInfo(c *gc.C) params.ModelInfo {
@@ -362,12 +380,15 @@ type mockState struct {
 	model           *mockModel
 	controllerModel *mockModel
 	users           []permission.UserAccess
+	groupAccess     permission.Access
 	cred            cloud.Credential
 	machines        []common.Machine
 	cfgDefaults     config.ModelDefaultAttributes
 	blockMsg        string
 	block           state.BlockType
 	migration       *mockMigration
+	usage           quota.Resources
+	defaultQuotas   string
 }
 
 type fakeModelDescription struct {
@@ -450,7 +471,8 @@ func (st *mockState) ControllerUUID() string {
 func (st *mockState) ControllerConfig() (controller.Config, error) {
 	st.MethodCall(st, "ControllerConfig")
 	return controller.Config{
-		controller.ControllerUUIDKey: "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+		controller.ControllerUUIDKey:  "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+		controller.DefaultModelQuotas: st.defaultQuotas,
 	}, st.NextErr()
 }
 
@@ -549,6 +571,26 @@ func (st *mockState) SetUserAccess(subject names.UserTag, target names.Tag, acce
 	return permission.UserAccess{}, st.NextErr()
 }
 
+func (st *mockState) UserGroupAccess(group string, target names.Tag) (permission.Access, error) {
+	st.MethodCall(st, "UserGroupAccess", group, target)
+	return st.groupAccess, st.NextErr()
+}
+
+func (st *mockState) UserAccessFromGroups(user names.UserTag, target names.Tag) (permission.Access, error) {
+	st.MethodCall(st, "UserAccessFromGroups", user, target)
+	return permission.NoAccess, st.NextErr()
+}
+
+func (st *mockState) SetUserGroupAccess(group string, target names.Tag, access permission.Access) error {
+	st.MethodCall(st, "SetUserGroupAccess", group, target, access)
+	return st.NextErr()
+}
+
+func (st *mockState) RemoveUserGroupAccess(group string, target names.Tag) error {
+	st.MethodCall(st, "RemoveUserGroupAccess", group, target)
+	return st.NextErr()
+}
+
 func (st *mockState) ModelConfigDefaultValues() (config.ModelDefaultAttributes, error) {
 	st.MethodCall(st, "ModelConfigDefaultValues")
 	return st.cfgDefaults, nil
@@ -615,6 +657,11 @@ func (st *mockState) LatestMigration() (state.ModelMigration, error) {
 	return st.migration, st.NextErr()
 }
 
+func (st *mockState) ModelUsage() (quota.Resources, error) {
+	st.MethodCall(st, "ModelUsage")
+	return st.usage, st.NextErr()
+}
+
 type mockBlock struct {
 	state.Block
 	t state.BlockType
@@ -683,6 +730,7 @@ type mockModel struct {
 	status status.StatusInfo
 	cfg    *config.Config
 	users  []*mockModelUser
+	quotas quota.Resources
 }
 
 func (m *mockModel) Config() (*config.Config, error) {
@@ -760,6 +808,21 @@ func (m *mockModel) DestroyIncludingHosted() error {
 	return m.NextErr()
 }
 
+func (m *mockModel) Quotas() quota.Resources {
+	m.MethodCall(m, "Quotas")
+	m.PopNoErr()
+	return m.quotas
+}
+
+func (m *mockModel) SetQuotas(quotas quota.Resources) error {
+	m.MethodCall(m, "SetQuotas", quotas)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.quotas = quotas
+	return nil
+}
+
 type mockModelUser struct {
 	gitjujutesting.Stub
 	userName       string
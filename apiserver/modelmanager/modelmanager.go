@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller/modelmanager"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/migration"
@@ -38,6 +39,16 @@ var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
 func init() {
 	common.RegisterStandardFacade("ModelManager", 2, newFacade)
+
+	// Facade version 3 adds group access to ModifyModelAccess.
+	common.RegisterStandardFacade("ModelManager", 3, newFacade)
+
+	// Facade version 4 adds application access to ModifyModelAccess.
+	common.RegisterStandardFacade("ModelManager", 4, newFacade)
+
+	// Facade version 5 adds model quotas to CreateModel and ModelInfo,
+	// and adds SetModelQuotas.
+	common.RegisterStandardFacade("ModelManager", 5, newFacade)
 }
 
 // ModelManager defines the methods on the modelmanager API endpoint.
@@ -47,6 +58,7 @@ type ModelManager interface {
 	DumpModelsDB(args params.Entities) params.MapResults
 	ListModels(user params.Entity) (params.UserModelList, error)
 	DestroyModels(args params.Entities) (params.ErrorResults, error)
+	SetModelQuotas(args params.SetModelQuotasArgs) (params.ErrorResults, error)
 }
 
 // ModelManagerAPI implements the model manager interface and is
@@ -293,6 +305,16 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 		return result, errors.Trace(err)
 	}
 
+	// Only controller superusers may choose the quotas of a model;
+	// models created by anyone else get the controller's defaults.
+	quotas := controllerCfg.DefaultModelQuotas()
+	if args.Quotas != nil {
+		if !m.isAdmin {
+			return result, errors.Annotate(common.ErrPerm, "only controller superusers may set model quotas")
+		}
+		quotas = quotaResources(*args.Quotas)
+	}
+
 	newConfig, err := m.newModelConfig(cloudSpec, args, controllerModel)
 	if err != nil {
 		return result, errors.Annotate(err, "failed to create config")
@@ -323,6 +345,7 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 		Config:          newConfig,
 		Owner:           ownerTag,
 		StorageProviderRegistry: storageProviderRegistry,
+		Quotas:          quotas,
 	})
 	if err != nil {
 		return result, errors.Annotate(err, "failed to create new model")
@@ -629,6 +652,16 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 			return params.ModelInfo{}, err
 		}
 	}
+	if quotas := model.Quotas(); quotas != (quota.Resources{}) {
+		info.Quotas = paramsModelQuotas(quotas)
+		if canSeeMachines {
+			usage, err := st.ModelUsage()
+			if err != nil {
+				return params.ModelInfo{}, errors.Trace(err)
+			}
+			info.Usage = paramsModelQuotas(usage)
+		}
+	}
 
 	migration, err := st.LatestMigration()
 	if err != nil && !errors.IsNotFound(err) {
@@ -651,6 +684,54 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 	return info, nil
 }
 
+// SetModelQuotas replaces the quotas on the resources that models may
+// use. Only controller superusers may set model quotas.
+func (m *ModelManagerAPI) SetModelQuotas(args params.SetModelQuotasArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Args))}
+	if err := m.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		results.Results[i].Error = common.ServerError(m.setModelQuotas(arg))
+	}
+	return results, nil
+}
+
+func (m *ModelManagerAPI) setModelQuotas(arg params.SetModelQuotas) error {
+	if !m.isAdmin {
+		return common.ErrPerm
+	}
+	modelTag, err := names.ParseModelTag(arg.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	model, err := m.state.GetModel(modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return model.SetQuotas(quotaResources(arg.Quotas))
+}
+
+func quotaResources(quotas params.ModelQuotas) quota.Resources {
+	return quota.Resources{
+		Machines: quotas.Machines,
+		Units:    quotas.Units,
+		Cores:    quotas.Cores,
+		Memory:   quotas.Memory,
+		Storage:  quotas.Storage,
+	}
+}
+
+func paramsModelQuotas(r quota.Resources) *params.ModelQuotas {
+	return &params.ModelQuotas{
+		Machines: r.Machines,
+		Units:    r.Units,
+		Cores:    r.Cores,
+		Memory:   r.Memory,
+		Storage:  r.Storage,
+	}
+}
+
 // ModifyModelAccess changes the model access granted to users.
 func (m *ModelManagerAPI) ModifyModelAccess(args params.ModifyModelAccessRequest) (result params.ErrorResults, _ error) {
 	result = params.ErrorResults{
@@ -667,7 +748,11 @@ func (m *ModelManagerAPI) ModifyModelAccess(args params.ModifyModelAccessRequest
 
 	for i, arg := range args.Changes {
 		modelAccess := permission.Access(arg.Access)
-		if err := permission.ValidateModelAccess(modelAccess); err != nil {
+		validateAccess := permission.ValidateModelAccess
+		if arg.Application != "" {
+			validateAccess = permission.ValidateApplicationAccess
+		}
+		if err := validateAccess(modelAccess); err != nil {
 			err = errors.Annotate(err, "could not modify model access")
 			result.Results[i].Error = common.ServerError(err)
 			continue
@@ -689,6 +774,18 @@ func (m *ModelManagerAPI) ModifyModelAccess(args params.ModifyModelAccessRequest
 			continue
 		}
 
+		if arg.Application != "" {
+			result.Results[i].Error = common.ServerError(
+				changeApplicationAccess(m.state, modelTag, m.apiUser, arg, m.isAdmin))
+			continue
+		}
+
+		if arg.Group != "" {
+			result.Results[i].Error = common.ServerError(
+				changeModelGroupAccess(m.state, modelTag, m.apiUser, arg.Group, arg.Action, modelAccess, m.isAdmin))
+			continue
+		}
+
 		targetUserTag, err := names.ParseUserTag(arg.UserTag)
 		if err != nil {
 			result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify model access"))
@@ -711,9 +808,10 @@ func userAuthorizedToChangeAccess(st common.ModelManagerBackend, userIsAdmin boo
 		return nil
 	}
 
-	// Get the current user's ModelUser for the Model to see if the user has
-	// permission to grant or revoke permissions on the model.
-	currentUser, err := st.UserAccess(userTag, st.ModelTag())
+	// Get the current user's access to the Model, including that of their
+	// groups, to see if the user has permission to grant or revoke
+	// permissions on the model.
+	currentUser, err := common.EffectiveUserAccess(st)(userTag, st.ModelTag())
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// No, this user doesn't have permission.
@@ -796,6 +894,138 @@ func changeModelAccess(accessor common.ModelManagerBackend, modelTag names.Model
 	}
 }
 
+// changeModelGroupAccess grants or revokes access to a model for a local
+// group of users, in the same way as changeModelAccess does for a user.
+func changeModelGroupAccess(accessor common.ModelManagerBackend, modelTag names.ModelTag, apiUser names.UserTag, group string, action params.ModelAction, access permission.Access, userIsAdmin bool) error {
+	st, err := accessor.ForModel(modelTag)
+	if err != nil {
+		return errors.Annotate(err, "could not lookup model")
+	}
+	defer st.Close()
+
+	if err := userAuthorizedToChangeAccess(st, userIsAdmin, apiUser); err != nil {
+		return errors.Trace(err)
+	}
+
+	current, err := st.UserGroupAccess(group, modelTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "could not look up model access for group")
+	}
+	hasAccess := err == nil
+
+	switch action {
+	case params.GrantModelAccess:
+		// Only set access if greater access is being granted.
+		if hasAccess && current.EqualOrGreaterModelAccessThan(access) {
+			return errors.Errorf("group already has %q access or greater", access)
+		}
+		err := st.SetUserGroupAccess(group, modelTag, access)
+		return errors.Annotate(err, "could not grant model access")
+
+	case params.RevokeModelAccess:
+		if !hasAccess {
+			return errors.Errorf("group %q has no access to model", group)
+		}
+		switch access {
+		case permission.ReadAccess:
+			// Revoking read access removes all access.
+			err := st.RemoveUserGroupAccess(group, modelTag)
+			return errors.Annotate(err, "could not revoke model access")
+		case permission.WriteAccess:
+			// Revoking write access sets read-only.
+			err := st.SetUserGroupAccess(group, modelTag, permission.ReadAccess)
+			return errors.Annotate(err, "could not set model access to read-only")
+		case permission.AdminAccess:
+			// Revoking admin access sets read-write.
+			err := st.SetUserGroupAccess(group, modelTag, permission.WriteAccess)
+			return errors.Annotate(err, "could not set model access to read-write")
+
+		default:
+			return errors.Errorf("don't know how to revoke %q access", access)
+		}
+
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
+
+// changeApplicationAccess grants or revokes access to an application in
+// a model for a user, or for a local group of users if one is named.
+func changeApplicationAccess(accessor common.ModelManagerBackend, modelTag names.ModelTag, apiUser names.UserTag, arg params.ModifyModelAccess, userIsAdmin bool) error {
+	if !names.IsValidApplication(arg.Application) {
+		return errors.NotValidf("application name %q", arg.Application)
+	}
+	appTag := names.NewApplicationTag(arg.Application)
+	var targetUserTag names.UserTag
+	if arg.Group == "" {
+		var err error
+		targetUserTag, err = names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			return errors.Annotate(err, "could not modify application access")
+		}
+	}
+
+	st, err := accessor.ForModel(modelTag)
+	if err != nil {
+		return errors.Annotate(err, "could not lookup model")
+	}
+	defer st.Close()
+
+	if err := userAuthorizedToChangeAccess(st, userIsAdmin, apiUser); err != nil {
+		return errors.Trace(err)
+	}
+
+	switch arg.Action {
+	case params.GrantModelAccess:
+		if err := checkModelAccessForApplication(st, modelTag, targetUserTag, arg.Group); err != nil {
+			return errors.Trace(err)
+		}
+		if arg.Group != "" {
+			err = st.SetUserGroupAccess(arg.Group, appTag, permission.Access(arg.Access))
+		} else {
+			_, err = st.SetUserAccess(targetUserTag, appTag, permission.Access(arg.Access))
+		}
+		return errors.Annotate(err, "could not grant application access")
+
+	case params.RevokeModelAccess:
+		if arg.Group != "" {
+			err = st.RemoveUserGroupAccess(arg.Group, appTag)
+		} else {
+			err = st.RemoveUserAccess(targetUserTag, appTag)
+		}
+		return errors.Annotate(err, "could not revoke application access")
+
+	default:
+		return errors.Errorf("unknown action %q", arg.Action)
+	}
+}
+
+// checkModelAccessForApplication checks that the user, or the group if
+// one is named, has access to the model of an application. Access granted
+// on the application is of no use without it, as model access is needed
+// to log in to the model at all.
+func checkModelAccessForApplication(st common.ModelManagerBackend, modelTag names.ModelTag, user names.UserTag, group string) error {
+	if group != "" {
+		_, err := st.UserGroupAccess(group, modelTag)
+		if errors.IsNotFound(err) {
+			return errors.Errorf("group %q has no access to model", group)
+		}
+		return errors.Annotate(err, "could not look up model access for group")
+	}
+	_, err := st.UserAccess(user, modelTag)
+	if !errors.IsNotFound(err) {
+		return errors.Annotate(err, "could not look up model access for user")
+	}
+	access, err := st.UserAccessFromGroups(user, modelTag)
+	if err != nil {
+		return errors.Annotate(err, "could not look up model access for user")
+	}
+	if access == permission.NoAccess {
+		return errors.Errorf("user %q has no access to model", user.Id())
+	}
+	return nil
+}
+
 // ModelDefaults returns the default config values used when creating a new model.
 func (m *ModelManagerAPI) ModelDefaults() (params.ModelDefaultsResult, error) {
 	result := params.ModelDefaultsResult{}
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -197,6 +198,7 @@ func (s *modelManagerSuite) TestCreateModelArgs(c *gc.C) {
 		"LastModelConnection",
 		"LastModelConnection",
 		"AllMachines",
+		"ModelUsage",
 		"LatestMigration",
 		"Close",
 		"Close",
@@ -648,6 +650,73 @@ func (s *modelManagerSuite) TestAddModelCantCreateModelForSomeoneElse(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "\"add-model\" permission does not permit creation of models for different owners: permission denied")
 }
 
+func (s *modelManagerSuite) TestCreateModelQuotas(c *gc.C) {
+	s.st.defaultQuotas = "machines=5"
+	args := createArgs(names.NewUserTag("admin"))
+	args.Quotas = &params.ModelQuotas{Machines: 10, Memory: 65536}
+	_, err := s.api.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	newModelArgs := s.getModelArgs(c)
+	c.Assert(newModelArgs.Quotas, gc.Equals, quota.Resources{Machines: 10, Memory: 65536})
+}
+
+func (s *modelManagerSuite) TestCreateModelDefaultQuotas(c *gc.C) {
+	s.st.defaultQuotas = "machines=5 storage=1T"
+	_, err := s.api.CreateModel(createArgs(names.NewUserTag("admin")))
+	c.Assert(err, jc.ErrorIsNil)
+	newModelArgs := s.getModelArgs(c)
+	c.Assert(newModelArgs.Quotas, gc.Equals, quota.Resources{Machines: 5, Storage: 1024 * 1024})
+}
+
+func (s *modelManagerSuite) TestCreateModelQuotasAsNormalUser(c *gc.C) {
+	addModelUser := names.NewUserTag("add-model")
+	s.setAPIUser(c, addModelUser)
+	args := createArgs(addModelUser)
+	args.Quotas = &params.ModelQuotas{Machines: 100}
+	_, err := s.api.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, "only controller superusers may set model quotas: permission denied")
+	for _, call := range s.st.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "NewModel")
+	}
+}
+
+func (s *modelManagerSuite) TestSetModelQuotas(c *gc.C) {
+	results, err := s.api.SetModelQuotas(params.SetModelQuotasArgs{
+		Args: []params.SetModelQuotas{{
+			ModelTag: coretesting.ModelTag.String(),
+			Quotas:   params.ModelQuotas{Units: 20, Cores: 8},
+		}, {
+			ModelTag: "user-bob",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"user-bob" is not a valid model tag`)
+	c.Assert(s.st.model.quotas, gc.Equals, quota.Resources{Units: 20, Cores: 8})
+}
+
+func (s *modelManagerSuite) TestSetModelQuotasAsNormalUser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("charlie"))
+	results, err := s.api.SetModelQuotas(params.SetModelQuotasArgs{
+		Args: []params.SetModelQuotas{{
+			ModelTag: coretesting.ModelTag.String(),
+			Quotas:   params.ModelQuotas{Units: 20},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "permission denied")
+	c.Assert(s.st.model.quotas, gc.Equals, quota.Resources{})
+}
+
+func (s *modelManagerSuite) TestBlockChangesSetModelQuotas(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockChangesSetModelQuotas")
+	_, err := s.api.SetModelQuotas(params.SetModelQuotasArgs{
+		Args: []params.SetModelQuotas{{ModelTag: coretesting.ModelTag.String()}},
+	})
+	s.assertBlocked(c, err, "TestBlockChangesSetModelQuotas")
+}
+
 // modelManagerStateSuite contains end-to-end tests.
 // Prefer adding tests to modelManagerSuite above.
 type modelManagerStateSuite struct {
@@ -1104,6 +1173,193 @@ func (s *modelManagerStateSuite) TestGrantOnlyGreaterAccess(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `user already has "read" access or greater`)
 }
 
+func (s *modelManagerStateSuite) modifyGroupAccess(c *gc.C, group string, action params.ModelAction, access params.UserAccessPermission, model names.ModelTag) error {
+	args := params.ModifyModelAccessRequest{
+		Changes: []params.ModifyModelAccess{{
+			Group:    group,
+			Action:   action,
+			Access:   access,
+			ModelTag: model.String(),
+		}}}
+
+	result, err := s.modelmanager.ModifyModelAccess(args)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+func (s *modelManagerStateSuite) TestGrantModelToGroup(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	_, err := s.State.AddUserGroup("ops", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyGroupAccess(c, "ops", params.GrantModelAccess, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.modifyGroupAccess(c, "ops", params.GrantModelAccess, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, gc.ErrorMatches, `group already has "read" access or greater`)
+	err = s.modifyGroupAccess(c, "ops", params.GrantModelAccess, params.ModelAdminAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.UserGroupAccess("ops", st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AdminAccess)
+
+	err = s.modifyGroupAccess(c, "dev", params.GrantModelAccess, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, gc.ErrorMatches, `could not grant model access: group "dev" not found`)
+}
+
+func (s *modelManagerStateSuite) TestRevokeModelFromGroup(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	_, err := s.State.AddUserGroup("ops", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUserGroupAccess("ops", st.ModelTag(), permission.AdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyGroupAccess(c, "ops", params.RevokeModelAccess, params.ModelAdminAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.UserGroupAccess("ops", st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+
+	err = s.modifyGroupAccess(c, "ops", params.RevokeModelAccess, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserGroupAccess("ops", st.ModelTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.modifyGroupAccess(c, "ops", params.RevokeModelAccess, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, gc.ErrorMatches, `group "ops" has no access to model`)
+}
+
+func (s *modelManagerStateSuite) modifyApplicationAccess(c *gc.C, change params.ModifyModelAccess) error {
+	change.ModelTag = s.State.ModelTag().String()
+	change.Access = params.ModelWriteAccess
+	result, err := s.modelmanager.ModifyModelAccess(params.ModifyModelAccessRequest{
+		Changes: []params.ModifyModelAccess{change},
+	})
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+func (s *modelManagerStateSuite) TestGrantApplication(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar"})
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+
+	err := s.modifyApplicationAccess(c, params.ModifyModelAccess{
+		UserTag:     user.Tag().String(),
+		Action:      params.GrantModelAccess,
+		Application: "mysql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.UserAccess(user.UserTag(), app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.WriteAccess)
+
+	err = s.modifyApplicationAccess(c, params.ModifyModelAccess{
+		UserTag:     user.Tag().String(),
+		Action:      params.RevokeModelAccess,
+		Application: "mysql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserAccess(user.UserTag(), app.ApplicationTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.modifyApplicationAccess(c, params.ModifyModelAccess{
+		UserTag:     user.Tag().String(),
+		Action:      params.GrantModelAccess,
+		Application: "wordpress",
+	})
+	c.Assert(err, gc.ErrorMatches, `could not grant application access: cannot grant access to application "wordpress": application "wordpress" not found`)
+}
+
+func (s *modelManagerStateSuite) TestGrantApplicationInvalidAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar"})
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+
+	result, err := s.modelmanager.ModifyModelAccess(params.ModifyModelAccessRequest{
+		Changes: []params.ModifyModelAccess{{
+			UserTag:     user.Tag().String(),
+			Action:      params.GrantModelAccess,
+			Access:      params.ModelAdminAccess,
+			ModelTag:    s.State.ModelTag().String(),
+			Application: "mysql",
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `could not modify model access: "admin" application access not valid`)
+}
+
+func (s *modelManagerStateSuite) TestGrantApplicationToGroup(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	_, err := s.State.AddUserGroup("dba", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUserGroupAccess("dba", s.State.ModelTag(), permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyApplicationAccess(c, params.ModifyModelAccess{
+		Group:       "dba",
+		Action:      params.GrantModelAccess,
+		Application: "mysql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.UserGroupAccess("dba", app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+
+	err = s.modifyApplicationAccess(c, params.ModifyModelAccess{
+		Group:       "dba",
+		Action:      params.RevokeModelAccess,
+		Application: "mysql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserGroupAccess("dba", app.ApplicationTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *modelManagerStateSuite) TestGrantApplicationRequiresModelAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", NoModelUser: true})
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	group, err := s.State.AddUserGroup("dba", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyApplicationAccess(c, params.ModifyModelAccess{
+		UserTag:     user.Tag().String(),
+		Action:      params.GrantModelAccess,
+		Application: "mysql",
+	})
+	c.Assert(err, gc.ErrorMatches, `user "foobar" has no access to model`)
+	err = s.modifyApplicationAccess(c, params.ModifyModelAccess{
+		Group:       "dba",
+		Action:      params.GrantModelAccess,
+		Application: "mysql",
+	})
+	c.Assert(err, gc.ErrorMatches, `group "dba" has no access to model`)
+
+	// Model access granted to a group the user is a member of will do.
+	err = group.AddMembers(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUserGroupAccess("dba", s.State.ModelTag(), permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.modifyApplicationAccess(c, params.ModifyModelAccess{
+		UserTag:     user.Tag().String(),
+		Action:      params.GrantModelAccess,
+		Application: "mysql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.UserAccess(user.UserTag(), app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.WriteAccess)
+}
+
 func (s *modelManagerStateSuite) assertNewUser(c *gc.C, modelUser permission.UserAccess, userTag, creatorTag names.UserTag) {
 	c.Assert(modelUser.UserTag, gc.Equals, userTag)
 	c.Assert(modelUser.CreatedBy, gc.Equals, creatorTag)
@@ -1238,6 +1494,34 @@ func (s *modelManagerStateSuite) TestGrantToModelWriteAccess(c *gc.C) {
 	c.Assert(modelUser.Access, gc.Equals, permission.ReadAccess)
 }
 
+func (s *modelManagerStateSuite) TestGrantToModelGroupAdminAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	// The user is a model admin only through their group.
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	group, err := s.State.AddUserGroup("ops", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMembers(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUserGroupAccess("ops", st.ModelTag(), permission.AdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setAPIUser(c, user.UserTag())
+
+	other := names.NewUserTag("other@remote")
+	err = s.grant(c, other, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	modelUser, err := st.UserAccess(other, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access, gc.Equals, permission.ReadAccess)
+
+	_, err = s.State.AddUserGroup("dev", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.modifyGroupAccess(c, "dev", params.GrantModelAccess, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *modelManagerStateSuite) TestGrantModelInvalidUserTag(c *gc.C) {
 	s.setAPIUser(c, s.AdminUserTag(c))
 	for _, testParam := range []struct {
@@ -25,6 +25,11 @@ type AuditContext struct {
 	// ModelUUID is the UUID of the model the audit observer is
 	// currently running on.
 	ModelUUID string
+
+	// CaptureArgs determines whether the observer records the
+	// redacted arguments and result of each mutating API call,
+	// rather than recording every call's request.
+	CaptureArgs bool
 }
 
 type ErrorHandler func(error)
@@ -34,6 +39,7 @@ func NewAudit(ctx *AuditContext, handleAuditEntry audit.AuditEntrySinkFn, errorH
 	return &Audit{
 		jujuServerVersion: ctx.JujuServerVersion,
 		modelUUID:         ctx.ModelUUID,
+		captureArgs:       ctx.CaptureArgs,
 		errorHandler:      errorHandler,
 		handleAuditEntry:  handleAuditEntry,
 	}
@@ -44,6 +50,7 @@ func NewAudit(ctx *AuditContext, handleAuditEntry audit.AuditEntrySinkFn, errorH
 type Audit struct {
 	jujuServerVersion version.Number
 	modelUUID         string
+	captureArgs       bool
 	errorHandler      ErrorHandler
 	handleAuditEntry  audit.AuditEntrySinkFn
 
@@ -79,6 +86,7 @@ func (a *Audit) RPCObserver() rpc.Observer {
 	return &AuditRPCObserver{
 		jujuServerVersion: a.jujuServerVersion,
 		modelUUID:         a.modelUUID,
+		captureArgs:       a.captureArgs,
 		errorHandler:      a.errorHandler,
 		handleAuditEntry:  a.handleAuditEntry,
 		authenticatedTag:  a.state.authenticatedTag,
@@ -91,14 +99,23 @@ func (a *Audit) RPCObserver() rpc.Observer {
 type AuditRPCObserver struct {
 	jujuServerVersion version.Number
 	modelUUID         string
+	captureArgs       bool
 	errorHandler      ErrorHandler
 	handleAuditEntry  audit.AuditEntrySinkFn
 	authenticatedTag  string
 	remoteAddress     string
+
+	// pending holds the entry for a captured call until its
+	// reply is sent.
+	pending *audit.AuditEntry
 }
 
 // ServerRequest implements Observer.
 func (a *AuditRPCObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
+	if a.captureArgs {
+		a.captureRequest(hdr, body)
+		return
+	}
 	auditEntry := a.boilerplateAuditEntry()
 	auditEntry.OriginName = a.authenticatedTag
 
@@ -112,7 +129,65 @@ func (a *AuditRPCObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
 }
 
 // ServerReply implements Observer.
-func (a *AuditRPCObserver) ServerReply(rpc.Request, *rpc.Header, interface{}) {}
+func (a *AuditRPCObserver) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}) {
+	if a.pending == nil {
+		return
+	}
+	auditEntry := *a.pending
+	a.pending = nil
+
+	if hdr.Error != "" {
+		auditEntry.Data["error"] = hdr.Error
+		if hdr.ErrorCode != "" {
+			auditEntry.Data["error-code"] = hdr.ErrorCode
+		}
+	} else if result, err := redact(req.Type, req.Action, body); err != nil {
+		a.errorHandler(errors.Annotate(err, "capturing API call result"))
+	} else if !isEmpty(result) {
+		auditEntry.Data["result"] = result
+	}
+	if err := a.handleAuditEntry(auditEntry); err != nil {
+		a.errorHandler(errors.Trace(err))
+	}
+}
+
+// captureRequest records the details of a mutating call, to be
+// completed with its result when the reply is sent.
+func (a *AuditRPCObserver) captureRequest(hdr *rpc.Header, body interface{}) {
+	req := hdr.Request
+	if isReadOnlyCall(req.Type, req.Action) {
+		return
+	}
+	auditEntry := a.boilerplateAuditEntry()
+	auditEntry.OriginType = "API request"
+	auditEntry.Operation = rpcRequestToOperation(req)
+	auditEntry.Data = map[string]interface{}{
+		"facade":  req.Type,
+		"version": req.Version,
+		"method":  req.Action,
+	}
+	if body != nil {
+		args, err := redact(req.Type, req.Action, body)
+		if err != nil {
+			a.errorHandler(errors.Annotate(err, "capturing API call arguments"))
+		} else if !isEmpty(args) {
+			auditEntry.Data["args"] = args
+		}
+	}
+	a.pending = &auditEntry
+}
+
+// isEmpty reports whether a redacted value holds nothing worth
+// recording.
+func isEmpty(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+	return false
+}
 
 func (a *AuditRPCObserver) boilerplateAuditEntry() audit.AuditEntry {
 	return audit.AuditEntry{
@@ -0,0 +1,253 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer_test
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditSuite struct {
+	testing.IsolationSuite
+
+	entries []audit.AuditEntry
+	errors  []error
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (s *auditSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.entries = nil
+	s.errors = nil
+}
+
+func (s *auditSuite) newRPCObserver(captureArgs bool) rpc.Observer {
+	ctx := &observer.AuditContext{
+		JujuServerVersion: version.MustParse("2.2.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		CaptureArgs:       captureArgs,
+	}
+	handleEntry := func(entry audit.AuditEntry) error {
+		s.entries = append(s.entries, entry)
+		return nil
+	}
+	handleError := func(err error) {
+		s.errors = append(s.errors, err)
+	}
+	o := observer.NewAudit(ctx, handleEntry, handleError)
+	o.Join(&http.Request{RemoteAddr: "10.0.0.1:1234"}, 1)
+	o.Login(names.NewUserTag("bob"), coretesting.ModelTag, false, "")
+	return o.RPCObserver()
+}
+
+func request(facade string, version int, method string) *rpc.Header {
+	return &rpc.Header{
+		RequestId: 1,
+		Request: rpc.Request{
+			Type:    facade,
+			Version: version,
+			Action:  method,
+		},
+	}
+}
+
+func (s *auditSuite) TestRecordsRequestWithoutCapture(c *gc.C) {
+	o := s.newRPCObserver(false)
+	hdr := request("Client", 1, "FullStatus")
+	args := params.StatusParams{Patterns: []string{"mysql"}}
+	o.ServerRequest(hdr, args)
+	o.ServerReply(hdr.Request, &rpc.Header{}, params.FullStatus{})
+
+	c.Assert(s.errors, gc.HasLen, 0)
+	c.Assert(s.entries, gc.HasLen, 1)
+	entry := s.entries[0]
+	c.Check(entry.OriginName, gc.Equals, "user-bob")
+	c.Check(entry.RemoteAddress, gc.Equals, "10.0.0.1:1234")
+	c.Check(entry.Operation, gc.Equals, "Client:v1 - FullStatus")
+	c.Check(entry.Data, jc.DeepEquals, map[string]interface{}{"request-body": args})
+}
+
+func (s *auditSuite) TestCaptureRecordsArgsAndResult(c *gc.C) {
+	o := s.newRPCObserver(true)
+	hdr := request("Application", 4, "Set")
+	o.ServerRequest(hdr, params.ApplicationSet{
+		ApplicationName: "mysql",
+		Options:         map[string]string{"dataset-size": "80%"},
+	})
+	c.Assert(s.entries, gc.HasLen, 0)
+	o.ServerReply(hdr.Request, &rpc.Header{}, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+
+	c.Assert(s.errors, gc.HasLen, 0)
+	c.Assert(s.entries, gc.HasLen, 1)
+	entry := s.entries[0]
+	c.Check(entry.OriginType, gc.Equals, "API request")
+	c.Check(entry.Operation, gc.Equals, "Application:v4 - Set")
+	c.Check(entry.Validate(), jc.ErrorIsNil)
+	c.Check(entry.Data, jc.DeepEquals, map[string]interface{}{
+		"facade":  "Application",
+		"version": 4,
+		"method":  "Set",
+		"args": map[string]interface{}{
+			"application": "mysql",
+			"options":     map[string]interface{}{"dataset-size": "80%"},
+		},
+		"result": map[string]interface{}{
+			"results": []interface{}{map[string]interface{}{}},
+		},
+	})
+}
+
+func (s *auditSuite) TestCaptureRecordsError(c *gc.C) {
+	o := s.newRPCObserver(true)
+	hdr := request("Application", 4, "Destroy")
+	o.ServerRequest(hdr, params.ApplicationDestroy{ApplicationName: "mysql"})
+	o.ServerReply(hdr.Request, &rpc.Header{
+		Error:     "permission denied",
+		ErrorCode: params.CodeUnauthorized,
+	}, struct{}{})
+
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].Data, jc.DeepEquals, map[string]interface{}{
+		"facade":     "Application",
+		"version":    4,
+		"method":     "Destroy",
+		"args":       map[string]interface{}{"application": "mysql"},
+		"error":      "permission denied",
+		"error-code": params.CodeUnauthorized,
+	})
+}
+
+func (s *auditSuite) TestCaptureRedactsSecrets(c *gc.C) {
+	o := s.newRPCObserver(true)
+	hdr := request("UserManager", 1, "SetPassword")
+	o.ServerRequest(hdr, params.EntityPasswords{
+		Changes: []params.EntityPassword{{
+			Tag:      "user-mary",
+			Password: "sekrit",
+		}},
+	})
+	o.ServerReply(hdr.Request, &rpc.Header{}, struct{}{})
+
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].Data["args"], jc.DeepEquals, map[string]interface{}{
+		"changes": []interface{}{map[string]interface{}{
+			"tag":      "user-mary",
+			"password": "<redacted>",
+		}},
+	})
+	_, ok := s.entries[0].Data["result"]
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *auditSuite) TestCaptureRedactsFacadeSpecificFields(c *gc.C) {
+	o := s.newRPCObserver(true)
+	hdr := request("Cloud", 1, "UpdateCredentials")
+	o.ServerRequest(hdr, params.UpdateCloudCredentials{
+		Credentials: []params.UpdateCloudCredential{{
+			Tag: "cloudcred-aws_bob_default",
+			Credential: params.CloudCredential{
+				AuthType:   "access-key",
+				Attributes: map[string]string{"access-key": "key", "secret-key": "sekrit"},
+			},
+		}},
+	})
+	o.ServerReply(hdr.Request, &rpc.Header{}, struct{}{})
+
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].Data["args"], jc.DeepEquals, map[string]interface{}{
+		"credentials": []interface{}{map[string]interface{}{
+			"tag": "cloudcred-aws_bob_default",
+			"credential": map[string]interface{}{
+				"auth-type": "access-key",
+				"attrs":     "<redacted>",
+			},
+		}},
+	})
+}
+
+func (s *auditSuite) TestCaptureRedactsBackupKeys(c *gc.C) {
+	o := s.newRPCObserver(true)
+	hdr := request("Backups", 2, "Create")
+	o.ServerRequest(hdr, params.BackupsCreateArgs{
+		Notes:         "nightly",
+		EncryptionKey: "secret:sekrit",
+	})
+	o.ServerReply(hdr.Request, &rpc.Header{}, struct{}{})
+	hdr = request("Backups", 2, "Restore")
+	o.ServerRequest(hdr, params.RestoreArgs{
+		BackupId:      "backup-id",
+		DecryptionKey: "secret:sekrit",
+	})
+	o.ServerReply(hdr.Request, &rpc.Header{}, struct{}{})
+	hdr = request("Backups", 2, "Verify")
+	o.ServerRequest(hdr, params.BackupsVerifyArgs{
+		ID:            "backup-id",
+		DecryptionKey: "secret:sekrit",
+	})
+	o.ServerReply(hdr.Request, &rpc.Header{}, struct{}{})
+
+	c.Assert(s.entries, gc.HasLen, 3)
+	c.Check(s.entries[0].Data["args"], jc.DeepEquals, map[string]interface{}{
+		"notes":          "nightly",
+		"encryption-key": "<redacted>",
+	})
+	c.Check(s.entries[1].Data["args"], jc.DeepEquals, map[string]interface{}{
+		"backup-id":      "backup-id",
+		"decryption-key": "<redacted>",
+	})
+	c.Check(s.entries[2].Data["args"], jc.DeepEquals, map[string]interface{}{
+		"id":             "backup-id",
+		"decryption-key": "<redacted>",
+	})
+}
+
+func (s *auditSuite) TestCaptureSkipsReadOnlyCalls(c *gc.C) {
+	o := s.newRPCObserver(true)
+	for _, hdr := range []*rpc.Header{
+		request("Client", 1, "FullStatus"),
+		request("Application", 4, "Get"),
+		request("Pinger", 1, "Ping"),
+		request("AllWatcher", 1, "Next"),
+		request("Controller", 4, "AuditLog"),
+	} {
+		o.ServerRequest(hdr, struct{}{})
+		o.ServerReply(hdr.Request, &rpc.Header{}, struct{}{})
+	}
+	c.Assert(s.entries, gc.HasLen, 0)
+}
+
+func (s *auditSuite) TestCaptureReportsSinkError(c *gc.C) {
+	ctx := &observer.AuditContext{
+		JujuServerVersion: version.MustParse("2.2.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		CaptureArgs:       true,
+	}
+	var handled []error
+	o := observer.NewAudit(ctx, func(audit.AuditEntry) error {
+		return errors.New("boom")
+	}, func(err error) {
+		handled = append(handled, err)
+	}).RPCObserver()
+
+	hdr := request("Application", 4, "Destroy")
+	o.ServerRequest(hdr, params.ApplicationDestroy{ApplicationName: "mysql"})
+	o.ServerReply(hdr.Request, &rpc.Header{}, struct{}{})
+	c.Assert(handled, gc.HasLen, 1)
+	c.Check(handled[0], gc.ErrorMatches, "boom")
+}
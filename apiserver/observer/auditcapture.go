@@ -0,0 +1,166 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+// redactedValue replaces the values of redacted fields.
+const redactedValue = "<redacted>"
+
+// redaction describes a field whose value must never be recorded in
+// the audit log. An empty Facade or Method matches any facade or
+// method. Field is matched against the names of argument and result
+// fields, and map keys, at any depth.
+type redaction struct {
+	Facade string
+	Method string
+	Field  string
+}
+
+// redactions lists the fields scrubbed from the arguments and results
+// of captured API calls.
+var redactions = []redaction{
+	// Secrets which may be passed to any facade.
+	{Field: "password"},
+	{Field: "macaroon"},
+	{Field: "macaroons"},
+	{Field: "metrics-credentials"},
+	{Field: "secret-key"},
+	{Field: "private-key"},
+	{Field: "ca-private-key"},
+	{Field: "shared-secret"},
+	{Field: "token"},
+
+	// Login requests carry the entity's password as credentials.
+	{Facade: "Admin", Field: "credentials"},
+
+	// Cloud credential attributes hold provider secrets.
+	{Facade: "Cloud", Field: "attrs"},
+
+	// Backup keys may be secret keys, which decrypt every backup.
+	{Facade: "Backups", Method: "Create", Field: "encryption-key"},
+	{Facade: "Backups", Method: "Restore", Field: "decryption-key"},
+	{Facade: "Backups", Method: "Verify", Field: "decryption-key"},
+}
+
+func isRedacted(facade, method, field string) bool {
+	for _, r := range redactions {
+		if r.Facade != "" && r.Facade != facade {
+			continue
+		}
+		if r.Method != "" && r.Method != method {
+			continue
+		}
+		if r.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// redact returns a copy of value, which must be serializable as JSON,
+// with the values of any fields matched by the redaction table for
+// the given call replaced. The copy is made of plain maps, slices and
+// scalars so that it can be stored by any audit sink.
+func redact(facade, method string, value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var plain interface{}
+	if err := json.Unmarshal(data, &plain); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return redactPlain(facade, method, plain), nil
+}
+
+func redactPlain(facade, method string, value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			if isRedacted(facade, method, k) {
+				value[k] = redactedValue
+				continue
+			}
+			value[k] = redactPlain(facade, method, v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = redactPlain(facade, method, v)
+		}
+	}
+	return value
+}
+
+// readOnlyFacades lists the facades whose methods never change
+// anything. Facades with names ending in "Watcher" are also treated
+// as read-only.
+var readOnlyFacades = set.NewStrings(
+	"Admin",
+	"Pinger",
+)
+
+// readOnlyMethodPrefixes identify methods which, by convention, never
+// change anything.
+var readOnlyMethodPrefixes = []string{
+	"Find",
+	"Get",
+	"List",
+	"Show",
+	"Watch",
+}
+
+// readOnlyCalls lists the other calls, as "Facade.Method", which never
+// change anything.
+var readOnlyCalls = set.NewStrings(
+	"Action.Actions",
+	"Action.ApplicationsCharmsActions",
+	"Application.CharmRelations",
+	"Bundle.ExportBundle",
+	"Client.APIHostPorts",
+	"Client.AgentVersion",
+	"Client.FullStatus",
+	"Client.ModelGet",
+	"Client.ModelInfo",
+	"Client.ModelUserInfo",
+	"Client.PrivateAddress",
+	"Client.PublicAddress",
+	"Client.StatusHistory",
+	"Cloud.Cloud",
+	"Cloud.Clouds",
+	"Cloud.Credential",
+	"Cloud.DefaultCloud",
+	"Cloud.UserCredentials",
+	"Controller.AllModels",
+	"Controller.AuditLog",
+	"Controller.ControllerConfig",
+	"Controller.HostedModelConfigs",
+	"Controller.ModelConfig",
+	"Controller.ModelStatus",
+	"ModelConfig.ModelGet",
+	"ModelManager.ModelDefaults",
+	"ModelManager.ModelInfo",
+	"ModelManager.ModelStatus",
+	"UserManager.UserInfo",
+)
+
+// isReadOnlyCall reports whether the given call is known not to
+// change anything, and so is not captured in the audit log.
+func isReadOnlyCall(facade, method string) bool {
+	if readOnlyFacades.Contains(facade) || strings.HasSuffix(facade, "Watcher") {
+		return true
+	}
+	for _, prefix := range readOnlyMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return readOnlyCalls.Contains(facade + "." + method)
+}
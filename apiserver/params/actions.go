@@ -25,6 +25,14 @@ const (
 	// ActionRunning is the status of an Action that has been started but
 	// not completed yet.
 	ActionRunning string = "running"
+
+	// ActionAborting is the status of a running Action that has been
+	// cancelled, and is being stopped.
+	ActionAborting string = "aborting"
+
+	// ActionAborted is the status of an Action that was stopped after
+	// being cancelled while running.
+	ActionAborted string = "aborted"
 )
 
 // Actions is a slice of Action for bulk requests.
@@ -138,3 +146,10 @@ type ActionSpec struct {
 	Description string                 `json:"description"`
 	Params      map[string]interface{} `json:"params"`
 }
+
+// ActionPruneArgs holds the arguments for pruning the actions of a
+// model.
+type ActionPruneArgs struct {
+	MaxHistoryTime time.Duration `json:"max-history-time"`
+	MaxHistoryMB   int           `json:"max-history-mb"`
+}
@@ -12,6 +12,11 @@ import (
 // BackupsCreateArgs holds the args for the API Create method.
 type BackupsCreateArgs struct {
 	Notes string `json:"notes"`
+
+	// EncryptionKey is the key with which to encrypt the backup,
+	// overriding the controller's backup-encryption-key. It is
+	// supported by facade version 2 and later.
+	EncryptionKey string `json:"encryption-key,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -39,9 +44,50 @@ type BackupsRemoveArgs struct {
 	ID string `json:"id"`
 }
 
+// BackupsVerifyArgs holds the args for the API Verify method.
+type BackupsVerifyArgs struct {
+	ID string `json:"id"`
+
+	// DecryptionKey is the key with which to decrypt the backup, if
+	// it is encrypted. Without it, only the backup's checksum is
+	// verified.
+	DecryptionKey string `json:"decryption-key,omitempty"`
+}
+
+// BackupsVerifyResult holds the outcome of verifying a backup.
+type BackupsVerifyResult struct {
+	ID       string `json:"id"`
+	Checksum string `json:"checksum"`
+	Size     int64  `json:"size"`
+
+	ContentsVerified bool           `json:"contents-verified"`
+	Collections      int            `json:"collections"`
+	Documents        int            `json:"documents"`
+	Version          version.Number `json:"version"`
+	Compatible       bool           `json:"compatible"`
+
+	// Problems lists everything found wrong with the backup. The
+	// backup is sound if it is empty.
+	Problems []string `json:"problems,omitempty"`
+}
+
 // BackupsListResult holds the list of all stored backups.
 type BackupsListResult struct {
 	List []BackupsMetadataResult `json:"list"`
+
+	// Schedule holds the status of the controller's backup schedule,
+	// if it has ever been enabled.
+	Schedule *BackupsScheduleResult `json:"schedule,omitempty"`
+}
+
+// BackupsScheduleResult holds the outcome of the most recent run of
+// the controller's backup schedule.
+type BackupsScheduleResult struct {
+	Schedule     string    `json:"schedule"`
+	LastRun      time.Time `json:"last-run"` // May be zero...
+	LastBackupID string    `json:"last-backup-id,omitempty"`
+	LastError    string    `json:"last-error,omitempty"`
+	NextRun      time.Time `json:"next-run"` // May be zero...
 }
 
 // BackupsListResult holds the list of all stored backups.
@@ -59,14 +105,16 @@ type BackupsMetadataResult struct {
 	Size           int64     `json:"size"`
 	Stored         time.Time `json:"stored"` // May be zero...
 
-	Started  time.Time      `json:"started"`
-	Finished time.Time      `json:"finished"` // May be zero...
-	Notes    string         `json:"notes"`
-	Model    string         `json:"model"`
-	Machine  string         `json:"machine"`
-	Hostname string         `json:"hostname"`
-	Version  version.Number `json:"version"`
-	Series   string         `json:"series"`
+	Started        time.Time      `json:"started"`
+	Finished       time.Time      `json:"finished"` // May be zero...
+	Notes          string         `json:"notes"`
+	Scheduled      bool           `json:"scheduled,omitempty"`
+	KeyFingerprint string         `json:"key-fingerprint,omitempty"`
+	Model          string         `json:"model"`
+	Machine        string         `json:"machine"`
+	Hostname       string         `json:"hostname"`
+	Version        version.Number `json:"version"`
+	Series         string         `json:"series"`
 
 	CACert       string `json:"ca-cert"`
 	CAPrivateKey string `json:"ca-private-key"`
@@ -76,4 +124,8 @@ type BackupsMetadataResult struct {
 type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
+
+	// DecryptionKey is the key with which to decrypt the backup, if
+	// it is encrypted. It is supported by facade version 2 and later.
+	DecryptionKey string `json:"decryption-key,omitempty"`
 }
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -57,6 +59,9 @@ type ModifyControllerAccess struct {
 	UserTag string           `json:"user-tag"`
 	Action  ControllerAction `json:"action"`
 	Access  string           `json:"access"`
+	// Group, if set, names the local group whose access is modified,
+	// instead of that of the user.
+	Group string `json:"group,omitempty"`
 }
 
 // UserAccess holds the level of access a user
@@ -87,3 +92,33 @@ const (
 	GrantControllerAccess  ControllerAction = "grant"
 	RevokeControllerAccess ControllerAction = "revoke"
 )
+
+// AuditLogQuery holds the criteria used to select audit log entries.
+// Unset fields match all entries.
+type AuditLogQuery struct {
+	ModelUUID  string     `json:"model-uuid,omitempty"`
+	OriginName string     `json:"origin-name,omitempty"`
+	Operation  string     `json:"operation,omitempty"`
+	After      *time.Time `json:"after,omitempty"`
+	Before     *time.Time `json:"before,omitempty"`
+	Offset     int        `json:"offset,omitempty"`
+	Limit      int        `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds a single entry from the controller's audit log.
+type AuditLogEntry struct {
+	JujuServerVersion string                 `json:"juju-server-version"`
+	ModelUUID         string                 `json:"model-uuid"`
+	Timestamp         time.Time              `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
+
+// AuditLogResults holds the audit log entries matching an
+// AuditLogQuery, oldest first.
+type AuditLogResults struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
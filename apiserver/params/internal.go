@@ -128,6 +128,11 @@ type ModelCreateArgs struct {
 	// and the owner is the controller owner, the same credential
 	// used for the controller model will be used.
 	CloudCredentialTag string `json:"credential,omitempty"`
+
+	// Quotas holds the quotas on the resources that the model may
+	// use. If this is nil, the controller's default model quotas
+	// are used.
+	Quotas *ModelQuotas `json:"quotas,omitempty"`
 }
 
 // Model holds the result of an API call returning a name and UUID
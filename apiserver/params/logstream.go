@@ -51,4 +51,23 @@ type LogStreamConfig struct {
 
 	// MaxLookbackRecords is the maximum number of log records to stream from the past.
 	MaxLookbackRecords int `schema:"maxlookbackrecords" url:"maxlookbackrecords,omitempty"`
+
+	// Level is the minimum level of log records to stream. If it is
+	// not set then records of all levels are streamed.
+	Level string `schema:"level" url:"level,omitempty"`
+
+	// IncludeEntity lists the entities whose records are streamed.
+	// The entries are tags, which may include the "*" wildcard.
+	IncludeEntity []string `schema:"includeEntity" url:"includeEntity,omitempty"`
+
+	// ExcludeEntity lists the entities whose records are not streamed.
+	ExcludeEntity []string `schema:"excludeEntity" url:"excludeEntity,omitempty"`
+
+	// IncludeModule lists the logging modules whose records are
+	// streamed.
+	IncludeModule []string `schema:"includeModule" url:"includeModule,omitempty"`
+
+	// ExcludeModule lists the logging modules whose records are not
+	// streamed.
+	ExcludeModule []string `schema:"excludeModule" url:"excludeModule,omitempty"`
 }
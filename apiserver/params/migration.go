@@ -77,6 +77,7 @@ type SerializedModel struct {
 	Charms    []string                  `json:"charms"`
 	Tools     []SerializedModelTools    `json:"tools"`
 	Resources []SerializedModelResource `json:"resources"`
+	Quotas    *ModelQuotas              `json:"quotas,omitempty"`
 }
 
 // SerializedModelTools holds the version and URI for a given tools
@@ -138,6 +139,7 @@ type MigrationModelInfo struct {
 	OwnerTag               string         `json:"owner-tag"`
 	AgentVersion           version.Number `json:"agent-version"`
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
+	Quotas                 *ModelQuotas   `json:"quotas,omitempty"`
 }
 
 // MigrationStatus reports the current status of a model migration.
@@ -147,6 +147,38 @@ type ModelInfo struct {
 	// Migration contains information about the latest failed or
 	// currently-running migration. It'll be nil if there isn't one.
 	Migration *ModelMigrationStatus `json:"migration,omitempty"`
+
+	// Quotas holds the quotas on the resources that the model may
+	// use. It'll be nil if the model's resources are not limited.
+	Quotas *ModelQuotas `json:"quotas,omitempty"`
+
+	// Usage holds the resources that the model uses, as counted
+	// against its quotas. It'll be nil if the model's resources are
+	// not limited. Like Machines, it is available to owners and users
+	// with write access or greater.
+	Usage *ModelQuotas `json:"usage,omitempty"`
+}
+
+// ModelQuotas holds the quotas on, or the use of, the resources of a
+// model. Memory and storage sizes are in MiB; zero quotas are not
+// limited.
+type ModelQuotas struct {
+	Machines int    `json:"machines,omitempty"`
+	Units    int    `json:"units,omitempty"`
+	Cores    uint64 `json:"cores,omitempty"`
+	Memory   uint64 `json:"memory,omitempty"`
+	Storage  uint64 `json:"storage,omitempty"`
+}
+
+// SetModelQuotas holds the quotas to set on a model.
+type SetModelQuotas struct {
+	ModelTag string      `json:"model-tag"`
+	Quotas   ModelQuotas `json:"quotas"`
+}
+
+// SetModelQuotasArgs holds the arguments to a SetModelQuotas call.
+type SetModelQuotasArgs struct {
+	Args []SetModelQuotas `json:"args"`
 }
 
 // ModelInfoResult holds the result of a ModelInfo call.
@@ -230,6 +262,12 @@ type ModifyModelAccess struct {
 	Action   ModelAction          `json:"action"`
 	Access   UserAccessPermission `json:"access"`
 	ModelTag string               `json:"model-tag"`
+	// Group, if set, names the local group whose access is modified,
+	// instead of that of the user.
+	Group string `json:"group,omitempty"`
+	// Application, if set, names the application in the model whose
+	// access is modified, instead of the access to the model itself.
+	Application string `json:"application,omitempty"`
 }
 
 // ModelAction is an action that can be performed on a model.
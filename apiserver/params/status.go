@@ -15,6 +15,11 @@ import (
 // StatusParams holds parameters for the Status call.
 type StatusParams struct {
 	Patterns []string `json:"patterns"`
+
+	// Filters holds status filter expressions, such as
+	// "workload=blocked|error". Only entities matching one of
+	// them, and those needed to show them, are included.
+	Filters []string `json:"filters,omitempty"`
 }
 
 // TODO(ericsnow) Add FullStatusResult.
@@ -222,6 +227,34 @@ type StatusHistoryResults struct {
 	Results []StatusHistoryResult `json:"results"`
 }
 
+// ModelStatusHistoryRequest holds the parameters to filter a query for
+// the status history of a whole model.
+type ModelStatusHistoryRequest struct {
+	Kinds        []string   `json:"kinds,omitempty"`
+	Applications []string   `json:"applications,omitempty"`
+	Since        *time.Time `json:"since,omitempty"`
+	Until        *time.Time `json:"until,omitempty"`
+	Size         int        `json:"size,omitempty"`
+	Exclude      []string   `json:"exclude,omitempty"`
+}
+
+// ModelStatusHistoryEntry holds a status history entry of an entity in
+// a model.
+type ModelStatusHistoryEntry struct {
+	Tag    string                 `json:"tag"`
+	Kind   string                 `json:"kind"`
+	Status string                 `json:"status"`
+	Info   string                 `json:"info"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Since  *time.Time             `json:"since"`
+}
+
+// ModelStatusHistoryResult holds the status history of a model, oldest
+// entry first.
+type ModelStatusHistoryResult struct {
+	Statuses []ModelStatusHistoryEntry `json:"statuses"`
+}
+
 // StatusHistoryPruneArgs holds arguments for status history
 // prunning process.
 type StatusHistoryPruneArgs struct {
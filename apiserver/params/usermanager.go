@@ -15,6 +15,7 @@ type UserInfo struct {
 	CreatedBy      string     `json:"created-by"`
 	DateCreated    time.Time  `json:"date-created"`
 	LastConnection *time.Time `json:"last-connection,omitempty"`
+	LockedUntil    *time.Time `json:"locked-until,omitempty"`
 	Disabled       bool       `json:"disabled"`
 }
 
@@ -67,3 +68,87 @@ type AddUserResult struct {
 	SecretKey []byte `json:"secret-key,omitempty"`
 	Error     *Error `json:"error,omitempty"`
 }
+
+// UserGroup holds information on a local group of users.
+type UserGroup struct {
+	Name        string    `json:"name"`
+	Members     []string  `json:"members"`
+	CreatedBy   string    `json:"created-by"`
+	DateCreated time.Time `json:"date-created"`
+}
+
+// UserGroupResult holds the result of a GroupInfo call.
+type UserGroupResult struct {
+	Result *UserGroup `json:"result,omitempty"`
+	Error  *Error     `json:"error,omitempty"`
+}
+
+// UserGroupResults holds the result of a bulk GroupInfo API call.
+type UserGroupResults struct {
+	Results []UserGroupResult `json:"results"`
+}
+
+// UserGroupNames holds the names of local groups of users. An empty
+// list passed to GroupInfo indicates that all groups should be returned.
+type UserGroupNames struct {
+	Names []string `json:"names"`
+}
+
+// ModifyUserGroupMembers holds the parameters for changing the members
+// of local groups of users.
+type ModifyUserGroupMembers struct {
+	Changes []UserGroupMembers `json:"changes"`
+}
+
+// UserGroupMembers holds the users added to or removed from a group.
+type UserGroupMembers struct {
+	Group    string   `json:"group"`
+	UserTags []string `json:"user-tags"`
+}
+
+// AddAPITokens holds the parameters for creating API tokens.
+type AddAPITokens struct {
+	Tokens []AddAPIToken `json:"tokens"`
+}
+
+// AddAPIToken holds the parameters for creating an API token for the
+// authenticated user. The token grants at most the given model access
+// to the given models, until it expires.
+type AddAPIToken struct {
+	Name      string     `json:"name"`
+	Access    string     `json:"access"`
+	ModelTags []string   `json:"model-tags"`
+	Expires   *time.Time `json:"expires,omitempty"`
+}
+
+// AddAPITokenResults holds the results of a bulk AddTokens API call.
+type AddAPITokenResults struct {
+	Results []AddAPITokenResult `json:"results"`
+}
+
+// AddAPITokenResult holds the secret token that was created, which is
+// not stored and cannot be retrieved again, or an error.
+type AddAPITokenResult struct {
+	Token string `json:"token,omitempty"`
+	Error *Error `json:"error,omitempty"`
+}
+
+// APIToken holds information on an API token.
+type APIToken struct {
+	Name        string     `json:"name"`
+	Owner       string     `json:"owner"`
+	Access      string     `json:"access"`
+	Models      []string   `json:"models"`
+	Expires     *time.Time `json:"expires,omitempty"`
+	DateCreated time.Time  `json:"date-created"`
+}
+
+// APITokenResults holds the result of a ListTokens API call.
+type APITokenResults struct {
+	Results []APIToken `json:"results"`
+}
+
+// APITokenNames holds the names of API tokens.
+type APITokenNames struct {
+	Names []string `json:"names"`
+}
@@ -149,6 +149,13 @@ func (h *registerUserHandler) processPost(req *http.Request, st *state.State) (
 	if err := json.Unmarshal(payloadBytes, &requestPayload); err != nil {
 		return failure(errors.Annotate(err, "cannot unmarshal payload"))
 	}
+	controllerCfg, err := st.ControllerConfig()
+	if err != nil {
+		return failure(errors.Trace(err))
+	}
+	if err := controllerCfg.PasswordPolicy().Validate(requestPayload.Password); err != nil {
+		return failure(errors.Trace(err))
+	}
 	if err := user.SetPassword(requestPayload.Password); err != nil {
 		return failure(errors.Annotate(err, "setting new password"))
 	}
@@ -0,0 +1,37 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/common"
+)
+
+func passwordChangeMethodsOnly(facadeName, methodName string) error {
+	if !IsMethodAllowedWithExpiredPassword(facadeName, methodName) {
+		return common.ErrPasswordExpired
+	}
+	return nil
+}
+
+func IsMethodAllowedWithExpiredPassword(facadeName, methodName string) bool {
+	methods, ok := allowedMethodsWithExpiredPassword[facadeName]
+	if !ok {
+		return false
+	}
+	return methods.Contains(methodName)
+}
+
+// allowedMethodsWithExpiredPassword stores api calls that are not blocked
+// for local users whose passwords have expired, so that they can set a
+// new one.
+var allowedMethodsWithExpiredPassword = map[string]set.Strings{
+	"UserManager": set.NewStrings(
+		"SetPassword", // for "juju change-user-password"
+	),
+	"Pinger": set.NewStrings(
+		"Ping",
+	),
+}
@@ -0,0 +1,38 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/testing"
+)
+
+type restrictPasswordsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&restrictPasswordsSuite{})
+
+func (r *restrictPasswordsSuite) TestAllowedMethods(c *gc.C) {
+	root := apiserver.TestingPasswordExpiredRoot(nil)
+	checkAllowed := func(facade, method string) {
+		caller, err := root.FindMethod(facade, 1, method)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(caller, gc.NotNil)
+	}
+	checkAllowed("UserManager", "SetPassword")
+	checkAllowed("Pinger", "Ping")
+}
+
+func (r *restrictPasswordsSuite) TestFindDisallowedMethod(c *gc.C) {
+	root := apiserver.TestingPasswordExpiredRoot(nil)
+	caller, err := root.FindMethod("Client", 1, "FullStatus")
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPasswordExpired)
+	c.Assert(caller, gc.IsNil)
+}
@@ -0,0 +1,35 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/common"
+)
+
+func tokenMethodsOnly(facadeName, methodName string) error {
+	if !IsMethodAllowedWithToken(facadeName, methodName) {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func IsMethodAllowedWithToken(facadeName, methodName string) bool {
+	methods, ok := forbiddenMethodsWithToken[facadeName]
+	if !ok {
+		return true
+	}
+	return !methods.Contains(methodName)
+}
+
+// forbiddenMethodsWithToken stores api calls that are blocked for users
+// logged in with an API token, so that a token cannot be used to gain
+// more access than it grants.
+var forbiddenMethodsWithToken = map[string]set.Strings{
+	"UserManager": set.NewStrings(
+		"SetPassword",
+		"AddTokens",
+	),
+}
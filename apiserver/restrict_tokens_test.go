@@ -0,0 +1,41 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/testing"
+)
+
+type restrictTokensSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&restrictTokensSuite{})
+
+func (r *restrictTokensSuite) TestAllowedMethods(c *gc.C) {
+	root := apiserver.TestingTokenRoot(nil)
+	checkAllowed := func(facade string, version int, method string) {
+		caller, err := root.FindMethod(facade, version, method)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(caller, gc.NotNil)
+	}
+	checkAllowed("Client", 1, "FullStatus")
+	checkAllowed("UserManager", 3, "ListTokens")
+	checkAllowed("UserManager", 3, "RevokeTokens")
+}
+
+func (r *restrictTokensSuite) TestFindDisallowedMethod(c *gc.C) {
+	root := apiserver.TestingTokenRoot(nil)
+	for _, method := range []string{"SetPassword", "AddTokens"} {
+		caller, err := root.FindMethod("UserManager", 3, method)
+		c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+		c.Check(caller, gc.IsNil)
+	}
+}
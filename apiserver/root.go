@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
@@ -383,12 +384,30 @@ func (r *apiHandler) GetAuthEntity() state.Entity {
 
 // HasPermission returns true if the logged in user can perform <operation> on <target>.
 func (r *apiHandler) HasPermission(operation permission.Access, target names.Tag) (bool, error) {
-	return common.HasPermission(r.state.UserAccess, r.entity.Tag(), operation, target)
+	return common.HasPermission(r.userAccess(), r.entity.Tag(), operation, target)
+}
+
+// userAccess returns a function that reports the access a user has on a
+// target. When logged in with an API token, that access is limited by
+// the token.
+func (r *apiHandler) userAccess() func(names.UserTag, names.Tag) (permission.UserAccess, error) {
+	return entityUserAccess(r.state, r.entity)
+}
+
+// entityUserAccess returns a function that reports the access a user
+// has on a target, limited by the API token the entity authenticated
+// with, if any.
+func entityUserAccess(st *state.State, entity state.Entity) func(names.UserTag, names.Tag) (permission.UserAccess, error) {
+	userAccess := common.EffectiveUserAccess(st)
+	if token, ok := entity.(*authentication.TokenEntity); ok {
+		return common.ScopedUserAccess(userAccess, token.Token)
+	}
+	return userAccess
 }
 
 // UserHasPermission returns true if the passed in user can perform <operation> on <target>.
 func (r *apiHandler) UserHasPermission(user names.UserTag, operation permission.Access, target names.Tag) (bool, error) {
-	return common.HasPermission(r.state.UserAccess, user, operation, target)
+	return common.HasPermission(common.EffectiveUserAccess(r.state), user, operation, target)
 }
 
 // DescribeFacades returns the list of available Facades and their Versions
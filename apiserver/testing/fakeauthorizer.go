@@ -75,6 +75,8 @@ func (fa FakeAuthorizer) HasPermission(operation permission.Access, target names
 // setting permissionname as the name that user will always have the given permission.
 // setting permissionnamemodeltagstring as the name will make that user have the given
 // permission only in that model.
+// setting permissionnameapplicationtagstring as the name will make that user have the
+// given permission only on that application.
 func nameBasedHasPermission(name string, operation permission.Access, target names.Tag) bool {
 	var perm permission.Access
 	switch {
@@ -100,6 +102,9 @@ func nameBasedHasPermission(name string, operation permission.Access, target nam
 	if len(name) == 0 {
 		return operation == perm
 	}
+	if target.Kind() == names.ApplicationTagKind {
+		return operation == perm && name == target.String()
+	}
 	if target.Kind() != names.ModelTagKind {
 		return false
 	}
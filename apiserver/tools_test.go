@@ -287,6 +287,33 @@ func (s *toolsSuite) TestMigrateToolsUnauth(c *gc.C) {
 	)
 }
 
+func (s *toolsSuite) TestMigrateToolsAPITokenUnauth(c *gc.C) {
+	// API tokens are limited to login access on the controller,
+	// whatever the access of their owner.
+	controllerTag := names.NewControllerTag(s.ControllerConfig.ControllerUUID())
+	_, err := s.State.SetUserAccess(s.userTag, controllerTag, permission.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	_, token, err := s.State.AddAPIToken(state.AddAPITokenArgs{
+		Owner:      s.userTag,
+		Name:       "ci",
+		Access:     permission.AdminAccess,
+		ModelUUIDs: []string{s.State.ModelUUID()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.password = token
+
+	_, v, toolPath := s.setupToolsForUpload(c)
+	uri := s.baseURL(c)
+	uri.Path = "/migrate/tools"
+	uri.RawQuery = "?binaryVersion=" + v.String()
+
+	resp := s.uploadRequest(c, uri.String(), "application/x-tar-gz", toolPath)
+	s.assertErrorResponse(
+		c, resp, http.StatusUnauthorized,
+		"not a controller admin",
+	)
+}
+
 func (s *toolsSuite) TestBlockUpload(c *gc.C) {
 	// Make some fake tools.
 	_, v, toolPath := s.setupToolsForUpload(c)
@@ -29,6 +29,9 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
 	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV4)
+
+	// Facade version 5 adds ActionStatus.
+	common.RegisterStandardFacade("Uniter", 5, NewUniterAPIV4)
 }
 
 // UniterAPIV3 implements the API version 3, used by the uniter worker.
@@ -952,6 +955,19 @@ func (u *UniterAPIV3) FinishActions(args params.ActionExecutionResults) (params.
 	return common.FinishActions(args, actionFn), nil
 }
 
+// ActionStatus returns the current status of the Actions represented by
+// the passed in Tags, so that the unit can tell when one it is running
+// should be aborted.
+func (u *UniterAPIV3) ActionStatus(args params.Entities) (params.StringResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, u.st.ActionByTag)
+	return common.ActionStatuses(args, actionFn), nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// AddTokens creates API tokens for the authenticated user. Each token
+// grants the access of the user, limited to the given models and model
+// access. The secret tokens are returned; they are not stored, so they
+// cannot be retrieved again.
+func (api *UserManagerAPI) AddTokens(args params.AddAPITokens) (params.AddAPITokenResults, error) {
+	var result params.AddAPITokenResults
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.AddAPITokenResult, len(args.Tokens))
+	for i, arg := range args.Tokens {
+		token, err := api.addToken(arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(errors.Annotate(err, "failed to create token"))
+			continue
+		}
+		result.Results[i].Token = token
+	}
+	return result, nil
+}
+
+func (api *UserManagerAPI) addToken(arg params.AddAPIToken) (string, error) {
+	modelUUIDs := make([]string, len(arg.ModelTags))
+	for i, tag := range arg.ModelTags {
+		modelTag, err := names.ParseModelTag(tag)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		modelUUIDs[i] = modelTag.Id()
+	}
+	var expires time.Time
+	if arg.Expires != nil {
+		expires = *arg.Expires
+	}
+	_, token, err := api.state.AddAPIToken(state.AddAPITokenArgs{
+		Owner:      api.apiUser,
+		Name:       arg.Name,
+		Access:     permission.Access(arg.Access),
+		ModelUUIDs: modelUUIDs,
+		Expires:    expires,
+	})
+	return token, errors.Trace(err)
+}
+
+// ListTokens returns information on the API tokens of the authenticated
+// user.
+func (api *UserManagerAPI) ListTokens() (params.APITokenResults, error) {
+	var result params.APITokenResults
+	tokens, err := api.state.APITokensForUser(api.apiUser)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.APIToken, len(tokens))
+	for i, token := range tokens {
+		info := params.APIToken{
+			Name:        token.Name(),
+			Owner:       token.Owner().Id(),
+			Access:      string(token.Access()),
+			Models:      api.modelNames(token.ModelUUIDs()),
+			DateCreated: token.DateCreated(),
+		}
+		if expires := token.Expires(); !expires.IsZero() {
+			info.Expires = &expires
+		}
+		result.Results[i] = info
+	}
+	return result, nil
+}
+
+// modelNames returns the qualified names of the models with the given
+// UUIDs. The UUIDs of models that no longer exist are returned as they
+// are.
+func (api *UserManagerAPI) modelNames(uuids []string) []string {
+	modelNames := make([]string, len(uuids))
+	for i, uuid := range uuids {
+		model, err := api.state.GetModel(names.NewModelTag(uuid))
+		if err != nil {
+			logger.Debugf("cannot get model %q: %v", uuid, err)
+			modelNames[i] = uuid
+			continue
+		}
+		modelNames[i] = fmt.Sprintf("%s/%s", model.Owner().Id(), model.Name())
+	}
+	return modelNames
+}
+
+// RevokeTokens removes the named API tokens of the authenticated user.
+func (api *UserManagerAPI) RevokeTokens(args params.APITokenNames) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.check.RemoveAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ErrorResult, len(args.Names))
+	for i, name := range args.Names {
+		if err := api.state.RemoveAPIToken(api.apiUser, name); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
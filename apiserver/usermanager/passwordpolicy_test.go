@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/usermanager"
+	"github.com/juju/juju/controller"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type passwordPolicySuite struct {
+	jujutesting.JujuConnSuite
+
+	usermanager *usermanager.UserManagerAPI
+}
+
+var _ = gc.Suite(&passwordPolicySuite{})
+
+func (s *passwordPolicySuite) SetUpTest(c *gc.C) {
+	s.ControllerConfigAttrs = map[string]interface{}{
+		controller.PasswordMinLength:         10,
+		controller.PasswordRequireComplexity: true,
+	}
+	s.JujuConnSuite.SetUpTest(c)
+
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	var err error
+	s.usermanager, err = usermanager.NewUserManagerAPI(s.State, common.NewResources(), authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *passwordPolicySuite) TestAddUserPasswordTooShort(c *gc.C) {
+	result, err := s.usermanager.AddUser(params.AddUsers{
+		Users: []params.AddUser{{
+			Username: "foobar",
+			Password: "Sh0rt!",
+		}, {
+			Username: "barfoo",
+			Password: "L0nger-Password",
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches,
+		"failed to create user: password must be at least 10 characters long")
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	_, err = s.State.User(names.NewLocalUserTag("foobar"))
+	c.Assert(err, gc.ErrorMatches, `user "foobar" not found`)
+}
+
+func (s *passwordPolicySuite) TestAddUserWithSecretKeyIgnoresPolicy(c *gc.C) {
+	result, err := s.usermanager.AddUser(params.AddUsers{
+		Users: []params.AddUser{{Username: "foobar"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+}
+
+func (s *passwordPolicySuite) TestSetPasswordNotComplex(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+
+	results, err := s.usermanager.SetPassword(params.EntityPasswords{
+		Changes: []params.EntityPassword{{
+			Tag:      alex.Tag().String(),
+			Password: "all-lower-case",
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		"password must contain at least three of: lower case letters, upper case letters, digits and symbols")
+
+	err = alex.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(alex.PasswordValid("all-lower-case"), jc.IsFalse)
+}
+
+func (s *passwordPolicySuite) TestUserInfoLockedOut(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+	err := alex.RecordFailedLogin(1, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	lockedUntil, err := alex.LockedOutUntil()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.usermanager.UserInfo(params.UserInfoRequest{
+		Entities: []params.Entity{{Tag: alex.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Result.LockedUntil, jc.DeepEquals, &lockedUntil)
+}
+
+func (s *passwordPolicySuite) TestEnableUserLiftsLockout(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+	err := alex.RecordFailedLogin(1, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.usermanager.EnableUser(params.Entities{
+		Entities: []params.Entity{{Tag: alex.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	lockedUntil, err := alex.LockedOutUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lockedUntil.IsZero(), jc.IsTrue)
+}
@@ -0,0 +1,158 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// checkGroupAccess returns an error unless the authenticated user is a
+// controller superuser. Only superusers may manage groups, as access
+// granted to a group applies to all its members.
+func (api *UserManagerAPI) checkGroupAccess() error {
+	isSuperUser, err := api.hasControllerAdminAccess()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isSuperUser {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// AddGroups adds local groups of users with the given names.
+func (api *UserManagerAPI) AddGroups(args params.UserGroupNames) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.checkGroupAccess(); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ErrorResult, len(args.Names))
+	for i, name := range args.Names {
+		if _, err := api.state.AddUserGroup(name, api.apiUser); err != nil {
+			result.Results[i].Error = common.ServerError(errors.Annotate(err, "failed to create group"))
+		}
+	}
+	return result, nil
+}
+
+// RemoveGroups removes the local groups of users with the given names,
+// along with all the access granted to them.
+func (api *UserManagerAPI) RemoveGroups(args params.UserGroupNames) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.check.RemoveAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.checkGroupAccess(); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ErrorResult, len(args.Names))
+	for i, name := range args.Names {
+		if err := api.state.RemoveUserGroup(name); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// GroupInfo returns information on local groups of users. If no names
+// are given, all groups are returned.
+func (api *UserManagerAPI) GroupInfo(args params.UserGroupNames) (params.UserGroupResults, error) {
+	var results params.UserGroupResults
+	if err := api.checkGroupAccess(); err != nil {
+		return results, errors.Trace(err)
+	}
+
+	if len(args.Names) == 0 {
+		groups, err := api.state.AllUserGroups()
+		if err != nil {
+			return results, errors.Trace(err)
+		}
+		results.Results = make([]params.UserGroupResult, len(groups))
+		for i, group := range groups {
+			results.Results[i].Result = groupInfo(group)
+		}
+		return results, nil
+	}
+
+	results.Results = make([]params.UserGroupResult, len(args.Names))
+	for i, name := range args.Names {
+		group, err := api.state.UserGroup(name)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = groupInfo(group)
+	}
+	return results, nil
+}
+
+func groupInfo(group *state.UserGroup) *params.UserGroup {
+	return &params.UserGroup{
+		Name:        group.Name(),
+		Members:     group.Members(),
+		CreatedBy:   group.CreatedBy(),
+		DateCreated: group.DateCreated(),
+	}
+}
+
+// AddGroupMembers adds local users to local groups.
+func (api *UserManagerAPI) AddGroupMembers(args params.ModifyUserGroupMembers) (params.ErrorResults, error) {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	return api.modifyGroupMembers(args, (*state.UserGroup).AddMembers)
+}
+
+// RemoveGroupMembers removes users from local groups.
+func (api *UserManagerAPI) RemoveGroupMembers(args params.ModifyUserGroupMembers) (params.ErrorResults, error) {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	return api.modifyGroupMembers(args, (*state.UserGroup).RemoveMembers)
+}
+
+func (api *UserManagerAPI) modifyGroupMembers(
+	args params.ModifyUserGroupMembers,
+	method func(*state.UserGroup, ...names.UserTag) error,
+) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.checkGroupAccess(); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ErrorResult, len(args.Changes))
+	for i, arg := range args.Changes {
+		if err := modifyGroupMembers(api.state, arg, method); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+func modifyGroupMembers(
+	st *state.State,
+	arg params.UserGroupMembers,
+	method func(*state.UserGroup, ...names.UserTag) error,
+) error {
+	users := make([]names.UserTag, len(arg.UserTags))
+	for i, tag := range arg.UserTags {
+		user, err := names.ParseUserTag(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		users[i] = user
+	}
+	group, err := st.UserGroup(arg.Group)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(method(group, users...))
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/usermanager"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *userManagerSuite) TestAddGroups(c *gc.C) {
+	result, err := s.usermanager.AddGroups(params.UserGroupNames{Names: []string{"ops", "b^d"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `failed to create group: invalid group name "b\^d"`)
+
+	group, err := s.State.UserGroup("ops")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(group.CreatedBy(), gc.Equals, s.adminName)
+}
+
+func (s *userManagerSuite) TestRemoveGroups(c *gc.C) {
+	_, err := s.State.AddUserGroup("ops", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.usermanager.RemoveGroups(params.UserGroupNames{Names: []string{"ops", "dev"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `group "dev" not found`)
+
+	_, err = s.State.UserGroup("ops")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *userManagerSuite) TestGroupInfo(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "sam", NoModelUser: true})
+	for _, name := range []string{"ops", "dev"} {
+		_, err := s.State.AddUserGroup(name, s.AdminUserTag(c))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	result, err := s.usermanager.AddGroupMembers(params.ModifyUserGroupMembers{
+		Changes: []params.UserGroupMembers{{
+			Group:    "ops",
+			UserTags: []string{names.NewUserTag("sam").String()},
+		}, {
+			Group:    "qa",
+			UserTags: []string{names.NewUserTag("sam").String()},
+		}, {
+			Group:    "ops",
+			UserTags: []string{"not-a-tag"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `group "qa" not found`)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `"not-a-tag" is not a valid tag`)
+
+	info, err := s.usermanager.GroupInfo(params.UserGroupNames{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Results, gc.HasLen, 2)
+	c.Check(info.Results[0].Result.Name, gc.Equals, "dev")
+	c.Check(info.Results[0].Result.Members, gc.HasLen, 0)
+	c.Check(info.Results[1].Result.Name, gc.Equals, "ops")
+	c.Check(info.Results[1].Result.Members, jc.DeepEquals, []string{"sam"})
+	c.Check(info.Results[1].Result.CreatedBy, gc.Equals, s.adminName)
+
+	info, err = s.usermanager.GroupInfo(params.UserGroupNames{Names: []string{"ops", "qa"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Results, gc.HasLen, 2)
+	c.Check(info.Results[0].Result.Name, gc.Equals, "ops")
+	c.Check(info.Results[1].Error, gc.ErrorMatches, `group "qa" not found`)
+
+	result, err = s.usermanager.RemoveGroupMembers(params.ModifyUserGroupMembers{
+		Changes: []params.UserGroupMembers{{
+			Group:    "ops",
+			UserTags: []string{names.NewUserTag("sam").String()},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	group, err := s.State.UserGroup("ops")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(group.Members(), gc.HasLen, 0)
+}
+
+func (s *userManagerSuite) TestGroupsAsNormalUser(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{Tag: alex.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.UserGroupNames{Names: []string{"ops"}}
+	_, err = usermanager.AddGroups(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = usermanager.RemoveGroups(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = usermanager.GroupInfo(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = usermanager.AddGroupMembers(params.ModifyUserGroupMembers{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = usermanager.RemoveGroupMembers(params.ModifyUserGroupMembers{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
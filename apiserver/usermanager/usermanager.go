@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
@@ -21,6 +22,12 @@ var logger = loggo.GetLogger("juju.apiserver.usermanager")
 
 func init() {
 	common.RegisterStandardFacade("UserManager", 1, NewUserManagerAPI)
+
+	// Facade version 2 adds the management of local user groups.
+	common.RegisterStandardFacade("UserManager", 2, NewUserManagerAPI)
+
+	// Facade version 3 adds API tokens.
+	common.RegisterStandardFacade("UserManager", 3, NewUserManagerAPI)
 }
 
 // UserManagerAPI implements the user manager interface and is the concrete
@@ -78,6 +85,16 @@ func (api *UserManagerAPI) hasControllerAdminAccess() (bool, error) {
 	return isAdmin, err
 }
 
+// passwordPolicy returns the controller's policy for the passwords of
+// local users.
+func (api *UserManagerAPI) passwordPolicy() (controller.PasswordPolicy, error) {
+	controllerCfg, err := api.state.ControllerConfig()
+	if err != nil {
+		return controller.PasswordPolicy{}, errors.Trace(err)
+	}
+	return controllerCfg.PasswordPolicy(), nil
+}
+
 // AddUser adds a user with a username, and either a password or
 // a randomly generated secret key which will be returned.
 func (api *UserManagerAPI) AddUser(args params.AddUsers) (params.AddUserResults, error) {
@@ -102,10 +119,20 @@ func (api *UserManagerAPI) AddUser(args params.AddUsers) (params.AddUserResults,
 		return result, common.ErrPerm
 	}
 
+	policy, err := api.passwordPolicy()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
 	for i, arg := range args.Users {
 		var user *state.User
 		var err error
 		if arg.Password != "" {
+			if err := policy.Validate(arg.Password); err != nil {
+				err = errors.Annotate(err, "failed to create user")
+				result.Results[i].Error = common.ServerError(err)
+				continue
+			}
 			user, err = api.state.AddUser(arg.Username, arg.DisplayName, arg.Password, api.apiUser.Id())
 		} else {
 			user, err = api.state.AddUserWithSecretKey(arg.Username, arg.DisplayName, api.apiUser.Id())
@@ -195,7 +222,8 @@ func (api *UserManagerAPI) getUser(tag string) (*state.User, error) {
 }
 
 // EnableUser enables one or more users.  If the user is already enabled,
-// the action is considered a success.
+// the action is considered a success. Enabling a user also lifts any
+// lockout after too many failed logins.
 func (api *UserManagerAPI) EnableUser(users params.Entities) (params.ErrorResults, error) {
 	isSuperUser, err := api.hasControllerAdminAccess()
 	if err != nil {
@@ -208,7 +236,14 @@ func (api *UserManagerAPI) EnableUser(users params.Entities) (params.ErrorResult
 	if err := api.check.ChangeAllowed(); err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
-	return api.enableUserImpl(users, "enable", (*state.User).Enable)
+	return api.enableUserImpl(users, "enable", enableUser)
+}
+
+func enableUser(user *state.User) error {
+	if err := user.Enable(); err != nil {
+		return errors.Trace(err)
+	}
+	return user.ResetFailedLogins()
 }
 
 // DisableUser disables one or more users.  If the user is already disabled,
@@ -290,6 +325,13 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 		} else {
 			lastLogin = &userLastLogin
 		}
+		var lockedUntil *time.Time
+		userLockedUntil, err := user.LockedOutUntil()
+		if err != nil {
+			logger.Debugf("error getting lockout: %v", err)
+		} else if !userLockedUntil.IsZero() {
+			lockedUntil = &userLockedUntil
+		}
 		result := params.UserInfoResult{
 			Result: &params.UserInfo{
 				Username:       user.Name(),
@@ -297,6 +339,7 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 				CreatedBy:      user.CreatedBy(),
 				DateCreated:    user.DateCreated(),
 				LastConnection: lastLogin,
+				LockedUntil:    lockedUntil,
 				Disabled:       user.IsDisabled(),
 			},
 		}
@@ -391,6 +434,13 @@ func (api *UserManagerAPI) setPassword(arg params.EntityPassword) error {
 	if arg.Password == "" {
 		return errors.New("cannot use an empty password")
 	}
+	policy, err := api.passwordPolicy()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := policy.Validate(arg.Password); err != nil {
+		return errors.Trace(err)
+	}
 	if err := user.SetPassword(arg.Password); err != nil {
 		return errors.Annotate(err, "failed to set password")
 	}
@@ -78,3 +78,45 @@ func (e AuditEntry) Validate() error {
 
 	return nil
 }
+
+// Query defines the criteria used to select audit entries from a
+// backing store. Zero valued fields match all entries.
+type Query struct {
+	// ModelUUID restricts the results to entries written on the
+	// given model.
+	ModelUUID string
+	// OriginName restricts the results to entries triggered by
+	// the given origin.
+	OriginName string
+	// Operation restricts the results to entries recording the
+	// given operation.
+	Operation string
+	// After restricts the results to entries generated at or
+	// after this time.
+	After time.Time
+	// Before restricts the results to entries generated before
+	// this time.
+	Before time.Time
+	// Offset is the number of matching entries to skip.
+	Offset int
+	// Limit is the maximum number of entries to return. A value of
+	// zero means no limit.
+	Limit int
+}
+
+// Validate ensures that the query is well formed.
+func (q Query) Validate() error {
+	if q.ModelUUID != "" && !utils.IsValidUUIDString(q.ModelUUID) {
+		return errors.NotValidf("ModelUUID %q", q.ModelUUID)
+	}
+	if !q.After.IsZero() && !q.Before.IsZero() && !q.After.Before(q.Before) {
+		return errors.NotValidf("time range %s to %s", q.After, q.Before)
+	}
+	if q.Offset < 0 {
+		return errors.NotValidf("negative Offset")
+	}
+	if q.Limit < 0 {
+		return errors.NotValidf("negative Limit")
+	}
+	return nil
+}
@@ -103,6 +103,30 @@ func (s *auditSuite) TestValidate_EmptyJujuServerVersionErrors(c *gc.C) {
 	c.Check(validationErr, gc.ErrorMatches, "JujuServerVersion not assigned")
 }
 
+func (s *auditSuite) TestQueryValidate_EmptyQueryValid(c *gc.C) {
+	c.Check(audit.Query{}.Validate(), jc.ErrorIsNil)
+}
+
+func (s *auditSuite) TestQueryValidate_InvalidModelUUIDErrors(c *gc.C) {
+	validationErr := audit.Query{ModelUUID: "."}.Validate()
+	c.Check(validationErr, jc.Satisfies, errors.IsNotValid)
+	c.Check(validationErr, gc.ErrorMatches, `ModelUUID "." not valid`)
+}
+
+func (s *auditSuite) TestQueryValidate_EmptyTimeRangeErrors(c *gc.C) {
+	now := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	validationErr := audit.Query{After: now, Before: now}.Validate()
+	c.Check(validationErr, jc.Satisfies, errors.IsNotValid)
+	c.Check(validationErr, gc.ErrorMatches, "time range .* not valid")
+}
+
+func (s *auditSuite) TestQueryValidate_NegativePagingErrors(c *gc.C) {
+	validationErr := audit.Query{Offset: -1}.Validate()
+	c.Check(validationErr, gc.ErrorMatches, "negative Offset not valid")
+	validationErr = audit.Query{Limit: -1}.Validate()
+	c.Check(validationErr, gc.ErrorMatches, "negative Limit not valid")
+}
+
 func validEntry() audit.AuditEntry {
 	return audit.AuditEntry{
 		JujuServerVersion: version.MustParse("1.0.0"),
@@ -0,0 +1,267 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// SyslogClient exposes the functionality of the logfwd/syslog client
+// needed to forward audit entries.
+type SyslogClient interface {
+	// Send sends the records to the remote syslog host.
+	Send([]logfwd.Record) error
+
+	// Close closes the connection to the remote syslog host.
+	Close() error
+}
+
+const (
+	// DefaultSyslogBufferSize is the default number of audit entries
+	// held by a SyslogSink while they wait to be sent.
+	DefaultSyslogBufferSize = 1000
+
+	// DefaultSyslogRetryDelay is the default time a SyslogSink waits
+	// before trying again after failing to send an entry.
+	DefaultSyslogRetryDelay = 10 * time.Second
+
+	// DefaultSyslogQueueTimeout is the default time a SyslogSink waits
+	// for room in a full buffer before giving up on queueing an entry.
+	DefaultSyslogQueueTimeout = 5 * time.Second
+)
+
+// SyslogSinkConfig holds the configuration of a SyslogSink.
+type SyslogSinkConfig struct {
+	// ControllerUUID identifies the controller in forwarded records.
+	ControllerUUID string
+
+	// OpenClient opens a connection to the remote syslog host.
+	OpenClient func() (SyslogClient, error)
+
+	// Clock is used to wait before retrying failed sends, and for
+	// room in a full buffer.
+	Clock clock.Clock
+
+	// BufferSize is the number of entries held while they wait to
+	// be sent.
+	BufferSize int
+
+	// RetryDelay is how long to wait before trying again after
+	// failing to send an entry.
+	RetryDelay time.Duration
+
+	// QueueTimeout is how long to wait for room in a full buffer
+	// before giving up on queueing an entry.
+	QueueTimeout time.Duration
+
+	// Overflow, if set, saves the entries which cannot be queued.
+	// Without it, such entries fail to be handled.
+	Overflow AuditEntrySinkFn
+}
+
+// SyslogSink forwards audit entries to a remote syslog host as log
+// records. Entries are queued and sent in the background, so that a
+// slow or unreachable syslog host doesn't hold up API requests. While
+// the queue is full, new entries wait a bounded time for room, and are
+// then saved to the overflow sink instead; no entry is silently lost.
+// The client is opened lazily, and reopened after a failure, so that
+// an unreachable syslog host doesn't prevent the controller from
+// starting.
+type SyslogSink struct {
+	tomb    tomb.Tomb
+	config  SyslogSinkConfig
+	records chan logfwd.Record
+
+	mu         sync.Mutex
+	overflowed int
+}
+
+// NewSyslogSink returns a SyslogSink with the given configuration,
+// which sends entries until it is killed.
+func NewSyslogSink(config SyslogSinkConfig) *SyslogSink {
+	s := &SyslogSink{
+		config:  config,
+		records: make(chan logfwd.Record, config.BufferSize),
+	}
+	go func() {
+		defer s.tomb.Done()
+		s.tomb.Kill(s.loop())
+	}()
+	return s
+}
+
+// syslogSoftwareName identifies audit records in the syslog APP-NAME
+// field, distinguishing them from agent log records.
+const syslogSoftwareName = "jujud-audit"
+
+// Handle queues the entry to be sent to the syslog host. It has the
+// signature of an AuditEntrySinkFn. If the queue stays full for the
+// configured timeout, the entry is saved to the overflow sink; Handle
+// fails if there is none, or it fails too.
+func (s *SyslogSink) Handle(entry AuditEntry) error {
+	rec, err := s.recordFromEntry(entry)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	select {
+	case s.records <- rec:
+		return nil
+	default:
+	}
+	select {
+	case s.records <- rec:
+		return nil
+	case <-s.tomb.Dying():
+	case <-s.config.Clock.After(s.config.QueueTimeout):
+	}
+	return s.overflow(entry)
+}
+
+// overflow saves an entry which could not be queued to the overflow
+// sink.
+func (s *SyslogSink) overflow(entry AuditEntry) error {
+	if s.config.Overflow == nil {
+		return errors.New("syslog audit queue is full")
+	}
+	if err := s.config.Overflow(entry); err != nil {
+		return errors.Annotate(err, "syslog audit queue is full, cannot save audit entry elsewhere")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.overflowed == 0 {
+		logger.Warningf("syslog audit queue is full, saving audit entries to the overflow sink")
+	}
+	s.overflowed++
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (s *SyslogSink) Kill() {
+	s.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (s *SyslogSink) Wait() error {
+	return s.tomb.Wait()
+}
+
+func (s *SyslogSink) loop() error {
+	var client SyslogClient
+	defer func() {
+		if client != nil {
+			if err := client.Close(); err != nil {
+				logger.Debugf("closing syslog connection: %v", err)
+			}
+		}
+	}()
+	for {
+		var rec logfwd.Record
+		select {
+		case <-s.tomb.Dying():
+			return tomb.ErrDying
+		case rec = <-s.records:
+		}
+		// Keep trying to send the record; further entries are
+		// queued meanwhile, until the queue fills up.
+		for {
+			var err error
+			client, err = s.send(client, rec)
+			if err == nil {
+				break
+			}
+			logger.Warningf("%v", err)
+			select {
+			case <-s.tomb.Dying():
+				return tomb.ErrDying
+			case <-s.config.Clock.After(s.config.RetryDelay):
+			}
+		}
+		s.mu.Lock()
+		overflowed := s.overflowed
+		s.overflowed = 0
+		s.mu.Unlock()
+		if overflowed > 0 {
+			logger.Warningf("saved %d audit entries to the overflow sink while the syslog queue was full", overflowed)
+		}
+	}
+}
+
+// send sends the record, opening a client first if there is none.
+// It returns the client to use for the next record, which is nil if
+// sending failed.
+func (s *SyslogSink) send(client SyslogClient, rec logfwd.Record) (SyslogClient, error) {
+	if client == nil {
+		var err error
+		client, err = s.config.OpenClient()
+		if err != nil {
+			return nil, errors.Annotate(err, "opening syslog connection")
+		}
+	}
+	if err := client.Send([]logfwd.Record{rec}); err != nil {
+		// Drop the connection so that the next
+		// attempt gets a fresh one.
+		if closeErr := client.Close(); closeErr != nil {
+			logger.Debugf("closing syslog connection: %v", closeErr)
+		}
+		return nil, errors.Annotate(err, "sending audit entry to syslog")
+	}
+	return client, nil
+}
+
+func (s *SyslogSink) recordFromEntry(entry AuditEntry) (logfwd.Record, error) {
+	tag, err := names.ParseTag(entry.OriginName)
+	if err != nil {
+		return logfwd.Record{}, errors.Annotate(err, "parsing audit entry origin")
+	}
+	origin, err := logfwd.OriginForJuju(tag, s.config.ControllerUUID, entry.ModelUUID, entry.JujuServerVersion)
+	if err != nil {
+		return logfwd.Record{}, errors.Trace(err)
+	}
+	origin.Software.Name = syslogSoftwareName
+
+	msg, err := json.Marshal(syslogMessage{
+		ModelUUID:     entry.ModelUUID,
+		RemoteAddress: entry.RemoteAddress,
+		OriginType:    entry.OriginType,
+		OriginName:    entry.OriginName,
+		Operation:     entry.Operation,
+		Data:          entry.Data,
+	})
+	if err != nil {
+		return logfwd.Record{}, errors.Annotate(err, "marshalling audit entry")
+	}
+	rec := logfwd.Record{
+		Origin:    origin,
+		Timestamp: entry.Timestamp,
+		Level:     loggo.INFO,
+		Location: logfwd.SourceLocation{
+			Module: "juju.audit",
+		},
+		Message: string(msg),
+	}
+	if err := rec.Validate(); err != nil {
+		return logfwd.Record{}, errors.Trace(err)
+	}
+	return rec, nil
+}
+
+// syslogMessage is the body of audit entries forwarded to syslog.
+type syslogMessage struct {
+	ModelUUID     string                 `json:"model-uuid"`
+	RemoteAddress string                 `json:"remote-address"`
+	OriginType    string                 `json:"origin-type"`
+	OriginName    string                 `json:"origin-name"`
+	Operation     string                 `json:"operation"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+}
@@ -0,0 +1,296 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/logfwd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditSyslogSuite struct {
+	testing.IsolationSuite
+	stub   *testing.Stub
+	client *stubSyslogClient
+	clock  *testing.Clock
+	opened int
+
+	queueTimeout time.Duration
+	overflow     func(audit.AuditEntry) error
+}
+
+var _ = gc.Suite(&auditSyslogSuite{})
+
+func (s *auditSyslogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &testing.Stub{}
+	s.client = &stubSyslogClient{
+		stub: s.stub,
+		sent: make(chan []logfwd.Record, 10),
+	}
+	s.clock = testing.NewClock(time.Time{})
+	s.opened = 0
+	s.queueTimeout = 500 * time.Millisecond
+	s.overflow = nil
+}
+
+func (s *auditSyslogSuite) open() (audit.SyslogClient, error) {
+	s.stub.AddCall("Open")
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	s.opened++
+	return s.client, nil
+}
+
+func (s *auditSyslogSuite) userEntry() audit.AuditEntry {
+	entry := validEntry()
+	entry.ModelUUID = coretesting.ModelTag.Id()
+	entry.OriginType = "user"
+	entry.OriginName = "user-bob"
+	entry.Operation = "Application:Destroy"
+	entry.Data = map[string]interface{}{"application": "mysql"}
+	return entry
+}
+
+func (s *auditSyslogSuite) newSink(c *gc.C, bufferSize int) *audit.SyslogSink {
+	sink := audit.NewSyslogSink(audit.SyslogSinkConfig{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		OpenClient:     s.open,
+		Clock:          s.clock,
+		BufferSize:     bufferSize,
+		RetryDelay:     time.Second,
+		QueueTimeout:   s.queueTimeout,
+		Overflow:       s.overflow,
+	})
+	s.AddCleanup(func(c *gc.C) {
+		c.Check(worker.Stop(sink), jc.ErrorIsNil)
+	})
+	return sink
+}
+
+// waitSent waits until the client has sent the given number of records.
+func (s *auditSyslogSuite) waitSent(c *gc.C, n int) []logfwd.Record {
+	for i := 0; i < n; i++ {
+		select {
+		case rec := <-s.client.sent:
+			if i == n-1 {
+				return rec
+			}
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for record %d", i)
+		}
+	}
+	return nil
+}
+
+func (s *auditSyslogSuite) TestSendsRecord(c *gc.C) {
+	sink := s.newSink(c, 10)
+	entry := s.userEntry()
+	err := sink.Handle(entry)
+	c.Assert(err, jc.ErrorIsNil)
+
+	records := s.waitSent(c, 1)
+	s.stub.CheckCallNames(c, "Open", "Send")
+	c.Assert(records, gc.HasLen, 1)
+	rec := records[0]
+	c.Check(rec.Origin.ControllerUUID, gc.Equals, coretesting.ControllerTag.Id())
+	c.Check(rec.Origin.ModelUUID, gc.Equals, coretesting.ModelTag.Id())
+	c.Check(rec.Origin.Type, gc.Equals, logfwd.OriginTypeUser)
+	c.Check(rec.Origin.Name, gc.Equals, "bob")
+	c.Check(rec.Origin.Software.Name, gc.Equals, "jujud-audit")
+	c.Check(rec.Timestamp, gc.Equals, entry.Timestamp)
+	c.Check(rec.Level, gc.Equals, loggo.INFO)
+	c.Check(rec.Location.Module, gc.Equals, "juju.audit")
+
+	var msg map[string]interface{}
+	err = json.Unmarshal([]byte(rec.Message), &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg, jc.DeepEquals, map[string]interface{}{
+		"model-uuid":     coretesting.ModelTag.Id(),
+		"remote-address": "8.8.8.8",
+		"origin-type":    "user",
+		"origin-name":    "user-bob",
+		"operation":      "Application:Destroy",
+		"data":           map[string]interface{}{"application": "mysql"},
+	})
+}
+
+func (s *auditSyslogSuite) TestReusesClient(c *gc.C) {
+	sink := s.newSink(c, 10)
+	c.Assert(sink.Handle(s.userEntry()), jc.ErrorIsNil)
+	c.Assert(sink.Handle(s.userEntry()), jc.ErrorIsNil)
+	s.waitSent(c, 2)
+	s.stub.CheckCallNames(c, "Open", "Send", "Send")
+}
+
+func (s *auditSyslogSuite) TestOpenFailureRetries(c *gc.C) {
+	s.stub.SetErrors(errors.New("no route to host"))
+	sink := s.newSink(c, 10)
+	// Entries are queued without waiting for the syslog host.
+	err := sink.Handle(s.userEntry())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitSent(c, 1)
+	s.stub.CheckCallNames(c, "Open", "Open", "Send")
+}
+
+func (s *auditSyslogSuite) TestSendFailureReopens(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("broken pipe"))
+	sink := s.newSink(c, 10)
+	err := sink.Handle(s.userEntry())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitSent(c, 1)
+	s.stub.CheckCallNames(c, "Open", "Send", "Close", "Open", "Send")
+	c.Assert(s.opened, gc.Equals, 2)
+}
+
+// fillQueue handles entries until a sink with a buffer of one entry
+// is full, while the syslog host cannot be reached.
+func (s *auditSyslogSuite) fillQueue(c *gc.C, sink *audit.SyslogSink) {
+	c.Assert(sink.Handle(s.userEntry()), jc.ErrorIsNil)
+	// Wait for the first entry to be taken from the queue, so that
+	// the next one fills it.
+	err := s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink.Handle(s.userEntry()), jc.ErrorIsNil)
+}
+
+// handleWhenFull handles an entry with a full queue, and returns
+// its result once the queue timeout has passed.
+func (s *auditSyslogSuite) handleWhenFull(c *gc.C, sink *audit.SyslogSink, entry audit.AuditEntry) error {
+	result := make(chan error, 1)
+	go func() {
+		result <- sink.Handle(entry)
+	}()
+	select {
+	case err := <-result:
+		c.Fatalf("entry handled without waiting for room: %v", err)
+	case <-time.After(coretesting.ShortWait):
+	}
+	// Both the sink's retry and the waiting entry use the clock.
+	err := s.clock.WaitAdvance(500*time.Millisecond, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for entry to be handled")
+	}
+	return nil
+}
+
+func (s *auditSyslogSuite) TestOverflowsWhenQueueFull(c *gc.C) {
+	var overflowed []audit.AuditEntry
+	s.overflow = func(entry audit.AuditEntry) error {
+		overflowed = append(overflowed, entry)
+		return nil
+	}
+	s.stub.SetErrors(errors.New("no route to host"))
+	sink := s.newSink(c, 1)
+	s.fillQueue(c, sink)
+
+	entry := s.userEntry()
+	entry.Operation = "Application:Expose"
+	err := s.handleWhenFull(c, sink, entry)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(overflowed, jc.DeepEquals, []audit.AuditEntry{entry})
+
+	err = s.clock.WaitAdvance(500*time.Millisecond, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitSent(c, 2)
+	select {
+	case <-s.client.sent:
+		c.Fatalf("overflowed entry was sent")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.stub.CheckCallNames(c, "Open", "Open", "Send", "Send")
+}
+
+func (s *auditSyslogSuite) TestOverflowFailure(c *gc.C) {
+	s.overflow = func(audit.AuditEntry) error {
+		return errors.New("disk full")
+	}
+	s.stub.SetErrors(errors.New("no route to host"))
+	sink := s.newSink(c, 1)
+	s.fillQueue(c, sink)
+
+	err := s.handleWhenFull(c, sink, s.userEntry())
+	c.Assert(err, gc.ErrorMatches, "syslog audit queue is full, cannot save audit entry elsewhere: disk full")
+}
+
+func (s *auditSyslogSuite) TestQueueFullWithoutOverflow(c *gc.C) {
+	s.stub.SetErrors(errors.New("no route to host"))
+	sink := s.newSink(c, 1)
+	s.fillQueue(c, sink)
+
+	err := s.handleWhenFull(c, sink, s.userEntry())
+	c.Assert(err, gc.ErrorMatches, "syslog audit queue is full")
+}
+
+func (s *auditSyslogSuite) TestWaitsForRoomInQueue(c *gc.C) {
+	s.queueTimeout = 5 * time.Second
+	s.stub.SetErrors(errors.New("no route to host"))
+	sink := s.newSink(c, 1)
+	s.fillQueue(c, sink)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- sink.Handle(s.userEntry())
+	}()
+	// The retry comes before the queue timeout; sending the queued
+	// entries makes room for the waiting one.
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-result:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for entry to be handled")
+	}
+	s.waitSent(c, 3)
+}
+
+func (s *auditSyslogSuite) TestInvalidOrigin(c *gc.C) {
+	sink := s.newSink(c, 10)
+	entry := s.userEntry()
+	entry.OriginName = "."
+	err := sink.Handle(entry)
+	c.Assert(err, gc.ErrorMatches, `parsing audit entry origin: .*`)
+	s.stub.CheckNoCalls(c)
+}
+
+type stubSyslogClient struct {
+	stub *testing.Stub
+	sent chan []logfwd.Record
+}
+
+func (c *stubSyslogClient) Send(records []logfwd.Record) error {
+	c.stub.AddCall("Send", records)
+	if err := c.stub.NextErr(); err != nil {
+		return err
+	}
+	c.sent <- records
+	return nil
+}
+
+func (c *stubSyslogClient) Close() error {
+	c.stub.AddCall("Close")
+	return c.stub.NextErr()
+}
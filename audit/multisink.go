@@ -0,0 +1,41 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// NamedSink associates an audit entry sink with the name used to
+// identify it when it fails.
+type NamedSink struct {
+	Name string
+	Sink AuditEntrySinkFn
+}
+
+// NewMultiSink returns an audit entry sink which writes each entry to
+// all of the given sinks. A failing sink doesn't prevent the entry from
+// being written to the others; the returned error identifies every sink
+// which failed, and carries the first failure as its cause.
+func NewMultiSink(sinks ...NamedSink) AuditEntrySinkFn {
+	return func(entry AuditEntry) error {
+		var failed []string
+		var firstErr error
+		for _, sink := range sinks {
+			if err := sink.Sink(entry); err != nil {
+				logger.Debugf("cannot save audit record to %s: %v", sink.Name, err)
+				failed = append(failed, sink.Name)
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+		if firstErr == nil {
+			return nil
+		}
+		return errors.Annotatef(firstErr, "cannot save audit record to %s", strings.Join(failed, ", "))
+	}
+}
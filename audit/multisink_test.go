@@ -0,0 +1,57 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type multiSinkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&multiSinkSuite{})
+
+func recordingSink(name string, calls *[]string, err error) audit.NamedSink {
+	return audit.NamedSink{
+		Name: name,
+		Sink: func(audit.AuditEntry) error {
+			*calls = append(*calls, name)
+			return err
+		},
+	}
+}
+
+func (s *multiSinkSuite) TestAllSinksCalled(c *gc.C) {
+	var calls []string
+	sink := audit.NewMultiSink(
+		recordingSink("file", &calls, nil),
+		recordingSink("database", &calls, nil),
+	)
+	err := sink(validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, jc.DeepEquals, []string{"file", "database"})
+}
+
+func (s *multiSinkSuite) TestFailuresReported(c *gc.C) {
+	var calls []string
+	sink := audit.NewMultiSink(
+		recordingSink("file", &calls, errors.New("disk full")),
+		recordingSink("database", &calls, nil),
+		recordingSink("syslog", &calls, errors.New("connection refused")),
+	)
+	err := sink(validEntry())
+	c.Assert(err, gc.ErrorMatches, "cannot save audit record to file, syslog: disk full")
+	c.Assert(calls, jc.DeepEquals, []string{"file", "database", "syslog"})
+}
+
+func (s *multiSinkSuite) TestNoSinks(c *gc.C) {
+	err := audit.NewMultiSink()(validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+}
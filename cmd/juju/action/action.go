@@ -58,6 +58,16 @@ type APIClient interface {
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
 }
 
+// StatusAPIClient represents the client API functionality used to find
+// the units an action is run on.
+type StatusAPIClient interface {
+	io.Closer
+
+	// Status returns the status of the applications and units matching
+	// the given patterns.
+	Status(patterns []string) (*params.FullStatus, error)
+}
+
 // ActionCommandBase is the base type for action sub-commands.
 type ActionCommandBase struct {
 	modelcmd.ModelCommandBase
@@ -75,3 +85,16 @@ var newAPIClient = func(c *ActionCommandBase) (APIClient, error) {
 	}
 	return action.NewClient(root), nil
 }
+
+// NewStatusAPIClient returns a client for the client api endpoint.
+func (c *ActionCommandBase) NewStatusAPIClient() (StatusAPIClient, error) {
+	return newStatusAPIClient(c)
+}
+
+var newStatusAPIClient = func(c *ActionCommandBase) (StatusAPIClient, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewCancelCommand() cmd.Command {
+	return modelcmd.Wrap(&cancelCommand{})
+}
+
+// cancelCommand cancels Actions by ID.
+type cancelCommand struct {
+	ActionCommandBase
+	out          cmd.Output
+	requestedIds []string
+}
+
+const cancelDoc = `
+Cancel the actions matching the given IDs or partial ID prefixes.
+
+An action which has not started running is cancelled straight away.
+An action which is running is marked as aborting, and stopped by the
+unit running it; it is then shown as aborted, and any results it had
+set before being stopped may be seen with show-action-output.
+
+Examples:
+    juju cancel-action 1b3f
+    juju cancel-action 1b3f 7e22
+
+See also:
+    run-action
+    show-action-status
+    show-action-output
+`
+
+// SetFlags sets up the output.
+func (c *cancelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+func (c *cancelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "cancel-action",
+		Args:    "<action ID>|<action ID prefix> [...]",
+		Purpose: "Cancel pending or running actions.",
+		Doc:     cancelDoc,
+	}
+}
+
+func (c *cancelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action ID specified")
+	}
+	c.requestedIds = args
+	return nil
+}
+
+func (c *cancelCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actions := make([]params.Action, len(c.requestedIds))
+	for i, id := range c.requestedIds {
+		tag, err := getActionTagByPrefix(api, id)
+		if err != nil {
+			return err
+		}
+		actions[i] = params.Action{Tag: tag.String()}
+	}
+
+	results, err := api.Cancel(params.Actions{Actions: actions})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != len(actions) {
+		return errors.Errorf("expected %d results, got %d", len(actions), len(results.Results))
+	}
+	return c.out.Write(ctx, resultsToMap(results.Results))
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type CancelSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&CancelSuite{})
+
+func (s *CancelSuite) TestInit(c *gc.C) {
+	for _, modelFlag := range s.modelFlags {
+		cmd, _ := action.NewCancelCommandForTest(s.store)
+		err := testing.InitCommand(cmd, []string{modelFlag, "admin"})
+		c.Check(err, gc.ErrorMatches, "no action ID specified")
+
+		cmd, cancelCmd := action.NewCancelCommandForTest(s.store)
+		err = testing.InitCommand(cmd, []string{modelFlag, "admin", "1b3f", "7e22"})
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(cancelCmd.RequestedIds(), jc.DeepEquals, []string{"1b3f", "7e22"})
+	}
+}
+
+func (s *CancelSuite) TestRun(c *gc.C) {
+	pendingTag := "action-1b3f0000-0000-4000-8000-feedfacebeef"
+	runningTag := "action-7e220000-0000-4000-8000-feedfacebeef"
+	client := &fakeAPIClient{
+		actionTagMatches: params.FindTagsResults{
+			Matches: map[string][]params.Entity{
+				"1b3f": {{Tag: pendingTag}},
+				"7e22": {{Tag: runningTag}},
+			},
+		},
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: pendingTag, Receiver: "unit-mysql-0"},
+			Status: params.ActionCancelled,
+		}, {
+			Action: &params.Action{Tag: runningTag, Receiver: "unit-mysql-1"},
+			Status: params.ActionAborting,
+		}},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	cmd, _ := action.NewCancelCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, cmd, "1b3f", "7e22")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.cancelledActions, jc.DeepEquals, params.Actions{
+		Actions: []params.Action{{Tag: pendingTag}, {Tag: runningTag}},
+	})
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+actions:
+- id: 1b3f0000-0000-4000-8000-feedfacebeef
+  status: cancelled
+  unit: mysql/0
+- id: 7e220000-0000-4000-8000-feedfacebeef
+  status: aborting
+  unit: mysql/1
+`[1:])
+}
+
+func (s *CancelSuite) TestRunNoMatch(c *gc.C) {
+	client := &fakeAPIClient{actionTagMatches: tagsForIdPrefix("1b3f")}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	cmd, _ := action.NewCancelCommandForTest(s.store)
+	_, err := testing.RunCommand(c, cmd, "1b3f")
+	c.Assert(err, gc.ErrorMatches, `actions for identifier "1b3f" not found`)
+	c.Check(client.cancelledActions.Actions, gc.HasLen, 0)
+}
+
+func (s *CancelSuite) TestRunAPIError(c *gc.C) {
+	client := &fakeAPIClient{
+		actionTagMatches: tagsForIdPrefix("1b3f", "action-1b3f0000-0000-4000-8000-feedfacebeef"),
+		apiErr:           errors.New("boom"),
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	cmd, _ := action.NewCancelCommandForTest(s.store)
+	_, err := testing.RunCommand(c, cmd, "1b3f")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
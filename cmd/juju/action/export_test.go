@@ -14,6 +14,8 @@ import (
 
 var (
 	NewActionAPIClient = &newAPIClient
+	NewStatusAPIClient = &newStatusAPIClient
+	ResultPollInterval = &resultPollInterval
 	AddValueToMap      = addValueToMap
 )
 
@@ -25,6 +27,14 @@ type StatusCommand struct {
 	*statusCommand
 }
 
+type CancelCommand struct {
+	*cancelCommand
+}
+
+func (c *CancelCommand) RequestedIds() []string {
+	return c.requestedIds
+}
+
 type RunCommand struct {
 	*runCommand
 }
@@ -33,6 +43,10 @@ func (c *RunCommand) UnitTag() names.UnitTag {
 	return c.unitTag
 }
 
+func (c *RunCommand) Targets() []string {
+	return c.targets
+}
+
 func (c *RunCommand) ActionName() string {
 	return c.actionName
 }
@@ -73,6 +87,12 @@ func NewStatusCommandForTest(store jujuclient.ClientStore) (cmd.Command, *Status
 	return modelcmd.Wrap(c), &StatusCommand{c}
 }
 
+func NewCancelCommandForTest(store jujuclient.ClientStore) (cmd.Command, *CancelCommand) {
+	c := &cancelCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &CancelCommand{c}
+}
+
 func NewListCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ListCommand) {
 	c := &listCommand{}
 	c.SetClientStore(store)
@@ -123,6 +123,7 @@ type fakeAPIClient struct {
 	timeout            *time.Timer
 	actionResults      []params.ActionResult
 	enqueuedActions    params.Actions
+	cancelledActions   params.Actions
 	actionsByReceivers []params.ActionsByReceiver
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
@@ -166,6 +167,7 @@ func (c *fakeAPIClient) ListCompleted(args params.Entities) (params.ActionsByRec
 }
 
 func (c *fakeAPIClient) Cancel(args params.Actions) (params.ActionResults, error) {
+	c.cancelledActions = args
 	return params.ActionResults{
 		Results: c.actionResults,
 	}, c.apiErr
@@ -26,23 +26,44 @@ func NewRunCommand() cmd.Command {
 	return modelcmd.Wrap(&runCommand{})
 }
 
-// runCommand enqueues an Action for running on the given unit with given
+// runCommand enqueues an Action for running on the given units with given
 // params
 type runCommand struct {
 	ActionCommandBase
-	unitTag      names.UnitTag
-	actionName   string
-	paramsYAML   cmd.FileVar
-	parseStrings bool
-	wait         waitFlag
-	out          cmd.Output
-	args         [][]string
+	unitTag        names.UnitTag
+	targets        []string
+	unitStatuses   []string
+	leader         bool
+	excludeLeader  bool
+	maxConcurrency int
+	failFast       bool
+	actionName     string
+	paramsYAML     cmd.FileVar
+	parseStrings   bool
+	wait           waitFlag
+	out            cmd.Output
+	args           [][]string
 }
 
 const runDoc = `
 Queue an Action for execution on a given unit, with a given set of params.
 The Action ID is returned for use with 'juju show-action-output <ID>' or
 'juju show-action-status <ID>'.
+
+The Action may instead be run on several units, by giving a comma separated
+list of units and applications. Every unit of each application is targeted,
+unless the units are limited to those with the workload statuses given with
+--unit-status, or to (or excluding) the leader with --leader or
+--exclude-leader. The Action IDs are returned for each unit.
+
+When run on several units with --wait, the command reports the results of
+each unit along with a summary of how many units' Actions completed or
+failed. --max-concurrency limits how many units run the Action at once, the
+rest being queued as others finish; with --fail-fast, no more units are
+queued after any unit's Action fails. Units which were not queued are
+reported as skipped. The command exits with an error if any unit's Action
+failed, could not be queued, was skipped, or was still running when the
+wait timed out.
  
 Params are validated according to the charm for the unit's application.  The 
 valid params can be seen using "juju actions <application> --schema".
@@ -113,6 +134,22 @@ file:
 $ juju run-action sleeper/0 pause time=1000
 ...
 
+$ juju run-action mysql backup --wait
+summary:
+  completed: 3
+  total: 3
+units:
+  mysql/0:
+    id: <ID>
+    status: completed
+...
+
+$ juju run-action mysql,wordpress/0 pause --unit-status active --exclude-leader
+...
+
+$ juju run-action mysql upgrade --wait --max-concurrency 5 --fail-fast
+...
+
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
@@ -128,18 +165,23 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+	f.Var(cmd.NewAppendStringsValue(&c.unitStatuses), "unit-status", "Only run on units with these workload statuses")
+	f.BoolVar(&c.leader, "leader", false, "Only run on the leader units of the applications")
+	f.BoolVar(&c.excludeLeader, "exclude-leader", false, "Do not run on the leader units of the applications")
+	f.IntVar(&c.maxConcurrency, "max-concurrency", 0, "Run on at most this many units at once (requires --wait)")
+	f.BoolVar(&c.failFast, "fail-fast", false, "Stop queueing the action after any unit fails (requires --wait)")
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run-action",
-		Args:    "<unit> <action name> [key.key.key...=value]",
+		Args:    "<unit>|<application>[,...] <action name> [key.key.key...=value]",
 		Purpose: "Queue an action for execution.",
 		Doc:     runDoc,
 	}
 }
 
-// Init gets the unit tag, and checks for other correct args.
+// Init gets the unit tag or targets, and checks for other correct args.
 func (c *runCommand) Init(args []string) error {
 	switch len(args) {
 	case 0:
@@ -147,16 +189,14 @@ func (c *runCommand) Init(args []string) error {
 	case 1:
 		return errors.New("no action specified")
 	default:
-		// Grab and verify the unit and action names.
-		unitName := args[0]
-		if !names.IsValidUnit(unitName) {
-			return errors.Errorf("invalid unit name %q", unitName)
+		// Grab and verify the targets and action names.
+		if err := c.initTargets(args[0]); err != nil {
+			return err
 		}
 		ActionName := args[1]
 		if valid := ActionNameRule.MatchString(ActionName); !valid {
 			return errors.Errorf("invalid action name %q", ActionName)
 		}
-		c.unitTag = names.NewUnitTag(unitName)
 		c.actionName = ActionName
 		if len(args) == 2 {
 			return nil
@@ -242,6 +282,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if len(c.targets) > 0 {
+		return c.runOnUnits(ctx, api, actionParams)
+	}
+
 	actionParam := params.Actions{
 		Actions: []params.Action{{
 			Receiver:   c.unitTag.String(),
@@ -273,7 +317,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 
-	if !c.wait.forever && c.wait.d.Nanoseconds() <= 0 {
+	if !c.waiting() {
 		// Immediate return. This is the default, although rarely
 		// what cli users want. We should consider changing this
 		// default with Juju 3.0.
@@ -281,16 +325,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return c.out.Write(ctx, output)
 	}
 
-	var wait *time.Timer
-	if c.wait.d.Nanoseconds() <= 0 {
-		// Indefinite wait. Discard the tick.
-		wait = time.NewTimer(0 * time.Second)
-		_ = <-wait.C
-	} else {
-		wait = time.NewTimer(c.wait.d)
-	}
-
-	result, err = GetActionResult(api, tag.Id(), wait)
+	result, err = GetActionResult(api, tag.Id(), c.waitTimer())
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -298,3 +333,20 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	output["action-id"] = tag.Id() // Action ID is required in case we timed out.
 	return c.out.Write(ctx, output)
 }
+
+// waiting reports whether the command waits for the action results.
+func (c *runCommand) waiting() bool {
+	return c.wait.forever || c.wait.d.Nanoseconds() > 0
+}
+
+// waitTimer returns a timer which fires when the --wait timeout
+// expires, or never if waiting indefinitely.
+func (c *runCommand) waitTimer() *time.Timer {
+	if c.wait.d.Nanoseconds() <= 0 {
+		// Indefinite wait. Discard the tick.
+		wait := time.NewTimer(0 * time.Second)
+		_ = <-wait.C
+		return wait
+	}
+	return time.NewTimer(c.wait.d)
+}
@@ -60,6 +60,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		should               string
 		args                 []string
 		expectUnit           names.UnitTag
+		expectTargets        []string
 		expectAction         string
 		expectParamsYamlPath string
 		expectParseStrings   bool
@@ -77,7 +78,41 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	}, {
 		should:      "fail with invalid unit tag",
 		args:        []string{invalidUnitId, "valid-action-name"},
-		expectError: "invalid unit name \"something-strange-\"",
+		expectError: "invalid unit or application name \"something-strange-\"",
+	}, {
+		should:      "fail with invalid target in list",
+		args:        []string{"mysql," + invalidUnitId, "valid-action-name"},
+		expectError: "invalid unit or application name \"something-strange-\"",
+	}, {
+		should:      "fail with invalid workload status",
+		args:        []string{"mysql", "valid-action-name", "--unit-status", "bad"},
+		expectError: "invalid workload status \"bad\"",
+	}, {
+		should:      "fail with --leader and --exclude-leader",
+		args:        []string{"mysql", "valid-action-name", "--leader", "--exclude-leader"},
+		expectError: "--leader and --exclude-leader cannot be used together",
+	}, {
+		should:      "fail with negative --max-concurrency",
+		args:        []string{"mysql", "valid-action-name", "--wait", "--max-concurrency", "-1"},
+		expectError: "--max-concurrency must not be negative",
+	}, {
+		should:      "fail with --max-concurrency and no --wait",
+		args:        []string{"mysql", "valid-action-name", "--max-concurrency", "5"},
+		expectError: "--max-concurrency requires --wait",
+	}, {
+		should:      "fail with --fail-fast and no --wait",
+		args:        []string{"mysql", "valid-action-name", "--fail-fast"},
+		expectError: "--fail-fast requires --wait",
+	}, {
+		should:        "target applications and units",
+		args:          []string{"mysql,wordpress/0", "valid-action-name"},
+		expectTargets: []string{"mysql", "wordpress/0"},
+		expectAction:  "valid-action-name",
+	}, {
+		should:        "target a unit selected by status",
+		args:          []string{validUnitId, "valid-action-name", "--unit-status", "active"},
+		expectTargets: []string{validUnitId},
+		expectAction:  "valid-action-name",
 	}, {
 		should:      "fail with invalid action name",
 		args:        []string{validUnitId, "BadName"},
@@ -169,6 +204,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			err := testing.InitCommand(wrappedCommand, args)
 			if t.expectError == "" {
 				c.Check(command.UnitTag(), gc.Equals, t.expectUnit)
+				c.Check(command.Targets(), jc.DeepEquals, t.expectTargets)
 				c.Check(command.ActionName(), gc.Equals, t.expectAction)
 				c.Check(command.ParamsYAML().Path, gc.Equals, t.expectParamsYamlPath)
 				c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
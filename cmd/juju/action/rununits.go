@@ -0,0 +1,337 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+)
+
+// resultPollInterval is how often the results of actions run on
+// several units are fetched.
+var resultPollInterval = 2 * time.Second
+
+// Statuses reported for units whose action was not run.
+const (
+	unitActionError   = "error"
+	unitActionSkipped = "skipped"
+)
+
+// initTargets parses the comma separated units and applications an
+// action is run on, and checks the options used to select the units.
+// A single unit is run on directly, unless its selection options are
+// given.
+func (c *runCommand) initTargets(arg string) error {
+	targets := strings.Split(arg, ",")
+	for _, target := range targets {
+		if !names.IsValidUnit(target) && !names.IsValidApplication(target) {
+			return errors.Errorf("invalid unit or application name %q", target)
+		}
+	}
+	for _, unitStatus := range c.unitStatuses {
+		if !status.Status(unitStatus).KnownWorkloadStatus() {
+			return errors.Errorf("invalid workload status %q", unitStatus)
+		}
+	}
+	if c.leader && c.excludeLeader {
+		return errors.New("--leader and --exclude-leader cannot be used together")
+	}
+	if c.maxConcurrency < 0 {
+		return errors.New("--max-concurrency must not be negative")
+	}
+	if c.maxConcurrency > 0 && !c.waiting() {
+		return errors.New("--max-concurrency requires --wait")
+	}
+	if c.failFast && !c.waiting() {
+		return errors.New("--fail-fast requires --wait")
+	}
+	selecting := len(c.unitStatuses) > 0 || c.leader || c.excludeLeader
+	if len(targets) == 1 && names.IsValidUnit(targets[0]) && !selecting {
+		c.unitTag = names.NewUnitTag(targets[0])
+		return nil
+	}
+	c.targets = targets
+	return nil
+}
+
+// selectUnits returns the names of the target units, and units of
+// target applications, which match the selection options.
+func (c *runCommand) selectUnits() ([]string, error) {
+	client, err := c.NewStatusAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+	fullStatus, err := client.Status(c.targets)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	targets := set.NewStrings(c.targets...)
+	unitStatuses := set.NewStrings(c.unitStatuses...)
+	found := set.NewStrings()
+	var units unitNames
+	var addUnit func(name string, unit params.UnitStatus)
+	addUnit = func(name string, unit params.UnitStatus) {
+		for subName, subUnit := range unit.Subordinates {
+			addUnit(subName, subUnit)
+		}
+		application, err := names.UnitApplication(name)
+		if err != nil {
+			return
+		}
+		found.Add(name)
+		found.Add(application)
+		switch {
+		case !targets.Contains(name) && !targets.Contains(application):
+		case !unitStatuses.IsEmpty() && !unitStatuses.Contains(unit.WorkloadStatus.Status):
+		case c.leader && !unit.Leader:
+		case c.excludeLeader && unit.Leader:
+		default:
+			units = append(units, name)
+		}
+	}
+	for _, application := range fullStatus.Applications {
+		for name, unit := range application.Units {
+			addUnit(name, unit)
+		}
+	}
+	for _, target := range c.targets {
+		if found.Contains(target) {
+			continue
+		}
+		if names.IsValidUnit(target) {
+			return nil, errors.NotFoundf("unit %q", target)
+		}
+		return nil, errors.Errorf("application %q has no units", target)
+	}
+	if len(units) == 0 {
+		return nil, errors.New("no units match the given options")
+	}
+	sort.Sort(units)
+	return units, nil
+}
+
+// runOnUnits runs the action on each selected unit. When waiting for
+// the results, no more than --max-concurrency units are queued at once,
+// and with --fail-fast no more are queued after any unit fails.
+func (c *runCommand) runOnUnits(ctx *cmd.Context, api APIClient, actionParams map[string]interface{}) error {
+	units, err := c.selectUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if !c.waiting() {
+		tags, failures, err := c.enqueueOnUnits(api, units, actionParams)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ids := make(map[string]string)
+		for unit, tag := range tags {
+			ids[unit] = tag.Id()
+		}
+		output := map[string]interface{}{"Actions queued": ids}
+		if len(failures) > 0 {
+			output["errors"] = failures
+		}
+		if err := c.out.Write(ctx, output); err != nil {
+			return err
+		}
+		if len(failures) > 0 {
+			return cmd.ErrSilent
+		}
+		return nil
+	}
+
+	wait := c.waitTimer()
+	tick := time.NewTimer(resultPollInterval)
+	queue := units
+	running := make(map[names.ActionTag]string)
+	results := make(map[string]map[string]interface{})
+	failed := false
+	for {
+		if !(c.failFast && failed) {
+			n := len(queue)
+			if c.maxConcurrency > 0 && n > c.maxConcurrency-len(running) {
+				n = c.maxConcurrency - len(running)
+			}
+			if n > 0 {
+				tags, failures, err := c.enqueueOnUnits(api, queue[:n], actionParams)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				queue = queue[n:]
+				for unit, tag := range tags {
+					running[tag] = unit
+				}
+				for unit, message := range failures {
+					results[unit] = map[string]interface{}{
+						"status":  unitActionError,
+						"message": message,
+					}
+					failed = true
+				}
+			}
+		}
+
+		if len(running) > 0 {
+			actionResults, err := fetchResults(api, running)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for tag, result := range actionResults {
+				unit := running[tag]
+				output := FormatActionResult(result)
+				output["action-id"] = tag.Id()
+				results[unit] = output
+				switch result.Status {
+				case params.ActionPending, params.ActionRunning, params.ActionAborting:
+					continue
+				case params.ActionCompleted:
+				default:
+					failed = true
+				}
+				delete(running, tag)
+			}
+		}
+
+		if len(running) == 0 {
+			if len(queue) == 0 || (c.failFast && failed) {
+				break
+			}
+			continue
+		}
+		timedOut := false
+		select {
+		case <-wait.C:
+			timedOut = true
+		case <-tick.C:
+			tick.Reset(resultPollInterval)
+		}
+		if timedOut {
+			break
+		}
+	}
+
+	for _, unit := range queue {
+		results[unit] = map[string]interface{}{"status": unitActionSkipped}
+	}
+	summary := map[string]int{"total": len(units)}
+	for _, result := range results {
+		summary[result["status"].(string)]++
+	}
+	if err := c.out.Write(ctx, map[string]interface{}{
+		"summary": summary,
+		"units":   results,
+	}); err != nil {
+		return err
+	}
+	if failed || len(queue) > 0 || len(running) > 0 {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// enqueueOnUnits queues the action on the given units, returning the
+// tags of the queued actions and the errors of units which could not
+// be queued.
+func (c *runCommand) enqueueOnUnits(api APIClient, units []string, actionParams map[string]interface{}) (map[string]names.ActionTag, map[string]string, error) {
+	args := params.Actions{Actions: make([]params.Action, len(units))}
+	for i, unit := range units {
+		args.Actions[i] = params.Action{
+			Receiver:   names.NewUnitTag(unit).String(),
+			Name:       c.actionName,
+			Parameters: actionParams,
+		}
+	}
+	results, err := api.Enqueue(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(results.Results) != len(units) {
+		return nil, nil, errors.New("illegal number of results returned")
+	}
+	tags := make(map[string]names.ActionTag)
+	failures := make(map[string]string)
+	for i, result := range results.Results {
+		unit := units[i]
+		if result.Error != nil {
+			failures[unit] = result.Error.Error()
+			continue
+		}
+		if result.Action == nil {
+			failures[unit] = "action failed to enqueue"
+			continue
+		}
+		tag, err := names.ParseActionTag(result.Action.Tag)
+		if err != nil {
+			failures[unit] = err.Error()
+			continue
+		}
+		tags[unit] = tag
+	}
+	return tags, failures, nil
+}
+
+// fetchResults returns the current results of the given actions.
+func fetchResults(api APIClient, actions map[names.ActionTag]string) (map[names.ActionTag]params.ActionResult, error) {
+	tags := make([]names.ActionTag, 0, len(actions))
+	entities := make([]params.Entity, 0, len(actions))
+	for tag := range actions {
+		tags = append(tags, tag)
+		entities = append(entities, params.Entity{Tag: tag.String()})
+	}
+	results, err := api.Actions(params.Entities{Entities: entities})
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d results, got %d", len(tags), len(results.Results))
+	}
+	byTag := make(map[names.ActionTag]params.ActionResult)
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "cannot get result of action %s", tags[i].Id())
+		}
+		byTag[tags[i]] = result
+	}
+	return byTag, nil
+}
+
+// unitNames sorts unit names by application, then unit number.
+type unitNames []string
+
+func (u unitNames) Len() int {
+	return len(u)
+}
+
+func (u unitNames) Swap(i, j int) {
+	u[i], u[j] = u[j], u[i]
+}
+
+func (u unitNames) Less(i, j int) bool {
+	appI, numI := splitUnitName(u[i])
+	appJ, numJ := splitUnitName(u[j])
+	if appI != appJ {
+		return appI < appJ
+	}
+	return numI < numJ
+}
+
+func splitUnitName(name string) (string, int) {
+	parts := strings.SplitN(name, "/", 2)
+	// Unit names are validated, so the number always parses.
+	num, _ := strconv.Atoi(parts[1])
+	return parts[0], num
+}
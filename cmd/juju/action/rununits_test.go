@@ -0,0 +1,327 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type RunUnitsSuite struct {
+	BaseActionSuite
+	client *fakeUnitsAPIClient
+	status *fakeStatusAPIClient
+}
+
+var _ = gc.Suite(&RunUnitsSuite{})
+
+func (s *RunUnitsSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.client = &fakeUnitsAPIClient{
+		fakeAPIClient: &fakeAPIClient{},
+		actionUnits:   make(map[string]string),
+		seen:          make(map[string]bool),
+		results:       make(map[string]params.ActionResult),
+	}
+	s.status = &fakeStatusAPIClient{status: &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {Units: map[string]params.UnitStatus{
+				"mysql/0": {
+					WorkloadStatus: params.DetailedStatus{Status: "active"},
+					Leader:         true,
+					Subordinates: map[string]params.UnitStatus{
+						"logging/0": {WorkloadStatus: params.DetailedStatus{Status: "active"}},
+					},
+				},
+				"mysql/1":  {WorkloadStatus: params.DetailedStatus{Status: "blocked"}},
+				"mysql/2":  {WorkloadStatus: params.DetailedStatus{Status: "active"}},
+				"mysql/10": {WorkloadStatus: params.DetailedStatus{Status: "active"}},
+			}},
+			"wordpress": {Units: map[string]params.UnitStatus{
+				"wordpress/0": {WorkloadStatus: params.DetailedStatus{Status: "active"}},
+			}},
+		},
+	}}
+	s.PatchValue(action.NewActionAPIClient, func(*action.ActionCommandBase) (action.APIClient, error) {
+		return s.client, nil
+	})
+	s.PatchValue(action.NewStatusAPIClient, func(*action.ActionCommandBase) (action.StatusAPIClient, error) {
+		return s.status, nil
+	})
+	s.PatchValue(action.ResultPollInterval, time.Duration(0))
+}
+
+func (s *RunUnitsSuite) runUnits(c *gc.C, args ...string) (*bytes.Buffer, error) {
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrappedCommand, append([]string{"-m", "admin"}, args...)...)
+	return ctx.Stdout.(*bytes.Buffer), err
+}
+
+type unitsOutput struct {
+	Summary map[string]int                    `yaml:"summary"`
+	Units   map[string]map[string]interface{} `yaml:"units"`
+}
+
+func (s *RunUnitsSuite) parseOutput(c *gc.C, stdout *bytes.Buffer) unitsOutput {
+	var output unitsOutput
+	err := yaml.Unmarshal(stdout.Bytes(), &output)
+	c.Assert(err, jc.ErrorIsNil)
+	return output
+}
+
+func (s *RunUnitsSuite) TestSelectUnits(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		patterns []string
+		units    []string
+	}{{
+		args:     []string{"mysql"},
+		patterns: []string{"mysql"},
+		units:    []string{"mysql/0", "mysql/1", "mysql/2", "mysql/10"},
+	}, {
+		args:     []string{"mysql/1,wordpress"},
+		patterns: []string{"mysql/1", "wordpress"},
+		units:    []string{"mysql/1", "wordpress/0"},
+	}, {
+		args:     []string{"mysql,logging", "--unit-status", "active"},
+		patterns: []string{"mysql", "logging"},
+		units:    []string{"logging/0", "mysql/0", "mysql/2", "mysql/10"},
+	}, {
+		args:     []string{"mysql", "--leader"},
+		patterns: []string{"mysql"},
+		units:    []string{"mysql/0"},
+	}, {
+		args:     []string{"mysql", "--exclude-leader", "--unit-status", "active,blocked"},
+		patterns: []string{"mysql"},
+		units:    []string{"mysql/1", "mysql/2", "mysql/10"},
+	}, {
+		args:     []string{"mysql/2", "--exclude-leader"},
+		patterns: []string{"mysql/2"},
+		units:    []string{"mysql/2"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		s.client.enqueued = nil
+		args := append([]string{test.args[0], "backup"}, test.args[1:]...)
+		stdout, err := s.runUnits(c, args...)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s.status.patterns, jc.DeepEquals, test.patterns)
+		c.Assert(s.client.enqueued, gc.HasLen, 1)
+		c.Check(s.client.enqueued[0], jc.DeepEquals, test.units)
+
+		var output map[string]map[string]string
+		err = yaml.Unmarshal(stdout.Bytes(), &output)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(output["Actions queued"], gc.HasLen, len(test.units))
+	}
+}
+
+func (s *RunUnitsSuite) TestSelectUnitsErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"mysql/3,mysql"},
+		err:  `unit "mysql/3" not found`,
+	}, {
+		args: []string{"mysql,haproxy"},
+		err:  `application "haproxy" has no units`,
+	}, {
+		args: []string{"mysql", "--unit-status", "error"},
+		err:  `no units match the given options`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		args := append([]string{test.args[0], "backup"}, test.args[1:]...)
+		_, err := s.runUnits(c, args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RunUnitsSuite) TestEnqueueErrors(c *gc.C) {
+	s.client.enqueueErrors = map[string]error{
+		"mysql/1": errors.New("no such action"),
+	}
+	stdout, err := s.runUnits(c, "mysql", "backup", "--exclude-leader")
+	c.Assert(errors.Cause(err), gc.Equals, cmd.ErrSilent)
+
+	var output map[string]map[string]string
+	err = yaml.Unmarshal(stdout.Bytes(), &output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(output["Actions queued"], gc.HasLen, 2)
+	c.Check(output["errors"], jc.DeepEquals, map[string]string{
+		"mysql/1": "no such action",
+	})
+}
+
+func (s *RunUnitsSuite) TestWait(c *gc.C) {
+	s.client.results["mysql/1"] = params.ActionResult{
+		Status:  params.ActionFailed,
+		Message: "disk full",
+	}
+	s.client.enqueueErrors = map[string]error{
+		"mysql/10": errors.New("no such action"),
+	}
+	stdout, err := s.runUnits(c, "mysql", "backup", "--wait")
+	c.Assert(errors.Cause(err), gc.Equals, cmd.ErrSilent)
+	c.Check(s.client.enqueued, jc.DeepEquals, [][]string{
+		{"mysql/0", "mysql/1", "mysql/2", "mysql/10"},
+	})
+
+	output := s.parseOutput(c, stdout)
+	c.Check(output.Summary, jc.DeepEquals, map[string]int{
+		"total":     4,
+		"completed": 2,
+		"failed":    1,
+		"error":     1,
+	})
+	c.Check(output.Units, gc.HasLen, 4)
+	c.Check(output.Units["mysql/0"]["status"], gc.Equals, "completed")
+	c.Check(output.Units["mysql/0"]["results"], jc.DeepEquals, map[interface{}]interface{}{
+		"unit": "mysql/0",
+	})
+	c.Check(output.Units["mysql/0"]["action-id"], gc.Not(gc.Equals), "")
+	c.Check(output.Units["mysql/1"]["status"], gc.Equals, "failed")
+	c.Check(output.Units["mysql/1"]["message"], gc.Equals, "disk full")
+	c.Check(output.Units["mysql/10"], jc.DeepEquals, map[string]interface{}{
+		"status":  "error",
+		"message": "no such action",
+	})
+}
+
+func (s *RunUnitsSuite) TestWaitMaxConcurrency(c *gc.C) {
+	stdout, err := s.runUnits(c, "mysql", "backup", "--wait", "--max-concurrency", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.client.enqueued, jc.DeepEquals, [][]string{
+		{"mysql/0", "mysql/1", "mysql/2"},
+		{"mysql/10"},
+	})
+	output := s.parseOutput(c, stdout)
+	c.Check(output.Summary, jc.DeepEquals, map[string]int{
+		"total":     4,
+		"completed": 4,
+	})
+}
+
+func (s *RunUnitsSuite) TestWaitFailFast(c *gc.C) {
+	s.client.results["mysql/1"] = params.ActionResult{Status: params.ActionFailed}
+	stdout, err := s.runUnits(c, "mysql", "backup", "--wait", "--max-concurrency", "2", "--fail-fast")
+	c.Assert(errors.Cause(err), gc.Equals, cmd.ErrSilent)
+	c.Check(s.client.enqueued, jc.DeepEquals, [][]string{
+		{"mysql/0", "mysql/1"},
+	})
+	output := s.parseOutput(c, stdout)
+	c.Check(output.Summary, jc.DeepEquals, map[string]int{
+		"total":     4,
+		"completed": 1,
+		"failed":    1,
+		"skipped":   2,
+	})
+	c.Check(output.Units["mysql/10"], jc.DeepEquals, map[string]interface{}{
+		"status": "skipped",
+	})
+}
+
+func (s *RunUnitsSuite) TestWaitTimeoutSkipped(c *gc.C) {
+	s.client.results["mysql/0"] = params.ActionResult{Status: params.ActionRunning}
+	stdout, err := s.runUnits(c, "mysql", "backup", "--wait=10ms", "--max-concurrency", "1")
+	c.Assert(errors.Cause(err), gc.Equals, cmd.ErrSilent)
+	output := s.parseOutput(c, stdout)
+	c.Check(output.Summary, jc.DeepEquals, map[string]int{
+		"total":   4,
+		"running": 1,
+		"skipped": 3,
+	})
+}
+
+func (s *RunUnitsSuite) TestWaitTimeout(c *gc.C) {
+	s.client.results["mysql/2"] = params.ActionResult{Status: params.ActionRunning}
+	stdout, err := s.runUnits(c, "mysql/1,mysql/2", "backup", "--wait=10ms")
+	// Units still running when the wait times out are not successes.
+	c.Assert(errors.Cause(err), gc.Equals, cmd.ErrSilent)
+	output := s.parseOutput(c, stdout)
+	c.Check(output.Summary, jc.DeepEquals, map[string]int{
+		"total":     2,
+		"completed": 1,
+		"running":   1,
+	})
+}
+
+type fakeStatusAPIClient struct {
+	status   *params.FullStatus
+	patterns []string
+}
+
+func (c *fakeStatusAPIClient) Status(patterns []string) (*params.FullStatus, error) {
+	c.patterns = patterns
+	return c.status, nil
+}
+
+func (c *fakeStatusAPIClient) Close() error {
+	return nil
+}
+
+// fakeUnitsAPIClient reports each action it enqueues as running when
+// first fetched, and then with the unit's result, or as completed.
+type fakeUnitsAPIClient struct {
+	*fakeAPIClient
+	enqueued      [][]string
+	enqueueErrors map[string]error
+	actionUnits   map[string]string
+	seen          map[string]bool
+	results       map[string]params.ActionResult
+}
+
+func (c *fakeUnitsAPIClient) Enqueue(args params.Actions) (params.ActionResults, error) {
+	var units []string
+	results := make([]params.ActionResult, len(args.Actions))
+	for i, a := range args.Actions {
+		tag, err := names.ParseUnitTag(a.Receiver)
+		if err != nil {
+			return params.ActionResults{}, err
+		}
+		units = append(units, tag.Id())
+		if err := c.enqueueErrors[tag.Id()]; err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		actionTag := names.NewActionTag(utils.MustNewUUID().String())
+		c.actionUnits[actionTag.String()] = tag.Id()
+		results[i].Action = &params.Action{Tag: actionTag.String()}
+	}
+	c.enqueued = append(c.enqueued, units)
+	return params.ActionResults{Results: results}, nil
+}
+
+func (c *fakeUnitsAPIClient) Actions(args params.Entities) (params.ActionResults, error) {
+	results := make([]params.ActionResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		unit := c.actionUnits[entity.Tag]
+		if !c.seen[entity.Tag] {
+			c.seen[entity.Tag] = true
+			results[i].Status = params.ActionRunning
+			continue
+		}
+		result, ok := c.results[unit]
+		if !ok {
+			result = params.ActionResult{
+				Status: params.ActionCompleted,
+				Output: map[string]interface{}{"unit": unit},
+			}
+		}
+		results[i] = result
+	}
+	return params.ActionResults{Results: results}, nil
+}
@@ -140,6 +140,19 @@ timing:
 			Started:  time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
 		}},
 		expectedErr: "test timed out before wait time",
+	}, {
+		should:            "keep waiting while the action is aborting",
+		withAPIDelay:      2 * time.Second,
+		withClientWait:    "6s",
+		withClientQueryID: validActionId,
+		withAPITimeout:    4 * time.Second,
+		withTags:          tagsForIdPrefix(validActionId, validActionTagString),
+		withAPIResponse: []params.ActionResult{{
+			Status:   "aborting",
+			Enqueued: time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Started:  time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+		}},
+		expectedErr: "test timed out before wait time",
 	}, {
 		should:            "pretty-print action output",
 		withClientQueryID: validActionId,
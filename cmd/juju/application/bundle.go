@@ -60,19 +60,11 @@ func deployBundle(
 	log deploymentLogger,
 	bundleStorage map[string]map[string]storage.Constraints,
 ) (map[*charm.URL]*macaroon.Macaroon, error) {
-	verifyConstraints := func(s string) error {
-		_, err := constraints.Parse(s)
-		return err
-	}
-	verifyStorage := func(s string) error {
-		_, err := storage.ParseConstraints(s)
-		return err
-	}
 	var verifyError error
 	if bundleFilePath == "" {
-		verifyError = data.Verify(verifyConstraints, verifyStorage)
+		verifyError = data.Verify(verifyBundleConstraints, verifyBundleStorage)
 	} else {
-		verifyError = data.VerifyLocal(bundleFilePath, verifyConstraints, verifyStorage)
+		verifyError = data.VerifyLocal(bundleFilePath, verifyBundleConstraints, verifyBundleStorage)
 	}
 	if verifyError != nil {
 		if verr, ok := verifyError.(*charm.VerificationError); ok {
@@ -167,6 +159,18 @@ func deployBundle(
 	return csMacs, nil
 }
 
+// verifyBundleConstraints checks the constraints declared in a bundle.
+func verifyBundleConstraints(s string) error {
+	_, err := constraints.Parse(s)
+	return err
+}
+
+// verifyBundleStorage checks the storage constraints declared in a bundle.
+func verifyBundleStorage(s string) error {
+	_, err := storage.ParseConstraints(s)
+	return err
+}
+
 // bundleHandler provides helpers and the state required to deploy a bundle.
 type bundleHandler struct {
 	// bundleDir is the path where the bundle file is located for local bundles.
@@ -0,0 +1,723 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/constraints"
+)
+
+const diffBundleDoc = `
+Compares a bundle with the current model and reports the differences.
+
+The bundle is compared with the model as exported by "juju export-bundle".
+Both are expanded into the changes that deploying them would make, as
+"juju deploy" does, so that for instance a placement directive repeated
+for the remaining units of an application is compared unit by unit.
+The comparison covers applications, charms (including revisions when the
+bundle specifies one), options, constraints, unit counts, exposure,
+relations and machine placement. Bundle options which are not set on an
+application are compared with the application's effective value, so an
+option given its charm default in the bundle is not a difference. Machines and unit placement are only
+compared when the bundle declares them, in which case bundle machine ids
+are matched against the machine ids in the model.
+
+Differences are reported per entity, giving the bundle and model values
+side by side. Entities which only exist on one side are reported as
+missing from the other. An empty result ({}) means the model matches the
+bundle. The command exits with a non-zero status when there are
+differences, so that it can be used to check that a model matches its
+bundle.
+
+Examples:
+
+    juju diff-bundle ./production.yaml
+    juju diff-bundle ./production.yaml --format json
+    juju diff-bundle ./bundle-dir -m staging
+
+See also:
+    deploy
+    export-bundle
+`
+
+// NewDiffBundleCommand returns a command to compare a bundle against
+// the current model.
+func NewDiffBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&diffBundleCommand{})
+}
+
+// DiffBundleAPI specifies the API calls used by the diff-bundle command.
+type DiffBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+
+	// ApplicationConfig returns the effective values of the
+	// application's options, including charm defaults.
+	ApplicationConfig(name string) (map[string]interface{}, error)
+}
+
+// diffBundleAPI implements DiffBundleAPI over a single API connection.
+type diffBundleAPI struct {
+	*bundle.Client
+	application *application.Client
+}
+
+// ApplicationConfig is part of the DiffBundleAPI interface.
+func (api *diffBundleAPI) ApplicationConfig(name string) (map[string]interface{}, error) {
+	results, err := api.application.Get(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	values := make(map[string]interface{})
+	for key, info := range results.Config {
+		info, ok := info.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, ok := info["value"]; ok {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	out    cmd.Output
+	api    DiffBundleAPI
+	bundle string
+}
+
+// Info implements cmd.Command.
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file or directory>",
+		Purpose: "Compares a bundle with the current model.",
+		Doc:     diffBundleDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements cmd.Command.
+func (c *diffBundleCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no bundle specified")
+	case 1:
+		c.bundle = args[0]
+		return nil
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+}
+
+func (c *diffBundleCommand) getAPI() (DiffBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return &diffBundleAPI{
+		Client:      bundle.NewClient(api),
+		application: application.NewClient(api),
+	}, nil
+}
+
+// Run implements cmd.Command.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	bundleData, err := readLocalBundle(ctx.AbsPath(c.bundle))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	modelYAML, err := client.ExportBundle()
+	if err != nil {
+		return errors.Annotate(err, "exporting model")
+	}
+	modelData, err := charm.ReadBundleData(strings.NewReader(modelYAML))
+	if err != nil {
+		return errors.Annotate(err, "reading exported model")
+	}
+
+	diff, err := diffBundles(bundleData, modelData, client.ApplicationConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.out.Write(ctx, diff); err != nil {
+		return errors.Trace(err)
+	}
+	if !diff.empty() {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// readLocalBundle reads and verifies the bundle stored in the given
+// YAML file or bundle directory.
+func readLocalBundle(path string) (*charm.BundleData, error) {
+	data, err := charmrepo.ReadBundleFile(path)
+	bundlePath := filepath.Dir(path)
+	if err != nil {
+		b, _, pathErr := charmrepo.NewBundleAtPath(path)
+		if pathErr != nil {
+			return nil, errors.Annotatef(err, "cannot read bundle %q", path)
+		}
+		data = b.Data()
+		bundlePath = path
+	}
+	if err := data.VerifyLocal(bundlePath, verifyBundleConstraints, verifyBundleStorage); err != nil {
+		if verr, ok := err.(*charm.VerificationError); ok {
+			errs := make([]string, len(verr.Errors))
+			for i, err := range verr.Errors {
+				errs[i] = err.Error()
+			}
+			return nil, errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
+		}
+		return nil, errors.Annotate(err, "cannot verify bundle")
+	}
+	return data, nil
+}
+
+const (
+	missingFromBundle = "bundle"
+	missingFromModel  = "model"
+)
+
+// bundleDiff describes the differences between a bundle and a model.
+type bundleDiff struct {
+	Applications map[string]*applicationDiff `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Machines     map[string]*machineDiff     `yaml:"machines,omitempty" json:"machines,omitempty"`
+	Relations    *relationsDiff              `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+func (d *bundleDiff) empty() bool {
+	return len(d.Applications) == 0 && len(d.Machines) == 0 && d.Relations == nil
+}
+
+// applicationDiff describes the differences of a single application.
+// Missing is set, and no other field, when the application only exists
+// on one side.
+type applicationDiff struct {
+	Missing     string                 `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Charm       *stringDiff            `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Options     map[string]*optionDiff `yaml:"options,omitempty" json:"options,omitempty"`
+	Constraints *stringDiff            `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	NumUnits    *intDiff               `yaml:"num_units,omitempty" json:"num_units,omitempty"`
+	To          *stringsDiff           `yaml:"to,omitempty" json:"to,omitempty"`
+	Expose      *boolDiff              `yaml:"expose,omitempty" json:"expose,omitempty"`
+}
+
+func (d *applicationDiff) empty() bool {
+	return d.Missing == "" &&
+		d.Charm == nil &&
+		len(d.Options) == 0 &&
+		d.Constraints == nil &&
+		d.NumUnits == nil &&
+		d.To == nil &&
+		d.Expose == nil
+}
+
+// machineDiff describes the differences of a single machine.
+type machineDiff struct {
+	Missing     string      `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Series      *stringDiff `yaml:"series,omitempty" json:"series,omitempty"`
+	Constraints *stringDiff `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+}
+
+func (d *machineDiff) empty() bool {
+	return d.Missing == "" && d.Series == nil && d.Constraints == nil
+}
+
+// relationsDiff holds the relations which only exist on one side.
+type relationsDiff struct {
+	BundleAdditions [][]string `yaml:"bundle-additions,omitempty" json:"bundle-additions,omitempty"`
+	ModelAdditions  [][]string `yaml:"model-additions,omitempty" json:"model-additions,omitempty"`
+}
+
+type stringDiff struct {
+	Bundle string `yaml:"bundle" json:"bundle"`
+	Model  string `yaml:"model" json:"model"`
+}
+
+type stringsDiff struct {
+	Bundle []string `yaml:"bundle" json:"bundle"`
+	Model  []string `yaml:"model" json:"model"`
+}
+
+type intDiff struct {
+	Bundle int `yaml:"bundle" json:"bundle"`
+	Model  int `yaml:"model" json:"model"`
+}
+
+type boolDiff struct {
+	Bundle bool `yaml:"bundle" json:"bundle"`
+	Model  bool `yaml:"model" json:"model"`
+}
+
+type optionDiff struct {
+	Bundle interface{} `yaml:"bundle" json:"bundle"`
+	Model  interface{} `yaml:"model" json:"model"`
+}
+
+// deployment holds the applications, unit placements and relations that
+// deploying a bundle results in, as computed by bundlechanges.
+type deployment struct {
+	applications map[string]*deployedApplication
+	relations    [][]string
+}
+
+// deployedApplication holds an application of a deployment.
+type deployedApplication struct {
+	charm       string
+	options     map[string]interface{}
+	constraints string
+	expose      bool
+
+	// placements holds the placement of each unit: a bundle machine
+	// id, a container on one ("lxd:0"), or "new" for a new machine.
+	placements []string
+}
+
+// newDeployment returns the deployment of the given bundle data.
+func newDeployment(data *charm.BundleData) *deployment {
+	changes := bundlechanges.FromData(data)
+	byId := make(map[string]bundlechanges.Change)
+	var topLevel []string
+	for _, change := range changes {
+		byId[change.Id()] = change
+		if change, ok := change.(*bundlechanges.AddMachineChange); ok && change.Params.ContainerType == "" {
+			topLevel = append(topLevel, change.Id())
+		}
+	}
+
+	// The bundle machines are added in order of their ids, before
+	// any new machines needed by unit placement directives.
+	sort.Sort(byChangeNumber(topLevel))
+	machineIds := make([]string, 0, len(data.Machines))
+	for id := range data.Machines {
+		machineIds = append(machineIds, id)
+	}
+	sort.Sort(byMachineId(machineIds))
+	bundleMachines := make(map[string]string)
+	for i, id := range topLevel {
+		if i < len(machineIds) {
+			bundleMachines[id] = machineIds[i]
+		}
+	}
+
+	var resolve func(string) string
+	resolve = func(value string) string {
+		if !strings.HasPrefix(value, "$") {
+			return value
+		}
+		id := value[1:]
+		switch change := byId[id].(type) {
+		case *bundlechanges.AddCharmChange:
+			return change.Params.Charm
+		case *bundlechanges.AddApplicationChange:
+			return change.Params.Application
+		case *bundlechanges.AddMachineChange:
+			p := change.Params
+			switch {
+			case p.ContainerType == "":
+				if machineId, ok := bundleMachines[id]; ok {
+					return machineId
+				}
+				return "new"
+			case p.ParentId == "":
+				return p.ContainerType + ":new"
+			default:
+				return p.ContainerType + ":" + resolve(p.ParentId)
+			}
+		case *bundlechanges.AddUnitChange:
+			// A unit placed with another unit shares its machine.
+			if change.Params.To == "" {
+				return "new"
+			}
+			return resolve(change.Params.To)
+		}
+		return value
+	}
+	resolveEndpoint := func(endpoint string) string {
+		parts := strings.SplitN(endpoint, ":", 2)
+		parts[0] = resolve(parts[0])
+		return strings.Join(parts, ":")
+	}
+
+	d := &deployment{
+		applications: make(map[string]*deployedApplication),
+	}
+	for _, change := range changes {
+		switch change := change.(type) {
+		case *bundlechanges.AddApplicationChange:
+			p := change.Params
+			d.applications[p.Application] = &deployedApplication{
+				charm:       resolve(p.Charm),
+				options:     p.Options,
+				constraints: p.Constraints,
+			}
+		}
+	}
+	for _, change := range changes {
+		switch change := change.(type) {
+		case *bundlechanges.ExposeChange:
+			if app, ok := d.applications[resolve(change.Params.Application)]; ok {
+				app.expose = true
+			}
+		case *bundlechanges.AddUnitChange:
+			if app, ok := d.applications[resolve(change.Params.Application)]; ok {
+				app.placements = append(app.placements, resolve("$"+change.Id()))
+			}
+		case *bundlechanges.AddRelationChange:
+			d.relations = append(d.relations, []string{
+				resolveEndpoint(change.Params.Endpoint1),
+				resolveEndpoint(change.Params.Endpoint2),
+			})
+		}
+	}
+	return d
+}
+
+// byChangeNumber sorts change ids by the sequence number that
+// bundlechanges appends to them, which is the order they were added in.
+type byChangeNumber []string
+
+func (s byChangeNumber) Len() int      { return len(s) }
+func (s byChangeNumber) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byChangeNumber) Less(i, j int) bool {
+	return changeNumber(s[i]) < changeNumber(s[j])
+}
+
+func changeNumber(id string) int {
+	n, _ := strconv.Atoi(id[strings.LastIndex(id, "-")+1:])
+	return n
+}
+
+// byMachineId sorts top level machine ids numerically.
+type byMachineId []string
+
+func (s byMachineId) Len() int      { return len(s) }
+func (s byMachineId) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byMachineId) Less(i, j int) bool {
+	a, _ := strconv.Atoi(s[i])
+	b, _ := strconv.Atoi(s[j])
+	return a < b
+}
+
+// applicationConfigFunc returns the effective values of an application's
+// options in the model.
+type applicationConfigFunc func(name string) (map[string]interface{}, error)
+
+// diffBundles compares the given bundle with the bundle representation
+// of a model.
+func diffBundles(bundleData, modelData *charm.BundleData, appConfig applicationConfigFunc) (*bundleDiff, error) {
+	diff := &bundleDiff{
+		Applications: make(map[string]*applicationDiff),
+		Machines:     make(map[string]*machineDiff),
+	}
+	comparePlacement := len(bundleData.Machines) > 0
+	bundleDeployment := newDeployment(bundleData)
+	modelDeployment := newDeployment(modelData)
+
+	for name, bundleApp := range bundleDeployment.applications {
+		modelApp, ok := modelDeployment.applications[name]
+		if !ok {
+			diff.Applications[name] = &applicationDiff{Missing: missingFromModel}
+			continue
+		}
+		appDiff, err := diffApplications(name, bundleApp, modelApp, comparePlacement, appConfig)
+		if err != nil {
+			return nil, errors.Annotatef(err, "comparing application %q", name)
+		}
+		if !appDiff.empty() {
+			diff.Applications[name] = appDiff
+		}
+	}
+	for name := range modelDeployment.applications {
+		if _, ok := bundleDeployment.applications[name]; !ok {
+			diff.Applications[name] = &applicationDiff{Missing: missingFromBundle}
+		}
+	}
+
+	if comparePlacement {
+		for id, bundleMachine := range bundleData.Machines {
+			modelMachine, ok := modelData.Machines[id]
+			if !ok {
+				diff.Machines[id] = &machineDiff{Missing: missingFromModel}
+				continue
+			}
+			if bundleMachine == nil {
+				bundleMachine = &charm.MachineSpec{}
+			}
+			if modelMachine == nil {
+				modelMachine = &charm.MachineSpec{}
+			}
+			machineDiff := &machineDiff{
+				Series: diffStrings(
+					effectiveSeries(bundleMachine.Series, bundleData.Series),
+					effectiveSeries(modelMachine.Series, modelData.Series),
+				),
+			}
+			consDiff, err := diffConstraints(bundleMachine.Constraints, modelMachine.Constraints)
+			if err != nil {
+				return nil, errors.Annotatef(err, "comparing machine %q", id)
+			}
+			machineDiff.Constraints = consDiff
+			if !machineDiff.empty() {
+				diff.Machines[id] = machineDiff
+			}
+		}
+		for id := range modelData.Machines {
+			if _, ok := bundleData.Machines[id]; !ok {
+				diff.Machines[id] = &machineDiff{Missing: missingFromBundle}
+			}
+		}
+	}
+
+	diff.Relations = diffRelations(bundleDeployment.relations, modelDeployment.relations)
+	return diff, nil
+}
+
+func diffApplications(
+	name string,
+	bundleApp, modelApp *deployedApplication,
+	comparePlacement bool,
+	appConfig applicationConfigFunc,
+) (*applicationDiff, error) {
+	appDiff := &applicationDiff{
+		Options: make(map[string]*optionDiff),
+	}
+	sameCharm, err := charmsMatch(bundleApp.charm, modelApp.charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !sameCharm {
+		appDiff.Charm = &stringDiff{Bundle: bundleApp.charm, Model: modelApp.charm}
+	}
+
+	// Options not set on the application are compared with their
+	// effective values, which are only fetched when needed.
+	var effective map[string]interface{}
+	for key, bundleValue := range bundleApp.options {
+		modelValue, ok := modelApp.options[key]
+		if !ok {
+			if effective == nil {
+				if effective, err = appConfig(name); err != nil {
+					return nil, errors.Annotate(err, "getting application config")
+				}
+			}
+			modelValue = effective[key]
+		}
+		if !optionValuesEqual(bundleValue, modelValue) {
+			appDiff.Options[key] = &optionDiff{Bundle: bundleValue, Model: modelValue}
+		}
+	}
+	for key, modelValue := range modelApp.options {
+		if _, ok := bundleApp.options[key]; !ok {
+			appDiff.Options[key] = &optionDiff{Model: modelValue}
+		}
+	}
+
+	if appDiff.Constraints, err = diffConstraints(bundleApp.constraints, modelApp.constraints); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(bundleApp.placements) != len(modelApp.placements) {
+		appDiff.NumUnits = &intDiff{Bundle: len(bundleApp.placements), Model: len(modelApp.placements)}
+	}
+	if bundleApp.expose != modelApp.expose {
+		appDiff.Expose = &boolDiff{Bundle: bundleApp.expose, Model: modelApp.expose}
+	}
+	if comparePlacement {
+		bundleTo := sortedCopy(bundleApp.placements)
+		modelTo := sortedCopy(modelApp.placements)
+		if !reflect.DeepEqual(bundleTo, modelTo) {
+			appDiff.To = &stringsDiff{Bundle: bundleTo, Model: modelTo}
+		}
+	}
+	return appDiff, nil
+}
+
+// optionValuesEqual reports whether the option values are equal. Numbers
+// are compared by value, as those read from YAML and JSON differ in type.
+func optionValuesEqual(bundleValue, modelValue interface{}) bool {
+	if a, ok := numericValue(bundleValue); ok {
+		if b, ok := numericValue(modelValue); ok {
+			return a == b
+		}
+	}
+	return reflect.DeepEqual(bundleValue, modelValue)
+}
+
+func numericValue(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case float64:
+		return value, true
+	}
+	return 0, false
+}
+
+// charmsMatch reports whether the charm URL declared in a bundle
+// matches the charm URL used in the model. Series and revision are
+// only compared when the bundle specifies them.
+func charmsMatch(bundleCharm, modelCharm string) (bool, error) {
+	bundleURL, err := charm.ParseURL(bundleCharm)
+	if err != nil {
+		// Local charm paths cannot be resolved without deploying
+		// them, so compare the charm names only.
+		return filepath.Base(bundleCharm) == charmName(modelCharm), nil
+	}
+	modelURL, err := charm.ParseURL(modelCharm)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if bundleURL.Schema != modelURL.Schema ||
+		bundleURL.User != modelURL.User ||
+		bundleURL.Name != modelURL.Name {
+		return false, nil
+	}
+	if bundleURL.Series != "" && bundleURL.Series != modelURL.Series {
+		return false, nil
+	}
+	if bundleURL.Revision >= 0 && bundleURL.Revision != modelURL.Revision {
+		return false, nil
+	}
+	return true, nil
+}
+
+func charmName(charmURL string) string {
+	curl, err := charm.ParseURL(charmURL)
+	if err != nil {
+		return charmURL
+	}
+	return curl.Name
+}
+
+// diffConstraints compares constraints in their normalised form, so that
+// for instance "mem=4G" and "mem=4096M" are considered equal.
+func diffConstraints(bundleCons, modelCons string) (*stringDiff, error) {
+	bundleValue, err := constraints.Parse(bundleCons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelValue, err := constraints.Parse(modelCons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return diffStrings(bundleValue.String(), modelValue.String()), nil
+}
+
+func diffStrings(bundleValue, modelValue string) *stringDiff {
+	if bundleValue == modelValue {
+		return nil
+	}
+	return &stringDiff{Bundle: bundleValue, Model: modelValue}
+}
+
+func effectiveSeries(series, defaultSeries string) string {
+	if series != "" {
+		return series
+	}
+	return defaultSeries
+}
+
+// diffRelations returns the relations which only exist on one side, or
+// nil if the relations match. Bundle relations may omit endpoint names,
+// in which case they match any endpoint of the same application.
+func diffRelations(bundleRelations, modelRelations [][]string) *relationsDiff {
+	matched := make([]bool, len(modelRelations))
+	var diff relationsDiff
+	for _, bundleRelation := range bundleRelations {
+		found := false
+		for i, modelRelation := range modelRelations {
+			if !matched[i] && relationsMatch(bundleRelation, modelRelation) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff.BundleAdditions = append(diff.BundleAdditions, bundleRelation)
+		}
+	}
+	for i, modelRelation := range modelRelations {
+		if !matched[i] {
+			diff.ModelAdditions = append(diff.ModelAdditions, modelRelation)
+		}
+	}
+	if len(diff.BundleAdditions) == 0 && len(diff.ModelAdditions) == 0 {
+		return nil
+	}
+	return &diff
+}
+
+func relationsMatch(bundleRelation, modelRelation []string) bool {
+	if len(bundleRelation) != 2 || len(modelRelation) != 2 {
+		return false
+	}
+	return (endpointsMatch(bundleRelation[0], modelRelation[0]) &&
+		endpointsMatch(bundleRelation[1], modelRelation[1])) ||
+		(endpointsMatch(bundleRelation[0], modelRelation[1]) &&
+			endpointsMatch(bundleRelation[1], modelRelation[0]))
+}
+
+func endpointsMatch(bundleEndpoint, modelEndpoint string) bool {
+	bundleApp, bundleName := splitEndpoint(bundleEndpoint)
+	modelApp, modelName := splitEndpoint(modelEndpoint)
+	if bundleApp != modelApp {
+		return false
+	}
+	return bundleName == "" || bundleName == modelName
+}
+
+func splitEndpoint(endpoint string) (string, string) {
+	parts := strings.SplitN(endpoint, ":", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+func sortedCopy(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make([]string, len(values))
+	copy(result, values)
+	sort.Strings(result)
+	return result
+}
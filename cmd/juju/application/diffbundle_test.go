@@ -0,0 +1,305 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type DiffBundleSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeDiffBundleAPI
+	dir  string
+}
+
+var _ = gc.Suite(&DiffBundleSuite{})
+
+type fakeDiffBundleAPI struct {
+	model  string
+	err    error
+	config map[string]map[string]interface{}
+}
+
+func (f *fakeDiffBundleAPI) Close() error {
+	return nil
+}
+
+func (f *fakeDiffBundleAPI) ExportBundle() (string, error) {
+	return f.model, f.err
+}
+
+func (f *fakeDiffBundleAPI) ApplicationConfig(name string) (map[string]interface{}, error) {
+	return f.config[name], nil
+}
+
+const diffBundleModel = `
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: 1
+    constraints: mem=4096M
+    to: ["0"]
+  wordpress:
+    charm: cs:xenial/wordpress-5
+    num_units: 2
+    expose: true
+    options:
+      blog-title: production
+    to: ["1", "lxd:0"]
+  haproxy:
+    charm: cs:xenial/haproxy-40
+    num_units: 1
+    to: ["1"]
+machines:
+  "0":
+    constraints: cores=4
+  "1": {}
+relations:
+- [wordpress:db, mysql:server]
+- [haproxy:reverseproxy, wordpress:website]
+`
+
+func (s *DiffBundleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeDiffBundleAPI{model: diffBundleModel}
+	s.dir = c.MkDir()
+}
+
+func (s *DiffBundleSuite) writeBundle(c *gc.C, content string) string {
+	path := filepath.Join(s.dir, "bundle.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *DiffBundleSuite) runDiffBundle(c *gc.C, args ...string) (string, error) {
+	ctx, err := testing.RunCommand(c, application.NewDiffBundleCommandForTest(s.fake), args...)
+	return testing.Stdout(ctx), err
+}
+
+func (s *DiffBundleSuite) TestInitNoBundle(c *gc.C) {
+	_, err := s.runDiffBundle(c)
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+}
+
+func (s *DiffBundleSuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := s.runDiffBundle(c, "one", "two")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["two"\]`)
+}
+
+func (s *DiffBundleSuite) TestNoDifferences(c *gc.C) {
+	path := s.writeBundle(c, diffBundleModel)
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "{}\n")
+}
+
+func (s *DiffBundleSuite) TestNormalisedValuesMatch(c *gc.C) {
+	path := s.writeBundle(c, `
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+    constraints: mem=4G
+  wordpress:
+    charm: wordpress
+    num_units: 2
+    expose: true
+    options:
+      blog-title: production
+  haproxy:
+    charm: cs:xenial/haproxy
+    num_units: 1
+relations:
+- [wordpress, mysql]
+- [haproxy, wordpress]
+`)
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "{}\n")
+}
+
+func (s *DiffBundleSuite) TestCharmDefaultOptionsMatch(c *gc.C) {
+	// Values read from the API are as decoded from JSON.
+	s.fake.config = map[string]map[string]interface{}{
+		"mysql": {"max-connections": float64(-1), "dataset-size": "80%"},
+	}
+	path := s.writeBundle(c, `
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: 1
+    constraints: mem=4096M
+    options:
+      max-connections: -1
+      dataset-size: 50%
+    to: ["0"]
+  wordpress:
+    charm: cs:xenial/wordpress-5
+    num_units: 2
+    expose: true
+    options:
+      blog-title: production
+    to: ["1", "lxd:0"]
+  haproxy:
+    charm: cs:xenial/haproxy-40
+    num_units: 1
+    to: ["1"]
+machines:
+  "0":
+    constraints: cores=4
+  "1": {}
+relations:
+- [wordpress:db, mysql:server]
+- [haproxy:reverseproxy, wordpress:website]
+`)
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(out, gc.Equals, `
+applications:
+  mysql:
+    options:
+      dataset-size:
+        bundle: 50%
+        model: 80%
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestRepeatedPlacementMatches(c *gc.C) {
+	s.fake.model = `
+applications:
+  wordpress:
+    charm: cs:xenial/wordpress-5
+    num_units: 3
+    to: ["0", "lxd:0", "lxd:0"]
+machines:
+  "0": {}
+`
+	path := s.writeBundle(c, `
+applications:
+  wordpress:
+    charm: cs:xenial/wordpress-5
+    num_units: 3
+    to: ["0", "lxd:0"]
+machines:
+  "0": {}
+`)
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "{}\n")
+}
+
+func (s *DiffBundleSuite) TestDifferences(c *gc.C) {
+	path := s.writeBundle(c, `
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-58
+    num_units: 1
+    constraints: mem=8G
+    to: ["0"]
+  wordpress:
+    charm: cs:xenial/wordpress-5
+    num_units: 3
+    options:
+      blog-title: staging
+    to: ["2", "lxd:0"]
+  memcached:
+    charm: cs:xenial/memcached-1
+    num_units: 1
+machines:
+  "0":
+    constraints: cores=4
+  "2": {}
+relations:
+- [wordpress:db, mysql:server]
+- [wordpress:cache, memcached:cache]
+`)
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(out, gc.Equals, `
+applications:
+  haproxy:
+    missing: bundle
+  memcached:
+    missing: model
+  mysql:
+    charm:
+      bundle: cs:xenial/mysql-58
+      model: cs:xenial/mysql-57
+    constraints:
+      bundle: mem=8192M
+      model: mem=4096M
+  wordpress:
+    options:
+      blog-title:
+        bundle: staging
+        model: production
+    num_units:
+      bundle: 3
+      model: 2
+    to:
+      bundle:
+      - "2"
+      - lxd:0
+      - lxd:0
+      model:
+      - "1"
+      - lxd:0
+    expose:
+      bundle: false
+      model: true
+machines:
+  "1":
+    missing: bundle
+  "2":
+    missing: model
+relations:
+  bundle-additions:
+  - - wordpress:cache
+    - memcached:cache
+  model-additions:
+  - - haproxy:reverseproxy
+    - wordpress:website
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestJSONOutput(c *gc.C) {
+	path := s.writeBundle(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: 1
+  wordpress:
+    charm: cs:xenial/wordpress-5
+    num_units: 2
+    expose: true
+    options:
+      blog-title: production
+`)
+	out, err := s.runDiffBundle(c, path, "--format", "json")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(out, gc.Equals, `{"applications":{"haproxy":{"missing":"bundle"},"mysql":{"constraints":{"bundle":"","model":"mem=4096M"}}},"relations":{"model-additions":[["wordpress:db","mysql:server"],["haproxy:reverseproxy","wordpress:website"]]}}`+"\n")
+}
+
+func (s *DiffBundleSuite) TestInvalidBundle(c *gc.C) {
+	path := s.writeBundle(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: -1
+`)
+	_, err := s.runDiffBundle(c, path)
+	c.Assert(err, gc.ErrorMatches, "(?s)the provided bundle has the following errors:.*negative number of units.*")
+}
@@ -74,6 +74,11 @@ func NewConsumeCommandForTest(api applicationConsumeAPI) cmd.Command {
 	return modelcmd.Wrap(&consumeCommand{api: api})
 }
 
+// NewDiffBundleCommandForTest returns a DiffBundleCommand with the api provided as specified.
+func NewDiffBundleCommandForTest(api DiffBundleAPI) cmd.Command {
+	return modelcmd.Wrap(&diffBundleCommand{api: api})
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
@@ -6,6 +6,7 @@ package backups
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 
 	"github.com/juju/cmd"
@@ -16,6 +17,7 @@ import (
 	apiserverbackups "github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/backupcrypt"
 	statebackups "github.com/juju/juju/state/backups"
 )
 
@@ -24,7 +26,7 @@ import (
 type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
-	Create(notes string) (*params.BackupsMetadataResult, error)
+	Create(notes, encryptionKey string) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -35,10 +37,12 @@ type APIClient interface {
 	Upload(ar io.ReadSeeker, meta params.BackupsMetadataResult) (string, error)
 	// Remove removes the stored backup.
 	Remove(id string) error
+	// Verify checks that the stored backup can be restored.
+	Verify(id, decryptionKey string) (*params.BackupsVerifyResult, error)
 	// Restore will restore a backup with the given id into the controller.
-	Restore(string, backups.ClientConnection) error
+	Restore(string, string, backups.ClientConnection) error
 	// RestoreReader will restore a backup file into the controller.
-	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, backups.ClientConnection) error
+	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, string, backups.ClientConnection) error
 }
 
 // CommandBase is the base type for backups sub-commands.
@@ -80,6 +84,8 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "started:         %v\n", result.Started)
 	fmt.Fprintf(ctx.Stdout, "finished:        %v\n", result.Finished)
 	fmt.Fprintf(ctx.Stdout, "notes:           %q\n", result.Notes)
+	fmt.Fprintf(ctx.Stdout, "scheduled:       %v\n", result.Scheduled)
+	fmt.Fprintf(ctx.Stdout, "key fingerprint: %q\n", result.KeyFingerprint)
 
 	fmt.Fprintf(ctx.Stdout, "model ID:        %q\n", result.Model)
 	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
@@ -93,7 +99,20 @@ type ArchiveReader interface {
 	io.Closer
 }
 
-func getArchive(filename string) (rc ArchiveReader, metaResult *params.BackupsMetadataResult, err error) {
+// readKeyFile returns the backup encryption key held in the named file.
+func readKeyFile(filename string) (*backupcrypt.Key, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	key, err := backupcrypt.ParseKey(string(data))
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading key from %q", filename)
+	}
+	return key, nil
+}
+
+func getArchive(filename string, key *backupcrypt.Key) (rc ArchiveReader, metaResult *params.BackupsMetadataResult, err error) {
 	defer func() {
 		if err != nil && rc != nil {
 			rc.Close()
@@ -105,8 +124,25 @@ func getArchive(filename string) (rc ArchiveReader, metaResult *params.BackupsMe
 		return nil, nil, errors.Trace(err)
 	}
 
+	// The archive is uploaded as it is, but the metadata is read
+	// from the decrypted content.
+	var content io.Reader = archive
+	encrypted, err := backupcrypt.IsEncrypted(archive)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if encrypted {
+		if key == nil {
+			return nil, nil, errors.Errorf("backup file %q is encrypted; use --key-file to give the key to decrypt it", filename)
+		}
+		content, err = backupcrypt.NewReader(archive, key)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	}
+
 	// Extract the metadata.
-	ad, err := statebackups.NewArchiveDataReader(archive)
+	ad, err := statebackups.NewArchiveDataReader(content)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -143,6 +179,9 @@ func getArchive(filename string) (rc ArchiveReader, metaResult *params.BackupsMe
 	if meta.Finished == nil || meta.Finished.IsZero() {
 		meta.Finished = fileMeta.Finished
 	}
+	if encrypted {
+		meta.KeyFingerprint = key.Fingerprint()
+	}
 	_, err = archive.Seek(0, os.SEEK_SET)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
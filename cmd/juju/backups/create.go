@@ -44,6 +44,13 @@ Therefore, you should use the --download or --filename options, or use:
 to get a local copy of the backup archive.
 This local copy can then be used to restore an model even if that
 model was already destroyed or is otherwise unavailable.
+
+The backup archive is encrypted if the controller has a
+backup-encryption-key, or if the --key-file option is used. The file
+must hold either a secret key or a key pair's public (or private) key,
+as created by "juju generate-backup-key"; only the public key is sent
+to the controller. The same secret key, or the private key, is needed
+to restore the backup.
 `
 
 // NewCreateCommand returns a command used to create backups.
@@ -60,6 +67,8 @@ type createCommand struct {
 	Filename string
 	// Notes is the custom message to associated with the new backup.
 	Notes string
+	// KeyFile holds the key with which to encrypt the backup.
+	KeyFile string
 }
 
 // Info implements Command.Info.
@@ -77,6 +86,7 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.StringVar(&c.KeyFile, "key-file", "", "Encrypt the backup with the key in this file")
 }
 
 // Init implements Command.Init.
@@ -104,13 +114,23 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 			return err
 		}
 	}
+	var encryptionKey string
+	if c.KeyFile != "" {
+		key, err := readKeyFile(c.KeyFile)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// Only the public half of a key pair is needed to encrypt.
+		encryptionKey = key.Public().String()
+	}
+
 	client, err := c.NewAPIClient()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer client.Close()
 
-	result, err := client.Create(c.Notes)
+	result, err := client.Create(c.Notes, encryptionKey)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -5,6 +5,8 @@ package backups_test
 
 import (
 	"bytes"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -13,6 +15,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/core/backupcrypt"
 	"github.com/juju/juju/testing"
 )
 
@@ -105,6 +108,32 @@ func (s *createSuite) TestNotes(c *gc.C) {
 	client.Check(c, s.metaresult.ID, "spam", "Create", "Download")
 }
 
+func (s *createSuite) TestKeyFile(c *gc.C) {
+	private, public, err := backupcrypt.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	keyFile := filepath.Join(c.MkDir(), "backup.key")
+	err = ioutil.WriteFile(keyFile, []byte(private.String()), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.BaseBackupsSuite.setDownload()
+	_, err = testing.RunCommand(c, s.wrappedCommand, "--key-file", keyFile, "--quiet")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, s.metaresult.ID, "", "Create", "Download")
+	// Only the public key is sent to the controller.
+	c.Check(client.key, gc.Equals, public.String())
+}
+
+func (s *createSuite) TestKeyFileInvalid(c *gc.C) {
+	keyFile := filepath.Join(c.MkDir(), "backup.key")
+	err := ioutil.WriteFile(keyFile, []byte("spam"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setSuccess()
+	_, err = testing.RunCommand(c, s.wrappedCommand, "--key-file", keyFile)
+	c.Check(err, gc.ErrorMatches, `reading key from ".*": backup key without type not valid`)
+}
+
 func (s *createSuite) TestFilename(c *gc.C) {
 	client := s.setDownload()
 	ctx, err := testing.RunCommand(c, s.wrappedCommand, "--filename", "backup.tgz", "--quiet")
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/backupcrypt"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/testing"
@@ -61,6 +62,12 @@ func NewUploadCommandForTest() cmd.Command {
 	return modelcmd.Wrap(c)
 }
 
+func NewVerifyCommandForTest() cmd.Command {
+	c := &verifyCommand{}
+	c.Log = &cmd.Log{}
+	return modelcmd.Wrap(c)
+}
+
 func NewRemoveCommandForTest() cmd.Command {
 	c := &removeCommand{}
 	c.Log = &cmd.Log{}
@@ -70,7 +77,7 @@ func NewRemoveCommandForTest() cmd.Command {
 func NewRestoreCommandForTest(
 	store jujuclient.ClientStore,
 	api RestoreAPI,
-	getArchive func(string) (ArchiveReader, *params.BackupsMetadataResult, error),
+	getArchive func(string, *backupcrypt.Key) (ArchiveReader, *params.BackupsMetadataResult, error),
 	newEnviron func(environs.OpenParams) (environs.Environ, error),
 	getRebootstrapParams func(*cmd.Context, string, *params.BackupsMetadataResult) (*restoreBootstrapParams, error),
 ) cmd.Command {
@@ -0,0 +1,116 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/core/backupcrypt"
+)
+
+const generateKeyDoc = `
+generate-backup-key creates a new key for encrypting backups, and
+writes it to the given file, which must not already exist.
+
+By default a key pair is created. The private key is written to the
+file, and the public key is printed; it can be given to the controller
+as its backup-encryption-key when it is bootstrapped, so that backups
+are encrypted without the controller ever holding the key needed to
+decrypt them.
+
+With --secret, a secret key is created instead, which both encrypts
+and decrypts backups. Its fingerprint is printed.
+
+Keep the key file safe: encrypted backups cannot be restored without
+it.
+
+Examples:
+    juju generate-backup-key backup.key
+    juju bootstrap --config backup-encryption-key=public:... aws
+
+See also:
+    create-backup
+    restore-backup
+`
+
+// NewGenerateKeyCommand returns a command used to create a key for
+// encrypting backups.
+func NewGenerateKeyCommand() cmd.Command {
+	return &generateKeyCommand{}
+}
+
+// generateKeyCommand is the sub-command for creating a backup key.
+type generateKeyCommand struct {
+	cmd.CommandBase
+	// Filename is where to write the key.
+	Filename string
+	// Secret is whether to create a secret key rather than a key pair.
+	Secret bool
+}
+
+// Info implements Command.Info.
+func (c *generateKeyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "generate-backup-key",
+		Args:    "<filename>",
+		Purpose: "Create a key for encrypting backups.",
+		Doc:     generateKeyDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *generateKeyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.BoolVar(&c.Secret, "secret", false, "Create a secret key rather than a key pair")
+}
+
+// Init implements Command.Init.
+func (c *generateKeyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("key filename not specified")
+	}
+	c.Filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *generateKeyCommand) Run(ctx *cmd.Context) error {
+	var key, public *backupcrypt.Key
+	var err error
+	if c.Secret {
+		key, err = backupcrypt.GenerateSecretKey()
+	} else {
+		key, public, err = backupcrypt.GenerateKeyPair()
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	filename := ctx.AbsPath(c.Filename)
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if os.IsExist(err) {
+		return errors.Errorf("key file %q already exists", c.Filename)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := fmt.Fprintln(file, key.String()); err != nil {
+		file.Close()
+		return errors.Annotatef(err, "writing key file %q", c.Filename)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Annotatef(err, "writing key file %q", c.Filename)
+	}
+
+	if public != nil {
+		fmt.Fprintln(ctx.Stdout, public.String())
+	} else {
+		fmt.Fprintln(ctx.Stdout, key.Fingerprint())
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/core/backupcrypt"
+	"github.com/juju/juju/testing"
+)
+
+type generateKeySuite struct {
+	testing.BaseSuite
+	filename string
+}
+
+var _ = gc.Suite(&generateKeySuite{})
+
+func (s *generateKeySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.filename = filepath.Join(c.MkDir(), "backup.key")
+}
+
+func (s *generateKeySuite) readKey(c *gc.C) *backupcrypt.Key {
+	info, err := os.Stat(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+	data, err := ioutil.ReadFile(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	key, err := backupcrypt.ParseKey(string(data))
+	c.Assert(err, jc.ErrorIsNil)
+	return key
+}
+
+func (s *generateKeySuite) TestKeyPair(c *gc.C) {
+	ctx, err := testing.RunCommand(c, backups.NewGenerateKeyCommand(), s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	key := s.readKey(c)
+	c.Check(key.Type(), gc.Equals, backupcrypt.PrivateKey)
+	out := strings.TrimSpace(testing.Stdout(ctx))
+	c.Check(out, gc.Equals, key.Public().String())
+}
+
+func (s *generateKeySuite) TestSecret(c *gc.C) {
+	ctx, err := testing.RunCommand(c, backups.NewGenerateKeyCommand(), "--secret", s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	key := s.readKey(c)
+	c.Check(key.Type(), gc.Equals, backupcrypt.SecretKey)
+	out := strings.TrimSpace(testing.Stdout(ctx))
+	c.Check(out, gc.Equals, key.Fingerprint())
+}
+
+func (s *generateKeySuite) TestFileExists(c *gc.C) {
+	err := ioutil.WriteFile(s.filename, []byte("precious"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, backups.NewGenerateKeyCommand(), s.filename)
+	c.Assert(err, gc.ErrorMatches, `key file ".*" already exists`)
+	data, err := ioutil.ReadFile(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "precious")
+}
+
+func (s *generateKeySuite) TestNoFilename(c *gc.C) {
+	_, err := testing.RunCommand(c, backups.NewGenerateKeyCommand())
+	c.Check(err, gc.ErrorMatches, "key filename not specified")
+}
@@ -9,11 +9,16 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
 const listDoc = `
 backups provides the metadata associated with all backups.
+
+If the controller has a backup schedule (see the "backup-schedule"
+controller configuration setting), the outcome of its last run and
+the time of its next run are also displayed.
 `
 
 // NewListCommand returns a command used to list metadata for backups.
@@ -63,18 +68,27 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	if len(result.List) == 0 {
+	c.dumpList(ctx, result.List)
+	if result.Schedule != nil {
+		c.dumpSchedule(ctx, result.Schedule)
+	}
+	return nil
+}
+
+// dumpList writes the backups in the list to stdout.
+func (c *listCommand) dumpList(ctx *cmd.Context, list []params.BackupsMetadataResult) {
+	if len(list) == 0 {
 		ctx.Infof("No backups to display.")
-		return nil
+		return
 	}
 
 	verbose := c.Log != nil && c.Log.Verbose
 	if verbose {
-		c.dumpMetadata(ctx, &result.List[0])
+		c.dumpMetadata(ctx, &list[0])
 	} else {
-		fmt.Fprintln(ctx.Stdout, result.List[0].ID)
+		fmt.Fprintln(ctx.Stdout, list[0].ID)
 	}
-	for _, resultItem := range result.List[1:] {
+	for _, resultItem := range list[1:] {
 		if verbose {
 			fmt.Fprintln(ctx.Stdout)
 			c.dumpMetadata(ctx, &resultItem)
@@ -82,5 +96,20 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 			fmt.Fprintln(ctx.Stdout, resultItem.ID)
 		}
 	}
-	return nil
+}
+
+// dumpSchedule reports the status of the backup schedule.
+func (c *listCommand) dumpSchedule(ctx *cmd.Context, schedule *params.BackupsScheduleResult) {
+	ctx.Infof("Backup schedule: %s", schedule.Schedule)
+	switch {
+	case schedule.LastRun.IsZero():
+		ctx.Infof("  last run: never")
+	case schedule.LastError != "":
+		ctx.Infof("  last run: %v (failed: %s)", schedule.LastRun, schedule.LastError)
+	default:
+		ctx.Infof("  last run: %v (created %s)", schedule.LastRun, schedule.LastBackupID)
+	}
+	if !schedule.NextRun.IsZero() {
+		ctx.Infof("  next run: %v", schedule.NextRun)
+	}
 }
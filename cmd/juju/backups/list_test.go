@@ -4,12 +4,15 @@
 package backups_test
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/backups"
 	"github.com/juju/juju/testing"
 )
@@ -44,6 +47,24 @@ func (s *listSuite) TestBrief(c *gc.C) {
 	s.checkStd(c, ctx, out, "")
 }
 
+func (s *listSuite) TestSchedule(c *gc.C) {
+	client := s.setSuccess()
+	lastRun := time.Date(2017, 3, 10, 2, 30, 0, 0, time.UTC)
+	client.schedule = &params.BackupsScheduleResult{
+		Schedule:  "30 2 * * *",
+		LastRun:   lastRun,
+		LastError: "boom",
+		NextRun:   lastRun.Add(24 * time.Hour),
+	}
+	ctx, err := testing.RunCommand(c, s.subcommand)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStd(c, ctx, s.metaresult.ID+"\n", `
+Backup schedule: 30 2 * * *
+  last run: 2017-03-10 02:30:00 +0000 UTC (failed: boom)
+  next run: 2017-03-11 02:30:00 +0000 UTC
+`[1:])
+}
+
 func (s *listSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := testing.RunCommand(c, s.subcommand)
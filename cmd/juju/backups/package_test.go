@@ -32,6 +32,8 @@ stored:          0001-01-01 00:00:00 +0000 UTC
 started:         0001-01-01 00:00:00 +0000 UTC
 finished:        0001-01-01 00:00:00 +0000 UTC
 notes:           ""
+scheduled:       false
+key fingerprint: ""
 model ID:        ""
 machine ID:      ""
 created on host: ""
@@ -115,14 +117,17 @@ func (s *BaseBackupsSuite) checkStd(c *gc.C, ctx *cmd.Context, out, err string)
 }
 
 type fakeAPIClient struct {
-	metaresult *params.BackupsMetadataResult
-	archive    io.ReadCloser
-	err        error
+	metaresult   *params.BackupsMetadataResult
+	verifyresult *params.BackupsVerifyResult
+	schedule     *params.BackupsScheduleResult
+	archive      io.ReadCloser
+	err          error
 
 	calls []string
 	args  []string
 	idArg string
 	notes string
+	key   string
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -131,10 +136,11 @@ func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
 	c.Check(f.notes, gc.Equals, notes)
 }
 
-func (c *fakeAPIClient) Create(notes string) (*params.BackupsMetadataResult, error) {
+func (c *fakeAPIClient) Create(notes, encryptionKey string) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Create")
-	c.args = append(c.args, "notes")
+	c.args = append(c.args, "notes", "encryptionKey")
 	c.notes = notes
+	c.key = encryptionKey
 	if c.err != nil {
 		return nil, c.err
 	}
@@ -158,6 +164,7 @@ func (c *fakeAPIClient) List() (*params.BackupsListResult, error) {
 	}
 	var result params.BackupsListResult
 	result.List = []params.BackupsMetadataResult{*c.metaresult}
+	result.Schedule = c.schedule
 	return &result, nil
 }
 
@@ -189,14 +196,25 @@ func (c *fakeAPIClient) Remove(id string) error {
 	return nil
 }
 
+func (c *fakeAPIClient) Verify(id, decryptionKey string) (*params.BackupsVerifyResult, error) {
+	c.calls = append(c.calls, "Verify")
+	c.args = append(c.args, "id", "decryptionKey")
+	c.idArg = id
+	c.key = decryptionKey
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.verifyresult, nil
+}
+
 func (c *fakeAPIClient) Close() error {
 	return nil
 }
 
-func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, apibackups.ClientConnection) error {
+func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, string, apibackups.ClientConnection) error {
 	return nil
 }
 
-func (c *fakeAPIClient) Restore(string, apibackups.ClientConnection) error {
+func (c *fakeAPIClient) Restore(string, string, apibackups.ClientConnection) error {
 	return nil
 }
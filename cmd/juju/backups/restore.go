@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/backupcrypt"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/environs/config"
@@ -52,13 +53,14 @@ type restoreCommand struct {
 	constraintsStr string
 	filename       string
 	backupId       string
+	keyFile        string
 	bootstrap      bool
 	buildAgent     bool
 
 	newAPIClientFunc         func() (RestoreAPI, error)
 	newEnvironFunc           func(environs.OpenParams) (environs.Environ, error)
 	getRebootstrapParamsFunc func(*cmd.Context, string, *params.BackupsMetadataResult) (*restoreBootstrapParams, error)
-	getArchiveFunc           func(string) (ArchiveReader, *params.BackupsMetadataResult, error)
+	getArchiveFunc           func(string, *backupcrypt.Key) (ArchiveReader, *params.BackupsMetadataResult, error)
 	waitForAgentFunc         func(ctx *cmd.Context, c *modelcmd.ModelCommandBase, controllerName, hostedModelName string) error
 }
 
@@ -68,10 +70,10 @@ type RestoreAPI interface {
 	Close() error
 
 	// Restore is taken from backups.Client.
-	Restore(backupId string, newClient backups.ClientConnection) error
+	Restore(backupId, decryptionKey string, newClient backups.ClientConnection) error
 
 	// RestoreReader is taken from backups.Client.
-	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient backups.ClientConnection) error
+	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, decryptionKey string, newClient backups.ClientConnection) error
 }
 
 var restoreDoc = `
@@ -89,6 +91,9 @@ If the provided state cannot be restored, this command will fail with
 an appropriate message.  For instance, if the existing bootstrap
 instance is already running then the command will fail with a message
 to that effect.
+
+Encrypted backups are restored by giving the file holding the secret
+key, or the private key, with --key-file.
 `
 
 var BootstrapFunc = bootstrap.Bootstrap
@@ -110,6 +115,7 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.bootstrap, "b", false, "Bootstrap a new state machine")
 	f.StringVar(&c.filename, "file", "", "Provide a file to be used as the backup.")
 	f.StringVar(&c.backupId, "id", "", "Provide the name of the backup to be restored")
+	f.StringVar(&c.keyFile, "key-file", "", "Decrypt the backup with the key in this file")
 	f.BoolVar(&c.buildAgent, "build-agent", false, "Build binary agent if bootstraping a new machine")
 }
 
@@ -380,6 +386,19 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	var key *backupcrypt.Key
+	var decryptionKey string
+	if c.keyFile != "" {
+		key, err = readKeyFile(c.keyFile)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !key.CanDecrypt() {
+			return errors.Errorf("cannot decrypt backups with a %s key", key.Type())
+		}
+		decryptionKey = key.String()
+	}
+
 	var archive ArchiveReader
 	var meta *params.BackupsMetadataResult
 	target := c.backupId
@@ -389,7 +408,7 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		// we need it now to rebootstrap.
 		target = c.filename
 		var err error
-		archive, meta, err = c.getArchiveFunc(c.filename)
+		archive, meta, err = c.getArchiveFunc(c.filename, key)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -411,9 +430,9 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	// We have a backup client, now use the relevant method
 	// to restore the backup.
 	if c.filename != "" {
-		err = client.RestoreReader(archive, meta, c.newClient)
+		err = client.RestoreReader(archive, meta, decryptionKey, c.newClient)
 	} else {
-		err = client.Restore(c.backupId, c.newClient)
+		err = client.Restore(c.backupId, decryptionKey, c.newClient)
 	}
 	if err != nil {
 		return errors.Trace(err)
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/backups"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/backupcrypt"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/instance"
@@ -115,7 +116,7 @@ func (*mockRestoreAPI) Close() error {
 	return nil
 }
 
-func (*mockRestoreAPI) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, apibackups.ClientConnection) error {
+func (*mockRestoreAPI) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, string, apibackups.ClientConnection) error {
 	return nil
 }
 
@@ -131,7 +132,7 @@ func (s *restoreSuite) TestRestoreReboostrapControllerExists(c *gc.C) {
 	fakeEnv := fakeEnviron{controllerInstances: []instance.Id{"1"}}
 	s.command = backups.NewRestoreCommandForTest(
 		s.store, &mockRestoreAPI{},
-		func(string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+		func(string, *backupcrypt.Key) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
 			return &mockArchiveReader{}, &params.BackupsMetadataResult{}, nil
 		},
 		backups.GetEnvironFunc(fakeEnv),
@@ -145,7 +146,7 @@ func (s *restoreSuite) TestRestoreReboostrapNoControllers(c *gc.C) {
 	fakeEnv := fakeEnviron{}
 	s.command = backups.NewRestoreCommandForTest(
 		s.store, &mockRestoreAPI{},
-		func(string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+		func(string, *backupcrypt.Key) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
 			return &mockArchiveReader{}, &params.BackupsMetadataResult{
 				CACert: testing.CACert,
 			}, nil
@@ -168,7 +169,7 @@ func (s *restoreSuite) TestRestoreReboostrapReadsMetadata(c *gc.C) {
 	}
 	s.command = backups.NewRestoreCommandForTest(
 		s.store, &mockRestoreAPI{},
-		func(string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+		func(string, *backupcrypt.Key) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
 			return &mockArchiveReader{}, &metadata, nil
 		},
 		backups.GetEnvironFunc(fakeEnviron{}),
@@ -189,7 +190,7 @@ func (s *restoreSuite) TestFailedRestoreReboostrapMaintainsControllerInfo(c *gc.
 	}
 	s.command = backups.NewRestoreCommandForTest(
 		s.store, &mockRestoreAPI{},
-		func(string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+		func(string, *backupcrypt.Key) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
 			return &mockArchiveReader{}, &metadata, nil
 		},
 		nil,
@@ -222,7 +223,7 @@ func (s *restoreSuite) TestRestoreReboostrapWritesUpdatedControllerInfo(c *gc.C)
 	fakeEnv := fakeEnviron{}
 	s.command = backups.NewRestoreCommandForTest(
 		s.store, &mockRestoreAPI{},
-		func(string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+		func(string, *backupcrypt.Key) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
 			return &mockArchiveReader{}, &metadata, nil
 		},
 		backups.GetEnvironFunc(fakeEnv),
@@ -270,7 +271,7 @@ func (s *restoreSuite) TestRestoreReboostrapBuiltInProvider(c *gc.C) {
 	fakeEnv := fakeEnviron{}
 	s.command = backups.NewRestoreCommandForTest(
 		s.store, &mockRestoreAPI{},
-		func(string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+		func(string, *backupcrypt.Key) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
 			return &mockArchiveReader{}, &metadata, nil
 		},
 		backups.GetEnvironFunc(fakeEnv),
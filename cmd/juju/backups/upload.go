@@ -8,13 +8,18 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/backupcrypt"
 )
 
 const uploadDoc = `
 upload-backup sends a backup archive file to remote storage.
+
+An encrypted archive is uploaded as it is; the key in the file given
+with --key-file is only used to read the archive's metadata.
 `
 
 // NewUploadCommand returns a command used to send a backup
@@ -28,6 +33,8 @@ type uploadCommand struct {
 	CommandBase
 	// Filename is where to find the archive to upload.
 	Filename string
+	// KeyFile holds the key with which to read an encrypted archive.
+	KeyFile string
 }
 
 // Info implements Command.Info.
@@ -40,6 +47,12 @@ func (c *uploadCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *uploadCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.KeyFile, "key-file", "", "Decrypt the archive's metadata with the key in this file")
+}
+
 // Init implements Command.Init.
 func (c *uploadCommand) Init(args []string) error {
 	if len(args) == 0 {
@@ -60,13 +73,22 @@ func (c *uploadCommand) Run(ctx *cmd.Context) error {
 			return err
 		}
 	}
+	var key *backupcrypt.Key
+	if c.KeyFile != "" {
+		var err error
+		key, err = readKeyFile(c.KeyFile)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	client, err := c.NewAPIClient()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer client.Close()
 
-	archive, meta, err := getArchive(c.Filename)
+	archive, meta, err := getArchive(c.Filename, key)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -0,0 +1,189 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	apiserverbackups "github.com/juju/juju/apiserver/backups"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/backupcrypt"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+const verifyDoc = `
+verify-backup checks that a backup could be restored, without restoring
+it. The backup's archive is read in full and checked against its
+checksum, and its contents are checked: the archive must hold all the
+files restore-backup expects, and the database dump within it must be
+made of well formed documents. The version of Juju which made the
+backup is also checked.
+
+Stored backups are verified by the controller. A backup archive file
+may be verified locally, with --file, instead.
+
+Encrypted backups are verified in full only if the file holding the
+secret key, or the private key, is given with --key-file. Without it,
+only the backup's checksum is verified.
+
+The command fails if any problem is found.
+
+Examples:
+    juju verify-backup 20170310-023000.deadbeef-0bad-400d-8000-4b1d0d06f00d
+    juju verify-backup --file juju-backup-20170310-023000.tar.gz
+
+See also:
+    backups
+    restore-backup
+`
+
+// NewVerifyCommand returns a command used to verify a backup.
+func NewVerifyCommand() cmd.Command {
+	return modelcmd.Wrap(&verifyCommand{})
+}
+
+// verifyCommand is the sub-command for verifying a backup.
+type verifyCommand struct {
+	CommandBase
+	// ID is the ID of the stored backup to verify.
+	ID string
+	// Filename is the backup archive file to verify.
+	Filename string
+	// KeyFile holds the key with which to decrypt the backup.
+	KeyFile string
+}
+
+// Info implements Command.Info.
+func (c *verifyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "verify-backup",
+		Args:    "[<ID>]",
+		Purpose: "Check that a backup can be restored.",
+		Doc:     verifyDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *verifyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.Filename, "file", "", "Verify this backup archive file")
+	f.StringVar(&c.KeyFile, "key-file", "", "Decrypt the backup with the key in this file")
+}
+
+// Init implements Command.Init.
+func (c *verifyCommand) Init(args []string) error {
+	if len(args) > 0 {
+		c.ID, args = args[0], args[1:]
+	}
+	if c.ID == "" && c.Filename == "" {
+		return errors.New("missing ID or --file")
+	}
+	if c.ID != "" && c.Filename != "" {
+		return errors.New("cannot specify both an ID and --file")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *verifyCommand) Run(ctx *cmd.Context) error {
+	if c.Log != nil {
+		if err := c.Log.Start(ctx); err != nil {
+			return err
+		}
+	}
+
+	var key *backupcrypt.Key
+	if c.KeyFile != "" {
+		var err error
+		key, err = readKeyFile(c.KeyFile)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !key.CanDecrypt() {
+			return errors.Errorf("cannot decrypt backups with a %s key", key.Type())
+		}
+	}
+
+	var result *params.BackupsVerifyResult
+	var err error
+	if c.Filename != "" {
+		result, err = c.verifyFile(ctx.AbsPath(c.Filename), key)
+	} else {
+		result, err = c.verifyStored(key)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	c.dumpVerifyResult(ctx, result)
+	if len(result.Problems) > 0 {
+		return errors.Errorf("backup failed verification")
+	}
+	return nil
+}
+
+// verifyStored asks the controller to verify the stored backup.
+func (c *verifyCommand) verifyStored(key *backupcrypt.Key) (*params.BackupsVerifyResult, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+
+	var decryptionKey string
+	if key != nil {
+		decryptionKey = key.String()
+	}
+	return client.Verify(c.ID, decryptionKey)
+}
+
+// verifyFile verifies the backup archive file.
+func (c *verifyCommand) verifyFile(filename string, key *backupcrypt.Key) (*params.BackupsVerifyResult, error) {
+	archive, metaResult, err := getArchive(filename, key)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading backup file")
+	}
+	defer archive.Close()
+
+	meta := apiserverbackups.MetadataFromResult(*metaResult)
+	result, err := statebackups.VerifyArchive(meta, archive, key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	verifyResult := apiserverbackups.VerifyResultFromVerification(filename, result)
+	return &verifyResult, nil
+}
+
+// dumpVerifyResult writes the outcome of verifying a backup to stdout.
+func (c *verifyCommand) dumpVerifyResult(ctx *cmd.Context, result *params.BackupsVerifyResult) {
+	fmt.Fprintf(ctx.Stdout, "backup ID:       %q\n", result.ID)
+	fmt.Fprintf(ctx.Stdout, "checksum:        %q\n", result.Checksum)
+	fmt.Fprintf(ctx.Stdout, "size (B):        %d\n", result.Size)
+	if result.ContentsVerified {
+		fmt.Fprintf(ctx.Stdout, "contents:        %d collections, %d documents\n", result.Collections, result.Documents)
+	} else if len(result.Problems) == 0 {
+		fmt.Fprintf(ctx.Stdout, "contents:        not verified; the backup is encrypted, see --key-file\n")
+	} else {
+		fmt.Fprintf(ctx.Stdout, "contents:        not verified\n")
+	}
+	compatible := "compatible"
+	if !result.Compatible {
+		compatible = "not compatible"
+	}
+	fmt.Fprintf(ctx.Stdout, "juju version:    %v (%s)\n", result.Version, compatible)
+
+	if len(result.Problems) == 0 {
+		fmt.Fprintln(ctx.Stdout, "\nno problems found")
+		return
+	}
+	fmt.Fprintln(ctx.Stdout, "\nproblems found:")
+	for _, problem := range result.Problems {
+		fmt.Fprintf(ctx.Stdout, "  %s\n", problem)
+	}
+}
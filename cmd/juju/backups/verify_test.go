@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/testing"
+)
+
+type verifySuite struct {
+	BaseBackupsSuite
+	subcommand cmd.Command
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.subcommand = backups.NewVerifyCommandForTest()
+}
+
+func (s *verifySuite) TestOkay(c *gc.C) {
+	client := s.setSuccess()
+	client.verifyresult = &params.BackupsVerifyResult{
+		ID:               "spam",
+		Checksum:         "checksum",
+		Size:             42,
+		ContentsVerified: true,
+		Collections:      2,
+		Documents:        3,
+		Version:          version.MustParse("2.2.0"),
+		Compatible:       true,
+	}
+	ctx, err := testing.RunCommand(c, s.subcommand, "spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "spam", "", "Verify")
+	c.Check(client.key, gc.Equals, "")
+	s.checkStd(c, ctx, `
+backup ID:       "spam"
+checksum:        "checksum"
+size (B):        42
+contents:        2 collections, 3 documents
+juju version:    2.2.0 (compatible)
+
+no problems found
+`[1:], "")
+}
+
+func (s *verifySuite) TestProblems(c *gc.C) {
+	client := s.setSuccess()
+	client.verifyresult = &params.BackupsVerifyResult{
+		ID:       "spam",
+		Checksum: "checksum",
+		Size:     40,
+		Version:  version.MustParse("1.25.6"),
+		Problems: []string{
+			"backups made using Juju version 1.25.6 cannot be restored",
+			"archive size mismatch: expected 42 bytes, got 40",
+		},
+	}
+	ctx, err := testing.RunCommand(c, s.subcommand, "spam")
+	c.Assert(err, gc.ErrorMatches, "backup failed verification")
+
+	s.checkStd(c, ctx, `
+backup ID:       "spam"
+checksum:        "checksum"
+size (B):        40
+contents:        not verified
+juju version:    1.25.6 (not compatible)
+
+problems found:
+  backups made using Juju version 1.25.6 cannot be restored
+  archive size mismatch: expected 42 bytes, got 40
+`[1:], "")
+}
+
+func (s *verifySuite) TestArgs(c *gc.C) {
+	_, err := testing.RunCommand(c, s.subcommand)
+	c.Check(err, gc.ErrorMatches, "missing ID or --file")
+
+	_, err = testing.RunCommand(c, s.subcommand, "spam", "--file", "backup.tar.gz")
+	c.Check(err, gc.ErrorMatches, "cannot specify both an ID and --file")
+
+	_, err = testing.RunCommand(c, s.subcommand, "spam", "eggs")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["eggs"\]`)
+}
+
+func (s *verifySuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := testing.RunCommand(c, s.subcommand, "spam")
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
@@ -4,9 +4,11 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"time"
 
 	"github.com/juju/ansiterm"
@@ -15,6 +17,7 @@ import (
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
 	"github.com/juju/loggo/loggocolor"
+	"github.com/juju/utils/clock"
 	"github.com/mattn/go-isatty"
 
 	"github.com/juju/juju/api/common"
@@ -49,13 +52,37 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--include-message' and '--exclude-message' options filter by the
+content of the log message. Their values are regular expressions, which
+match anywhere in the message unless anchored with '^' or '$'. With
+'--lines', the lines shown are found among the last 100000 messages
+selected by the other options; fewer lines are shown if not enough of
+those match.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* All --include-message options are logically ORed together.
+* All --exclude-message options are logically ORed together.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --include-message and --exclude-message selections are logically ANDed
+  to form the complete filter.
+
+The '--since' and '--until' options restrict the output to log messages
+recorded within a time range. Each accepts an RFC3339 timestamp, a date and
+time ("YYYY-MM-DD hh:mm[:ss]"), a time of day today ("hh:mm[:ss]") or a
+duration, such as 30m, which is taken as that long ago. Times without a
+time zone are taken as local time, or UTC if '--utc' is given. Using
+'--since' shows all matching messages from that time onwards, as with
+'--replay'. Using '--until' implies '--no-tail', as no new messages can
+fall within the range.
+
+With '--format=json', each log record is emitted as a single JSON object
+per line, containing the entity, timestamp, severity, module, location
+and message of the record. The timestamp is always given to nanosecond
+precision, in local time unless '--utc' is given.
 
 Examples:
 
@@ -94,6 +121,21 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show all messages from unit mysql/0 logged between 14:02 and 14:10 today:
+
+    juju debug-log --include unit-mysql-0 --since 14:02 --until 14:10
+
+Show all ERROR messages logged in the last hour, and then stop:
+
+    juju debug-log --level ERROR --since 1h --no-tail
+
+Show all messages reporting hook failures, except those for the install
+hook, as JSON:
+
+    juju debug-log --replay --no-tail --format=json \
+        --include-message 'hook failed' \
+        --exclude-message 'install'
+
 See also: 
     status
     ssh`
@@ -129,10 +171,21 @@ type debugLogCommand struct {
 	notail bool
 	color  bool
 
-	format string
-	tz     *time.Location
+	since string
+	until string
+	clock clock.Clock
+
+	format     string
+	timeFormat string
+	tz         *time.Location
 }
 
+// Output formats supported by debug-log.
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
 func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeEntity), "i", "Only show log messages for these entities")
@@ -141,6 +194,8 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeMessage), "include-message", "Only show log messages matching these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeMessage), "exclude-message", "Do not show log messages matching these regular expressions")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -149,6 +204,8 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.UintVar(&c.params.Backlog, "lines", defaultLineCount, "")
 	f.UintVar(&c.params.Limit, "limit", 0, "Exit once this many of the most recent (possibly filtered) lines are shown")
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
+	f.StringVar(&c.since, "since", "", "Only show log messages recorded at or after this time")
+	f.StringVar(&c.until, "until", "", "Only show log messages recorded before this time, then stop")
 
 	f.BoolVar(&c.notail, "no-tail", false, "Stop after returning existing log messages")
 	f.BoolVar(&c.tail, "tail", false, "Wait for new logs")
@@ -158,6 +215,7 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.location, "location", false, "Show filename and line numbers")
 	f.BoolVar(&c.date, "date", false, "Show dates as well as times")
 	f.BoolVar(&c.ms, "ms", false, "Show times to millisecond precision")
+	f.StringVar(&c.format, "format", formatText, "Output format, one of [text, json]")
 }
 
 func (c *debugLogCommand) Init(args []string) error {
@@ -169,23 +227,109 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	for _, expr := range append(c.params.IncludeMessage, c.params.ExcludeMessage...) {
+		if _, err := regexp.Compile(expr); err != nil {
+			return errors.Errorf("message filter %q is not a valid regular expression", expr)
+		}
+	}
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
+	if c.tail && c.until != "" {
+		return errors.NotValidf("setting --tail and --until")
+	}
+	switch c.format {
+	case formatText, formatJSON:
+	default:
+		return errors.Errorf("format value %q is not one of %q, %q", c.format, formatText, formatJSON)
+	}
 	if c.utc {
 		c.tz = time.UTC
 	}
+	if err := c.initTimeRange(); err != nil {
+		return errors.Trace(err)
+	}
 	if c.date {
-		c.format = "2006-01-02 15:04:05"
+		c.timeFormat = "2006-01-02 15:04:05"
 	} else {
-		c.format = "15:04:05"
+		c.timeFormat = "15:04:05"
 	}
 	if c.ms {
-		c.format = c.format + ".000"
+		c.timeFormat = c.timeFormat + ".000"
 	}
 	return cmd.CheckEmpty(args)
 }
 
+// initTimeRange sets the time range of the requested log messages
+// from the --since and --until options.
+func (c *debugLogCommand) initTimeRange() error {
+	if c.since == "" && c.until == "" {
+		return nil
+	}
+	if c.clock == nil {
+		c.clock = clock.WallClock
+	}
+	now := c.clock.Now().In(c.tz)
+	if c.since != "" {
+		since, err := parseLogTime(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.params.StartTime = since
+		c.params.Replay = true
+	}
+	if c.until != "" {
+		until, err := parseLogTime(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		c.params.EndTime = until
+	}
+	if c.since != "" && c.until != "" && !c.params.StartTime.Before(c.params.EndTime) {
+		return errors.New("--since must be earlier than --until")
+	}
+	return nil
+}
+
+// logTimeLayouts are the layouts accepted for --since and --until,
+// besides durations. Layouts without a date are taken as times of
+// day on the current date.
+var logTimeLayouts = []struct {
+	layout    string
+	timeOfDay bool
+}{
+	{time.RFC3339Nano, false},
+	{"2006-01-02 15:04:05", false},
+	{"2006-01-02 15:04", false},
+	{"2006-01-02", false},
+	{"15:04:05", true},
+	{"15:04", true},
+}
+
+// parseLogTime parses a time given as a timestamp, a date, a time of
+// day or a duration before now. Times without a zone are taken to be
+// in now's location.
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, errors.Errorf("negative duration %q", value)
+		}
+		return now.Add(-d).UTC(), nil
+	}
+	for _, l := range logTimeLayouts {
+		t, err := time.ParseInLocation(l.layout, value, now.Location())
+		if err != nil {
+			continue
+		}
+		if l.timeOfDay {
+			year, month, day := now.Date()
+			t = time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), 0, now.Location())
+		}
+		return t.UTC(), nil
+	}
+	return time.Time{}, errors.Errorf("expected a timestamp, date, time of day or duration, got %q", value)
+}
+
 type DebugLogAPI interface {
 	WatchDebugLog(params common.DebugLogParams) (<-chan common.LogMessage, error)
 	Close() error
@@ -207,7 +351,7 @@ func isTerminal(out io.Writer) bool {
 func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	if c.tail {
 		c.params.NoTail = false
-	} else if c.notail {
+	} else if c.notail || !c.params.EndTime.IsZero() {
 		c.params.NoTail = true
 	} else {
 		// Set the default tail option to true if the caller is
@@ -224,6 +368,9 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if c.format == formatJSON {
+		return c.writeJSONRecords(ctx.Stdout, messages)
+	}
 	writer := ansiterm.NewWriter(ctx.Stdout)
 	if c.color {
 		writer.SetColorCapable(true)
@@ -239,6 +386,36 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	return nil
 }
 
+// jsonLogRecord is the representation of a log record written by
+// --format=json.
+type jsonLogRecord struct {
+	Entity    string    `json:"entity"`
+	Timestamp time.Time `json:"timestamp"`
+	Severity  string    `json:"severity"`
+	Module    string    `json:"module"`
+	Location  string    `json:"location"`
+	Message   string    `json:"message"`
+}
+
+// writeJSONRecords writes each record received from messages as a
+// JSON object on a line of its own.
+func (c *debugLogCommand) writeJSONRecords(w io.Writer, messages <-chan common.LogMessage) error {
+	encoder := json.NewEncoder(w)
+	for msg := range messages {
+		if err := encoder.Encode(jsonLogRecord{
+			Entity:    msg.Entity,
+			Timestamp: msg.Timestamp.In(c.tz),
+			Severity:  msg.Severity,
+			Module:    msg.Module,
+			Location:  msg.Location,
+			Message:   msg.Message,
+		}); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 var SeverityColor = map[string]*ansiterm.Context{
 	"TRACE":   ansiterm.Foreground(ansiterm.Default),
 	"DEBUG":   ansiterm.Foreground(ansiterm.Green),
@@ -252,7 +429,7 @@ var SeverityColor = map[string]*ansiterm.Context{
 }
 
 func (c *debugLogCommand) writeLogRecord(w *ansiterm.Writer, r common.LogMessage) {
-	ts := r.Timestamp.In(c.tz).Format(c.format)
+	ts := r.Timestamp.In(c.tz).Format(c.timeFormat)
 	fmt.Fprintf(w, "%s: %s ", r.Entity, ts)
 	SeverityColor[r.Severity].Fprintf(w, r.Severity)
 	fmt.Fprintf(w, " %s ", r.Module)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/loggo"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -71,6 +72,19 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				ExcludeModule: []string{"juju.foo", "unit"},
 				Backlog:       10,
 			},
+		}, {
+			args: []string{"--include-message", "^started", "--exclude-message", "failed$"},
+			expected: common.DebugLogParams{
+				IncludeMessage: []string{"^started"},
+				ExcludeMessage: []string{"failed$"},
+				Backlog:        10,
+			},
+		}, {
+			args:     []string{"--include-message", "foo("},
+			errMatch: `message filter "foo\(" is not a valid regular expression`,
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format value "yaml" is not one of "text", "json"`,
 		}, {
 			args: []string{"--replay"},
 			expected: common.DebugLogParams{
@@ -100,6 +114,75 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 	}
 }
 
+func (s *DebugLogSuite) TestTimeRangeParsing(c *gc.C) {
+	// test timezone is 6 hours east of UTC
+	tz := time.FixedZone("test", 6*60*60)
+	now := time.Date(2017, 5, 10, 16, 30, 0, 0, time.UTC)
+	for i, test := range []struct {
+		args     []string
+		since    time.Time
+		until    time.Time
+		errMatch string
+	}{{
+		args:  []string{"--since", "2017-05-10T14:02:00Z"},
+		since: time.Date(2017, 5, 10, 14, 2, 0, 0, time.UTC),
+	}, {
+		args:  []string{"--since", "14:02", "--until", "14:10:30"},
+		since: time.Date(2017, 5, 10, 8, 2, 0, 0, time.UTC),
+		until: time.Date(2017, 5, 10, 8, 10, 30, 0, time.UTC),
+	}, {
+		args:  []string{"--utc", "--since", "14:02", "--until", "14:10:30"},
+		since: time.Date(2017, 5, 10, 14, 2, 0, 0, time.UTC),
+		until: time.Date(2017, 5, 10, 14, 10, 30, 0, time.UTC),
+	}, {
+		args:  []string{"--until", "2017-05-09 23:00"},
+		until: time.Date(2017, 5, 9, 17, 0, 0, 0, time.UTC),
+	}, {
+		args:  []string{"--since", "90m"},
+		since: time.Date(2017, 5, 10, 15, 0, 0, 0, time.UTC),
+	}, {
+		args:     []string{"--since", "yesterday"},
+		errMatch: `invalid --since value: expected a timestamp, date, time of day or duration, got "yesterday"`,
+	}, {
+		args:     []string{"--until", "-1h"},
+		errMatch: `invalid --until value: negative duration "-1h"`,
+	}, {
+		args:     []string{"--since", "1h", "--until", "2h"},
+		errMatch: `--since must be earlier than --until`,
+	}, {
+		args:     []string{"--until", "1h", "--tail"},
+		errMatch: `setting --tail and --until not valid`,
+	}} {
+		c.Logf("test %v", i)
+		command := &debugLogCommand{tz: tz, clock: jujutesting.NewClock(now)}
+		err := testing.InitCommand(modelcmd.Wrap(command), test.args)
+		if test.errMatch != "" {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(command.params.StartTime, gc.Equals, test.since)
+		c.Check(command.params.EndTime, gc.Equals, test.until)
+		c.Check(command.params.Replay, gc.Equals, !test.since.IsZero())
+	}
+}
+
+func (s *DebugLogSuite) TestUntilStopsTailing(c *gc.C) {
+	fake := &fakeDebugLogAPI{}
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return fake, nil
+	})
+	_, err := testing.RunCommand(c, newDebugLogCommand(),
+		"--until", "2017-05-10T14:10:00Z",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake.params, gc.DeepEquals, common.DebugLogParams{
+		Backlog: 10,
+		EndTime: time.Date(2017, 5, 10, 14, 10, 0, 0, time.UTC),
+		NoTail:  true,
+	})
+}
+
 func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	fake := &fakeDebugLogAPI{}
 	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
@@ -163,6 +246,14 @@ func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 	checkOutput(
 		"--location",
 		"machine-0: 14:15:23 INFO test.module somefile.go:123 this is the log output\n")
+	checkOutput(
+		"--format=json", "--utc",
+		`{"entity":"machine-0","timestamp":"2016-10-09T08:15:23.345Z","severity":"INFO",`+
+			`"module":"test.module","location":"somefile.go:123","message":"this is the log output"}`+"\n")
+	checkOutput(
+		"--format=json",
+		`{"entity":"machine-0","timestamp":"2016-10-09T14:15:23.345+06:00","severity":"INFO",`+
+			`"module":"test.module","location":"somefile.go:123","message":"this is the log output"}`+"\n")
 }
 
 type fakeDebugLogAPI struct {
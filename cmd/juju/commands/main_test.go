@@ -390,6 +390,7 @@ var commandNames = []string{
 	"actions",
 	"add-cloud",
 	"add-credential",
+	"add-group",
 	"add-machine",
 	"add-model",
 	"add-relation",
@@ -397,17 +398,21 @@ var commandNames = []string{
 	"add-ssh-key",
 	"add-storage",
 	"add-subnet",
+	"add-to-group",
+	"add-token",
 	"add-unit",
 	"add-user",
 	"agree",
 	"agreements",
 	"allocate",
 	"attach",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bootstrap",
 	"budgets",
 	"cached-images",
+	"cancel-action",
 	"change-user-password",
 	"charm",
 	"clouds",
@@ -424,6 +429,7 @@ var commandNames = []string{
 	"deploy",
 	"destroy-controller",
 	"destroy-model",
+	"diff-bundle",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
@@ -432,10 +438,13 @@ var commandNames = []string{
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"export-bundle",
 	"expose",
+	"generate-backup-key",
 	"get-constraints",
 	"get-model-constraints",
 	"grant",
+	"groups",
 	"gui",
 	"help",
 	"help-tool",
@@ -450,6 +459,7 @@ var commandNames = []string{
 	"list-controllers",
 	"list-credentials",
 	"list-disabled-commands",
+	"list-groups",
 	"list-machines",
 	"list-models",
 	"list-payloads",
@@ -461,6 +471,7 @@ var commandNames = []string{
 	"list-storage",
 	"list-storage-pools",
 	"list-subnets",
+	"list-tokens",
 	"list-users",
 	"login",
 	"logout",
@@ -480,6 +491,8 @@ var commandNames = []string{
 	"remove-cached-images",
 	"remove-cloud",
 	"remove-credential",
+	"remove-from-group",
+	"remove-group",
 	"remove-machine",
 	"remove-relation",
 	"remove-ssh-key",
@@ -491,6 +504,7 @@ var commandNames = []string{
 	"restore-backup",
 	"retry-provisioning",
 	"revoke",
+	"revoke-token",
 	"run",
 	"run-action",
 	"scp",
@@ -500,6 +514,7 @@ var commandNames = []string{
 	"set-default-region",
 	"set-meter-status",
 	"set-model-constraints",
+	"set-model-quotas",
 	"set-plan",
 	"show-action-output",
 	"show-action-status",
@@ -509,6 +524,7 @@ var commandNames = []string{
 	"show-controller",
 	"show-machine",
 	"show-model",
+	"show-model-status-log",
 	"show-status",
 	"show-status-log",
 	"show-storage",
@@ -522,6 +538,7 @@ var commandNames = []string{
 	"subnets",
 	"switch",
 	"sync-tools",
+	"tokens",
 	"unexpose",
 	"unregister",
 	"update-allocation",
@@ -532,6 +549,7 @@ var commandNames = []string{
 	"upgrade-juju",
 	"upload-backup",
 	"users",
+	"verify-backup",
 	"version",
 	"whoami",
 }
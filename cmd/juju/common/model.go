@@ -11,6 +11,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/status"
 )
 
@@ -28,6 +29,14 @@ type ModelInfo struct {
 	Status         ModelStatus                 `json:"status" yaml:"status"`
 	Users          map[string]ModelUserInfo    `json:"users" yaml:"users"`
 	Machines       map[string]ModelMachineInfo `json:"machines,omitempty" yaml:"machines,omitempty"`
+	Quotas         map[string]ModelQuotaInfo   `json:"quotas,omitempty" yaml:"quotas,omitempty"`
+}
+
+// ModelQuotaInfo contains a quota on the resources of a model, and how
+// much of the resource the model uses if that is known.
+type ModelQuotaInfo struct {
+	Limit string `json:"limit" yaml:"limit"`
+	Used  string `json:"used,omitempty" yaml:"used,omitempty"`
 }
 
 // ModelMachineInfo contains information about a machine in a model.
@@ -96,9 +105,57 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 		ProviderType:   info.ProviderType,
 		Users:          ModelUserInfoFromParams(info.Users, now),
 		Machines:       ModelMachineInfoFromParams(info.Machines),
+		Quotas:         ModelQuotaInfoFromParams(info.Quotas, info.Usage),
 	}, nil
 }
 
+// ModelQuotaInfoFromParams translates the quotas and usage of a model
+// to a map of the names of the limited resources to ModelQuotaInfo.
+// Usage may be nil if it is not known.
+func ModelQuotaInfoFromParams(quotas, usage *params.ModelQuotas) map[string]ModelQuotaInfo {
+	if quotas == nil {
+		return nil
+	}
+	known := usage != nil
+	if !known {
+		usage = &params.ModelQuotas{}
+	}
+	output := make(map[string]ModelQuotaInfo)
+	add := func(name string, limit, used uint64, format func(uint64) string) {
+		if limit == 0 {
+			return
+		}
+		info := ModelQuotaInfo{Limit: format(limit)}
+		if known {
+			info.Used = format(used)
+		}
+		output[name] = info
+	}
+	add(quota.Machines, uint64(quotas.Machines), uint64(usage.Machines), formatCount)
+	add(quota.Units, uint64(quotas.Units), uint64(usage.Units), formatCount)
+	add(quota.Cores, quotas.Cores, usage.Cores, formatCount)
+	add(quota.Memory, quotas.Memory, usage.Memory, formatSize)
+	add(quota.Storage, quotas.Storage, usage.Storage, formatSize)
+	return output
+}
+
+func formatCount(n uint64) string {
+	return fmt.Sprint(n)
+}
+
+// formatSize renders a size in MiB in the largest whole unit.
+func formatSize(size uint64) string {
+	switch {
+	case size == 0:
+		return "0"
+	case size%(1024*1024) == 0:
+		return fmt.Sprintf("%dT", size/(1024*1024))
+	case size%1024 == 0:
+		return fmt.Sprintf("%dG", size/1024)
+	}
+	return fmt.Sprintf("%dM", size)
+}
+
 // ModelMachineInfoFromParams translates []params.ModelMachineInfo to a map of
 // machine ids to ModelMachineInfo.
 func ModelMachineInfoFromParams(machines []params.ModelMachineInfo) map[string]ModelMachineInfo {
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/jujuclient"
@@ -54,6 +55,9 @@ type addModelCommand struct {
 	CredentialName string
 	CloudRegion    string
 	Config         common.ConfigFlag
+	Quotas         string
+
+	quotas *quota.Resources
 }
 
 const addModelHelpDoc = `
@@ -84,6 +88,13 @@ You may also supply model-specific configuration as well as a
 cloud/region to which this model will be deployed. The cloud/region and credentials
 are the ones used to create any future resources within the model.
 
+Controller superusers may limit the resources that the model may use
+with --quotas, given as space separated name=value pairs. The quotas
+are on the number of machines and units, and on the total cores, memory
+and storage size of the model. Models added without --quotas have the
+quotas given by the controller's "default-model-quotas" configuration,
+if any. Quotas may be changed later with "juju set-model-quotas".
+
 If no cloud/region is specified, then the model will be deployed to
 the same cloud/region as the controller model. If a region is specified
 without a cloud qualifier, then it is assumed to be in the same cloud
@@ -99,6 +110,7 @@ Examples:
     juju add-model mymodel aws/us-east-1
     juju add-model mymodel --config my-config.yaml --config image-stream=daily
     juju add-model mymodel --credential credential_name --config authorized-keys="ssh-rsa ..."
+    juju add-model mymodel --quotas "machines=10 cores=40 memory=160G storage=2T"
 `
 
 func (c *addModelCommand) Info() *cmd.Info {
@@ -115,6 +127,7 @@ func (c *addModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.Owner, "owner", "", "The owner of the new model if not the current user")
 	f.StringVar(&c.CredentialName, "credential", "", "Credential used to add the model")
 	f.Var(&c.Config, "config", "Path to YAML model configuration file or individual options (--config config.yaml [--config key=value ...])")
+	f.StringVar(&c.Quotas, "quotas", "", "Quotas on the resources the model may use (e.g. \"machines=10 memory=64G\")")
 }
 
 func (c *addModelCommand) Init(args []string) error {
@@ -135,6 +148,14 @@ func (c *addModelCommand) Init(args []string) error {
 		return errors.Errorf("%q is not a valid user", c.Owner)
 	}
 
+	if c.Quotas != "" {
+		quotas, err := quota.Parse(c.Quotas)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.quotas = &quotas
+	}
+
 	return cmd.CheckEmpty(args)
 }
 
@@ -143,6 +164,7 @@ type AddModelAPI interface {
 		name, owner, cloudName, cloudRegion string,
 		cloudCredential names.CloudCredentialTag,
 		config map[string]interface{},
+		quotas *quota.Resources,
 	) (base.ModelInfo, error)
 }
 
@@ -216,7 +238,7 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 	}
 
 	addModelClient := c.newAddModelAPI(api)
-	model, err := addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	model, err := addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs, c.quotas)
 	if err != nil {
 		if params.IsCodeUnauthorized(err) {
 			common.PermissionsMessage(ctx.Stderr, "add a model")
@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -152,6 +153,9 @@ func (s *AddModelSuite) TestInit(c *gc.C) {
 		}, {
 			args: []string{"new-model", "cloud/region", "extra", "args"},
 			err:  `unrecognized args: \["extra" "args"\]`,
+		}, {
+			args: []string{"new-model", "--quotas", "machines=many"},
+			err:  `machines quota "many" not valid`,
 		},
 	} {
 		c.Logf("test %d", i)
@@ -322,6 +326,16 @@ func (s *AddModelSuite) TestCloudRegionPassedThrough(c *gc.C) {
 	c.Assert(s.fakeAddModelAPI.cloudRegion, gc.Equals, "us-west-1")
 }
 
+func (s *AddModelSuite) TestQuotasPassedThrough(c *gc.C) {
+	_, err := s.run(c, "test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAddModelAPI.quotas, gc.IsNil)
+
+	_, err = s.run(c, "other", "--quotas", "machines=10 memory=64G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAddModelAPI.quotas, jc.DeepEquals, &quota.Resources{Machines: 10, Memory: 64 * 1024})
+}
+
 func (s *AddModelSuite) TestDefaultCloudPassedThrough(c *gc.C) {
 	_, err := s.run(c, "test")
 	c.Assert(err, jc.ErrorIsNil)
@@ -524,6 +538,7 @@ type fakeAddClient struct {
 	cloudRegion     string
 	cloudCredential names.CloudCredentialTag
 	config          map[string]interface{}
+	quotas          *quota.Resources
 	err             error
 	model           base.ModelInfo
 }
@@ -534,7 +549,7 @@ func (*fakeAddClient) Close() error {
 	return nil
 }
 
-func (f *fakeAddClient) CreateModel(name, owner, cloudName, cloudRegion string, cloudCredential names.CloudCredentialTag, config map[string]interface{}) (base.ModelInfo, error) {
+func (f *fakeAddClient) CreateModel(name, owner, cloudName, cloudRegion string, cloudCredential names.CloudCredentialTag, config map[string]interface{}, quotas *quota.Resources) (base.ModelInfo, error) {
 	if f.err != nil {
 		return base.ModelInfo{}, f.err
 	}
@@ -543,6 +558,7 @@ func (f *fakeAddClient) CreateModel(name, owner, cloudName, cloudRegion string,
 	f.cloudName = cloudName
 	f.cloudRegion = cloudRegion
 	f.config = config
+	f.quotas = quotas
 	return f.model, nil
 }
 
@@ -0,0 +1,270 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewAuditLogCommand returns a command to query the controller's
+// audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{})
+}
+
+// auditLogCommand queries the controller's audit log.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	api   AuditLogAPI
+	clock clock.Clock
+	out   cmd.Output
+
+	modelUUID string
+	user      string
+	operation string
+	since     string
+	until     string
+	offset    int
+	limit     int
+
+	query params.AuditLogQuery
+}
+
+// AuditLogAPI defines the API methods that the audit-log command uses.
+type AuditLogAPI interface {
+	Close() error
+	AuditLog(params.AuditLogQuery) ([]params.AuditLogEntry, error)
+}
+
+// defaultAuditLogLimit is the number of entries shown when
+// no limit is given.
+const defaultAuditLogLimit = 100
+
+const auditLogHelpDoc = `
+Displays entries from the controller's audit log, oldest first.
+
+Entries can be filtered by the model they were recorded on, the user
+who triggered them, the operation performed and the time they were
+recorded. The --since and --until options accept either an RFC3339
+timestamp, a date (YYYY-MM-DD) or a duration, such as 24h, which is
+taken as that long ago.
+
+At most --limit entries are shown; use --offset to page through longer
+results.
+
+Only controller superusers may view the audit log.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --since 24h
+    juju audit-log --operation "Application:v4 - Destroy" --since 2017-05-01 --until 2017-05-02
+    juju audit-log --model-uuid 3b2c7c5e-1e8b-4b6e-8d3c-4f2a0b7c9d1e --offset 100 --format yaml
+
+See also:
+    controller-config
+`
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Displays entries from the controller's audit log.",
+		Doc:     strings.TrimSpace(auditLogHelpDoc),
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.modelUUID, "model-uuid", "", "Only show entries recorded on the model with this UUID")
+	f.StringVar(&c.user, "user", "", "Only show entries triggered by this user")
+	f.StringVar(&c.operation, "operation", "", "Only show entries recording this operation")
+	f.StringVar(&c.since, "since", "", "Only show entries recorded at or after this time")
+	f.StringVar(&c.until, "until", "", "Only show entries recorded before this time")
+	f.IntVar(&c.offset, "offset", 0, "Skip this many matching entries")
+	f.IntVar(&c.limit, "limit", defaultAuditLogLimit, "Show at most this many entries")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if err := cmd.CheckEmpty(args); err != nil {
+		return err
+	}
+	if c.modelUUID != "" && !utils.IsValidUUIDString(c.modelUUID) {
+		return errors.NotValidf("model UUID %q", c.modelUUID)
+	}
+	if c.user != "" && !names.IsValidUser(c.user) {
+		return errors.NotValidf("user name %q", c.user)
+	}
+	if c.offset < 0 {
+		return errors.New("--offset must not be negative")
+	}
+	if c.limit <= 0 {
+		return errors.New("--limit must be positive")
+	}
+	if c.clock == nil {
+		c.clock = clock.WallClock
+	}
+	now := c.clock.Now()
+
+	c.query = params.AuditLogQuery{
+		ModelUUID: c.modelUUID,
+		Operation: c.operation,
+		Offset:    c.offset,
+		Limit:     c.limit,
+	}
+	if c.user != "" {
+		c.query.OriginName = names.NewUserTag(c.user).String()
+	}
+	if c.since != "" {
+		since, err := parseAuditLogTime(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.query.After = &since
+	}
+	if c.until != "" {
+		until, err := parseAuditLogTime(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		c.query.Before = &until
+	}
+	if c.query.After != nil && c.query.Before != nil && !c.query.After.Before(*c.query.Before) {
+		return errors.New("--since must be earlier than --until")
+	}
+	return nil
+}
+
+// parseAuditLogTime parses a time given as an RFC3339 timestamp, a
+// date, or a duration before now.
+func parseAuditLogTime(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, errors.Errorf("negative duration %q", value)
+		}
+		return now.Add(-d).UTC(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Time{}, errors.Errorf("expected a timestamp, date or duration, got %q", value)
+}
+
+func (c *auditLogCommand) getAPI() (AuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apicontroller.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	entries, err := client.AuditLog(c.query)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 {
+		ctx.Infof("No matching audit log entries.")
+		return nil
+	}
+	result := make([]auditLogEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = auditLogEntry{
+			Timestamp:         common.FormatTime(&entry.Timestamp, true),
+			ModelUUID:         entry.ModelUUID,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			RemoteAddress:     entry.RemoteAddress,
+			Operation:         entry.Operation,
+			JujuServerVersion: entry.JujuServerVersion,
+			Data:              entry.Data,
+		}
+	}
+	if err := c.out.Write(ctx, result); err != nil {
+		return err
+	}
+	if len(entries) >= c.limit {
+		ctx.Infof("More entries may match; use --offset %d to see them.", c.offset+len(entries))
+	}
+	return nil
+}
+
+// auditLogEntry defines the serialization of an audit log entry
+// for yaml and json output.
+type auditLogEntry struct {
+	Timestamp         string                 `yaml:"timestamp" json:"timestamp"`
+	ModelUUID         string                 `yaml:"model-uuid" json:"model-uuid"`
+	OriginType        string                 `yaml:"origin-type" json:"origin-type"`
+	OriginName        string                 `yaml:"origin-name" json:"origin-name"`
+	RemoteAddress     string                 `yaml:"remote-address" json:"remote-address"`
+	Operation         string                 `yaml:"operation" json:"operation"`
+	JujuServerVersion string                 `yaml:"juju-server-version" json:"juju-server-version"`
+	Data              map[string]interface{} `yaml:"data,omitempty" json:"data,omitempty"`
+}
+
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Model", "Origin", "Address", "Operation")
+	for _, entry := range entries {
+		w.Println(
+			entry.Timestamp,
+			entry.ModelUUID,
+			originName(entry.OriginName),
+			entry.RemoteAddress,
+			entry.Operation,
+		)
+	}
+	w.Flush()
+	return nil
+}
+
+// originName returns the origin in the form users type it,
+// stripping the "user-" prefix from user tags.
+func originName(origin string) string {
+	if tag, err := names.ParseUserTag(origin); err == nil {
+		return tag.Id()
+	}
+	return origin
+}
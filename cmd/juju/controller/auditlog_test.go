@@ -0,0 +1,183 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+var auditLogNow = time.Date(2017, 5, 2, 12, 0, 0, 0, time.UTC)
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.clock = testing.NewClock(auditLogNow)
+	s.api = &fakeAuditLogAPI{
+		entries: []params.AuditLogEntry{{
+			JujuServerVersion: "2.2.0",
+			ModelUUID:         "3b2c7c5e-1e8b-4b6e-8d3c-4f2a0b7c9d1e",
+			Timestamp:         auditLogNow.Add(-time.Hour),
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "API request",
+			OriginName:        "user-bob",
+			Operation:         "Application:v4 - Destroy",
+		}},
+	}
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.clock, s.store)
+	return coretesting.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"foo"},
+		err:  `unrecognized args: \["foo"\]`,
+	}, {
+		args: []string{"--model-uuid", "foo"},
+		err:  `model UUID "foo" not valid`,
+	}, {
+		args: []string{"--user", "not/valid"},
+		err:  `user name "not/valid" not valid`,
+	}, {
+		args: []string{"--offset", "-1"},
+		err:  "--offset must not be negative",
+	}, {
+		args: []string{"--limit", "0"},
+		err:  "--limit must be positive",
+	}, {
+		args: []string{"--since", "yesterday"},
+		err:  `invalid --since value: expected a timestamp, date or duration, got "yesterday"`,
+	}, {
+		args: []string{"--since", "1h", "--until", "2h"},
+		err:  "--since must be earlier than --until",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := controller.NewAuditLogCommandForTest(s.api, s.clock, s.store)
+		err := coretesting.InitCommand(command, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AuditLogSuite) TestQuery(c *gc.C) {
+	_, err := s.run(c,
+		"--model-uuid", "3b2c7c5e-1e8b-4b6e-8d3c-4f2a0b7c9d1e",
+		"--user", "bob",
+		"--operation", "Application:v4 - Destroy",
+		"--since", "24h",
+		"--until", "2017-05-02T10:00:00Z",
+		"--offset", "20",
+		"--limit", "10",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	after := auditLogNow.Add(-24 * time.Hour)
+	before := time.Date(2017, 5, 2, 10, 0, 0, 0, time.UTC)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"AuditLog", []interface{}{params.AuditLogQuery{
+			ModelUUID:  "3b2c7c5e-1e8b-4b6e-8d3c-4f2a0b7c9d1e",
+			OriginName: "user-bob",
+			Operation:  "Application:v4 - Destroy",
+			After:      &after,
+			Before:     &before,
+			Offset:     20,
+			Limit:      10,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestDefaultQuery(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "AuditLog", params.AuditLogQuery{Limit: 100})
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+Time                  Model                                 Origin  Address   Operation
+2017-05-02 11:00:00Z  3b2c7c5e-1e8b-4b6e-8d3c-4f2a0b7c9d1e  bob     10.0.0.1  Application:v4 - Destroy
+`[1:])
+}
+
+func (s *AuditLogSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+- timestamp: 2017-05-02 11:00:00Z
+  model-uuid: 3b2c7c5e-1e8b-4b6e-8d3c-4f2a0b7c9d1e
+  origin-type: API request
+  origin-name: user-bob
+  remote-address: 10.0.0.1
+  operation: Application:v4 - Destroy
+  juju-server-version: 2.2.0
+`[1:])
+}
+
+func (s *AuditLogSuite) TestJSON(c *gc.C) {
+	ctx, err := s.run(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `[{"timestamp":"2017-05-02 11:00:00Z","model-uuid":"3b2c7c5e-1e8b-4b6e-8d3c-4f2a0b7c9d1e","origin-type":"API request","origin-name":"user-bob","remote-address":"10.0.0.1","operation":"Application:v4 - Destroy","juju-server-version":"2.2.0"}]`+"\n")
+}
+
+func (s *AuditLogSuite) TestMoreEntries(c *gc.C) {
+	ctx, err := s.run(c, "--limit", "1", "--offset", "5")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "More entries may match; use --offset 6 to see them.\n")
+}
+
+func (s *AuditLogSuite) TestNoEntries(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "No matching audit log entries.\n")
+}
+
+func (s *AuditLogSuite) TestAPIError(c *gc.C) {
+	s.api.SetErrors(errors.New("permission denied"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeAuditLogAPI struct {
+	testing.Stub
+	entries []params.AuditLogEntry
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeAuditLogAPI) AuditLog(query params.AuditLogQuery) ([]params.AuditLogEntry, error) {
+	f.MethodCall(f, "AuditLog", query)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.entries, nil
+}
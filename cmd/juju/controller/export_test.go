@@ -170,3 +170,11 @@ func FmtModelStatus(data ModelData) string {
 func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, error) {
 	return newData(api, ctrUUID)
 }
+
+// NewAuditLogCommandForTest returns an audit-log command with the API
+// and clock provided as specified.
+func NewAuditLogCommandForTest(api AuditLogAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	c := &auditLogCommand{api: api, clock: clock}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -56,6 +56,14 @@ func NewDumpCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.C
 	return modelcmd.WrapController(cmd)
 }
 
+// NewSetQuotasCommandForTest returns a SetQuotasCommand with the api
+// provided as specified.
+func NewSetQuotasCommandForTest(api SetQuotasAPI, store jujuclient.ClientStore) (cmd.Command, *SetQuotasCommand) {
+	cmd := &setQuotasCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd), &SetQuotasCommand{cmd}
+}
+
 // NewDumpDBCommandForTest returns a DumpDBCommand with the api provided as specified.
 func NewDumpDBCommandForTest(api DumpDBAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &dumpDBCommand{api: api}
@@ -90,6 +98,10 @@ type RevokeCommand struct {
 	*revokeCommand
 }
 
+type SetQuotasCommand struct {
+	*setQuotasCommand
+}
+
 // NewGrantCommandForTest returns a GrantCommand with the api provided as specified.
 func NewGrantCommandForTest(api GrantModelAPI, store jujuclient.ClientStore) (cmd.Command, *GrantCommand) {
 	cmd := &grantCommand{
@@ -107,3 +119,10 @@ func NewRevokeCommandForTest(api RevokeModelAPI, store jujuclient.ClientStore) (
 	cmd.SetClientStore(store)
 	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
 }
+
+// NewExportBundleCommandForTest returns an ExportBundleCommand with the api provided as specified.
+func NewExportBundleCommandForTest(api ExportBundleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportBundleCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
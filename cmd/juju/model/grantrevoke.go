@@ -6,6 +6,7 @@ package model
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -13,14 +14,24 @@ import (
 )
 
 var usageGrantSummary = `
-Grants access level to a Juju user for a model or controller.`[1:]
+Grants access level to a Juju user or group for a model or controller.`[1:]
 
 var usageGrantDetails = `
 By default, the controller is the current controller.
 
+With --group, access is granted to a local group of users instead, and
+applies to all of its members. A user's access is the greater of that
+granted to them and that granted to the groups they are a member of.
+
 Users with read access are limited in what they can do with models:
 ` + "`juju models`, `juju machines`, and `juju status`" + `.
 
+With --app, write access is granted to the named applications in a
+single model instead of to the whole model. Users with read access to
+the model may then change the configuration of those applications, add
+units to them and run actions on them, but not make other changes to
+the model. The user or group must already have access to the model.
+
 Valid access levels for models are:
     read
     write
@@ -48,12 +59,22 @@ Grant user 'maria' 'add-model' access to the controller:
 
     juju grant maria add-model
 
+Grant the local group 'ops' 'admin' access to models 'model1' and 'model2':
+
+    juju grant --group ops admin model1 model2
+
+Grant the local group 'dba' 'write' access to application 'mysql' in
+model 'mymodel':
+
+    juju grant --group dba --app mysql write mymodel
+
 See also: 
     revoke
-    add-user`
+    add-user
+    add-group`
 
 var usageRevokeSummary = `
-Revokes access from a Juju user for a model or controller.`[1:]
+Revokes access from a Juju user or group for a model or controller.`[1:]
 
 var usageRevokeDetails = `
 By default, the controller is the current controller.
@@ -75,20 +96,41 @@ Revoke 'add-model' access from user 'maria' to the controller:
 
     juju revoke maria add-model
 
+Revoke 'admin' access from the local group 'ops' for model 'model1':
+
+    juju revoke --group ops admin model1
+
+Revoke access from user 'sam' for application 'mysql' in model 'mymodel':
+
+    juju revoke --app mysql sam write mymodel
+
 See also: 
-    grant`[1:]
+    grant
+    remove-group`[1:]
 
 type accessCommand struct {
 	modelcmd.ControllerCommandBase
 
-	User       string
-	ModelNames []string
-	Access     string
+	User         string
+	ModelNames   []string
+	Access       string
+	Group        bool
+	Applications []string
+}
+
+// SetFlags implements cmd.Command.
+func (c *accessCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.Group, "group", false, "Change the access of the named local group rather than a user")
+	f.Var(cmd.NewAppendStringsValue(&c.Applications), "app", "Change the access to the named applications rather than the model")
 }
 
 // Init implements cmd.Command.
 func (c *accessCommand) Init(args []string) error {
 	if len(args) < 1 {
+		if c.Group {
+			return errors.New("no group specified")
+		}
 		return errors.New("no user specified")
 	}
 
@@ -103,6 +145,12 @@ func (c *accessCommand) Init(args []string) error {
 	if c.Access == "addmodel" {
 		c.Access = "add-model"
 	}
+	if len(c.Applications) > 0 {
+		if len(c.ModelNames) != 1 {
+			return errors.New("application access requires exactly one model name")
+		}
+		return permission.ValidateApplicationAccess(permission.Access(c.Access))
+	}
 	if len(c.ModelNames) > 0 {
 		if err := permission.ValidateControllerAccess(permission.Access(c.Access)); err == nil {
 			return errors.Errorf("You have specified a controller access permission %q.\n"+
@@ -134,7 +182,7 @@ type grantCommand struct {
 func (c *grantCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "grant",
-		Args:    "<user name> <permission> [<model name> ...]",
+		Args:    "<user name>|--group <group name> [--app <application name> ...] <permission> [<model name> ...]",
 		Purpose: usageGrantSummary,
 		Doc:     usageGrantDetails,
 	}
@@ -155,12 +203,16 @@ func (c *grantCommand) getControllerAPI() (GrantControllerAPI, error) {
 type GrantModelAPI interface {
 	Close() error
 	GrantModel(user, access string, modelUUIDs ...string) error
+	GrantModelToGroup(group, access string, modelUUIDs ...string) error
+	GrantApplication(user, modelUUID string, applications ...string) error
+	GrantApplicationToGroup(group, modelUUID string, applications ...string) error
 }
 
 // GrantControllerAPI defines the API functions used by the grant command.
 type GrantControllerAPI interface {
 	Close() error
 	GrantController(user, access string) error
+	GrantControllerToGroup(group, access string) error
 }
 
 // Run implements cmd.Command.
@@ -178,7 +230,11 @@ func (c *grantCommand) runForController() error {
 	}
 	defer client.Close()
 
-	return block.ProcessBlockedError(client.GrantController(c.User, c.Access), block.BlockChange)
+	grant := client.GrantController
+	if c.Group {
+		grant = client.GrantControllerToGroup
+	}
+	return block.ProcessBlockedError(grant(c.User, c.Access), block.BlockChange)
 }
 
 func (c *grantCommand) runForModel() error {
@@ -192,7 +248,18 @@ func (c *grantCommand) runForModel() error {
 	if err != nil {
 		return err
 	}
-	return block.ProcessBlockedError(client.GrantModel(c.User, c.Access, models...), block.BlockChange)
+	if len(c.Applications) > 0 {
+		grant := client.GrantApplication
+		if c.Group {
+			grant = client.GrantApplicationToGroup
+		}
+		return block.ProcessBlockedError(grant(c.User, models[0], c.Applications...), block.BlockChange)
+	}
+	grant := client.GrantModel
+	if c.Group {
+		grant = client.GrantModelToGroup
+	}
+	return block.ProcessBlockedError(grant(c.User, c.Access, models...), block.BlockChange)
 }
 
 // NewRevokeCommand returns a new revoke command.
@@ -210,7 +277,7 @@ type revokeCommand struct {
 func (c *revokeCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "revoke",
-		Args:    "<user> <permission> [<model name> ...]",
+		Args:    "<user>|--group <group name> [--app <application name> ...] <permission> [<model name> ...]",
 		Purpose: usageRevokeSummary,
 		Doc:     usageRevokeDetails,
 	}
@@ -231,12 +298,16 @@ func (c *revokeCommand) getControllerAPI() (RevokeControllerAPI, error) {
 type RevokeModelAPI interface {
 	Close() error
 	RevokeModel(user, access string, modelUUIDs ...string) error
+	RevokeModelFromGroup(group, access string, modelUUIDs ...string) error
+	RevokeApplication(user, modelUUID string, applications ...string) error
+	RevokeApplicationFromGroup(group, modelUUID string, applications ...string) error
 }
 
 // RevokeControllerAPI defines the API functions used by the revoke command.
 type RevokeControllerAPI interface {
 	Close() error
 	RevokeController(user, access string) error
+	RevokeControllerFromGroup(group, access string) error
 }
 
 // Run implements cmd.Command.
@@ -254,7 +325,11 @@ func (c *revokeCommand) runForController() error {
 	}
 	defer client.Close()
 
-	return block.ProcessBlockedError(client.RevokeController(c.User, c.Access), block.BlockChange)
+	revoke := client.RevokeController
+	if c.Group {
+		revoke = client.RevokeControllerFromGroup
+	}
+	return block.ProcessBlockedError(revoke(c.User, c.Access), block.BlockChange)
 }
 
 func (c *revokeCommand) runForModel() error {
@@ -268,5 +343,16 @@ func (c *revokeCommand) runForModel() error {
 	if err != nil {
 		return err
 	}
-	return block.ProcessBlockedError(client.RevokeModel(c.User, c.Access, models...), block.BlockChange)
+	if len(c.Applications) > 0 {
+		revoke := client.RevokeApplication
+		if c.Group {
+			revoke = client.RevokeApplicationFromGroup
+		}
+		return block.ProcessBlockedError(revoke(c.User, models[0], c.Applications...), block.BlockChange)
+	}
+	revoke := client.RevokeModel
+	if c.Group {
+		revoke = client.RevokeModelFromGroup
+	}
+	return block.ProcessBlockedError(revoke(c.User, c.Access, models...), block.BlockChange)
 }
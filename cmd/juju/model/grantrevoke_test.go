@@ -83,6 +83,41 @@ func (s *grantRevokeSuite) TestAccess(c *gc.C) {
 	c.Assert(s.fake.access, gc.Equals, "write")
 }
 
+func (s *grantRevokeSuite) TestGroup(c *gc.C) {
+	_, err := s.run(c, "--group", "ops", "admin", "foo", "bar")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.group, gc.Equals, "ops")
+	c.Assert(s.fake.user, gc.Equals, "")
+	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, []string{fooModelUUID, barModelUUID})
+	c.Assert(s.fake.access, gc.Equals, "admin")
+}
+
+func (s *grantRevokeSuite) TestApplication(c *gc.C) {
+	_, err := s.run(c, "--app", "mysql", "--app", "pgsql", "sam", "write", "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.user, gc.Equals, "sam")
+	c.Assert(s.fake.applications, jc.DeepEquals, []string{"mysql", "pgsql"})
+	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, []string{fooModelUUID})
+}
+
+func (s *grantRevokeSuite) TestApplicationGroup(c *gc.C) {
+	_, err := s.run(c, "--group", "--app", "mysql", "dba", "write", "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.group, gc.Equals, "dba")
+	c.Assert(s.fake.user, gc.Equals, "")
+	c.Assert(s.fake.applications, jc.DeepEquals, []string{"mysql"})
+	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, []string{fooModelUUID})
+}
+
+func (s *grantRevokeSuite) TestApplicationInit(c *gc.C) {
+	_, err := s.run(c, "--app", "mysql", "sam", "write", "foo", "bar")
+	c.Assert(err, gc.ErrorMatches, "application access requires exactly one model name")
+	_, err = s.run(c, "--app", "mysql", "sam", "write")
+	c.Assert(err, gc.ErrorMatches, "application access requires exactly one model name")
+	_, err = s.run(c, "--app", "mysql", "sam", "admin", "foo")
+	c.Assert(err, gc.ErrorMatches, `"admin" application access not valid`)
+}
+
 func (s *grantRevokeSuite) TestBlockGrant(c *gc.C) {
 	s.fake.err = common.OperationBlockedError("TestBlockGrant")
 	_, err := s.run(c, "sam", "read", "foo")
@@ -116,6 +151,9 @@ func (s *grantSuite) TestInit(c *gc.C) {
 
 	err = testing.InitCommand(wrappedCmd, []string{})
 	c.Assert(err, gc.ErrorMatches, `no user specified`)
+
+	err = testing.InitCommand(wrappedCmd, []string{"--group"})
+	c.Assert(err, gc.ErrorMatches, `no group specified`)
 }
 
 // TestInitGrantAddModel checks that both the documented 'add-model' access and
@@ -191,10 +229,12 @@ func (s *grantSuite) TestControllerAccessForModel(c *gc.C) {
 }
 
 type fakeGrantRevokeAPI struct {
-	err        error
-	user       string
-	access     string
-	modelUUIDs []string
+	err          error
+	user         string
+	group        string
+	access       string
+	modelUUIDs   []string
+	applications []string
 }
 
 func (f *fakeGrantRevokeAPI) Close() error { return nil }
@@ -207,6 +247,36 @@ func (f *fakeGrantRevokeAPI) RevokeModel(user, access string, modelUUIDs ...stri
 	return f.fake(user, access, modelUUIDs...)
 }
 
+func (f *fakeGrantRevokeAPI) GrantModelToGroup(group, access string, modelUUIDs ...string) error {
+	f.group = group
+	return f.fake("", access, modelUUIDs...)
+}
+
+func (f *fakeGrantRevokeAPI) RevokeModelFromGroup(group, access string, modelUUIDs ...string) error {
+	f.group = group
+	return f.fake("", access, modelUUIDs...)
+}
+
+func (f *fakeGrantRevokeAPI) GrantApplication(user, modelUUID string, applications ...string) error {
+	f.applications = applications
+	return f.fake(user, "write", modelUUID)
+}
+
+func (f *fakeGrantRevokeAPI) RevokeApplication(user, modelUUID string, applications ...string) error {
+	f.applications = applications
+	return f.fake(user, "write", modelUUID)
+}
+
+func (f *fakeGrantRevokeAPI) GrantApplicationToGroup(group, modelUUID string, applications ...string) error {
+	f.group = group
+	return f.GrantApplication("", modelUUID, applications...)
+}
+
+func (f *fakeGrantRevokeAPI) RevokeApplicationFromGroup(group, modelUUID string, applications ...string) error {
+	f.group = group
+	return f.RevokeApplication("", modelUUID, applications...)
+}
+
 func (f *fakeGrantRevokeAPI) fake(user, access string, modelUUIDs ...string) error {
 	f.user = user
 	f.access = access
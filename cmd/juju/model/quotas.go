@@ -0,0 +1,117 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/quota"
+)
+
+const setModelQuotasHelpSummary = `
+Sets the quotas on the resources that a model may use.`[1:]
+
+const setModelQuotasHelpDetails = `
+Quotas limit the number of machines and units in a model, and the total
+cores, memory and storage size that it uses. Cores and memory are those
+of the model's top-level machines: their hardware once provisioned, and
+their constraints until then. Memory and storage sizes are in MiB unless
+they have one of the suffixes M, G, T or P.
+
+The given quotas replace all of the model's quotas; resources that are
+not given are no longer limited. Resources that the model already uses
+are not released, but no more will be added while it is over quota.
+
+Only controller superusers may set model quotas. The quotas and usage
+of a model are shown by "juju show-model".
+
+Examples:
+    juju set-model-quotas mymodel machines=10 cores=40 memory=160G
+    juju set-model-quotas mymodel units=50 storage=2T
+    juju set-model-quotas mymodel
+
+See also:
+    add-model
+    show-model`[1:]
+
+// NewSetQuotasCommand returns a command to set the quotas on the
+// resources that a model may use.
+func NewSetQuotasCommand() cmd.Command {
+	return modelcmd.WrapController(&setQuotasCommand{})
+}
+
+// setQuotasCommand sets the quotas on the resources of a model.
+type setQuotasCommand struct {
+	modelcmd.ControllerCommandBase
+	api SetQuotasAPI
+
+	ModelName string
+	Quotas    quota.Resources
+}
+
+// SetQuotasAPI defines the API methods that the set-model-quotas
+// command uses.
+type SetQuotasAPI interface {
+	Close() error
+	SetModelQuotas(modelUUID string, quotas quota.Resources) error
+}
+
+// Info implements Command.Info.
+func (c *setQuotasCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-model-quotas",
+		Args:    "<model name> [<resource>=<quota> ...]",
+		Purpose: setModelQuotasHelpSummary,
+		Doc:     setModelQuotasHelpDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *setQuotasCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no model specified")
+	}
+	c.ModelName = args[0]
+	quotas, err := quota.Parse(strings.Join(args[1:], " "))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.Quotas = quotas
+	return nil
+}
+
+func (c *setQuotasCommand) getAPI() (SetQuotasAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *setQuotasCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	models, err := c.ModelUUIDs([]string{c.ModelName})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := client.SetModelQuotas(models[0], c.Quotas); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "set model quotas")
+		}
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/core/quota"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type SetQuotasCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeSetQuotasAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&SetQuotasCommandSuite{})
+
+func (s *SetQuotasCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SetQuotasCommandSuite) TestInit(c *gc.C) {
+	wrappedCommand, command := model.NewSetQuotasCommandForTest(&s.fake, s.store)
+	err := testing.InitCommand(wrappedCommand, []string{})
+	c.Assert(err, gc.ErrorMatches, "no model specified")
+
+	wrappedCommand, _ = model.NewSetQuotasCommandForTest(&s.fake, s.store)
+	err = testing.InitCommand(wrappedCommand, []string{"mymodel", "planets=2"})
+	c.Assert(err, gc.ErrorMatches, `quota name "planets" not valid`)
+
+	wrappedCommand, command = model.NewSetQuotasCommandForTest(&s.fake, s.store)
+	err = testing.InitCommand(wrappedCommand, []string{"mymodel", "machines=10", "memory=64G"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.ModelName, gc.Equals, "mymodel")
+	c.Assert(command.Quotas, gc.Equals, quota.Resources{Machines: 10, Memory: 64 * 1024})
+}
+
+func (s *SetQuotasCommandSuite) TestSetQuotas(c *gc.C) {
+	command, _ := model.NewSetQuotasCommandForTest(&s.fake, s.store)
+	_, err := testing.RunCommand(c, command, "mymodel", "units=20", "storage=1T")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"SetModelQuotas", []interface{}{testing.ModelTag.Id(), quota.Resources{Units: 20, Storage: 1024 * 1024}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetQuotasCommandSuite) TestSetQuotasUnauthorized(c *gc.C) {
+	s.fake.SetErrors(&params.Error{Message: "permission denied", Code: params.CodeUnauthorized})
+	command, _ := model.NewSetQuotasCommandForTest(&s.fake, s.store)
+	ctx, err := testing.RunCommand(c, command, "mymodel", "machines=5")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(testing.Stderr(ctx), gc.Matches, "(?s).*You do not have permission to set model quotas.*")
+}
+
+func (s *SetQuotasCommandSuite) TestSetQuotasUnknownModel(c *gc.C) {
+	command, _ := model.NewSetQuotasCommandForTest(&s.fake, s.store)
+	_, err := testing.RunCommand(c, command, "othermodel", "machines=5")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type fakeSetQuotasAPI struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeSetQuotasAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeSetQuotasAPI) SetModelQuotas(modelUUID string, quotas quota.Resources) error {
+	f.MethodCall(f, "SetModelQuotas", modelUUID, quotas)
+	return f.NextErr()
+}
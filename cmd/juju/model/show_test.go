@@ -175,6 +175,29 @@ func (s *ShowCommandSuite) TestShowFormatJson(c *gc.C) {
 	c.Assert(testing.Stdout(ctx), jc.JSONEquals, s.expectedOutput)
 }
 
+func (s *ShowCommandSuite) TestShowQuotas(c *gc.C) {
+	s.fake.info.Quotas = &params.ModelQuotas{Machines: 10, Memory: 65536, Storage: 1536}
+	s.fake.info.Usage = &params.ModelQuotas{Machines: 2, Units: 3, Memory: 4096}
+	s.expectedOutput["mymodel"].(attrs)["quotas"] = attrs{
+		"machines": attrs{"limit": "10", "used": "2"},
+		"memory":   attrs{"limit": "64G", "used": "4G"},
+		"storage":  attrs{"limit": "1536M", "used": "0"},
+	}
+	ctx, err := testing.RunCommand(c, s.newShowCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), jc.YAMLEquals, s.expectedOutput)
+}
+
+func (s *ShowCommandSuite) TestShowQuotasWithoutUsage(c *gc.C) {
+	s.fake.info.Quotas = &params.ModelQuotas{Units: 20}
+	s.expectedOutput["mymodel"].(attrs)["quotas"] = attrs{
+		"units": attrs{"limit": "20"},
+	}
+	ctx, err := testing.RunCommand(c, s.newShowCommand(), "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), jc.JSONEquals, s.expectedOutput)
+}
+
 func (s *ShowCommandSuite) TestUnrecognizedArg(c *gc.C) {
 	_, err := testing.RunCommand(c, s.newShowCommand(), "admin", "whoops")
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
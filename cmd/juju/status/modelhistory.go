@@ -0,0 +1,276 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/status"
+)
+
+// defaultModelStatusHistorySize is the number of entries shown when
+// neither a time range nor -n is given.
+const defaultModelStatusHistorySize = 100
+
+// NewModelStatusHistoryCommand returns a command that reports the
+// history of status changes across a whole model.
+func NewModelStatusHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&modelStatusHistoryCommand{clock: clock.WallClock})
+}
+
+type modelStatusHistoryAPI interface {
+	ModelStatusHistory(params.ModelStatusHistoryRequest) ([]params.ModelStatusHistoryEntry, error)
+	Close() error
+}
+
+var newAPIClientForModelStatusHistory = func(c *modelStatusHistoryCommand) (modelStatusHistoryAPI, error) {
+	return c.NewAPIClient()
+}
+
+type modelStatusHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	out                  cmd.Output
+	clock                clock.Clock
+	kinds                []string
+	applications         []string
+	sinceArg             string
+	untilArg             string
+	since                *time.Time
+	until                *time.Time
+	size                 int
+	isoTime              bool
+	includeStatusUpdates bool
+}
+
+var modelStatusHistoryDoc = `
+This command reports the history of status changes of every unit and
+machine in the model, as a single timeline with the oldest changes
+first.
+
+The statuses shown may be limited to those of some kinds with --kind,
+which takes a comma separated list of:
+    juju-unit: statuses of units' juju agents.
+    workload: statuses of units' workloads.
+    unit: both of the above.
+    juju-machine: statuses of machines' juju agents.
+    machine: statuses of machines.
+    juju-container: statuses of containers' juju agents.
+    container: statuses of containers.
+
+With --application, only the statuses of the units of the given
+applications are shown.
+
+--since and --until limit the statuses to those changed in a time
+range. They take a date (YYYY-MM-DD), a time in RFC3339 format, or a
+duration such as 90m or 2h, meaning that long ago. Without a time
+range, or -n, the last 100 statuses are shown. No more than the last
+10000 statuses are ever shown.
+
+The output may be formatted as a table, or as JSON or CSV for use by
+other tools.
+
+Examples:
+    juju show-model-status-log
+    juju show-model-status-log --application mysql,wordpress --since 2h
+    juju show-model-status-log --kind workload --since 2017-03-10 --until 2017-03-11
+    juju show-model-status-log --since 2017-03-10T08:00:00Z --format csv
+
+See also:
+    show-status-log
+    show-status
+`
+
+func (c *modelStatusHistoryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-model-status-log",
+		Purpose: "Output past statuses of all units and machines in the model.",
+		Doc:     modelStatusHistoryDoc,
+	}
+}
+
+func (c *modelStatusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.kinds), "kind", "Only show statuses of these kinds [juju-unit|workload|unit|juju-machine|machine|juju-container|container]")
+	f.Var(cmd.NewAppendStringsValue(&c.applications), "application", "Only show statuses of the units of these applications")
+	f.StringVar(&c.sinceArg, "since", "", "Only show statuses changed at or after this date, time or duration ago")
+	f.StringVar(&c.untilArg, "until", "", "Only show statuses changed before this date, time or duration ago")
+	f.IntVar(&c.size, "n", 0, "Only show the last N statuses")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	f.BoolVar(&c.includeStatusUpdates, "include-status-updates", false, "Include update status hook messages in the returned logs")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"tabular": c.formatTabular,
+		"json":    cmd.FormatJson,
+		"csv":     formatModelStatusHistoryCSV,
+	})
+}
+
+func (c *modelStatusHistoryCommand) Init(args []string) error {
+	if err := cmd.CheckEmpty(args); err != nil {
+		return err
+	}
+	for _, kind := range c.kinds {
+		if !status.HistoryKind(kind).Valid() {
+			return errors.Errorf("unexpected status kind %q", kind)
+		}
+	}
+	for _, application := range c.applications {
+		if !names.IsValidApplication(application) {
+			return errors.Errorf("invalid application name %q", application)
+		}
+	}
+	if c.size < 0 {
+		return errors.Errorf("-n must not be negative")
+	}
+	var err error
+	if c.since, err = c.parseTime(c.sinceArg); err != nil {
+		return errors.Annotate(err, "invalid --since")
+	}
+	if c.until, err = c.parseTime(c.untilArg); err != nil {
+		return errors.Annotate(err, "invalid --until")
+	}
+	if c.since != nil && c.until != nil && !c.until.After(*c.since) {
+		return errors.Errorf("--until must be after --since")
+	}
+	if c.since == nil && c.until == nil && c.size == 0 {
+		c.size = defaultModelStatusHistorySize
+	}
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return nil
+}
+
+// parseTime parses a date, an RFC3339 time, or a duration before now.
+func (c *modelStatusHistoryCommand) parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return &t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		t := c.clock.Now().Add(-d)
+		return &t, nil
+	}
+	return nil, errors.Errorf("expected a date, RFC3339 time or duration, got %q", value)
+}
+
+func (c *modelStatusHistoryCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := newAPIClientForModelStatusHistory(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer apiclient.Close()
+
+	args := params.ModelStatusHistoryRequest{
+		Kinds:        c.kinds,
+		Applications: c.applications,
+		Since:        c.since,
+		Until:        c.until,
+		Size:         c.size,
+	}
+	if !c.includeStatusUpdates {
+		args.Exclude = []string{runningHookMSG}
+	}
+	history, err := apiclient.ModelStatusHistory(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(history) == 0 {
+		ctx.Infof("No status history to display.")
+		return nil
+	}
+	entries := make([]modelStatusHistoryEntry, len(history))
+	for i, h := range history {
+		entries[i] = modelStatusHistoryEntry{
+			Entity:  entityName(h.Tag),
+			Kind:    h.Kind,
+			Status:  h.Status,
+			Message: h.Info,
+		}
+		if h.Since != nil {
+			entries[i].Time = h.Since.UTC()
+		}
+	}
+	return c.out.Write(ctx, entries)
+}
+
+// modelStatusHistoryEntry is the formatted form of a status history
+// entry.
+type modelStatusHistoryEntry struct {
+	Time    time.Time `json:"time"`
+	Entity  string    `json:"entity"`
+	Kind    string    `json:"kind"`
+	Status  string    `json:"status"`
+	Message string    `json:"message"`
+}
+
+// entityName returns the name of the unit or machine with the given
+// tag, as it is shown by status.
+func entityName(tag string) string {
+	t, err := names.ParseTag(tag)
+	if err != nil {
+		return tag
+	}
+	return t.Id()
+}
+
+func (c *modelStatusHistoryCommand) formatTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]modelStatusHistoryEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Time\tEntity\tType\tStatus\tMessage")
+	for _, entry := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			common.FormatTime(&entry.Time, c.isoTime),
+			entry.Entity, entry.Kind, entry.Status, entry.Message,
+		)
+	}
+	return tw.Flush()
+}
+
+func formatModelStatusHistoryCSV(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]modelStatusHistoryEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	w := csv.NewWriter(writer)
+	w.Write([]string{"time", "entity", "kind", "status", "message"})
+	for _, entry := range entries {
+		w.Write([]string{
+			entry.Time.Format(time.RFC3339Nano),
+			entry.Entity, entry.Kind, entry.Status, entry.Message,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
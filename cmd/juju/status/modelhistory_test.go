@@ -0,0 +1,163 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type fakeModelStatusHistoryAPI struct {
+	args    params.ModelStatusHistoryRequest
+	history []params.ModelStatusHistoryEntry
+	err     error
+}
+
+func (a *fakeModelStatusHistoryAPI) ModelStatusHistory(args params.ModelStatusHistoryRequest) ([]params.ModelStatusHistoryEntry, error) {
+	a.args = args
+	return a.history, a.err
+}
+
+func (a *fakeModelStatusHistoryAPI) Close() error {
+	return nil
+}
+
+var modelHistoryNow = time.Date(2017, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func (s *StatusSuite) runModelStatusHistory(c *gc.C, api *fakeModelStatusHistoryAPI, args ...string) (*cmd.Context, error) {
+	s.PatchValue(&newAPIClientForModelStatusHistory, func(*modelStatusHistoryCommand) (modelStatusHistoryAPI, error) {
+		return api, nil
+	})
+	command := modelcmd.Wrap(&modelStatusHistoryCommand{
+		clock: testing.NewClock(modelHistoryNow),
+	})
+	return coretesting.RunCommand(c, command, args...)
+}
+
+func modelHistoryFixture() []params.ModelStatusHistoryEntry {
+	at := func(minutes int) *time.Time {
+		t := modelHistoryNow.Add(time.Duration(minutes-60) * time.Minute)
+		return &t
+	}
+	return []params.ModelStatusHistoryEntry{{
+		Tag:    "machine-0",
+		Kind:   "juju-machine",
+		Status: "started",
+		Since:  at(0),
+	}, {
+		Tag:    "unit-mysql-0",
+		Kind:   "workload",
+		Status: "blocked",
+		Info:   "need a relation, with wordpress",
+		Since:  at(5),
+	}}
+}
+
+func (s *StatusSuite) TestModelStatusHistory(c *gc.C) {
+	api := &fakeModelStatusHistoryAPI{history: modelHistoryFixture()}
+	ctx, err := s.runModelStatusHistory(c, api, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(api.args, jc.DeepEquals, params.ModelStatusHistoryRequest{
+		Size:    100,
+		Exclude: []string{"running update-status hook"},
+	})
+	c.Check(coretesting.Stdout(ctx), gc.Equals, ""+
+		"Time                  Entity   Type          Status   Message\n"+
+		"2017-03-10 11:00:00Z  0        juju-machine  started  \n"+
+		"2017-03-10 11:05:00Z  mysql/0  workload      blocked  need a relation, with wordpress\n")
+}
+
+func (s *StatusSuite) TestModelStatusHistoryFilters(c *gc.C) {
+	api := &fakeModelStatusHistoryAPI{}
+	ctx, err := s.runModelStatusHistory(c, api,
+		"--kind", "workload,juju-unit",
+		"--application", "mysql",
+		"--since", "2h",
+		"--until", "2017-03-10T11:30:00Z",
+		"--include-status-updates",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	since := modelHistoryNow.Add(-2 * time.Hour)
+	until := time.Date(2017, 3, 10, 11, 30, 0, 0, time.UTC)
+	c.Check(api.args, jc.DeepEquals, params.ModelStatusHistoryRequest{
+		Kinds:        []string{"workload", "juju-unit"},
+		Applications: []string{"mysql"},
+		Since:        &since,
+		Until:        &until,
+	})
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "No status history to display.\n")
+}
+
+func (s *StatusSuite) TestModelStatusHistoryDate(c *gc.C) {
+	api := &fakeModelStatusHistoryAPI{}
+	_, err := s.runModelStatusHistory(c, api, "--since", "2017-03-09", "-n", "10")
+	c.Assert(err, jc.ErrorIsNil)
+	since := time.Date(2017, 3, 9, 0, 0, 0, 0, time.Local)
+	c.Check(api.args.Since, jc.DeepEquals, &since)
+	c.Check(api.args.Size, gc.Equals, 10)
+}
+
+func (s *StatusSuite) TestModelStatusHistoryCSV(c *gc.C) {
+	api := &fakeModelStatusHistoryAPI{history: modelHistoryFixture()}
+	ctx, err := s.runModelStatusHistory(c, api, "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, ""+
+		"time,entity,kind,status,message\n"+
+		"2017-03-10T11:00:00Z,0,juju-machine,started,\n"+
+		"2017-03-10T11:05:00Z,mysql/0,workload,blocked,\"need a relation, with wordpress\"\n")
+}
+
+func (s *StatusSuite) TestModelStatusHistoryJSON(c *gc.C) {
+	api := &fakeModelStatusHistoryAPI{history: modelHistoryFixture()}
+	ctx, err := s.runModelStatusHistory(c, api, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, `[`+
+		`{"time":"2017-03-10T11:00:00Z","entity":"0","kind":"juju-machine","status":"started","message":""},`+
+		`{"time":"2017-03-10T11:05:00Z","entity":"mysql/0","kind":"workload","status":"blocked","message":"need a relation, with wordpress"}`+
+		"]\n")
+}
+
+func (s *StatusSuite) TestModelStatusHistoryError(c *gc.C) {
+	api := &fakeModelStatusHistoryAPI{err: errors.New("boom")}
+	_, err := s.runModelStatusHistory(c, api)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *StatusSuite) TestModelStatusHistoryInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"mysql/0"},
+		err:  `unrecognized args: \["mysql/0"\]`,
+	}, {
+		args: []string{"--kind", "bad"},
+		err:  `unexpected status kind "bad"`,
+	}, {
+		args: []string{"--application", "Bad"},
+		err:  `invalid application name "Bad"`,
+	}, {
+		args: []string{"-n", "-1"},
+		err:  `-n must not be negative`,
+	}, {
+		args: []string{"--since", "yesterday"},
+		err:  `invalid --since: expected a date, RFC3339 time or duration, got "yesterday"`,
+	}, {
+		args: []string{"--since", "1h", "--until", "2h"},
+		err:  `--until must be after --since`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runModelStatusHistory(c, &fakeModelStatusHistoryAPI{}, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -8,11 +8,13 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -23,21 +25,25 @@ var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
 	Status(patterns []string) (*params.FullStatus, error)
+	FilteredStatus(patterns, filters []string) (*params.FullStatus, error)
 	Close() error
 }
 
 // NewStatusCommand returns a new command, which reports on the
 // runtime state of various system entities.
 func NewStatusCommand() cmd.Command {
-	return modelcmd.Wrap(&statusCommand{})
+	return modelcmd.Wrap(&statusCommand{clock: clock.WallClock})
 }
 
 type statusCommand struct {
 	modelcmd.ModelCommandBase
 	out      cmd.Output
 	patterns []string
+	filters  []string
 	isoTime  bool
 	api      statusAPI
+	watch    bool
+	clock    clock.Clock
 
 	color bool
 }
@@ -55,6 +61,22 @@ machines, applications, and units will also be displayed. If a subordinate unit
 is matched, then its principal unit will be displayed. If a principal unit is
 matched, then all of its subordinates will be displayed.
 
+Units, machines and applications may also be filtered by their status or
+charm with --filter. A filter is made of terms separated by commas, each
+of the form key=value or key!=value, all of which must be satisfied. The
+value may list alternatives separated by '|', and '*' may be used as a
+wildcard. The keys are:
+
+- workload: the unit's workload status
+- agent: the unit's agent status
+- machine: the status of the machine, or of the unit's machine
+- application: the name of the unit's application
+- charm: the name or URL of the application's charm
+
+--filter may be given more than once, to show entities matching any of
+the filters. As with patterns, machines and applications are displayed
+along with any matched units.
+
 The available output formats are:
 
 - tabular (default): Displays status in a tabular format with a separate table
@@ -73,10 +95,20 @@ The available output formats are:
 - json: Displays information about the model, machines, applications, and units
       in structured JSON format.
 
+With --watch, the tabular status is redrawn whenever the model changes,
+highlighting the rows which have changed, until interrupted. The controller
+notifies the client of changes to the model, and the full status is then
+fetched again and redrawn; changes made within two seconds of each other
+are drawn together, with a single fetch.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
+    juju show-status --filter 'workload=blocked|error'
+    juju show-status --filter agent=lost --filter 'machine!=started'
+    juju show-status --filter charm=mysql
+    juju show-status --watch
 
 See also:
     machines
@@ -99,6 +131,8 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.BoolVar(&c.watch, "watch", false, "Redraw the status whenever the model changes")
+	f.Var((*filterValue)(&c.filters), "filter", "Only show entities matching this status filter")
 
 	defaultFormat := "tabular"
 
@@ -115,6 +149,9 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.watch && c.out.Name() != "tabular" {
+		return errors.New("--watch is only supported with the tabular format")
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -140,24 +177,54 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
-	status, err := apiclient.Status(c.patterns)
+	if c.watch {
+		return c.watchStatus(ctx, apiclient)
+	}
+	formatted, err := c.getStatus(ctx, apiclient)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// getStatus returns the formatted status of the model.
+func (c *statusCommand) getStatus(ctx *cmd.Context, apiclient statusAPI) (formattedStatus, error) {
+	var status *params.FullStatus
+	var err error
+	if len(c.filters) > 0 {
+		status, err = apiclient.FilteredStatus(c.patterns, c.filters)
+	} else {
+		status, err = apiclient.Status(c.patterns)
+	}
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
-			return err
+			return formattedStatus{}, err
 		}
 		// Display any error, but continue to print status if some was returned
 		fmt.Fprintf(ctx.Stderr, "%v\n", err)
 	} else if status == nil {
-		return errors.Errorf("unable to obtain the current status")
+		return formattedStatus{}, errors.Errorf("unable to obtain the current status")
 	}
 
 	formatter := newStatusFormatter(status, c.ControllerName(), c.isoTime)
-	formatted, err := formatter.format()
-	if err != nil {
-		return err
-	}
-	return c.out.Write(ctx, formatted)
+	return formatter.format()
+}
+
+// filterValue is a flag value which collects each --filter given.
+// Unlike cmd.AppendStringsValue, it does not split values on commas,
+// which separate the terms of a single filter.
+type filterValue []string
+
+// Set implements gnuflag.Value.
+func (v *filterValue) Set(s string) error {
+	*v = append(*v, s)
+	return nil
+}
+
+// String implements gnuflag.Value.
+func (v *filterValue) String() string {
+	return strings.Join(*v, " ")
 }
 
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
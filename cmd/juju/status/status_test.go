@@ -3723,6 +3723,7 @@ func (s *StatusSuite) setupMigrationTest(c *gc.C) *state.State {
 type fakeAPIClient struct {
 	statusReturn *params.FullStatus
 	patternsUsed []string
+	filtersUsed  []string
 	closeCalled  bool
 }
 
@@ -3731,6 +3732,12 @@ func (a *fakeAPIClient) Status(patterns []string) (*params.FullStatus, error) {
 	return a.statusReturn, nil
 }
 
+func (a *fakeAPIClient) FilteredStatus(patterns, filters []string) (*params.FullStatus, error) {
+	a.patternsUsed = patterns
+	a.filtersUsed = filters
+	return a.statusReturn, nil
+}
+
 func (a *fakeAPIClient) Close() error {
 	a.closeCalled = true
 	return nil
@@ -4099,6 +4106,35 @@ func (s *StatusSuite) TestStatusWithNilStatusAPI(c *gc.C) {
 	c.Check(string(stderr), gc.Equals, "error: unable to obtain the current status\n")
 }
 
+func (s *StatusSuite) TestStatusFilters(c *gc.C) {
+	client := fakeAPIClient{
+		statusReturn: &params.FullStatus{
+			Model: params.ModelStatusInfo{
+				Name:     "controller",
+				CloudTag: "cloud-dummy",
+				Version:  "2.2.0",
+			},
+		},
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+
+	code, _, stderr := runStatus(c,
+		"--format", "yaml",
+		"--filter", "workload=blocked|error,agent=idle",
+		"--filter", "charm=mysql",
+		"mysql",
+	)
+	c.Check(code, gc.Equals, 0)
+	c.Check(string(stderr), gc.Equals, "")
+	c.Check(client.patternsUsed, jc.DeepEquals, []string{"mysql"})
+	c.Check(client.filtersUsed, jc.DeepEquals, []string{
+		"workload=blocked|error,agent=idle",
+		"charm=mysql",
+	})
+}
+
 func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 	status := formattedStatus{
 		Applications: map[string]applicationStatus{
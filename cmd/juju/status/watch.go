@@ -0,0 +1,176 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+const (
+	// clearScreen moves the cursor to the top left of the terminal
+	// and clears it, so that each redraw replaces the last.
+	clearScreen = "\x1b[H\x1b[2J"
+
+	// highlightOn and highlightOff surround rows which have changed
+	// since the last redraw.
+	highlightOn  = "\x1b[7m"
+	highlightOff = "\x1b[0m"
+)
+
+// watchRedrawInterval is the shortest time between redraws. Changes
+// made in quick succession, as when deploying a bundle, are drawn
+// together, with a single fetch of the status.
+var watchRedrawInterval = 2 * time.Second
+
+// allWatcher is the part of api.AllWatcher used to watch the model.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+var newAllWatcherForStatus = func(apiclient statusAPI) (allWatcher, error) {
+	client, ok := apiclient.(*api.Client)
+	if !ok {
+		return nil, errors.NotSupportedf("watching status with %T", apiclient)
+	}
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
+
+// watchStatus draws the status, then redraws it whenever the model changes,
+// until the watcher fails. The deltas pushed by the controller only tell
+// it when to redraw: each redraw fetches the full status again, at most
+// once per redraw interval, however many changes are pushed meanwhile.
+func (c *statusCommand) watchStatus(ctx *cmd.Context, apiclient statusAPI) error {
+	watcher, err := newAllWatcherForStatus(apiclient)
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+	defer watcher.Stop()
+
+	// The watcher is read continuously, so that changes pushed while
+	// the status is being fetched or drawn are collected for the next
+	// redraw rather than each causing one of their own.
+	changes := make(chan []multiwatcher.Delta)
+	failed := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			if err != nil {
+				failed <- err
+				return
+			}
+			select {
+			case changes <- deltas:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var previous []string
+	var watchErr error
+	for {
+		formatted, err := c.getStatus(ctx, apiclient)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var buf bytes.Buffer
+		if err := c.FormatTabular(&buf, formatted); err != nil {
+			return errors.Trace(err)
+		}
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		fmt.Fprint(ctx.Stdout, clearScreen)
+		writeHighlighted(ctx.Stdout, lines, previous)
+		previous = lines
+
+		if watchErr != nil {
+			return errors.Annotate(watchErr, "watching model")
+		}
+		changed, err := c.waitForChanges(changes, failed)
+		if !changed {
+			return errors.Annotate(err, "watching model")
+		}
+		// Draw the changes made before the watcher failed.
+		watchErr = err
+	}
+}
+
+// waitForChanges waits until the model changes in a way which affects
+// the status, then for the redraw interval, collecting all the deltas
+// received meanwhile. It reports whether the status should be redrawn,
+// along with any error from the watcher.
+func (c *statusCommand) waitForChanges(changes <-chan []multiwatcher.Delta, failed <-chan error) (bool, error) {
+	var redraw <-chan time.Time
+	for {
+		select {
+		case deltas := <-changes:
+			if redraw == nil && changesStatus(deltas) {
+				redraw = c.clock.After(watchRedrawInterval)
+			}
+		case err := <-failed:
+			return redraw != nil, err
+		case <-redraw:
+			return true, nil
+		}
+	}
+}
+
+// unshownKinds holds the kinds of entity whose changes do not affect
+// the tabular status.
+var unshownKinds = set.NewStrings("action", "annotation", "block")
+
+// changesStatus reports whether any of the deltas affects the status.
+func changesStatus(deltas []multiwatcher.Delta) bool {
+	for _, delta := range deltas {
+		if !unshownKinds.Contains(delta.Entity.EntityId().Kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// ansiCodes matches the escape codes used to color tabular output.
+var ansiCodes = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// rowKey returns a row as it is compared between redraws: without
+// color, and ignoring changes in column widths.
+func rowKey(line string) string {
+	return strings.Join(strings.Fields(ansiCodes.ReplaceAllString(line, "")), " ")
+}
+
+// writeHighlighted writes the lines to w, highlighting those which do
+// not appear in previous. Nothing is highlighted when there is nothing
+// to compare with.
+func writeHighlighted(w io.Writer, lines, previous []string) {
+	seen := set.NewStrings()
+	for _, line := range previous {
+		seen.Add(rowKey(line))
+	}
+	for _, line := range lines {
+		key := rowKey(line)
+		if previous == nil || key == "" || seen.Contains(key) {
+			fmt.Fprintln(w, line)
+			continue
+		}
+		fmt.Fprintln(w, highlightOn+ansiCodes.ReplaceAllString(line, "")+highlightOff)
+	}
+}
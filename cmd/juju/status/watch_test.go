@@ -0,0 +1,224 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type fakeWatchAPIClient struct {
+	statuses []*params.FullStatus
+	calls    int
+}
+
+func (a *fakeWatchAPIClient) Status(patterns []string) (*params.FullStatus, error) {
+	status := a.statuses[a.calls]
+	a.calls++
+	return status, nil
+}
+
+func (a *fakeWatchAPIClient) FilteredStatus(patterns, filters []string) (*params.FullStatus, error) {
+	return a.Status(patterns)
+}
+
+func (a *fakeWatchAPIClient) Close() error {
+	return nil
+}
+
+type fakeAllWatcher struct {
+	changes [][]multiwatcher.Delta
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.changes) == 0 {
+		return nil, errors.New("watcher was stopped")
+	}
+	deltas := w.changes[0]
+	w.changes = w.changes[1:]
+	return deltas, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
+
+var (
+	machineDeltas = []multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{ModelUUID: "uuid", Id: "0"},
+	}}
+	annotationDeltas = []multiwatcher.Delta{{
+		Entity: &multiwatcher.AnnotationInfo{ModelUUID: "uuid", Tag: "machine-0"},
+	}}
+)
+
+func machineFullStatus(machineStatus string) *params.FullStatus {
+	return &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Name:     "controller",
+			CloudTag: "cloud-dummy",
+			Version:  "2.2.0",
+		},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id:          "0",
+				AgentStatus: params.DetailedStatus{Status: machineStatus},
+				InstanceId:  "controller-0",
+				Series:      "xenial",
+			},
+			"1": {
+				Id:          "1",
+				AgentStatus: params.DetailedStatus{Status: "started"},
+				InstanceId:  "controller-1",
+				Series:      "xenial",
+			},
+		},
+	}
+}
+
+func (s *StatusSuite) TestWatch(c *gc.C) {
+	client := &fakeWatchAPIClient{
+		statuses: []*params.FullStatus{
+			machineFullStatus("pending"),
+			machineFullStatus("started"),
+		},
+	}
+	watcher := &fakeAllWatcher{changes: [][]multiwatcher.Delta{machineDeltas}}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	s.PatchValue(&newAllWatcherForStatus, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+	s.PatchValue(&watchRedrawInterval, time.Duration(0))
+
+	code, stdout, stderr := runStatus(c, "--watch")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, "error: watching model: watcher was stopped\n")
+	c.Check(client.calls, gc.Equals, 2)
+	c.Check(watcher.stopped, jc.IsTrue)
+
+	draws := strings.Split(string(stdout), clearScreen)
+	c.Assert(draws, gc.HasLen, 3)
+	c.Check(draws[0], gc.Equals, "")
+
+	// Nothing is highlighted when first drawn.
+	c.Check(draws[1], gc.Not(jc.Contains), highlightOn)
+	c.Check(draws[1], gc.Matches, `(?s).*\n0 +pending +controller-0 +xenial.*`)
+
+	// Only the changed machine is highlighted when redrawn.
+	highlighted := regexp.MustCompile(regexp.QuoteMeta(highlightOn) + "(.*)" + regexp.QuoteMeta(highlightOff))
+	matches := highlighted.FindAllStringSubmatch(draws[2], -1)
+	c.Assert(matches, gc.HasLen, 1)
+	c.Check(matches[0][1], gc.Matches, `0 +started +controller-0 +xenial.*`)
+}
+
+func (s *StatusSuite) TestWatchIgnoresUnshownChanges(c *gc.C) {
+	client := &fakeWatchAPIClient{
+		statuses: []*params.FullStatus{machineFullStatus("pending")},
+	}
+	watcher := &fakeAllWatcher{changes: [][]multiwatcher.Delta{
+		annotationDeltas,
+		annotationDeltas,
+	}}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	s.PatchValue(&newAllWatcherForStatus, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+	s.PatchValue(&watchRedrawInterval, time.Duration(0))
+
+	code, stdout, _ := runStatus(c, "--watch")
+	c.Check(code, gc.Equals, 1)
+	c.Check(client.calls, gc.Equals, 1)
+	c.Check(strings.Count(string(stdout), clearScreen), gc.Equals, 1)
+}
+
+func (s *StatusSuite) TestWaitForChangesCollectsDeltas(c *gc.C) {
+	clock := testing.NewClock(time.Time{})
+	command := &statusCommand{clock: clock}
+	changes := make(chan []multiwatcher.Delta)
+	failed := make(chan error, 1)
+	type result struct {
+		changed bool
+		err     error
+	}
+	results := make(chan result)
+	go func() {
+		changed, err := command.waitForChanges(changes, failed)
+		results <- result{changed, err}
+	}()
+
+	// The first change shown starts the redraw interval, and those
+	// which follow are drawn with it.
+	changes <- annotationDeltas
+	changes <- machineDeltas
+	changes <- machineDeltas
+	changes <- annotationDeltas
+	err := clock.WaitAdvance(watchRedrawInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case r := <-results:
+		c.Check(r.changed, jc.IsTrue)
+		c.Check(r.err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for changes")
+	}
+}
+
+func (s *StatusSuite) TestWaitForChangesWatcherError(c *gc.C) {
+	clock := testing.NewClock(time.Time{})
+	command := &statusCommand{clock: clock}
+	changes := make(chan []multiwatcher.Delta)
+	failed := make(chan error, 1)
+	failed <- errors.New("boom")
+	changed, err := command.waitForChanges(changes, failed)
+	c.Check(changed, jc.IsFalse)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *StatusSuite) TestWatchNotTabular(c *gc.C) {
+	code, _, stderr := runStatus(c, "--watch", "--format", "yaml")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "error: --watch is only supported with the tabular format\n")
+}
+
+func (s *StatusSuite) TestWriteHighlighted(c *gc.C) {
+	previous := []string{
+		"Unit      Workload  Agent  Message",
+		"mysql/0   active    idle   ready",
+		"",
+	}
+	lines := []string{
+		"Unit       Workload     Agent  Message",
+		"mysql/0    active       idle   ready",
+		"mysql/1    maintenance  idle   installing",
+		"",
+	}
+	var buf bytes.Buffer
+	writeHighlighted(&buf, lines, previous)
+	c.Check(buf.String(), gc.Equals, ""+
+		"Unit       Workload     Agent  Message\n"+
+		"mysql/0    active       idle   ready\n"+
+		highlightOn+"mysql/1    maintenance  idle   installing"+highlightOff+"\n"+
+		"\n")
+
+	buf.Reset()
+	writeHighlighted(&buf, lines, nil)
+	c.Check(buf.String(), gc.Equals, strings.Join(lines, "\n")+"\n")
+}
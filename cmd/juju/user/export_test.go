@@ -124,3 +124,75 @@ func NewWhoAmICommandForTest(store jujuclient.ClientStore) cmd.Command {
 	c := &whoAmICommand{store: store}
 	return c
 }
+
+type GroupMembersCommand struct {
+	*groupMembersCommand
+}
+
+// NewAddGroupCommandForTest returns an add-group command with the api
+// provided as specified.
+func NewAddGroupCommandForTest(api GroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addGroupCommand{groupCommandBase: groupCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRemoveGroupCommandForTest returns a remove-group command with the
+// api provided as specified.
+func NewRemoveGroupCommandForTest(api GroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeGroupCommand{groupCommandBase: groupCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewListGroupsCommandForTest returns a groups command with the api
+// provided as specified.
+func NewListGroupsCommandForTest(api GroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &listGroupsCommand{groupCommandBase: groupCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewAddToGroupCommandForTest returns an add-to-group command with the
+// api provided as specified.
+func NewAddToGroupCommandForTest(api GroupAPI, store jujuclient.ClientStore) (cmd.Command, *GroupMembersCommand) {
+	c := &addToGroupCommand{groupMembersCommand{groupCommandBase: groupCommandBase{api: api}}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c), &GroupMembersCommand{&c.groupMembersCommand}
+}
+
+// NewRemoveFromGroupCommandForTest returns a remove-from-group command
+// with the api provided as specified.
+func NewRemoveFromGroupCommandForTest(api GroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeFromGroupCommand{groupMembersCommand{groupCommandBase: groupCommandBase{api: api}}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+type AddTokenCommand struct {
+	*addTokenCommand
+}
+
+// NewAddTokenCommandForTest returns an add-token command with the api
+// and clock provided as specified.
+func NewAddTokenCommandForTest(api TokenAPI, store jujuclient.ClientStore, clock clock.Clock) (cmd.Command, *AddTokenCommand) {
+	c := &addTokenCommand{tokenCommandBase: tokenCommandBase{api: api}, clock: clock}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c), &AddTokenCommand{c}
+}
+
+// NewListTokensCommandForTest returns a tokens command with the api
+// provided as specified.
+func NewListTokensCommandForTest(api TokenAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &listTokensCommand{tokenCommandBase: tokenCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRevokeTokenCommandForTest returns a revoke-token command with the
+// api provided as specified.
+func NewRevokeTokenCommandForTest(api TokenAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &revokeTokenCommand{tokenCommandBase: tokenCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -0,0 +1,399 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var usageAddGroupSummary = `
+Adds local groups of users to a controller.`[1:]
+
+var usageAddGroupDetails = `
+Groups hold local users, and may be granted access to models and the
+controller in the same way as users. Each member of a group has the
+access granted to the group, in addition to their own.
+
+By default, the controller is the current controller.
+
+Examples:
+    juju add-group ops
+    juju add-group ops dev
+
+See also:
+    remove-group
+    groups
+    add-to-group
+    grant`[1:]
+
+var usageRemoveGroupSummary = `
+Removes local groups of users from a controller.`[1:]
+
+var usageRemoveGroupDetails = `
+Removing a group also removes all the access granted to it. The users
+in the group are not removed.
+
+By default, the controller is the current controller.
+
+Examples:
+    juju remove-group ops
+
+See also:
+    add-group
+    groups
+    revoke`[1:]
+
+var usageListGroupsSummary = `
+Lists the local groups of users in a controller.`[1:]
+
+var usageListGroupsDetails = `
+By default, the controller is the current controller.
+
+Examples:
+    juju groups
+    juju groups --format yaml
+
+See also:
+    add-group
+    remove-group
+    add-to-group
+    remove-from-group`[1:]
+
+var usageAddToGroupSummary = `
+Adds local users to a group.`[1:]
+
+var usageAddToGroupDetails = `
+Users who are already members of the group are ignored.
+
+By default, the controller is the current controller.
+
+Examples:
+    juju add-to-group ops bob
+    juju add-to-group ops bob mary
+
+See also:
+    remove-from-group
+    groups
+    add-user`[1:]
+
+var usageRemoveFromGroupSummary = `
+Removes users from a group.`[1:]
+
+var usageRemoveFromGroupDetails = `
+Users who are not members of the group are ignored.
+
+By default, the controller is the current controller.
+
+Examples:
+    juju remove-from-group ops bob
+
+See also:
+    add-to-group
+    groups`[1:]
+
+// GroupAPI defines the usermanager API methods that the group commands
+// use.
+type GroupAPI interface {
+	AddGroups(names ...string) error
+	RemoveGroups(names ...string) error
+	GroupInfo(names []string) ([]params.UserGroup, error)
+	AddGroupMembers(group string, usernames ...string) error
+	RemoveGroupMembers(group string, usernames ...string) error
+	Close() error
+}
+
+// groupCommandBase provides common attributes and methods that the group
+// commands need.
+type groupCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api GroupAPI
+}
+
+func (c *groupCommandBase) getAPI() (GroupAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewUserManagerAPIClient()
+}
+
+// NewAddGroupCommand returns a command to add local groups of users.
+func NewAddGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&addGroupCommand{})
+}
+
+// addGroupCommand adds local groups of users to a controller.
+type addGroupCommand struct {
+	groupCommandBase
+	Names []string
+}
+
+// Info implements Command.Info.
+func (c *addGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-group",
+		Args:    "<group name> ...",
+		Purpose: usageAddGroupSummary,
+		Doc:     usageAddGroupDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *addGroupCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name specified")
+	}
+	c.Names = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *addGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.AddGroups(c.Names...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	for _, name := range c.Names {
+		ctx.Infof("Group %q added", name)
+	}
+	return nil
+}
+
+// NewRemoveGroupCommand returns a command to remove local groups of users.
+func NewRemoveGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&removeGroupCommand{})
+}
+
+// removeGroupCommand removes local groups of users from a controller.
+type removeGroupCommand struct {
+	groupCommandBase
+	Names []string
+}
+
+// Info implements Command.Info.
+func (c *removeGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-group",
+		Args:    "<group name> ...",
+		Purpose: usageRemoveGroupSummary,
+		Doc:     usageRemoveGroupDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeGroupCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name specified")
+	}
+	c.Names = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.RemoveGroups(c.Names...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	for _, name := range c.Names {
+		ctx.Infof("Group %q removed", name)
+	}
+	return nil
+}
+
+// NewListGroupsCommand returns a command to list local groups of users.
+func NewListGroupsCommand() cmd.Command {
+	return modelcmd.WrapController(&listGroupsCommand{})
+}
+
+// listGroupsCommand lists the local groups of users in a controller.
+type listGroupsCommand struct {
+	groupCommandBase
+	out cmd.Output
+}
+
+// GroupInfo defines the serialization behaviour of group information.
+type GroupInfo struct {
+	Name        string   `yaml:"name" json:"name"`
+	Members     []string `yaml:"members" json:"members"`
+	CreatedBy   string   `yaml:"created-by" json:"created-by"`
+	DateCreated string   `yaml:"date-created" json:"date-created"`
+}
+
+// Info implements Command.Info.
+func (c *listGroupsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "groups",
+		Purpose: usageListGroupsSummary,
+		Doc:     usageListGroupsDetails,
+		Aliases: []string{"list-groups"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listGroupsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.groupCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatGroupsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listGroupsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *listGroupsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	result, err := api.GroupInfo(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result) == 0 {
+		ctx.Infof("No groups to display.")
+		return nil
+	}
+	groups := make([]GroupInfo, len(result))
+	for i, group := range result {
+		members := group.Members
+		if members == nil {
+			members = []string{}
+		}
+		groups[i] = GroupInfo{
+			Name:        group.Name,
+			Members:     members,
+			CreatedBy:   group.CreatedBy,
+			DateCreated: group.DateCreated.Format("2006-01-02"),
+		}
+	}
+	return c.out.Write(ctx, groups)
+}
+
+func formatGroupsTabular(writer io.Writer, value interface{}) error {
+	groups, ok := value.([]GroupInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", groups, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Name", "Members", "Created by", "Date created")
+	for _, group := range groups {
+		w.Println(group.Name, strings.Join(group.Members, ","), group.CreatedBy, group.DateCreated)
+	}
+	tw.Flush()
+	return nil
+}
+
+// groupMembersCommand provides the common behaviour of the commands that
+// change the members of a group.
+type groupMembersCommand struct {
+	groupCommandBase
+	Group     string
+	Usernames []string
+}
+
+// Init implements Command.Init.
+func (c *groupMembersCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name specified")
+	}
+	if len(args) == 1 {
+		return errors.New("no users specified")
+	}
+	c.Group = args[0]
+	c.Usernames = args[1:]
+	return nil
+}
+
+// NewAddToGroupCommand returns a command to add users to a group.
+func NewAddToGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&addToGroupCommand{})
+}
+
+// addToGroupCommand adds local users to a group.
+type addToGroupCommand struct {
+	groupMembersCommand
+}
+
+// Info implements Command.Info.
+func (c *addToGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-to-group",
+		Args:    "<group name> <user name> ...",
+		Purpose: usageAddToGroupSummary,
+		Doc:     usageAddToGroupDetails,
+	}
+}
+
+// Run implements Command.Run.
+func (c *addToGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	err = api.AddGroupMembers(c.Group, c.Usernames...)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// NewRemoveFromGroupCommand returns a command to remove users from a
+// group.
+func NewRemoveFromGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&removeFromGroupCommand{})
+}
+
+// removeFromGroupCommand removes users from a group.
+type removeFromGroupCommand struct {
+	groupMembersCommand
+}
+
+// Info implements Command.Info.
+func (c *removeFromGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-from-group",
+		Args:    "<group name> <user name> ...",
+		Purpose: usageRemoveFromGroupSummary,
+		Doc:     usageRemoveFromGroupDetails,
+	}
+}
+
+// Run implements Command.Run.
+func (c *removeFromGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	err = api.RemoveGroupMembers(c.Group, c.Usernames...)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,164 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/testing"
+)
+
+type GroupCommandSuite struct {
+	BaseSuite
+	mockAPI *mockGroupAPI
+}
+
+var _ = gc.Suite(&GroupCommandSuite{})
+
+func (s *GroupCommandSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mockAPI = &mockGroupAPI{}
+}
+
+func (s *GroupCommandSuite) TestAddGroup(c *gc.C) {
+	ctx, err := testing.RunCommand(c, user.NewAddGroupCommandForTest(s.mockAPI, s.store), "ops", "dev")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Group \"ops\" added\nGroup \"dev\" added\n")
+	s.mockAPI.CheckCalls(c, []jujutesting.StubCall{
+		{"AddGroups", []interface{}{[]string{"ops", "dev"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *GroupCommandSuite) TestAddGroupNoName(c *gc.C) {
+	_, err := testing.RunCommand(c, user.NewAddGroupCommandForTest(s.mockAPI, s.store))
+	c.Assert(err, gc.ErrorMatches, "no group name specified")
+}
+
+func (s *GroupCommandSuite) TestRemoveGroup(c *gc.C) {
+	ctx, err := testing.RunCommand(c, user.NewRemoveGroupCommandForTest(s.mockAPI, s.store), "ops")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Group \"ops\" removed\n")
+	s.mockAPI.CheckCalls(c, []jujutesting.StubCall{
+		{"RemoveGroups", []interface{}{[]string{"ops"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *GroupCommandSuite) TestListGroups(c *gc.C) {
+	s.mockAPI.groups = []params.UserGroup{{
+		Name:        "dev",
+		CreatedBy:   "admin",
+		DateCreated: time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		Name:        "ops",
+		Members:     []string{"bob", "mary"},
+		CreatedBy:   "admin",
+		DateCreated: time.Date(2017, 3, 2, 0, 0, 0, 0, time.UTC),
+	}}
+	ctx, err := testing.RunCommand(c, user.NewListGroupsCommandForTest(s.mockAPI, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"Name  Members   Created by  Date created\n"+
+		"dev             admin       2017-03-01\n"+
+		"ops   bob,mary  admin       2017-03-02\n"+
+		"\n")
+}
+
+func (s *GroupCommandSuite) TestListGroupsNone(c *gc.C) {
+	ctx, err := testing.RunCommand(c, user.NewListGroupsCommandForTest(s.mockAPI, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No groups to display.\n")
+}
+
+func (s *GroupCommandSuite) TestListGroupsYAML(c *gc.C) {
+	s.mockAPI.groups = []params.UserGroup{{
+		Name:        "ops",
+		Members:     []string{"bob"},
+		CreatedBy:   "admin",
+		DateCreated: time.Date(2017, 3, 2, 0, 0, 0, 0, time.UTC),
+	}}
+	ctx, err := testing.RunCommand(c, user.NewListGroupsCommandForTest(s.mockAPI, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- name: ops
+  members:
+  - bob
+  created-by: admin
+  date-created: "2017-03-02"
+`[1:])
+}
+
+func (s *GroupCommandSuite) TestAddToGroupInit(c *gc.C) {
+	wrappedCommand, command := user.NewAddToGroupCommandForTest(s.mockAPI, s.store)
+	err := testing.InitCommand(wrappedCommand, []string{})
+	c.Assert(err, gc.ErrorMatches, "no group name specified")
+	err = testing.InitCommand(wrappedCommand, []string{"ops"})
+	c.Assert(err, gc.ErrorMatches, "no users specified")
+	err = testing.InitCommand(wrappedCommand, []string{"ops", "bob", "mary"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.Group, gc.Equals, "ops")
+	c.Assert(command.Usernames, jc.DeepEquals, []string{"bob", "mary"})
+}
+
+func (s *GroupCommandSuite) TestAddToGroup(c *gc.C) {
+	command, _ := user.NewAddToGroupCommandForTest(s.mockAPI, s.store)
+	_, err := testing.RunCommand(c, command, "ops", "bob", "mary")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []jujutesting.StubCall{
+		{"AddGroupMembers", []interface{}{"ops", []string{"bob", "mary"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *GroupCommandSuite) TestRemoveFromGroup(c *gc.C) {
+	_, err := testing.RunCommand(c, user.NewRemoveFromGroupCommandForTest(s.mockAPI, s.store), "ops", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []jujutesting.StubCall{
+		{"RemoveGroupMembers", []interface{}{"ops", []string{"bob"}}},
+		{"Close", nil},
+	})
+}
+
+type mockGroupAPI struct {
+	jujutesting.Stub
+	groups []params.UserGroup
+}
+
+func (m *mockGroupAPI) AddGroups(names ...string) error {
+	m.MethodCall(m, "AddGroups", names)
+	return m.NextErr()
+}
+
+func (m *mockGroupAPI) RemoveGroups(names ...string) error {
+	m.MethodCall(m, "RemoveGroups", names)
+	return m.NextErr()
+}
+
+func (m *mockGroupAPI) GroupInfo(names []string) ([]params.UserGroup, error) {
+	m.MethodCall(m, "GroupInfo", names)
+	return m.groups, m.NextErr()
+}
+
+func (m *mockGroupAPI) AddGroupMembers(group string, usernames ...string) error {
+	m.MethodCall(m, "AddGroupMembers", group, usernames)
+	return m.NextErr()
+}
+
+func (m *mockGroupAPI) RemoveGroupMembers(group string, usernames ...string) error {
+	m.MethodCall(m, "RemoveGroupMembers", group, usernames)
+	return m.NextErr()
+}
+
+func (m *mockGroupAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
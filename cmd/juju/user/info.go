@@ -24,6 +24,11 @@ var helpDetails = `
 By default, the YAML format is used and the user name is the current
 user.
 
+If the controller's login-max-failed-attempts is set, users who are
+locked out after too many failed logins are shown with the time until
+which they are locked out. A controller administrator can lift the
+lockout with "juju enable-user".
+
 
 Examples:
     juju show-user
@@ -76,6 +81,7 @@ type UserInfo struct {
 	Access         string `yaml:"access" json:"access"`
 	DateCreated    string `yaml:"date-created,omitempty" json:"date-created,omitempty"`
 	LastConnection string `yaml:"last-connection,omitempty" json:"last-connection,omitempty"`
+	LockedUntil    string `yaml:"locked-until,omitempty" json:"locked-until,omitempty"`
 	Disabled       bool   `yaml:"disabled,omitempty" json:"disabled,omitempty"`
 }
 
@@ -155,6 +161,10 @@ func (c *infoCommandBase) apiUsersToUserInfoSlice(users []params.UserInfo) []Use
 			} else {
 				outInfo.DateCreated = common.UserFriendlyDuration(info.DateCreated, now)
 			}
+			// Users are locked out after too many failed logins.
+			if info.LockedUntil != nil {
+				outInfo.LockedUntil = info.LockedUntil.String()
+			}
 		}
 		output = append(output, outInfo)
 	}
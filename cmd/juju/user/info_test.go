@@ -32,6 +32,7 @@ var (
 	// Mock out timestamps
 	dateCreated    = time.Unix(352138205, 0).UTC()
 	lastConnection = time.Unix(1388534400, 0).UTC()
+	lockedUntil    = time.Unix(1388538000, 0).UTC()
 )
 
 func (s *UserInfoCommandSuite) NewShowUserCommand() cmd.Command {
@@ -58,6 +59,10 @@ func (*fakeUserInfoAPI) UserInfo(usernames []string, all usermanager.IncludeDisa
 		info.Username = "foobar"
 		info.DisplayName = "Foo Bar"
 		info.Access = "login"
+	case "locked":
+		info.Username = "locked"
+		info.Access = "login"
+		info.LockedUntil = &lockedUntil
 	case "fred@external":
 		info.Username = "fred@external"
 		info.DisplayName = "Fred External"
@@ -99,6 +104,17 @@ last-connection: 2014-01-01
 `)
 }
 
+func (s *UserInfoCommandSuite) TestUserInfoLockedOut(c *gc.C) {
+	context, err := testing.RunCommand(c, s.NewShowUserCommand(), "locked")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, `user-name: locked
+access: login
+date-created: 1981-02-27
+last-connection: 2014-01-01
+locked-until: 2014-01-01 01:00:00 +0000 UTC
+`)
+}
+
 func (s *UserInfoCommandSuite) TestUserInfoExternalUser(c *gc.C) {
 	context, err := testing.RunCommand(c, s.NewShowUserCommand(), "fred@external")
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,321 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var usageAddTokenSummary = `
+Creates an API token for the current user.`[1:]
+
+var usageAddTokenDetails = `
+An API token is a named, revocable credential for automation. It can be
+used to log in as the current user instead of a password, but it only
+grants access to the models it names, and at most the access given with
+--access. A token grants login access to the controller, so it cannot be
+used to manage users or the controller.
+
+The token is written to standard output. It is not stored by the
+controller, so it cannot be shown again; keep it somewhere safe. If it
+is lost, revoke it and create another.
+
+A token never expires unless --expires is given.
+
+By default, the controller is the current controller.
+
+Examples:
+    juju add-token ci default
+    juju add-token --access read --expires 720h monitoring default staging
+
+See also:
+    tokens
+    revoke-token`[1:]
+
+var usageListTokensSummary = `
+Lists the API tokens of the current user.`[1:]
+
+var usageListTokensDetails = `
+The tokens themselves are never shown; only their names, scope and expiry.
+
+By default, the controller is the current controller.
+
+Examples:
+    juju tokens
+    juju tokens --format yaml
+
+See also:
+    add-token
+    revoke-token`[1:]
+
+var usageRevokeTokenSummary = `
+Revokes API tokens of the current user.`[1:]
+
+var usageRevokeTokenDetails = `
+Revoked tokens can no longer be used to log in. Connections already made
+with a token are not affected.
+
+By default, the controller is the current controller.
+
+Examples:
+    juju revoke-token ci
+    juju revoke-token ci monitoring
+
+See also:
+    add-token
+    tokens`[1:]
+
+// TokenAPI defines the usermanager API methods that the token commands
+// use.
+type TokenAPI interface {
+	AddToken(name, access string, modelUUIDs []string, expires time.Time) (string, error)
+	ListTokens() ([]params.APIToken, error)
+	RevokeTokens(names ...string) error
+	Close() error
+}
+
+// tokenCommandBase provides common attributes and methods that the token
+// commands need.
+type tokenCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api TokenAPI
+}
+
+func (c *tokenCommandBase) getAPI() (TokenAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewUserManagerAPIClient()
+}
+
+// NewAddTokenCommand returns a command to create API tokens.
+func NewAddTokenCommand() cmd.Command {
+	return modelcmd.WrapController(&addTokenCommand{clock: clock.WallClock})
+}
+
+// addTokenCommand creates an API token for the current user.
+type addTokenCommand struct {
+	tokenCommandBase
+	clock clock.Clock
+
+	Name       string
+	ModelNames []string
+	Access     string
+	Expires    time.Duration
+}
+
+// Info implements Command.Info.
+func (c *addTokenCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-token",
+		Args:    "<token name> [<model name> ...]",
+		Purpose: usageAddTokenSummary,
+		Doc:     usageAddTokenDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addTokenCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.tokenCommandBase.SetFlags(f)
+	f.StringVar(&c.Access, "access", "read", "The most access the token grants to its models")
+	f.DurationVar(&c.Expires, "expires", 0, "How long until the token expires")
+}
+
+// Init implements Command.Init.
+func (c *addTokenCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no token name specified")
+	}
+	if c.Expires < 0 {
+		return errors.New("expiry must not be negative")
+	}
+	c.Name = args[0]
+	c.ModelNames = args[1:]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *addTokenCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	models, err := c.ModelUUIDs(c.ModelNames)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var expires time.Time
+	if c.Expires > 0 {
+		expires = c.clock.Now().Add(c.Expires)
+	}
+	token, err := api.AddToken(c.Name, c.Access, models, expires)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	fmt.Fprintln(ctx.Stdout, token)
+	ctx.Infof("Token %q added; it cannot be shown again", c.Name)
+	return nil
+}
+
+// NewListTokensCommand returns a command to list API tokens.
+func NewListTokensCommand() cmd.Command {
+	return modelcmd.WrapController(&listTokensCommand{})
+}
+
+// listTokensCommand lists the API tokens of the current user.
+type listTokensCommand struct {
+	tokenCommandBase
+	out cmd.Output
+}
+
+// TokenInfo defines the serialization behaviour of API token
+// information.
+type TokenInfo struct {
+	Name        string   `yaml:"name" json:"name"`
+	Access      string   `yaml:"access" json:"access"`
+	Models      []string `yaml:"models" json:"models"`
+	Expires     string   `yaml:"expires,omitempty" json:"expires,omitempty"`
+	DateCreated string   `yaml:"date-created" json:"date-created"`
+}
+
+// Info implements Command.Info.
+func (c *listTokensCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "tokens",
+		Purpose: usageListTokensSummary,
+		Doc:     usageListTokensDetails,
+		Aliases: []string{"list-tokens"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listTokensCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.tokenCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatTokensTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listTokensCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *listTokensCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	result, err := api.ListTokens()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result) == 0 {
+		ctx.Infof("No tokens to display.")
+		return nil
+	}
+	tokens := make([]TokenInfo, len(result))
+	for i, token := range result {
+		models := token.Models
+		if models == nil {
+			models = []string{}
+		}
+		tokens[i] = TokenInfo{
+			Name:        token.Name,
+			Access:      token.Access,
+			Models:      models,
+			DateCreated: token.DateCreated.Format("2006-01-02"),
+		}
+		if token.Expires != nil {
+			tokens[i].Expires = token.Expires.Format(time.RFC3339)
+		}
+	}
+	return c.out.Write(ctx, tokens)
+}
+
+func formatTokensTabular(writer io.Writer, value interface{}) error {
+	tokens, ok := value.([]TokenInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", tokens, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Name", "Access", "Models", "Expires", "Date created")
+	for _, token := range tokens {
+		expires := token.Expires
+		if expires == "" {
+			expires = "never"
+		}
+		w.Println(token.Name, token.Access, strings.Join(token.Models, ","), expires, token.DateCreated)
+	}
+	tw.Flush()
+	return nil
+}
+
+// NewRevokeTokenCommand returns a command to revoke API tokens.
+func NewRevokeTokenCommand() cmd.Command {
+	return modelcmd.WrapController(&revokeTokenCommand{})
+}
+
+// revokeTokenCommand revokes API tokens of the current user.
+type revokeTokenCommand struct {
+	tokenCommandBase
+	Names []string
+}
+
+// Info implements Command.Info.
+func (c *revokeTokenCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "revoke-token",
+		Args:    "<token name> ...",
+		Purpose: usageRevokeTokenSummary,
+		Doc:     usageRevokeTokenDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *revokeTokenCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no token name specified")
+	}
+	c.Names = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *revokeTokenCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.RevokeTokens(c.Names...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	for _, name := range c.Names {
+		ctx.Infof("Token %q revoked", name)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+const tokenModelUUID = "0701e916-3274-46e4-bd12-c31aff89cee3"
+
+type TokenCommandSuite struct {
+	BaseSuite
+	mockAPI *mockTokenAPI
+	clock   *fakeClock
+}
+
+var _ = gc.Suite(&TokenCommandSuite{})
+
+func (s *TokenCommandSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mockAPI = &mockTokenAPI{token: "juju-token:ci:secret"}
+	s.clock = &fakeClock{now: time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC)}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"current-user/default": {tokenModelUUID},
+		},
+	}
+}
+
+func (s *TokenCommandSuite) TestAddTokenInit(c *gc.C) {
+	wrappedCommand, _ := user.NewAddTokenCommandForTest(s.mockAPI, s.store, s.clock)
+	err := testing.InitCommand(wrappedCommand, []string{})
+	c.Assert(err, gc.ErrorMatches, "no token name specified")
+
+	wrappedCommand, _ = user.NewAddTokenCommandForTest(s.mockAPI, s.store, s.clock)
+	err = testing.InitCommand(wrappedCommand, []string{"--expires", "-1h", "ci"})
+	c.Assert(err, gc.ErrorMatches, "expiry must not be negative")
+
+	wrappedCommand, command := user.NewAddTokenCommandForTest(s.mockAPI, s.store, s.clock)
+	err = testing.InitCommand(wrappedCommand, []string{"ci", "default", "staging"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.Name, gc.Equals, "ci")
+	c.Assert(command.ModelNames, jc.DeepEquals, []string{"default", "staging"})
+	c.Assert(command.Access, gc.Equals, "read")
+	c.Assert(command.Expires, gc.Equals, time.Duration(0))
+}
+
+func (s *TokenCommandSuite) TestAddToken(c *gc.C) {
+	command, _ := user.NewAddTokenCommandForTest(s.mockAPI, s.store, s.clock)
+	ctx, err := testing.RunCommand(c, command, "--access", "write", "--expires", "24h", "ci", "default")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "juju-token:ci:secret\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Token \"ci\" added; it cannot be shown again\n")
+	s.mockAPI.CheckCalls(c, []jujutesting.StubCall{
+		{"AddToken", []interface{}{"ci", "write", []string{tokenModelUUID}, s.clock.Now().Add(24 * time.Hour)}},
+		{"Close", nil},
+	})
+}
+
+func (s *TokenCommandSuite) TestAddTokenNeverExpires(c *gc.C) {
+	command, _ := user.NewAddTokenCommandForTest(s.mockAPI, s.store, s.clock)
+	_, err := testing.RunCommand(c, command, "ci")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []jujutesting.StubCall{
+		{"AddToken", []interface{}{"ci", "read", []string(nil), time.Time{}}},
+		{"Close", nil},
+	})
+}
+
+func (s *TokenCommandSuite) TestListTokens(c *gc.C) {
+	expires := time.Date(2017, 4, 1, 0, 0, 0, 0, time.UTC)
+	s.mockAPI.tokens = []params.APIToken{{
+		Name:        "ci",
+		Owner:       "current-user",
+		Access:      "write",
+		Models:      []string{"current-user/default", "current-user/staging"},
+		Expires:     &expires,
+		DateCreated: time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		Name:        "monitoring",
+		Owner:       "current-user",
+		Access:      "read",
+		DateCreated: time.Date(2017, 3, 2, 0, 0, 0, 0, time.UTC),
+	}}
+	ctx, err := testing.RunCommand(c, user.NewListTokensCommandForTest(s.mockAPI, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"Name        Access  Models                                     Expires               Date created\n"+
+		"ci          write   current-user/default,current-user/staging  2017-04-01T00:00:00Z  2017-03-01\n"+
+		"monitoring  read                                               never                 2017-03-02\n"+
+		"\n")
+}
+
+func (s *TokenCommandSuite) TestListTokensNone(c *gc.C) {
+	ctx, err := testing.RunCommand(c, user.NewListTokensCommandForTest(s.mockAPI, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No tokens to display.\n")
+}
+
+func (s *TokenCommandSuite) TestListTokensYAML(c *gc.C) {
+	s.mockAPI.tokens = []params.APIToken{{
+		Name:        "monitoring",
+		Owner:       "current-user",
+		Access:      "read",
+		Models:      []string{"current-user/default"},
+		DateCreated: time.Date(2017, 3, 2, 0, 0, 0, 0, time.UTC),
+	}}
+	ctx, err := testing.RunCommand(c, user.NewListTokensCommandForTest(s.mockAPI, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- name: monitoring
+  access: read
+  models:
+  - current-user/default
+  date-created: "2017-03-02"
+`[1:])
+}
+
+func (s *TokenCommandSuite) TestRevokeToken(c *gc.C) {
+	ctx, err := testing.RunCommand(c, user.NewRevokeTokenCommandForTest(s.mockAPI, s.store), "ci", "monitoring")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Token \"ci\" revoked\nToken \"monitoring\" revoked\n")
+	s.mockAPI.CheckCalls(c, []jujutesting.StubCall{
+		{"RevokeTokens", []interface{}{[]string{"ci", "monitoring"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *TokenCommandSuite) TestRevokeTokenNoName(c *gc.C) {
+	_, err := testing.RunCommand(c, user.NewRevokeTokenCommandForTest(s.mockAPI, s.store))
+	c.Assert(err, gc.ErrorMatches, "no token name specified")
+}
+
+type mockTokenAPI struct {
+	jujutesting.Stub
+	token  string
+	tokens []params.APIToken
+}
+
+func (m *mockTokenAPI) AddToken(name, access string, modelUUIDs []string, expires time.Time) (string, error) {
+	m.MethodCall(m, "AddToken", name, access, modelUUIDs, expires)
+	return m.token, m.NextErr()
+}
+
+func (m *mockTokenAPI) ListTokens() ([]params.APIToken, error) {
+	m.MethodCall(m, "ListTokens")
+	return m.tokens, m.NextErr()
+}
+
+func (m *mockTokenAPI) RevokeTokens(names ...string) error {
+	m.MethodCall(m, "RevokeTokens", names)
+	return m.NextErr()
+}
+
+func (m *mockTokenAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
@@ -37,6 +37,7 @@ var (
 		"spaces-imported-gate",
 	}
 	aliveModelWorkers = []string{
+		"action-pruner",
 		"charm-revision-updater",
 		"compute-provisioner",
 		"environ-tracker",
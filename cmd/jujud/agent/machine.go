@@ -60,17 +60,20 @@ import (
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/cron"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/instance"
 	jujunames "github.com/juju/juju/juju/names"
 	"github.com/juju/juju/juju/paths"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/mongo/txnmetrics"
 	"github.com/juju/juju/pubsub/centralhub"
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/state/statemetrics"
@@ -80,6 +83,7 @@ import (
 	"github.com/juju/juju/watcher"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/apicaller"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dblogpruner"
@@ -1083,6 +1087,10 @@ func (a *MachineAgent) startStateWorkers(
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour*2, clock.WallClock), nil
 			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "backupscheduler", func() (worker.Worker, error) {
+				return a.newBackupSchedulerWorker(st, agentConfig)
+			})
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
@@ -1090,6 +1098,37 @@ func (a *MachineAgent) startStateWorkers(
 	return runner, nil
 }
 
+// newBackupSchedulerWorker returns a worker which backs up the
+// controller on the schedule given in the controller config. If no
+// schedule is configured, the worker does nothing.
+func (a *MachineAgent) newBackupSchedulerWorker(st *state.State, agentConfig agent.Config) (worker.Worker, error) {
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read controller config")
+	}
+	spec := controllerConfig.BackupSchedule()
+	if spec == "" {
+		return jworker.NewNoOpWorker(), nil
+	}
+	schedule, err := cron.Parse(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backupPaths := backups.Paths{
+		DataDir: agentConfig.DataDir(),
+		LogsDir: agentConfig.LogDir(),
+	}
+	return backupscheduler.New(backupscheduler.Config{
+		Backend:  backupscheduler.NewStateBackend(st, a.machineId, backupPaths),
+		Clock:    clock.WallClock,
+		Schedule: schedule,
+		Retention: backupscheduler.RetentionPolicy{
+			KeepLast:      controllerConfig.BackupKeepLast(),
+			KeepDailyDays: controllerConfig.BackupKeepDailyDays(),
+		},
+	})
+}
+
 // startModelWorkers starts the set of workers that run for every model
 // in each controller.
 func (a *MachineAgent) startModelWorkers(controllerUUID, modelUUID string) (worker.Worker, error) {
@@ -1122,6 +1161,7 @@ func (a *MachineAgent) startModelWorkers(controllerUUID, modelUUID string) (work
 		StatusHistoryPrunerMaxHistoryTime: 336 * time.Hour, // 2 weeks
 		StatusHistoryPrunerMaxHistoryMB:   5120,            // 5G
 		StatusHistoryPrunerInterval:       5 * time.Minute,
+		ActionPrunerInterval:              5 * time.Minute,
 		SpacesImportedGate:                a.discoverSpacesComplete,
 		NewEnvironFunc:                    newEnvirons,
 		NewMigrationMaster:                migrationmaster.NewWorker,
@@ -1198,16 +1238,29 @@ func (a *MachineAgent) newAPIserverWorker(
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
 
+	auditEntrySink, auditWorkers, err := newAuditEntrySink(st, logDir, controllerConfig)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create audit entry sink")
+	}
+	stopAuditWorkers := func() {
+		for _, w := range auditWorkers {
+			if err := worker.Stop(w); err != nil {
+				logger.Errorf("stopping audit entry sink: %v", err)
+			}
+		}
+	}
+
 	newObserver, err := newObserverFn(
 		controllerConfig,
 		clock.WallClock,
 		jujuversion.Current,
 		agentConfig.Model().Id(),
-		newAuditEntrySink(st, logDir),
+		auditEntrySink,
 		auditErrorHandler,
 		a.prometheusRegistry,
 	)
 	if err != nil {
+		stopAuditWorkers()
 		return nil, errors.Annotate(err, "cannot create RPC observer factory")
 	}
 	statePool := state.NewStatePool(st)
@@ -1240,15 +1293,67 @@ func (a *MachineAgent) newAPIserverWorker(
 		RegisterIntrospectionHandlers: registerIntrospectionHandlers,
 	})
 	if err != nil {
+		stopAuditWorkers()
 		return nil, errors.Annotate(err, "cannot start api server worker")
 	}
+	go func() {
+		// The audit entry sinks are only used by the API server.
+		server.Wait()
+		stopAuditWorkers()
+	}()
 
 	return server, nil
 }
 
-func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
-	persistFn := st.PutAuditEntryFn()
-	fileSinkFn := audit.NewLogFileSink(logDir)
+// newAuditEntrySink returns the audit entry sink configured for the
+// controller, along with any workers used by the sink, which must be
+// stopped when it is no longer used.
+func newAuditEntrySink(st *state.State, logDir string, controllerConfig controller.Config) (audit.AuditEntrySinkFn, []worker.Worker, error) {
+	var sinks []audit.NamedSink
+	var workers []worker.Worker
+	configured := set.NewStrings(controllerConfig.AuditLogSinks()...)
+	for _, name := range controllerConfig.AuditLogSinks() {
+		var sink audit.AuditEntrySinkFn
+		switch name {
+		case controller.AuditLogSinkFile:
+			sink = audit.NewLogFileSink(logDir)
+		case controller.AuditLogSinkDatabase:
+			sink = st.PutAuditEntryFn()
+		case controller.AuditLogSinkSyslog:
+			syslogConfig := controllerConfig.AuditSyslogConfig()
+			// Entries which cannot be queued for the syslog host are
+			// kept in the audit log file. If that sink is configured,
+			// they are written there already.
+			var overflow audit.AuditEntrySinkFn
+			if !configured.Contains(controller.AuditLogSinkFile) {
+				overflow = audit.NewLogFileSink(logDir)
+			}
+			syslogSink := audit.NewSyslogSink(audit.SyslogSinkConfig{
+				ControllerUUID: controllerConfig.ControllerUUID(),
+				OpenClient: func() (audit.SyslogClient, error) {
+					client, err := syslog.Open(syslogConfig)
+					if err != nil {
+						return nil, errors.Trace(err)
+					}
+					return client, nil
+				},
+				Clock:        clock.WallClock,
+				BufferSize:   audit.DefaultSyslogBufferSize,
+				RetryDelay:   audit.DefaultSyslogRetryDelay,
+				QueueTimeout: audit.DefaultSyslogQueueTimeout,
+				Overflow:     overflow,
+			})
+			workers = append(workers, syslogSink)
+			sink = syslogSink.Handle
+		default:
+			for _, w := range workers {
+				worker.Stop(w)
+			}
+			return nil, nil, errors.NotValidf("audit log sink %q", name)
+		}
+		sinks = append(sinks, audit.NamedSink{Name: name, Sink: sink})
+	}
+	multiSink := audit.NewMultiSink(sinks...)
 	return func(entry audit.AuditEntry) error {
 		// We don't care about auditing anything but user actions.
 		if _, err := names.ParseUserTag(entry.OriginName); err != nil {
@@ -1258,16 +1363,8 @@ func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 		if strings.HasPrefix(entry.Operation, "Pinger:") {
 			return nil
 		}
-		persistErr := persistFn(entry)
-		sinkErr := fileSinkFn(entry)
-		if persistErr == nil {
-			return errors.Annotate(sinkErr, "cannot save audit record to file")
-		}
-		if sinkErr == nil {
-			return errors.Annotate(persistErr, "cannot save audit record to database")
-		}
-		return errors.Annotate(persistErr, "cannot save audit record to file or database")
-	}
+		return multiSink(entry)
+	}, workers, nil
 }
 
 func newObserverFn(
@@ -1299,6 +1396,7 @@ func newObserverFn(
 			ctx := &observer.AuditContext{
 				JujuServerVersion: jujuServerVersion,
 				ModelUUID:         modelUUID,
+				CaptureArgs:       controllerConfig.AuditLogCaptureArgs(),
 			}
 			return observer.NewAudit(ctx, persistAuditEntry, auditErrorHandler)
 		})
@@ -481,7 +481,16 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
+				Config: sinks.SyslogConfig,
 				OpenFn: sinks.OpenSyslog,
+			}, {
+				Name:   "juju-log-forward-elasticsearch",
+				Config: sinks.ElasticsearchConfig,
+				OpenFn: sinks.OpenElasticsearch,
+			}, {
+				Name:   "juju-log-forward-gelf",
+				Config: sinks.GELFConfig,
+				OpenFn: sinks.OpenGELF,
 			}},
 		})),
 	}
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/feature"
 	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionpruner"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
@@ -88,6 +89,10 @@ type ManifoldsConfig struct {
 	StatusHistoryPrunerMaxHistoryMB   uint
 	StatusHistoryPrunerInterval       time.Duration
 
+	// ActionPrunerInterval determines how often the action-pruner
+	// worker removes old action results.
+	ActionPrunerInterval time.Duration
+
 	// SpacesImportedGate will be unlocked when spaces are known to
 	// have been imported.
 	SpacesImportedGate gate.Lock
@@ -296,6 +301,11 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			// TODO(fwereade): 2016-03-17 lp:1558657
 			NewTimer: jworker.NewTimer,
 		})),
+		actionPrunerName: ifNotMigrating(actionpruner.Manifold(actionpruner.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			PruneInterval: config.ActionPrunerInterval,
+		})),
 		machineUndertakerName: ifNotMigrating(machineundertaker.Manifold(machineundertaker.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
@@ -403,6 +413,7 @@ const (
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
+	actionPrunerName         = "action-pruner"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 )
@@ -29,6 +29,7 @@ import (
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/introspection"
 	"github.com/juju/juju/worker/logsender"
+	"github.com/juju/juju/worker/uniter/unitermetrics"
 )
 
 var (
@@ -58,6 +59,7 @@ type UnitAgent struct {
 	initialUpgradeCheckComplete chan struct{}
 
 	prometheusRegistry *prometheus.Registry
+	uniterMetrics      *unitermetrics.Collector
 }
 
 // NewUnitAgent creates a new UnitAgent value properly initialized.
@@ -66,14 +68,19 @@ func NewUnitAgent(ctx *cmd.Context, bufferedLogger *logsender.BufferedLogWriter)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &UnitAgent{
+	a := &UnitAgent{
 		AgentConf:        NewAgentConf(""),
 		configChangedVal: voyeur.NewValue(true),
 		ctx:              ctx,
 		initialUpgradeCheckComplete: make(chan struct{}),
 		bufferedLogger:              bufferedLogger,
 		prometheusRegistry:          prometheusRegistry,
-	}, nil
+		uniterMetrics:               unitermetrics.New(),
+	}
+	if err := a.prometheusRegistry.Register(a.uniterMetrics); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a, nil
 }
 
 // Info returns usage information for the command.
@@ -157,6 +164,7 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 		AgentConfigChanged:   a.configChangedVal,
 		ValidateMigration:    a.validateMigration,
 		PrometheusRegisterer: a.prometheusRegistry,
+		UniterMetrics:        a.uniterMetrics,
 	})
 
 	config := dependency.EngineConfig{
@@ -5,6 +5,8 @@ package controller
 
 import (
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -14,6 +16,10 @@ import (
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/backupcrypt"
+	"github.com/juju/juju/core/cron"
+	"github.com/juju/juju/core/quota"
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 var logger = loggo.GetLogger("juju.controller")
@@ -33,6 +39,109 @@ const (
 	// auditing information.
 	AuditingEnabled = "auditing-enabled"
 
+	// AuditLogSinks holds a comma separated list of the sinks which
+	// audit entries are written to when auditing is enabled. Valid
+	// sinks are "file", "database" and "syslog".
+	AuditLogSinks = "audit-log-sinks"
+
+	// AuditLogCaptureArgs determines whether the audit log records
+	// the redacted arguments and results of mutating API calls,
+	// rather than just the name of every call made.
+	AuditLogCaptureArgs = "audit-log-capture-args"
+
+	// AuditSyslogHost is the host-port of the syslog server which
+	// audit entries are forwarded to by the "syslog" audit sink.
+	AuditSyslogHost = "audit-syslog-host"
+
+	// AuditSyslogCACert is the CA certificate (x.509, PEM-encoded)
+	// used to validate the audit syslog server certificate.
+	AuditSyslogCACert = "audit-syslog-ca-cert"
+
+	// AuditSyslogClientCert is the TLS certificate (x.509,
+	// PEM-encoded) used when connecting to the audit syslog server.
+	AuditSyslogClientCert = "audit-syslog-client-cert"
+
+	// AuditSyslogClientKey is the TLS private key (x.509, PEM-encoded)
+	// used when connecting to the audit syslog server.
+	AuditSyslogClientKey = "audit-syslog-client-key"
+
+	// BackupSchedule is a cron-style schedule (e.g. "30 2 * * *"),
+	// evaluated in UTC, on which the controller backs itself up.
+	// Scheduled backups are disabled when it is empty.
+	BackupSchedule = "backup-schedule"
+
+	// BackupKeepLast is the number of most recent scheduled backups
+	// which are retained.
+	BackupKeepLast = "backup-keep-last"
+
+	// BackupKeepDailyDays is the number of days for which the last
+	// scheduled backup of each day is retained.
+	BackupKeepDailyDays = "backup-keep-daily-days"
+
+	// BackupEncryptionKey is the key with which backup archives are
+	// encrypted when no other key is given: either a secret key
+	// ("secret:...") or the public half of a key pair ("public:..."),
+	// as generated by "juju generate-backup-key". Archives are not
+	// encrypted by default when it is empty. It is never returned to
+	// clients or agents.
+	BackupEncryptionKey = "backup-encryption-key"
+
+	// BackupTarget is the URL of the location outside the controller
+	// where backups are stored: either "file:///some/dir" for a
+	// directory (e.g. an NFS mount) present on every controller
+	// machine, or "s3://bucket/prefix" for an S3-compatible object
+	// store. Backups are stored in the controller database when it
+	// is empty. Like the rest of the controller config, the target
+	// and its S3 settings are given at bootstrap and cannot be
+	// changed afterwards.
+	BackupTarget = "backup-target"
+
+	// BackupTargetS3Endpoint is the URL of the S3-compatible service
+	// used by an "s3" backup target. Amazon S3 is used when it is
+	// empty.
+	BackupTargetS3Endpoint = "backup-target-s3-endpoint"
+
+	// BackupTargetS3Region is the region of the bucket used by an
+	// "s3" backup target.
+	BackupTargetS3Region = "backup-target-s3-region"
+
+	// BackupTargetS3AccessKey is the access key used by an "s3"
+	// backup target. It is never returned to clients or agents.
+	BackupTargetS3AccessKey = "backup-target-s3-access-key"
+
+	// BackupTargetS3SecretKey is the secret key used by an "s3"
+	// backup target. It is never returned to clients or agents.
+	BackupTargetS3SecretKey = "backup-target-s3-secret-key"
+
+	// DefaultModelQuotas holds the quotas on the resources used by
+	// models created without quotas of their own, e.g.
+	// "machines=10 cores=40 memory=160G". Models are not limited by
+	// default when it is empty.
+	DefaultModelQuotas = "default-model-quotas"
+
+	// LoginMaxFailedAttempts is the number of consecutive failed
+	// password logins after which a local user is locked out for
+	// LoginLockoutDuration. Users are never locked out when it is 0.
+	LoginMaxFailedAttempts = "login-max-failed-attempts"
+
+	// LoginLockoutDuration is how long a local user is locked out
+	// for after too many failed password logins, e.g. "15m".
+	LoginLockoutDuration = "login-lockout-duration"
+
+	// PasswordMinLength is the minimum number of characters in the
+	// password of a local user.
+	PasswordMinLength = "password-min-length"
+
+	// PasswordRequireComplexity determines whether the passwords of
+	// local users must contain characters from at least three of:
+	// lower case letters, upper case letters, digits and symbols.
+	PasswordRequireComplexity = "password-require-complexity"
+
+	// PasswordMaxAge is how long a local user may use a password
+	// before they must change it, e.g. "2160h". Passwords do not
+	// expire when it is empty or zero.
+	PasswordMaxAge = "password-max-age"
+
 	// StatePort is the port used for mongo connections.
 	StatePort = "state-port"
 
@@ -79,6 +188,20 @@ const (
 	// AuditingEnabled config value.
 	DefaultAuditingEnabled = false
 
+	// DefaultAuditLogSinks contains the default value for the
+	// AuditLogSinks config value.
+	DefaultAuditLogSinks = AuditLogSinkFile + "," + AuditLogSinkDatabase
+
+	// DefaultLoginMaxFailedAttempts contains the default value for the
+	// LoginMaxFailedAttempts config value. Lockout is off by default,
+	// as anyone able to reach the API can lock out any local user,
+	// including the controller's admin.
+	DefaultLoginMaxFailedAttempts = 0
+
+	// DefaultLoginLockoutDuration contains the default value for the
+	// LoginLockoutDuration config value.
+	DefaultLoginLockoutDuration = "15m"
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -93,17 +216,62 @@ const (
 	DefaultMongoMemoryProfile = MongoProfLow
 )
 
+const (
+	// AuditLogSinkFile writes audit entries to the audit.log file
+	// in the log directory of each controller.
+	AuditLogSinkFile = "file"
+
+	// AuditLogSinkDatabase writes audit entries to the controller
+	// database, where they are shared by all controllers.
+	AuditLogSinkDatabase = "database"
+
+	// AuditLogSinkSyslog forwards audit entries to a remote syslog
+	// server.
+	AuditLogSinkSyslog = "syslog"
+)
+
+// SecretConfigAttributes are attributes which hold private keys or
+// credentials. They are only used within the controller, and are never
+// returned to API clients or agents.
+var SecretConfigAttributes = []string{
+	AuditSyslogClientKey,
+	BackupEncryptionKey,
+	BackupTargetS3AccessKey,
+	BackupTargetS3SecretKey,
+}
+
 // ControllerOnlyConfigAttributes are attributes which are only relevant
 // for a controller, never a model.
 var ControllerOnlyConfigAttributes = []string{
 	AllowModelAccessKey,
 	APIPort,
+	AuditLogCaptureArgs,
+	AuditLogSinks,
+	AuditSyslogCACert,
+	AuditSyslogClientCert,
+	AuditSyslogClientKey,
+	AuditSyslogHost,
 	AutocertDNSNameKey,
 	AutocertURLKey,
+	BackupEncryptionKey,
+	BackupKeepDailyDays,
+	BackupKeepLast,
+	BackupSchedule,
+	BackupTarget,
+	BackupTargetS3AccessKey,
+	BackupTargetS3Endpoint,
+	BackupTargetS3Region,
+	BackupTargetS3SecretKey,
 	CACertKey,
 	ControllerUUIDKey,
+	DefaultModelQuotas,
 	IdentityPublicKey,
 	IdentityURL,
+	LoginLockoutDuration,
+	LoginMaxFailedAttempts,
+	PasswordMaxAge,
+	PasswordMinLength,
+	PasswordRequireComplexity,
 	SetNUMAControlPolicyKey,
 	StatePort,
 	MongoMemoryProfile,
@@ -168,6 +336,17 @@ func (c Config) asString(name string) string {
 	return value
 }
 
+// asInt returns the given named attribute as an integer, returning 0
+// if it isn't found.
+func (c Config) asInt(name string) int {
+	// Values obtained over the api are encoded as float64.
+	if value, ok := c[name].(float64); ok {
+		return int(value)
+	}
+	value, _ := c[name].(int)
+	return value
+}
+
 // mustString returns the named attribute as an string, panicking if
 // it is not found or is empty.
 func (c Config) mustString(name string) string {
@@ -197,6 +376,153 @@ func (c Config) AuditingEnabled() bool {
 	return false
 }
 
+// AuditLogCaptureArgs returns whether the audit log records the
+// redacted arguments and results of mutating API calls. The default
+// is false.
+func (c Config) AuditLogCaptureArgs() bool {
+	if v, ok := c[AuditLogCaptureArgs].(bool); ok {
+		return v
+	}
+	return false
+}
+
+// AuditLogSinks returns the names of the sinks which audit entries
+// are written to when auditing is enabled.
+func (c Config) AuditLogSinks() []string {
+	value, ok := c[AuditLogSinks].(string)
+	if !ok {
+		value = DefaultAuditLogSinks
+	}
+	var sinks []string
+	for _, sink := range strings.Split(value, ",") {
+		if sink = strings.TrimSpace(sink); sink != "" {
+			sinks = append(sinks, sink)
+		}
+	}
+	return sinks
+}
+
+// AuditSyslogConfig returns the configuration used by the "syslog"
+// audit sink to connect to the remote syslog server.
+func (c Config) AuditSyslogConfig() syslog.RawConfig {
+	return syslog.RawConfig{
+		Enabled:    c.hasAuditLogSink(AuditLogSinkSyslog),
+		Host:       c.asString(AuditSyslogHost),
+		CACert:     c.asString(AuditSyslogCACert),
+		ClientCert: c.asString(AuditSyslogClientCert),
+		ClientKey:  c.asString(AuditSyslogClientKey),
+	}
+}
+
+func (c Config) hasAuditLogSink(name string) bool {
+	for _, sink := range c.AuditLogSinks() {
+		if sink == name {
+			return true
+		}
+	}
+	return false
+}
+
+// BackupSchedule returns the cron-style schedule on which the
+// controller backs itself up, or "" if scheduled backups are disabled.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupKeepLast returns the number of most recent scheduled backups
+// which are retained.
+func (c Config) BackupKeepLast() int {
+	return c.asInt(BackupKeepLast)
+}
+
+// BackupKeepDailyDays returns the number of days for which the last
+// scheduled backup of each day is retained.
+func (c Config) BackupKeepDailyDays() int {
+	return c.asInt(BackupKeepDailyDays)
+}
+
+// BackupEncryptionKey returns the key with which backup archives are
+// encrypted when no other key is given, or "" if they are not
+// encrypted by default.
+func (c Config) BackupEncryptionKey() string {
+	return c.asString(BackupEncryptionKey)
+}
+
+// BackupTarget returns the URL of the location outside the controller
+// where backups are stored, or "" if they are stored in the controller
+// database.
+func (c Config) BackupTarget() string {
+	return c.asString(BackupTarget)
+}
+
+// BackupTargetS3Endpoint returns the URL of the S3-compatible service
+// used by an "s3" backup target.
+func (c Config) BackupTargetS3Endpoint() string {
+	return c.asString(BackupTargetS3Endpoint)
+}
+
+// BackupTargetS3Region returns the region of the bucket used by an
+// "s3" backup target.
+func (c Config) BackupTargetS3Region() string {
+	return c.asString(BackupTargetS3Region)
+}
+
+// BackupTargetS3AccessKey returns the access key used by an "s3"
+// backup target.
+func (c Config) BackupTargetS3AccessKey() string {
+	return c.asString(BackupTargetS3AccessKey)
+}
+
+// BackupTargetS3SecretKey returns the secret key used by an "s3"
+// backup target.
+func (c Config) BackupTargetS3SecretKey() string {
+	return c.asString(BackupTargetS3SecretKey)
+}
+
+// LoginMaxFailedAttempts returns the number of consecutive failed
+// password logins after which a local user is locked out, or 0 if
+// users are never locked out.
+func (c Config) LoginMaxFailedAttempts() int {
+	if _, ok := c[LoginMaxFailedAttempts]; !ok {
+		return DefaultLoginMaxFailedAttempts
+	}
+	return c.asInt(LoginMaxFailedAttempts)
+}
+
+// LoginLockoutDuration returns how long a local user is locked out for
+// after too many failed password logins.
+func (c Config) LoginLockoutDuration() time.Duration {
+	v, ok := c[LoginLockoutDuration].(string)
+	if !ok {
+		v = DefaultLoginLockoutDuration
+	}
+	// The value has been validated already.
+	d, _ := time.ParseDuration(v)
+	return d
+}
+
+// PasswordPolicy returns the rules which the passwords of local users
+// must follow.
+func (c Config) PasswordPolicy() PasswordPolicy {
+	policy := PasswordPolicy{
+		MinLength: c.asInt(PasswordMinLength),
+	}
+	policy.RequireComplexity, _ = c[PasswordRequireComplexity].(bool)
+	if v := c.asString(PasswordMaxAge); v != "" {
+		// The value has been validated already.
+		policy.MaxAge, _ = time.ParseDuration(v)
+	}
+	return policy
+}
+
+// DefaultModelQuotas returns the quotas on the resources used by
+// models created without quotas of their own.
+func (c Config) DefaultModelQuotas() quota.Resources {
+	// The value has been validated already.
+	quotas, _ := quota.Parse(c.asString(DefaultModelQuotas))
+	return quotas
+}
+
 // ControllerUUID returns the uuid for the model's controller.
 func (c Config) ControllerUUID() string {
 	return c.mustString(ControllerUUIDKey)
@@ -310,6 +636,91 @@ func Validate(c Config) error {
 		}
 	}
 
+	for _, sink := range c.AuditLogSinks() {
+		switch sink {
+		case AuditLogSinkFile, AuditLogSinkDatabase, AuditLogSinkSyslog:
+		default:
+			return errors.Errorf("%s: unknown sink %q", AuditLogSinks, sink)
+		}
+	}
+	if c.hasAuditLogSink(AuditLogSinkSyslog) {
+		if err := c.AuditSyslogConfig().Validate(); err != nil {
+			return errors.Annotate(err, "invalid audit syslog configuration")
+		}
+	}
+
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := cron.Parse(v); err != nil {
+			return errors.Annotatef(err, "%s", BackupSchedule)
+		}
+	}
+	for _, name := range []string{BackupKeepLast, BackupKeepDailyDays} {
+		if c.asInt(name) < 0 {
+			return errors.Errorf("%s: expected a non-negative number, got %d", name, c.asInt(name))
+		}
+	}
+
+	if v := c.BackupEncryptionKey(); v != "" {
+		key, err := backupcrypt.ParseKey(v)
+		if err != nil {
+			return errors.Annotatef(err, "%s", BackupEncryptionKey)
+		}
+		if key.Type() == backupcrypt.PrivateKey {
+			return errors.Errorf("%s: expected a secret or public key, got a private key", BackupEncryptionKey)
+		}
+	}
+
+	if v := c.BackupTarget(); v != "" {
+		if err := c.validateBackupTarget(v); err != nil {
+			return errors.Annotatef(err, "%s", BackupTarget)
+		}
+	}
+
+	if _, err := quota.Parse(c.asString(DefaultModelQuotas)); err != nil {
+		return errors.Annotatef(err, "%s", DefaultModelQuotas)
+	}
+
+	for _, name := range []string{LoginMaxFailedAttempts, PasswordMinLength} {
+		if c.asInt(name) < 0 {
+			return errors.Errorf("%s: expected a non-negative number, got %d", name, c.asInt(name))
+		}
+	}
+	for _, name := range []string{LoginLockoutDuration, PasswordMaxAge} {
+		if v := c.asString(name); v != "" {
+			if d, err := time.ParseDuration(v); err != nil || d < 0 {
+				return errors.Errorf("%s: expected a non-negative duration, got %q", name, v)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c Config) validateBackupTarget(target string) error {
+	u, err := url.Parse(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch u.Scheme {
+	case "file":
+		if !strings.HasPrefix(u.Path, "/") || u.Host != "" {
+			return errors.Errorf("expected file:///absolute/path, got %q", target)
+		}
+	case "s3":
+		if u.Host == "" {
+			return errors.Errorf("expected s3://bucket[/prefix], got %q", target)
+		}
+		if c.BackupTargetS3AccessKey() == "" || c.BackupTargetS3SecretKey() == "" {
+			return errors.Errorf("%s and %s must be set for an s3 target", BackupTargetS3AccessKey, BackupTargetS3SecretKey)
+		}
+		if v := c.BackupTargetS3Endpoint(); v != "" {
+			if _, err := url.Parse(v); err != nil {
+				return errors.Annotatef(err, "invalid %s", BackupTargetS3Endpoint)
+			}
+		}
+	default:
+		return errors.Errorf("unsupported scheme %q, expected file or s3", u.Scheme)
+	}
 	return nil
 }
 
@@ -320,25 +731,67 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	APIPort:                 schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
+	AuditingEnabled:           schema.Bool(),
+	AuditLogCaptureArgs:       schema.Bool(),
+	AuditLogSinks:             schema.String(),
+	AuditSyslogHost:           schema.String(),
+	AuditSyslogCACert:         schema.String(),
+	AuditSyslogClientCert:     schema.String(),
+	AuditSyslogClientKey:      schema.String(),
+	APIPort:                   schema.ForceInt(),
+	StatePort:                 schema.ForceInt(),
+	BackupSchedule:            schema.String(),
+	BackupKeepLast:            schema.ForceInt(),
+	BackupKeepDailyDays:       schema.ForceInt(),
+	BackupEncryptionKey:       schema.String(),
+	BackupTarget:              schema.String(),
+	BackupTargetS3Endpoint:    schema.String(),
+	BackupTargetS3Region:      schema.String(),
+	BackupTargetS3AccessKey:   schema.String(),
+	BackupTargetS3SecretKey:   schema.String(),
+	DefaultModelQuotas:        schema.String(),
+	IdentityURL:               schema.String(),
+	IdentityPublicKey:         schema.String(),
+	LoginMaxFailedAttempts:    schema.ForceInt(),
+	LoginLockoutDuration:      schema.String(),
+	PasswordMinLength:         schema.ForceInt(),
+	PasswordRequireComplexity: schema.Bool(),
+	PasswordMaxAge:            schema.String(),
+	SetNUMAControlPolicyKey:   schema.Bool(),
+	AutocertURLKey:            schema.String(),
+	AutocertDNSNameKey:        schema.String(),
+	AllowModelAccessKey:       schema.Bool(),
+	MongoMemoryProfile:        schema.String(),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      schema.Omit,
+	APIPort:                   DefaultAPIPort,
+	AuditingEnabled:           DefaultAuditingEnabled,
+	AuditLogCaptureArgs:       schema.Omit,
+	AuditLogSinks:             schema.Omit,
+	AuditSyslogHost:           schema.Omit,
+	AuditSyslogCACert:         schema.Omit,
+	AuditSyslogClientCert:     schema.Omit,
+	AuditSyslogClientKey:      schema.Omit,
+	StatePort:                 DefaultStatePort,
+	BackupSchedule:            schema.Omit,
+	BackupKeepLast:            schema.Omit,
+	BackupKeepDailyDays:       schema.Omit,
+	BackupEncryptionKey:       schema.Omit,
+	BackupTarget:              schema.Omit,
+	BackupTargetS3Endpoint:    schema.Omit,
+	BackupTargetS3Region:      schema.Omit,
+	BackupTargetS3AccessKey:   schema.Omit,
+	BackupTargetS3SecretKey:   schema.Omit,
+	DefaultModelQuotas:        schema.Omit,
+	IdentityURL:               schema.Omit,
+	IdentityPublicKey:         schema.Omit,
+	LoginMaxFailedAttempts:    schema.Omit,
+	LoginLockoutDuration:      schema.Omit,
+	PasswordMinLength:         schema.Omit,
+	PasswordRequireComplexity: schema.Omit,
+	PasswordMaxAge:            schema.Omit,
+	SetNUMAControlPolicyKey:   DefaultNUMAControlPolicy,
+	AutocertURLKey:            schema.Omit,
+	AutocertDNSNameKey:        schema.Omit,
+	AllowModelAccessKey:       schema.Omit,
+	MongoMemoryProfile:        schema.Omit,
 })
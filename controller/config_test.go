@@ -15,6 +15,8 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/quota"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/testing"
 )
 
@@ -121,6 +123,129 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "unknown audit log sink",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.AuditLogSinks: "file,kafka",
+	},
+	expectError: `audit-log-sinks: unknown sink "kafka"`,
+}, {
+	about: "syslog audit log sink requires host",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.AuditLogSinks: "syslog",
+	},
+	expectError: `invalid audit syslog configuration: Host "" not valid`,
+}, {
+	about: "syslog audit log sink OK",
+	config: controller.Config{
+		controller.CACertKey:             testing.CACert,
+		controller.AuditLogSinks:         "database, syslog",
+		controller.AuditSyslogHost:       "10.0.0.1:6514",
+		controller.AuditSyslogCACert:     testing.CACert,
+		controller.AuditSyslogClientCert: testing.ServerCert,
+		controller.AuditSyslogClientKey:  testing.ServerKey,
+	},
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.BackupSchedule: "every day",
+	},
+	expectError: `backup-schedule: invalid schedule "every day": expected 5 fields, got 2`,
+}, {
+	about: "negative backup retention",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.BackupKeepLast: -1,
+	},
+	expectError: `backup-keep-last: expected a non-negative number, got -1`,
+}, {
+	about: "backup schedule OK",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.BackupSchedule:      "30 2 * * *",
+		controller.BackupKeepLast:      7,
+		controller.BackupKeepDailyDays: 30,
+	},
+}, {
+	about: "invalid backup encryption key",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.BackupEncryptionKey: "secret:YWJj",
+	},
+	expectError: `backup-encryption-key: secret backup key of 3 bytes not valid`,
+}, {
+	about: "private backup encryption key",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.BackupEncryptionKey: "private:AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=",
+	},
+	expectError: `backup-encryption-key: expected a secret or public key, got a private key`,
+}, {
+	about: "backup encryption key OK",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.BackupEncryptionKey: "public:AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=",
+	},
+}, {
+	about: "relative backup target directory",
+	config: controller.Config{
+		controller.CACertKey:    testing.CACert,
+		controller.BackupTarget: "file://backups",
+	},
+	expectError: `backup-target: expected file:///absolute/path, got "file://backups"`,
+}, {
+	about: "unknown backup target scheme",
+	config: controller.Config{
+		controller.CACertKey:    testing.CACert,
+		controller.BackupTarget: "ftp://example.com/backups",
+	},
+	expectError: `backup-target: unsupported scheme "ftp", expected file or s3`,
+}, {
+	about: "s3 backup target requires credentials",
+	config: controller.Config{
+		controller.CACertKey:    testing.CACert,
+		controller.BackupTarget: "s3://juju-backups",
+	},
+	expectError: `backup-target: backup-target-s3-access-key and backup-target-s3-secret-key must be set for an s3 target`,
+}, {
+	about: "backup target directory OK",
+	config: controller.Config{
+		controller.CACertKey:    testing.CACert,
+		controller.BackupTarget: "file:///srv/backups",
+	},
+}, {
+	about: "s3 backup target OK",
+	config: controller.Config{
+		controller.CACertKey:               testing.CACert,
+		controller.BackupTarget:            "s3://juju-backups/prod",
+		controller.BackupTargetS3Endpoint:  "https://minio.example.com:9000",
+		controller.BackupTargetS3AccessKey: "access",
+		controller.BackupTargetS3SecretKey: "secret",
+	},
+}, {
+	about: "invalid login lockout duration",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.LoginLockoutDuration: "a while",
+	},
+	expectError: `login-lockout-duration: expected a non-negative duration, got "a while"`,
+}, {
+	about: "negative password minimum length",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.PasswordMinLength: -1,
+	},
+	expectError: `password-min-length: expected a non-negative number, got -1`,
+}, {
+	about: "invalid default model quotas",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.DefaultModelQuotas: "machines=lots",
+	},
+	expectError: `default-model-quotas: machines quota "lots" not valid`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -134,3 +259,143 @@ func (s *ConfigSuite) TestValidate(c *gc.C) {
 		}
 	}
 }
+
+func (s *ConfigSuite) TestAuditLogSinksDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSinks(), jc.DeepEquals, []string{"file", "database"})
+	c.Assert(cfg.AuditSyslogConfig().Enabled, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestAuditLogCaptureArgs(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogCaptureArgs(), jc.IsFalse)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.AuditLogCaptureArgs: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogCaptureArgs(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestAuditSyslogConfig(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.AuditLogSinks:         "file,syslog",
+		controller.AuditSyslogHost:       "10.0.0.1:6514",
+		controller.AuditSyslogCACert:     testing.CACert,
+		controller.AuditSyslogClientCert: testing.ServerCert,
+		controller.AuditSyslogClientKey:  testing.ServerKey,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSinks(), jc.DeepEquals, []string{"file", "syslog"})
+	c.Assert(cfg.AuditSyslogConfig(), jc.DeepEquals, syslog.RawConfig{
+		Enabled:    true,
+		Host:       "10.0.0.1:6514",
+		CACert:     testing.CACert,
+		ClientCert: testing.ServerCert,
+		ClientKey:  testing.ServerKey,
+	})
+}
+
+func (s *ConfigSuite) TestBackupSchedule(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "")
+	c.Assert(cfg.BackupKeepLast(), gc.Equals, 0)
+	c.Assert(cfg.BackupKeepDailyDays(), gc.Equals, 0)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.BackupSchedule:      "@daily",
+		controller.BackupKeepLast:      7,
+		controller.BackupKeepDailyDays: 30,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "@daily")
+	c.Assert(cfg.BackupKeepLast(), gc.Equals, 7)
+	c.Assert(cfg.BackupKeepDailyDays(), gc.Equals, 30)
+}
+
+func (s *ConfigSuite) TestLoginLockout(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	// Users are not locked out unless lockout is configured.
+	c.Assert(cfg.LoginMaxFailedAttempts(), gc.Equals, 0)
+	c.Assert(cfg.LoginLockoutDuration(), gc.Equals, 15*time.Minute)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.LoginMaxFailedAttempts: 10,
+		controller.LoginLockoutDuration:   "1h",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LoginMaxFailedAttempts(), gc.Equals, 10)
+	c.Assert(cfg.LoginLockoutDuration(), gc.Equals, time.Hour)
+}
+
+func (s *ConfigSuite) TestPasswordPolicy(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.PasswordPolicy(), jc.DeepEquals, controller.PasswordPolicy{})
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.PasswordMinLength:         12,
+		controller.PasswordRequireComplexity: true,
+		controller.PasswordMaxAge:            "2160h",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.PasswordPolicy(), jc.DeepEquals, controller.PasswordPolicy{
+		MinLength:         12,
+		RequireComplexity: true,
+		MaxAge:            2160 * time.Hour,
+	})
+}
+
+func (s *ConfigSuite) TestDefaultModelQuotas(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.DefaultModelQuotas(), gc.Equals, quota.Resources{})
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.DefaultModelQuotas: "machines=10 memory=64G",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.DefaultModelQuotas(), gc.Equals, quota.Resources{Machines: 10, Memory: 64 * 1024})
+}
+
+func (s *ConfigSuite) TestPasswordPolicyValidate(c *gc.C) {
+	policy := controller.PasswordPolicy{MinLength: 8, RequireComplexity: true}
+	for i, test := range []struct {
+		password    string
+		expectError string
+	}{{
+		password:    "Sh0rt!",
+		expectError: "password must be at least 8 characters long",
+	}, {
+		password:    "alllowercase",
+		expectError: "password must contain at least three of: .*",
+	}, {
+		password:    "lowerUPPER",
+		expectError: "password must contain at least three of: .*",
+	}, {
+		password: "lowerUPPER1",
+	}, {
+		password: "lower-and-42",
+	}} {
+		c.Logf("test %d: %q", i, test.password)
+		err := policy.Validate(test.password)
+		if test.expectError == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.expectError)
+		}
+	}
+}
+
+func (s *ConfigSuite) TestPasswordPolicyExpired(c *gc.C) {
+	now := time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC)
+	policy := controller.PasswordPolicy{MaxAge: 24 * time.Hour}
+	c.Check(policy.Expired(now.Add(-23*time.Hour), now), jc.IsFalse)
+	c.Check(policy.Expired(now.Add(-24*time.Hour), now), jc.IsTrue)
+	c.Check(policy.Expired(time.Time{}, now), jc.IsFalse)
+	c.Check(controller.PasswordPolicy{}.Expired(now.Add(-1000*time.Hour), now), jc.IsFalse)
+}
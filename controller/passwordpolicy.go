@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/juju/errors"
+)
+
+// PasswordPolicy holds the rules which the passwords of local users
+// must follow.
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters in a password.
+	MinLength int
+
+	// RequireComplexity requires passwords to contain characters
+	// from at least three of: lower case letters, upper case
+	// letters, digits and symbols.
+	RequireComplexity bool
+
+	// MaxAge is how long a password may be used before it must be
+	// changed. Passwords do not expire when it is zero.
+	MaxAge time.Duration
+}
+
+// Validate returns an error describing how the password breaks the
+// policy, if it does.
+func (p PasswordPolicy) Validate(password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return errors.Errorf("password must be at least %d characters long", p.MinLength)
+	}
+	if p.RequireComplexity && characterClasses(password) < 3 {
+		return errors.New("password must contain at least three of: lower case letters, upper case letters, digits and symbols")
+	}
+	return nil
+}
+
+// Expired reports whether a password last changed at the given time
+// has expired by now. Passwords with an unknown change time never
+// expire.
+func (p PasswordPolicy) Expired(changed, now time.Time) bool {
+	if p.MaxAge == 0 || changed.IsZero() {
+		return false
+	}
+	return !now.Before(changed.Add(p.MaxAge))
+}
+
+// characterClasses returns the number of different classes of
+// character in s.
+func characterClasses(s string) int {
+	var lower, upper, digit, other int
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
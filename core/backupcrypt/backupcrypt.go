@@ -0,0 +1,277 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupcrypt encrypts and decrypts backup archives.
+//
+// Archives may be encrypted either with a secret key, or with the
+// public half of a key pair so that the controller never holds the key
+// needed to read them. An encrypted archive starts with a header
+// identifying the kind of key used, followed by the archive split into
+// chunks, each sealed with NaCl secretbox. Every chunk is sealed with a
+// distinct nonce, and the last one is marked as such, so that chunks
+// cannot be reordered, dropped or truncated without detection.
+package backupcrypt
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+const (
+	// magic starts every encrypted archive.
+	magic = "JUJUBKE1"
+
+	// modeSecret and modePublic follow the magic, identifying the
+	// kind of key the archive is encrypted with.
+	modeSecret = 's'
+	modePublic = 'p'
+
+	// noncePrefixSize is the size of the random part of each chunk's
+	// nonce; the rest holds the chunk number.
+	noncePrefixSize = 16
+
+	// chunkSize is the maximum size of the plaintext sealed in each
+	// chunk.
+	chunkSize = 64 * 1024
+
+	// finalChunk is set in a chunk's length, and in its nonce, if it
+	// is the last chunk of the archive.
+	finalChunk = 1 << 31
+)
+
+// errCorrupt is returned when a chunk cannot be opened.
+var errCorrupt = errors.New("cannot decrypt backup archive: wrong key or corrupt archive")
+
+// IsEncrypted reports whether the archive read from rs is encrypted.
+// It leaves rs positioned at the start of the archive.
+func IsEncrypted(rs io.ReadSeeker) (bool, error) {
+	header := make([]byte, len(magic))
+	n, err := io.ReadFull(rs, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return false, errors.Trace(err)
+	}
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return false, errors.Trace(err)
+	}
+	return string(header[:n]) == magic, nil
+}
+
+// chunkNonce returns the nonce used to seal the given chunk.
+func chunkNonce(prefix *[noncePrefixSize]byte, chunk uint64, final bool) *[24]byte {
+	var nonce [24]byte
+	copy(nonce[:], prefix[:])
+	if final {
+		chunk |= 1 << 63
+	}
+	binary.BigEndian.PutUint64(nonce[noncePrefixSize:], chunk)
+	return &nonce
+}
+
+// NewWriter returns a writer which encrypts an archive with the given
+// key, writing the result to w. The writer must be closed to complete
+// the archive; closing it does not close w.
+func NewWriter(w io.Writer, key *Key) (io.WriteCloser, error) {
+	cw := &writer{
+		w:   w,
+		buf: make([]byte, 0, chunkSize),
+	}
+	if _, err := rand.Read(cw.noncePrefix[:]); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	header := []byte(magic)
+	switch key.typ {
+	case SecretKey:
+		cw.key = key.bytes
+		header = append(header, modeSecret)
+		header = append(header, cw.noncePrefix[:]...)
+	case PublicKey, PrivateKey:
+		// The archive is sealed with a key shared between the
+		// recipient's key pair and a new ephemeral key pair, whose
+		// public half is stored in the header.
+		ephemeralPublic, ephemeralPrivate, err := box.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		box.Precompute(&cw.key, &key.Public().bytes, ephemeralPrivate)
+		header = append(header, modePublic)
+		header = append(header, cw.noncePrefix[:]...)
+		header = append(header, ephemeralPublic[:]...)
+	default:
+		return nil, errors.NotValidf("backup key type %q", key.typ)
+	}
+	if _, err := w.Write(header); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cw, nil
+}
+
+type writer struct {
+	w           io.Writer
+	key         [keySize]byte
+	noncePrefix [noncePrefixSize]byte
+	chunk       uint64
+	buf         []byte
+	closed      bool
+}
+
+// Write is part of the io.Writer interface.
+func (w *writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("write to closed backup archive")
+	}
+	written := 0
+	for len(p) > 0 {
+		if len(w.buf) == chunkSize {
+			if err := w.seal(false); err != nil {
+				return written, errors.Trace(err)
+			}
+		}
+		n := copy(w.buf[len(w.buf):chunkSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close is part of the io.Closer interface. It writes the final chunk
+// of the archive.
+func (w *writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return errors.Trace(w.seal(true))
+}
+
+// seal writes the buffered plaintext as the next chunk.
+func (w *writer) seal(final bool) error {
+	nonce := chunkNonce(&w.noncePrefix, w.chunk, final)
+	sealed := secretbox.Seal(make([]byte, 4), w.buf, nonce, &w.key)
+	length := uint32(len(sealed) - 4)
+	if final {
+		length |= finalChunk
+	}
+	binary.BigEndian.PutUint32(sealed, length)
+	if _, err := w.w.Write(sealed); err != nil {
+		return errors.Trace(err)
+	}
+	w.chunk++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// NewReader returns a reader which decrypts the archive read from r
+// with the given key, which must be either the secret key or the
+// private key corresponding to the public key the archive was
+// encrypted with. Reading fails if the archive has been tampered with,
+// or if it is incomplete.
+func NewReader(r io.Reader, key *Key) (io.Reader, error) {
+	header := make([]byte, len(magic)+1+noncePrefixSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, errors.Annotate(err, "reading encrypted backup archive header")
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, errors.New("backup archive is not encrypted")
+	}
+	cr := &reader{r: r}
+	copy(cr.noncePrefix[:], header[len(magic)+1:])
+
+	switch mode := header[len(magic)]; mode {
+	case modeSecret:
+		if key.typ != SecretKey {
+			return nil, errors.Errorf("backup archive is encrypted with a secret key, not a %s key", key.typ)
+		}
+		cr.key = key.bytes
+	case modePublic:
+		if key.typ != PrivateKey {
+			return nil, errors.Errorf("backup archive is encrypted with a key pair; a private key is needed, not a %s key", key.typ)
+		}
+		var ephemeralPublic [keySize]byte
+		if _, err := io.ReadFull(r, ephemeralPublic[:]); err != nil {
+			return nil, errors.Annotate(err, "reading encrypted backup archive header")
+		}
+		box.Precompute(&cr.key, &ephemeralPublic, &key.bytes)
+	default:
+		return nil, errors.Errorf("unknown backup archive encryption mode %q", mode)
+	}
+	return cr, nil
+}
+
+type reader struct {
+	r           io.Reader
+	key         [keySize]byte
+	noncePrefix [noncePrefixSize]byte
+	chunk       uint64
+	buf         []byte
+	final       bool
+	err         error
+}
+
+// Read is part of the io.Reader interface.
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.final {
+			r.err = r.checkEnd()
+			continue
+		}
+		r.err = r.open()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// open reads and opens the next chunk.
+func (r *reader) open() error {
+	var lengthBytes [4]byte
+	if _, err := io.ReadFull(r.r, lengthBytes[:]); err == io.EOF {
+		return errors.New("backup archive is truncated")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	length := binary.BigEndian.Uint32(lengthBytes[:])
+	final := length&finalChunk != 0
+	length &^= finalChunk
+	if length < secretbox.Overhead || length > chunkSize+secretbox.Overhead {
+		return errCorrupt
+	}
+	sealed := make([]byte, length)
+	if _, err := io.ReadFull(r.r, sealed); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errors.New("backup archive is truncated")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	nonce := chunkNonce(&r.noncePrefix, r.chunk, final)
+	opened, ok := secretbox.Open(nil, sealed, nonce, &r.key)
+	if !ok {
+		return errCorrupt
+	}
+	r.buf = opened
+	r.chunk++
+	r.final = final
+	return nil
+}
+
+// checkEnd ensures that nothing follows the final chunk. It returns
+// io.EOF if that is the case.
+func (r *reader) checkEnd() error {
+	var extra [1]byte
+	n, err := io.ReadFull(r.r, extra[:])
+	if n > 0 {
+		return errors.New("unexpected data after the end of the backup archive")
+	}
+	if err != io.EOF {
+		return errors.Trace(err)
+	}
+	return io.EOF
+}
@@ -0,0 +1,151 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupcrypt_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/backupcrypt"
+)
+
+type BackupCryptSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&BackupCryptSuite{})
+
+// archive returns data spanning several chunks, with a partial last
+// chunk.
+func archive() []byte {
+	data := make([]byte, 200*1024+17)
+	rand.New(rand.NewSource(0)).Read(data)
+	return data
+}
+
+func encrypt(c *gc.C, data []byte, key *backupcrypt.Key) []byte {
+	var buf bytes.Buffer
+	w, err := backupcrypt.NewWriter(&buf, key)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write(data)
+	c.Assert(err, jc.ErrorIsNil)
+	err = w.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func decrypt(data []byte, key *backupcrypt.Key) ([]byte, error) {
+	r, err := backupcrypt.NewReader(bytes.NewReader(data), key)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+func (*BackupCryptSuite) TestSecretKey(c *gc.C) {
+	key, err := backupcrypt.GenerateSecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	data := archive()
+	encrypted := encrypt(c, data, key)
+	c.Check(bytes.Contains(encrypted, data[:64]), jc.IsFalse)
+
+	decrypted, err := decrypt(encrypted, key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(decrypted, jc.DeepEquals, data)
+}
+
+func (*BackupCryptSuite) TestKeyPair(c *gc.C) {
+	private, public, err := backupcrypt.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	data := archive()
+	encrypted := encrypt(c, data, public)
+
+	decrypted, err := decrypt(encrypted, private)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(decrypted, jc.DeepEquals, data)
+
+	_, err = decrypt(encrypted, public)
+	c.Check(err, gc.ErrorMatches, "backup archive is encrypted with a key pair; a private key is needed, not a public key")
+}
+
+func (*BackupCryptSuite) TestEmpty(c *gc.C) {
+	key, err := backupcrypt.GenerateSecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	decrypted, err := decrypt(encrypt(c, nil, key), key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(decrypted, gc.HasLen, 0)
+}
+
+func (*BackupCryptSuite) TestWrongKey(c *gc.C) {
+	key, err := backupcrypt.GenerateSecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := backupcrypt.GenerateSecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	private, _, err := backupcrypt.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	encrypted := encrypt(c, archive(), key)
+
+	_, err = decrypt(encrypted, other)
+	c.Check(err, gc.ErrorMatches, "cannot decrypt backup archive: wrong key or corrupt archive")
+	_, err = decrypt(encrypted, private)
+	c.Check(err, gc.ErrorMatches, "backup archive is encrypted with a secret key, not a private key")
+}
+
+func (*BackupCryptSuite) TestTampered(c *gc.C) {
+	key, err := backupcrypt.GenerateSecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	encrypted := encrypt(c, archive(), key)
+
+	corrupt := append([]byte(nil), encrypted...)
+	corrupt[len(corrupt)/2] ^= 1
+	_, err = decrypt(corrupt, key)
+	c.Check(err, gc.ErrorMatches, "cannot decrypt backup archive: wrong key or corrupt archive")
+
+	_, err = decrypt(encrypted[:len(encrypted)-1], key)
+	c.Check(err, gc.ErrorMatches, "backup archive is truncated")
+
+	// Dropping the final chunk entirely is detected too. It holds
+	// the last 8209 bytes of the archive, plus the chunk's length
+	// and authenticator.
+	_, err = decrypt(encrypted[:len(encrypted)-(8209+4+16)], key)
+	c.Check(err, gc.ErrorMatches, "backup archive is truncated")
+
+	_, err = decrypt(append(encrypted, 0), key)
+	c.Check(err, gc.ErrorMatches, "unexpected data after the end of the backup archive")
+}
+
+func (*BackupCryptSuite) TestIsEncrypted(c *gc.C) {
+	key, err := backupcrypt.GenerateSecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	for i, test := range []struct {
+		data   []byte
+		expect bool
+	}{
+		{encrypt(c, []byte("spam"), key), true},
+		{[]byte("<compressed archive data>"), false},
+		{[]byte("JU"), false},
+		{nil, false},
+	} {
+		c.Logf("test %d", i)
+		r := bytes.NewReader(test.data)
+		encrypted, err := backupcrypt.IsEncrypted(r)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(encrypted, gc.Equals, test.expect)
+		rest, err := ioutil.ReadAll(r)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(len(rest), gc.Equals, len(test.data))
+	}
+}
+
+func (*BackupCryptSuite) TestNotEncrypted(c *gc.C) {
+	key, err := backupcrypt.GenerateSecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = decrypt([]byte("<compressed archive data, long enough>"), key)
+	c.Check(err, gc.ErrorMatches, "backup archive is not encrypted")
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupcrypt
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// KeyType identifies the kind of a Key.
+type KeyType string
+
+const (
+	// SecretKey is a symmetric key, used both to encrypt and to
+	// decrypt archives.
+	SecretKey KeyType = "secret"
+
+	// PublicKey is the public half of a key pair. It can be used to
+	// encrypt archives, but not to decrypt them.
+	PublicKey KeyType = "public"
+
+	// PrivateKey is the private half of a key pair, used to decrypt
+	// archives encrypted with the corresponding public key.
+	PrivateKey KeyType = "private"
+)
+
+// keySize is the size in bytes of all keys.
+const keySize = 32
+
+// Key is a key used to encrypt or decrypt backup archives.
+type Key struct {
+	typ   KeyType
+	bytes [keySize]byte
+}
+
+// ParseKey parses a key in the format produced by Key.String:
+// the key type, a colon, and the base64 encoded key.
+func ParseKey(s string) (*Key, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.NotValidf("backup key without type")
+	}
+	typ := KeyType(parts[0])
+	switch typ {
+	case SecretKey, PublicKey, PrivateKey:
+	default:
+		return nil, errors.NotValidf("backup key type %q", typ)
+	}
+	data, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.Annotate(err, "decoding backup key")
+	}
+	if len(data) != keySize {
+		return nil, errors.NotValidf("%s backup key of %d bytes", typ, len(data))
+	}
+	key := &Key{typ: typ}
+	copy(key.bytes[:], data)
+	return key, nil
+}
+
+// GenerateSecretKey returns a new random secret key.
+func GenerateSecretKey() (*Key, error) {
+	key := &Key{typ: SecretKey}
+	if _, err := rand.Read(key.bytes[:]); err != nil {
+		return nil, errors.Annotate(err, "generating secret key")
+	}
+	return key, nil
+}
+
+// GenerateKeyPair returns a new random key pair.
+func GenerateKeyPair() (private, public *Key, err error) {
+	pub, priv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "generating key pair")
+	}
+	return &Key{typ: PrivateKey, bytes: *priv}, &Key{typ: PublicKey, bytes: *pub}, nil
+}
+
+// Type returns the type of the key.
+func (k *Key) Type() KeyType {
+	return k.typ
+}
+
+// String returns the key in the format accepted by ParseKey.
+func (k *Key) String() string {
+	return string(k.typ) + ":" + base64.StdEncoding.EncodeToString(k.bytes[:])
+}
+
+// CanDecrypt reports whether the key can be used to decrypt archives.
+func (k *Key) CanDecrypt() bool {
+	return k.typ != PublicKey
+}
+
+// Public returns the public key corresponding to a private key. Public
+// and secret keys are returned unchanged.
+func (k *Key) Public() *Key {
+	if k.typ != PrivateKey {
+		return k
+	}
+	public := &Key{typ: PublicKey}
+	curve25519.ScalarBaseMult(&public.bytes, &k.bytes)
+	return public
+}
+
+// Fingerprint returns a string identifying the key, which reveals
+// nothing about the key itself. A private key has the same
+// fingerprint as its public key, so that the key needed to decrypt
+// an archive can be matched with the key it was encrypted with.
+func (k *Key) Fingerprint() string {
+	public := k.Public()
+	sum := sha256.Sum256(append([]byte(public.typ), public.bytes[:]...))
+	parts := make([]string, 16)
+	for i, b := range sum[:16] {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupcrypt_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/backupcrypt"
+)
+
+type KeySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&KeySuite{})
+
+func (*KeySuite) TestParseKey(c *gc.C) {
+	key, err := backupcrypt.ParseKey("secret:AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(key.Type(), gc.Equals, backupcrypt.SecretKey)
+	c.Check(key.String(), gc.Equals, "secret:AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=")
+	c.Check(key.CanDecrypt(), jc.IsTrue)
+}
+
+func (*KeySuite) TestParseKeyErrors(c *gc.C) {
+	for i, test := range []struct {
+		key    string
+		expect string
+	}{{
+		key:    "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=",
+		expect: `backup key without type not valid`,
+	}, {
+		key:    "shared:AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=",
+		expect: `backup key type "shared" not valid`,
+	}, {
+		key:    "secret:!!!",
+		expect: `decoding backup key: .*`,
+	}, {
+		key:    "public:AAECAwQ=",
+		expect: `public backup key of 5 bytes not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.key)
+		_, err := backupcrypt.ParseKey(test.key)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (*KeySuite) TestKeyPair(c *gc.C) {
+	private, public, err := backupcrypt.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(private.Type(), gc.Equals, backupcrypt.PrivateKey)
+	c.Check(public.Type(), gc.Equals, backupcrypt.PublicKey)
+	c.Check(private.CanDecrypt(), jc.IsTrue)
+	c.Check(public.CanDecrypt(), jc.IsFalse)
+	c.Check(private.Public().String(), gc.Equals, public.String())
+	c.Check(public.Public(), gc.Equals, public)
+	c.Check(private.Fingerprint(), gc.Equals, public.Fingerprint())
+}
+
+func (*KeySuite) TestFingerprint(c *gc.C) {
+	key1, err := backupcrypt.GenerateSecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	key2, err := backupcrypt.GenerateSecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(key1.Fingerprint(), gc.Matches, `([0-9a-f]{2}:){15}[0-9a-f]{2}`)
+	c.Check(key1.Fingerprint(), gc.Not(gc.Equals), key2.Fingerprint())
+
+	parsed, err := backupcrypt.ParseKey(key1.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(parsed.Fingerprint(), gc.Equals, key1.Fingerprint())
+}
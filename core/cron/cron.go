@@ -0,0 +1,197 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses cron-style schedules, and calculates the times
+// at which they fire.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// aliases holds the shorthand schedules accepted in place of the five
+// schedule fields.
+var aliases = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field describes one of the five fields of a schedule.
+type field struct {
+	name     string
+	min, max uint
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	// Both 0 and 7 mean Sunday.
+	{"day of week", 0, 7},
+}
+
+// maxSearch bounds how far into the future Next looks for a matching
+// time, so that schedules which can never fire (e.g. "0 0 31 2 *")
+// do not search forever.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Schedule is a parsed cron schedule. It has the usual five fields
+// (minute, hour, day of month, month and day of week); each field is
+// either "*", or a comma-separated list of values and ranges, each of
+// which may be followed by "/step". As with cron, when both the day
+// of month and the day of week are restricted, a day matches if it
+// matches either field.
+type Schedule struct {
+	spec string
+
+	minute, hour, dom, month, dow uint64
+
+	domStar, dowStar bool
+}
+
+// Parse parses a schedule in cron format, such as "30 2 * * *" (every
+// day at 02:30) or "0 */6 * * 1-5" (every six hours on weekdays). The
+// shorthand schedules @yearly, @annually, @monthly, @weekly, @daily,
+// @midnight and @hourly are also accepted.
+func Parse(spec string) (*Schedule, error) {
+	expanded := strings.TrimSpace(spec)
+	if alias, ok := aliases[expanded]; ok {
+		expanded = alias
+	}
+	parts := strings.Fields(expanded)
+	if len(parts) != len(fields) {
+		return nil, errors.Errorf("invalid schedule %q: expected %d fields, got %d", spec, len(fields), len(parts))
+	}
+	sets := make([]uint64, len(fields))
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid schedule %q", spec)
+		}
+		sets[i] = set
+	}
+	dow := sets[4]
+	if dow&(1<<7) != 0 {
+		dow |= 1
+	}
+	return &Schedule{
+		spec:    spec,
+		minute:  sets[0],
+		hour:    sets[1],
+		dom:     sets[2],
+		month:   sets[3],
+		dow:     dow,
+		domStar: parts[2] == "*",
+		dowStar: parts[4] == "*",
+	}, nil
+}
+
+// parseField returns the set of values matched by the given schedule
+// field, as a bitmask.
+func parseField(value string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(value, ",") {
+		rangeSpec, step := item, uint(1)
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.ParseUint(item[i+1:], 10, 8)
+			if err != nil || n == 0 {
+				return 0, errors.Errorf("%s: invalid step in %q", f.name, item)
+			}
+			rangeSpec, step = item[:i], uint(n)
+		}
+		var first, last uint
+		switch {
+		case rangeSpec == "*":
+			first, last = f.min, f.max
+		case strings.Contains(rangeSpec, "-"):
+			bounds := strings.SplitN(rangeSpec, "-", 2)
+			var err error
+			if first, err = parseValue(bounds[0], f); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if last, err = parseValue(bounds[1], f); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if first > last {
+				return 0, errors.Errorf("%s: invalid range %q", f.name, rangeSpec)
+			}
+		default:
+			var err error
+			if first, err = parseValue(rangeSpec, f); err != nil {
+				return 0, errors.Trace(err)
+			}
+			last = first
+			if step > 1 {
+				// "a/n" means every n starting from a.
+				last = f.max
+			}
+		}
+		for v := first; v <= last; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+func parseValue(value string, f field) (uint, error) {
+	n, err := strconv.ParseUint(value, 10, 8)
+	if err != nil {
+		return 0, errors.Errorf("%s: expected a number, got %q", f.name, value)
+	}
+	if uint(n) < f.min || uint(n) > f.max {
+		return 0, errors.Errorf("%s: %d out of range [%d, %d]", f.name, n, f.min, f.max)
+	}
+	return uint(n), nil
+}
+
+// String returns the schedule as it was given to Parse.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the earliest time after t at which the schedule fires,
+// in t's location. It returns the zero time if the schedule does not
+// fire within the next five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	limit := t.Add(maxSearch)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(limit) {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !has(s.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !has(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchDay(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
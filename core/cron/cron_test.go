@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cron"
+)
+
+type CronSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CronSuite{})
+
+// Friday 2017-03-10 10:20:30 UTC.
+var now = time.Date(2017, 3, 10, 10, 20, 30, 0, time.UTC)
+
+func (*CronSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec   string
+		expect time.Time
+	}{{
+		spec:   "* * * * *",
+		expect: time.Date(2017, 3, 10, 10, 21, 0, 0, time.UTC),
+	}, {
+		spec:   "30 2 * * *",
+		expect: time.Date(2017, 3, 11, 2, 30, 0, 0, time.UTC),
+	}, {
+		spec:   "45 10 * * *",
+		expect: time.Date(2017, 3, 10, 10, 45, 0, 0, time.UTC),
+	}, {
+		spec:   "*/15 * * * *",
+		expect: time.Date(2017, 3, 10, 10, 30, 0, 0, time.UTC),
+	}, {
+		spec:   "0 */6 * * *",
+		expect: time.Date(2017, 3, 10, 12, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 9-17/4 * * 1-5",
+		expect: time.Date(2017, 3, 10, 13, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 3 * * 1-5",
+		expect: time.Date(2017, 3, 13, 3, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 * * 7",
+		expect: time.Date(2017, 3, 12, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 1,15 * *",
+		expect: time.Date(2017, 3, 15, 0, 0, 0, 0, time.UTC),
+	}, {
+		// Day of month and day of week are ORed when both are set.
+		spec:   "0 0 20 * 6",
+		expect: time.Date(2017, 3, 11, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 29 2 *",
+		expect: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@hourly",
+		expect: time.Date(2017, 3, 10, 11, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@daily",
+		expect: time.Date(2017, 3, 11, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@weekly",
+		expect: time.Date(2017, 3, 12, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@monthly",
+		expect: time.Date(2017, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@yearly",
+		expect: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 31 2 *",
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+		schedule, err := cron.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.String(), gc.Equals, test.spec)
+		c.Check(schedule.Next(now), gc.Equals, test.expect)
+	}
+}
+
+func (*CronSuite) TestNextUsesLocation(c *gc.C) {
+	schedule, err := cron.Parse("30 2 * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	loc := time.FixedZone("test", 6*60*60)
+	next := schedule.Next(now.In(loc))
+	c.Assert(next, gc.Equals, time.Date(2017, 3, 11, 2, 30, 0, 0, loc))
+}
+
+func (*CronSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec   string
+		expect string
+	}{{
+		spec:   "",
+		expect: `invalid schedule "": expected 5 fields, got 0`,
+	}, {
+		spec:   "0 0 * *",
+		expect: `invalid schedule "0 0 \* \*": expected 5 fields, got 4`,
+	}, {
+		spec:   "@fortnightly",
+		expect: `invalid schedule "@fortnightly": expected 5 fields, got 1`,
+	}, {
+		spec:   "60 * * * *",
+		expect: `invalid schedule "60 \* \* \* \*": minute: 60 out of range \[0, 59\]`,
+	}, {
+		spec:   "0 0 0 * *",
+		expect: `invalid schedule "0 0 0 \* \*": day of month: 0 out of range \[1, 31\]`,
+	}, {
+		spec:   "0 x * * *",
+		expect: `invalid schedule "0 x \* \* \*": hour: expected a number, got "x"`,
+	}, {
+		spec:   "0 5-2 * * *",
+		expect: `invalid schedule "0 5-2 \* \* \*": hour: invalid range "5-2"`,
+	}, {
+		spec:   "*/0 * * * *",
+		expect: `invalid schedule "\*/0 \* \* \* \*": minute: invalid step in "\*/0"`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := cron.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
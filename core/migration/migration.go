@@ -10,6 +10,7 @@ import (
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/resource"
 )
 
@@ -55,6 +56,11 @@ type SerializedModel struct {
 
 	// Resources represents all the resources in use in the model.
 	Resources []SerializedModelResource
+
+	// Quotas holds the quotas on the resources that the model may
+	// use. The model description has no place for them, so they are
+	// set on the imported model separately.
+	Quotas quota.Resources
 }
 
 // SerializedModelResource defines the resource revisions for a
@@ -72,6 +78,7 @@ type ModelInfo struct {
 	Name                   string
 	AgentVersion           version.Number
 	ControllerAgentVersion version.Number
+	Quotas                 quota.Resources
 }
 
 func (i *ModelInfo) Validate() error {
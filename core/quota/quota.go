@@ -0,0 +1,173 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package quota defines the limits that may be placed on the resources
+// that a model uses.
+package quota
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+const (
+	// Machines is the name of the quota on the number of top-level
+	// machines in a model.
+	Machines = "machines"
+
+	// Units is the name of the quota on the number of principal units
+	// in a model.
+	Units = "units"
+
+	// Cores is the name of the quota on the total CPU cores of the
+	// top-level machines in a model.
+	Cores = "cores"
+
+	// Memory is the name of the quota on the total memory of the
+	// top-level machines in a model.
+	Memory = "memory"
+
+	// Storage is the name of the quota on the total size of the
+	// volumes and filesystems in a model.
+	Storage = "storage"
+)
+
+// Resources holds amounts of the resources that count against a model's
+// quotas. It is used both for the quotas themselves, where a zero amount
+// means that the resource is not limited, and for the resources that a
+// model uses.
+type Resources struct {
+	// Machines is the number of top-level machines. Containers are not
+	// counted, as they use the resources of their host.
+	Machines int
+
+	// Units is the number of principal units. Subordinate units are not
+	// counted, as they use the resources of their principal.
+	Units int
+
+	// Cores is the number of CPU cores of the top-level machines.
+	Cores uint64
+
+	// Memory is the memory of the top-level machines, in MiB.
+	Memory uint64
+
+	// Storage is the size of the volumes and filesystems, in MiB.
+	Storage uint64
+}
+
+// Parse parses quotas written as space separated name=value pairs, such
+// as "machines=10 memory=64G". Memory and storage sizes are in MiB
+// unless they have one of the suffixes M, G, T or P.
+func Parse(s string) (Resources, error) {
+	var r Resources
+	for _, field := range strings.Fields(s) {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return Resources{}, errors.NotValidf("quota %q", field)
+		}
+		name, value := parts[0], parts[1]
+		var err error
+		switch name {
+		case Machines:
+			r.Machines, err = parseCount(value)
+		case Units:
+			r.Units, err = parseCount(value)
+		case Cores:
+			r.Cores, err = strconv.ParseUint(value, 10, 64)
+		case Memory:
+			r.Memory, err = utils.ParseSize(value)
+		case Storage:
+			r.Storage, err = utils.ParseSize(value)
+		default:
+			return Resources{}, errors.NotValidf("quota name %q", name)
+		}
+		if err != nil {
+			return Resources{}, errors.NotValidf("%s quota %q", name, value)
+		}
+	}
+	return r, nil
+}
+
+func parseCount(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err == nil && n < 0 {
+		err = errors.New("negative count")
+	}
+	return n, err
+}
+
+// String returns the non-zero amounts in the form read by Parse.
+func (r Resources) String() string {
+	var fields []string
+	if r.Machines > 0 {
+		fields = append(fields, fmt.Sprintf("%s=%d", Machines, r.Machines))
+	}
+	if r.Units > 0 {
+		fields = append(fields, fmt.Sprintf("%s=%d", Units, r.Units))
+	}
+	if r.Cores > 0 {
+		fields = append(fields, fmt.Sprintf("%s=%d", Cores, r.Cores))
+	}
+	if r.Memory > 0 {
+		fields = append(fields, fmt.Sprintf("%s=%dM", Memory, r.Memory))
+	}
+	if r.Storage > 0 {
+		fields = append(fields, fmt.Sprintf("%s=%dM", Storage, r.Storage))
+	}
+	return strings.Join(fields, " ")
+}
+
+// Add returns the sum of the amounts.
+func (r Resources) Add(other Resources) Resources {
+	return Resources{
+		Machines: r.Machines + other.Machines,
+		Units:    r.Units + other.Units,
+		Cores:    r.Cores + other.Cores,
+		Memory:   r.Memory + other.Memory,
+		Storage:  r.Storage + other.Storage,
+	}
+}
+
+// Check returns an error if adding the requested resources to those
+// already used would exceed any of the quotas held in r. Resources that
+// are not requested are not checked, so a model that already exceeds a
+// quota may still be given other resources.
+func (r Resources) Check(used, requested Resources) error {
+	if r.Machines > 0 && requested.Machines > 0 && used.Machines+requested.Machines > r.Machines {
+		return exceededError{Machines, strconv.Itoa(r.Machines), strconv.Itoa(used.Machines)}
+	}
+	if r.Units > 0 && requested.Units > 0 && used.Units+requested.Units > r.Units {
+		return exceededError{Units, strconv.Itoa(r.Units), strconv.Itoa(used.Units)}
+	}
+	if r.Cores > 0 && requested.Cores > 0 && used.Cores+requested.Cores > r.Cores {
+		return exceededError{Cores, strconv.FormatUint(r.Cores, 10), strconv.FormatUint(used.Cores, 10)}
+	}
+	if r.Memory > 0 && requested.Memory > 0 && used.Memory+requested.Memory > r.Memory {
+		return exceededError{Memory, fmt.Sprintf("%dM", r.Memory), fmt.Sprintf("%dM", used.Memory)}
+	}
+	if r.Storage > 0 && requested.Storage > 0 && used.Storage+requested.Storage > r.Storage {
+		return exceededError{Storage, fmt.Sprintf("%dM", r.Storage), fmt.Sprintf("%dM", used.Storage)}
+	}
+	return nil
+}
+
+// exceededError is returned when a model's quota would be exceeded.
+type exceededError struct {
+	name, quota, used string
+}
+
+// Error is part of the error interface.
+func (e exceededError) Error() string {
+	return fmt.Sprintf("model %s quota of %s exceeded (%s in use)", e.name, e.quota, e.used)
+}
+
+// IsExceeded returns whether the cause of the error is that a model's
+// quota would be exceeded.
+func IsExceeded(err error) bool {
+	_, ok := errors.Cause(err).(exceededError)
+	return ok
+}
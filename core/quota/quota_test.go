@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quota_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/quota"
+)
+
+type QuotaSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&QuotaSuite{})
+
+func (s *QuotaSuite) TestParse(c *gc.C) {
+	r, err := quota.Parse("machines=10 units=20 cores=40 memory=64G storage=1T")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r, jc.DeepEquals, quota.Resources{
+		Machines: 10,
+		Units:    20,
+		Cores:    40,
+		Memory:   64 * 1024,
+		Storage:  1024 * 1024,
+	})
+	c.Assert(r.String(), gc.Equals, "machines=10 units=20 cores=40 memory=65536M storage=1048576M")
+
+	r, err = quota.Parse("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r, jc.DeepEquals, quota.Resources{})
+	c.Assert(r.String(), gc.Equals, "")
+}
+
+func (s *QuotaSuite) TestParseInvalid(c *gc.C) {
+	for i, test := range []struct {
+		quotas string
+		err    string
+	}{{
+		quotas: "machines",
+		err:    `quota "machines" not valid`,
+	}, {
+		quotas: "disks=10",
+		err:    `quota name "disks" not valid`,
+	}, {
+		quotas: "machines=-1",
+		err:    `machines quota "-1" not valid`,
+	}, {
+		quotas: "cores=many",
+		err:    `cores quota "many" not valid`,
+	}, {
+		quotas: "memory=64X",
+		err:    `memory quota "64X" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.quotas)
+		_, err := quota.Parse(test.quotas)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *QuotaSuite) TestCheck(c *gc.C) {
+	quotas := quota.Resources{Machines: 2, Memory: 4096}
+	used := quota.Resources{Machines: 1, Memory: 2048, Units: 100}
+
+	err := quotas.Check(used, quota.Resources{Machines: 1, Memory: 2048})
+	c.Assert(err, jc.ErrorIsNil)
+	err = quotas.Check(used, quota.Resources{Units: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = quotas.Check(used, quota.Resources{Machines: 2})
+	c.Assert(err, gc.ErrorMatches, `model machines quota of 2 exceeded \(1 in use\)`)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+	err = quotas.Check(used, quota.Resources{Machines: 1, Memory: 4096})
+	c.Assert(err, gc.ErrorMatches, `model memory quota of 4096M exceeded \(2048M in use\)`)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+}
+
+func (s *QuotaSuite) TestAdd(c *gc.C) {
+	r := quota.Resources{Machines: 1, Cores: 2}.Add(quota.Resources{Machines: 1, Storage: 1024})
+	c.Assert(r, jc.DeepEquals, quota.Resources{Machines: 2, Cores: 2, Storage: 1024})
+}
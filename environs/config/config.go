@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -22,6 +24,9 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/elasticsearch"
+	"github.com/juju/juju/logfwd/gelf"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -43,6 +48,16 @@ const (
 	FwNone = "none"
 )
 
+const (
+	// DefaultActionResultsAge is the default maximum age of the
+	// results of completed actions.
+	DefaultActionResultsAge = "336h" // 2 weeks
+
+	// DefaultActionResultsSize is the default maximum size of the
+	// stored results of completed actions.
+	DefaultActionResultsSize = "5G"
+)
+
 // TODO(katco-): Please grow this over time.
 // Centralized place to store values of config keys. This transitions
 // mistakes in referencing key-values to a compile-time error.
@@ -125,6 +140,71 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdLevel sets the minimum level of forwarded log records.
+	LogFwdLevel = "logforward-level"
+
+	// LogFwdIncludeEntity lists the entities (tags, which may include
+	// the "*" wildcard) whose log records are forwarded.
+	LogFwdIncludeEntity = "logforward-include"
+
+	// LogFwdExcludeEntity lists the entities (tags, which may include
+	// the "*" wildcard) whose log records are not forwarded.
+	LogFwdExcludeEntity = "logforward-exclude"
+
+	// LogFwdIncludeModule lists the logging modules whose records
+	// are forwarded.
+	LogFwdIncludeModule = "logforward-include-module"
+
+	// LogFwdExcludeModule lists the logging modules whose records
+	// are not forwarded.
+	LogFwdExcludeModule = "logforward-exclude-module"
+
+	// LogFwdElasticsearchURL sets the base URL of the Elasticsearch
+	// (or OpenSearch) cluster to which logs are forwarded.
+	LogFwdElasticsearchURL = "elasticsearch-url"
+
+	// LogFwdElasticsearchIndex sets the Elasticsearch index to which
+	// logs are forwarded.
+	LogFwdElasticsearchIndex = "elasticsearch-index"
+
+	// LogFwdElasticsearchUsername sets the username for Elasticsearch
+	// forwarding.
+	LogFwdElasticsearchUsername = "elasticsearch-username"
+
+	// LogFwdElasticsearchPassword sets the password for Elasticsearch
+	// forwarding.
+	LogFwdElasticsearchPassword = "elasticsearch-password"
+
+	// LogFwdElasticsearchCACert sets the certificate of the CA that
+	// signed the Elasticsearch server certificate.
+	LogFwdElasticsearchCACert = "elasticsearch-ca-cert"
+
+	// LogFwdElasticsearchClientCert sets the client certificate for
+	// Elasticsearch forwarding.
+	LogFwdElasticsearchClientCert = "elasticsearch-client-cert"
+
+	// LogFwdElasticsearchClientKey sets the client key for
+	// Elasticsearch forwarding.
+	LogFwdElasticsearchClientKey = "elasticsearch-client-key"
+
+	// LogFwdGELFHost sets the hostname:port of the Graylog GELF input.
+	LogFwdGELFHost = "gelf-host"
+
+	// LogFwdGELFProtocol sets the protocol (udp or tcp) used for GELF
+	// forwarding.
+	LogFwdGELFProtocol = "gelf-protocol"
+
+	// LogFwdGELFCACert sets the certificate of the CA that signed the
+	// Graylog server certificate.
+	LogFwdGELFCACert = "gelf-ca-cert"
+
+	// LogFwdGELFClientCert sets the client certificate for GELF
+	// forwarding.
+	LogFwdGELFClientCert = "gelf-client-cert"
+
+	// LogFwdGELFClientKey sets the client key for GELF forwarding.
+	LogFwdGELFClientKey = "gelf-client-key"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -133,6 +213,15 @@ const (
 	// metrics collected in this model for anonymized aggregate analytics.
 	TransmitVendorMetricsKey = "transmit-vendor-metrics"
 
+	// MaxActionResultsAge is the key for the maximum age of the results
+	// of completed actions; older results are pruned.
+	MaxActionResultsAge = "max-action-results-age"
+
+	// MaxActionResultsSize is the key for the maximum size of the
+	// stored results of completed actions, such as 512M or 5G; the
+	// oldest results are pruned to keep under it.
+	MaxActionResultsSize = "max-action-results-size"
+
 	// ExtraInfoKey is the key for arbitrary user specified string data that
 	// is stored against the model.
 	ExtraInfoKey = "extra-info"
@@ -322,6 +411,8 @@ var defaultConfigValues = map[string]interface{}{
 	"development":              false,
 	"test-mode":                false,
 	TransmitVendorMetricsKey:   true,
+	MaxActionResultsAge:        DefaultActionResultsAge,
+	MaxActionResultsSize:       DefaultActionResultsSize,
 
 	// Image and agent streams and URLs.
 	"image-stream":       "released",
@@ -464,15 +555,47 @@ func Validate(cfg, old *Config) error {
 	}
 
 	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
+		// Log forwarding may be enabled for other sinks only,
+		// in which case no syslog host is needed.
+		if lfCfg.Host == "" && cfg.otherLogFwdSinkConfigured() {
+			lfCfg.Enabled = false
+		}
 		if err := lfCfg.Validate(); err != nil {
 			return errors.Annotate(err, "invalid syslog forwarding config")
 		}
 	}
 
+	if _, err := cfg.logFwdFilter(); err != nil {
+		return errors.Annotate(err, "invalid log forwarding filter")
+	}
+
+	if lfCfg, ok := cfg.LogFwdElasticsearch(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid elasticsearch forwarding config")
+		}
+	}
+
+	if lfCfg, ok := cfg.LogFwdGELF(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid gelf forwarding config")
+		}
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
 
+	if v, ok := cfg.defined[MaxActionResultsAge].(string); ok {
+		if d, err := time.ParseDuration(v); err != nil || d < 0 {
+			return errors.Errorf("invalid %s in model configuration: %q", MaxActionResultsAge, v)
+		}
+	}
+	if v, ok := cfg.defined[MaxActionResultsSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Errorf("invalid %s in model configuration: %q", MaxActionResultsSize, v)
+		}
+	}
+
 	// Ensure the resource tags have the expected k=v format.
 	if _, err := cfg.resourceTags(); err != nil {
 		return errors.Annotate(err, "validating resource tags")
@@ -705,6 +828,103 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdElasticsearch returns the Elasticsearch forwarding config.
+func (c *Config) LogFwdElasticsearch() (*elasticsearch.RawConfig, bool) {
+	partial := false
+	var lfCfg elasticsearch.RawConfig
+
+	for key, field := range map[string]*string{
+		LogFwdElasticsearchURL:        &lfCfg.URL,
+		LogFwdElasticsearchIndex:      &lfCfg.Index,
+		LogFwdElasticsearchUsername:   &lfCfg.Username,
+		LogFwdElasticsearchPassword:   &lfCfg.Password,
+		LogFwdElasticsearchCACert:     &lfCfg.CACert,
+		LogFwdElasticsearchClientCert: &lfCfg.ClientCert,
+		LogFwdElasticsearchClientKey:  &lfCfg.ClientKey,
+	} {
+		if s, ok := c.defined[key]; ok && s != "" {
+			partial = true
+			*field = s.(string)
+		}
+	}
+
+	if !partial {
+		return nil, false
+	}
+	lfCfg.Enabled, _ = c.defined[LogForwardEnabled].(bool)
+	return &lfCfg, true
+}
+
+// LogFwdGELF returns the Graylog (GELF) forwarding config.
+func (c *Config) LogFwdGELF() (*gelf.RawConfig, bool) {
+	partial := false
+	var lfCfg gelf.RawConfig
+
+	for key, field := range map[string]*string{
+		LogFwdGELFHost:       &lfCfg.Host,
+		LogFwdGELFProtocol:   &lfCfg.Protocol,
+		LogFwdGELFCACert:     &lfCfg.CACert,
+		LogFwdGELFClientCert: &lfCfg.ClientCert,
+		LogFwdGELFClientKey:  &lfCfg.ClientKey,
+	} {
+		if s, ok := c.defined[key]; ok && s != "" {
+			partial = true
+			*field = s.(string)
+		}
+	}
+
+	if !partial {
+		return nil, false
+	}
+	lfCfg.Enabled, _ = c.defined[LogForwardEnabled].(bool)
+	return &lfCfg, true
+}
+
+// LogFwdFilter returns the filter selecting the log records to be
+// forwarded.
+func (c *Config) LogFwdFilter() logfwd.Filter {
+	// The filter is checked when the config is validated.
+	filter, _ := c.logFwdFilter()
+	return filter
+}
+
+func (c *Config) logFwdFilter() (logfwd.Filter, error) {
+	filter := logfwd.Filter{
+		IncludeEntity: splitList(c.asString(LogFwdIncludeEntity)),
+		ExcludeEntity: splitList(c.asString(LogFwdExcludeEntity)),
+		IncludeModule: splitList(c.asString(LogFwdIncludeModule)),
+		ExcludeModule: splitList(c.asString(LogFwdExcludeModule)),
+	}
+	if value := c.asString(LogFwdLevel); value != "" {
+		level, ok := loggo.ParseLevel(value)
+		if !ok {
+			return logfwd.Filter{}, errors.NotValidf("%s %q", LogFwdLevel, value)
+		}
+		filter.MinLevel = level
+	}
+	if err := filter.Validate(); err != nil {
+		return logfwd.Filter{}, errors.Trace(err)
+	}
+	return filter, nil
+}
+
+// splitList splits a comma or space separated list.
+func splitList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+// otherLogFwdSinkConfigured reports whether logs are to be forwarded
+// to a sink other than syslog.
+func (c *Config) otherLogFwdSinkConfigured() bool {
+	if _, ok := c.LogFwdElasticsearch(); ok {
+		return true
+	}
+	_, ok := c.LogFwdGELF()
+	return ok
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -801,6 +1021,31 @@ func (c *Config) TransmitVendorMetrics() bool {
 	}
 }
 
+// MaxActionResultsAge returns the maximum age of the results of
+// completed actions. Zero means results are not pruned by age.
+func (c *Config) MaxActionResultsAge() time.Duration {
+	v, ok := c.defined[MaxActionResultsAge].(string)
+	if !ok {
+		v = DefaultActionResultsAge
+	}
+	// The value has been validated already.
+	d, _ := time.ParseDuration(v)
+	return d
+}
+
+// MaxActionResultsSizeMB returns the maximum size, in megabytes, of the
+// stored results of completed actions. Zero means results are not
+// pruned by size.
+func (c *Config) MaxActionResultsSizeMB() uint {
+	v, ok := c.defined[MaxActionResultsSize].(string)
+	if !ok {
+		v = DefaultActionResultsSize
+	}
+	// The value has been validated already.
+	size, _ := utils.ParseSize(v)
+	return uint(size)
+}
+
 // ProvisionerHarvestMode reports the harvesting methodology the
 // provisioner should take.
 func (c *Config) ProvisionerHarvestMode() HarvestMode {
@@ -964,6 +1209,26 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
 
+	LogFwdLevel:         schema.Omit,
+	LogFwdIncludeEntity: schema.Omit,
+	LogFwdExcludeEntity: schema.Omit,
+	LogFwdIncludeModule: schema.Omit,
+	LogFwdExcludeModule: schema.Omit,
+
+	LogFwdElasticsearchURL:        schema.Omit,
+	LogFwdElasticsearchIndex:      schema.Omit,
+	LogFwdElasticsearchUsername:   schema.Omit,
+	LogFwdElasticsearchPassword:   schema.Omit,
+	LogFwdElasticsearchCACert:     schema.Omit,
+	LogFwdElasticsearchClientCert: schema.Omit,
+	LogFwdElasticsearchClientKey:  schema.Omit,
+
+	LogFwdGELFHost:       schema.Omit,
+	LogFwdGELFProtocol:   schema.Omit,
+	LogFwdGELFCACert:     schema.Omit,
+	LogFwdGELFClientCert: schema.Omit,
+	LogFwdGELFClientKey:  schema.Omit,
+
 	// Storage related config.
 	// Environ providers will specify their own defaults.
 	StorageDefaultBlockSourceKey: schema.Omit,
@@ -997,6 +1262,8 @@ var alwaysOptional = schema.Defaults{
 	"test-mode":                  schema.Omit,
 	TransmitVendorMetricsKey:     schema.Omit,
 	NetBondReconfigureDelayKey:   schema.Omit,
+	MaxActionResultsAge:          schema.Omit,
+	MaxActionResultsSize:         schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1032,6 +1299,16 @@ var immutableAttributes = []string{
 	"firewall-mode",
 }
 
+// SecretAttributes are attributes which hold private keys or
+// credentials used to forward logs. They are only handed to controller
+// agents, and are never returned to API clients or other agents.
+var SecretAttributes = []string{
+	LogFwdSyslogClientKey,
+	LogFwdElasticsearchPassword,
+	LogFwdElasticsearchClientKey,
+	LogFwdGELFClientKey,
+}
+
 var (
 	withDefaultsChecker = schema.FieldMap(fields, defaultsWhenParsing)
 	noDefaultsChecker   = schema.FieldMap(fields, alwaysOptional)
@@ -1316,6 +1593,96 @@ global or per instance security groups.`,
 		Description: `The syslog client key in PEM format.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
+		Secret:      true,
+	},
+	LogFwdLevel: {
+		Description: `The minimum level (TRACE, DEBUG, INFO, WARNING or ERROR) of forwarded log records.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdIncludeEntity: {
+		Description: `Only forward log records from these entities (e.g. "machine-0 unit-mysql-*").`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdExcludeEntity: {
+		Description: `Do not forward log records from these entities.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdIncludeModule: {
+		Description: `Only forward log records from these logging modules (e.g. "juju.worker").`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdExcludeModule: {
+		Description: `Do not forward log records from these logging modules.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdElasticsearchURL: {
+		Description: `The base URL of the Elasticsearch (or OpenSearch) cluster to forward logs to.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdElasticsearchIndex: {
+		Description: `The Elasticsearch index to forward logs to (default "juju").`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdElasticsearchUsername: {
+		Description: `The username used to authenticate with Elasticsearch.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdElasticsearchPassword: {
+		Description: `The password used to authenticate with Elasticsearch.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+		Secret:      true,
+	},
+	LogFwdElasticsearchCACert: {
+		Description: `The certificate of the CA that signed the Elasticsearch server certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdElasticsearchClientCert: {
+		Description: `The Elasticsearch client certificate in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdElasticsearchClientKey: {
+		Description: `The Elasticsearch client key in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+		Secret:      true,
+	},
+	LogFwdGELFHost: {
+		Description: `The hostname:port of the Graylog GELF input.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdGELFProtocol: {
+		Description: `The protocol used to forward logs to Graylog.`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{"udp", "tcp"},
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdGELFCACert: {
+		Description: `The certificate of the CA that signed the Graylog server certificate, in PEM format (TCP only).`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdGELFClientCert: {
+		Description: `The Graylog client certificate in PEM format (TCP only).`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdGELFClientKey: {
+		Description: `The Graylog client key in PEM format (TCP only).`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+		Secret:      true,
 	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
@@ -1362,4 +1729,14 @@ data of the store. (default false)`,
 		Type:        environschema.Tint,
 		Group:       environschema.EnvironGroup,
 	},
+	MaxActionResultsAge: {
+		Description: "The maximum age of the results of completed actions, such as 336h; older results are pruned",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxActionResultsSize: {
+		Description: "The maximum size of the stored results of completed actions, such as 5G; the oldest results are pruned to keep under it",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/testing"
 )
 
@@ -526,6 +527,27 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			config.NetBondReconfigureDelayKey: 1234,
 		}),
+	}, {
+		about:       "max-action-results values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.MaxActionResultsAge:  "72h",
+			config.MaxActionResultsSize: "512M",
+		}),
+	}, {
+		about:       "invalid max-action-results-age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.MaxActionResultsAge: "a week",
+		}),
+		err: `invalid max-action-results-age in model configuration: "a week"`,
+	}, {
+		about:       "invalid max-action-results-size",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.MaxActionResultsSize: "lots",
+		}),
+		err: `invalid max-action-results-size in model configuration: "lots"`,
 	}, {
 		about:       "transmit-vendor-metrics asserted with default value",
 		useDefaults: config.UseDefaults,
@@ -550,6 +572,64 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid log forwarding filter",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":        true,
+			"syslog-host":               "localhost:1234",
+			"logforward-level":          "info",
+			"logforward-include":        "unit-mysql-*, machine-0",
+			"logforward-exclude":        "unit-mysql-1",
+			"logforward-include-module": "juju.worker",
+			"logforward-exclude-module": "juju.worker.uniter juju.worker.logger",
+		}),
+	}, {
+		about:       "Invalid log forwarding level",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-level": "chatty",
+		}),
+		err: `invalid log forwarding filter: logforward-level "chatty" not valid`,
+	}, {
+		about:       "Valid elasticsearch config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":     true,
+			"elasticsearch-url":      "https://es.example.com:9200",
+			"elasticsearch-index":    "juju-logs",
+			"elasticsearch-username": "juju",
+			"elasticsearch-password": "sekrit",
+			"elasticsearch-ca-cert":  testing.CACert,
+		}),
+	}, {
+		about:       "Invalid elasticsearch URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"elasticsearch-url":  "es.example.com",
+		}),
+		err: `invalid elasticsearch forwarding config: URL "es.example.com" \(scheme must be http or https\) not valid`,
+	}, {
+		about:       "Valid gelf config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"gelf-host":          "graylog.example.com:12201",
+			"gelf-protocol":      "tcp",
+			"gelf-ca-cert":       testing.CACert,
+			"gelf-client-cert":   testing.ServerCert,
+			"gelf-client-key":    testing.ServerKey,
+		}),
+	}, {
+		about:       "Invalid gelf TLS over UDP",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"gelf-host":          "graylog.example.com",
+			"gelf-ca-cert":       testing.CACert,
+		}),
+		err: `invalid gelf forwarding config: TLS over udp not valid`,
 	},
 }
 
@@ -572,6 +652,28 @@ func (s *ConfigSuite) TestConfig(c *gc.C) {
 	}
 }
 
+func (s *ConfigSuite) TestLogFwdFilter(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-level":          "info",
+		"logforward-include":        "unit-mysql-*, machine-0",
+		"logforward-exclude":        "unit-mysql-1",
+		"logforward-include-module": "juju.worker",
+		"logforward-exclude-module": "juju.worker.uniter juju.worker.logger",
+	})
+	c.Assert(cfg.LogFwdFilter(), jc.DeepEquals, logfwd.Filter{
+		MinLevel:      loggo.INFO,
+		IncludeEntity: []string{"unit-mysql-*", "machine-0"},
+		ExcludeEntity: []string{"unit-mysql-1"},
+		IncludeModule: []string{"juju.worker"},
+		ExcludeModule: []string{"juju.worker.uniter", "juju.worker.logger"},
+	})
+}
+
+func (s *ConfigSuite) TestLogFwdFilterDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.LogFwdFilter(), jc.DeepEquals, logfwd.Filter{})
+}
+
 func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 	cfg, err := config.New(test.useDefaults, test.attrs)
 	if test.err != "" {
@@ -652,6 +754,23 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Check(lfCfg.ClientKey, gc.Equals, "")
 	}
 
+	esCfg, hasESCfg := cfg.LogFwdElasticsearch()
+	if v, _ := test.attrs["elasticsearch-url"].(string); v != "" {
+		c.Assert(hasESCfg, jc.IsTrue)
+		c.Assert(esCfg.URL, gc.Equals, v)
+		c.Assert(esCfg.Enabled, gc.Equals, test.attrs["logforward-enabled"])
+	} else {
+		c.Assert(hasESCfg, jc.IsFalse)
+	}
+	gelfCfg, hasGELFCfg := cfg.LogFwdGELF()
+	if v, _ := test.attrs["gelf-host"].(string); v != "" {
+		c.Assert(hasGELFCfg, jc.IsTrue)
+		c.Assert(gelfCfg.Host, gc.Equals, v)
+		c.Assert(gelfCfg.Enabled, gc.Equals, test.attrs["logforward-enabled"])
+	} else {
+		c.Assert(hasGELFCfg, jc.IsFalse)
+	}
+
 	if v, ok := test.attrs["ssl-hostname-verification"]; ok {
 		c.Assert(cfg.SSLHostnameVerification(), gc.Equals, v)
 	}
@@ -999,6 +1118,21 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestMaxActionResultsDefaults(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.MaxActionResultsAge(), gc.Equals, 336*time.Hour)
+	c.Assert(config.MaxActionResultsSizeMB(), gc.Equals, uint(5120))
+}
+
+func (s *ConfigSuite) TestMaxActionResults(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"max-action-results-age":  "72h",
+		"max-action-results-size": "0",
+	})
+	c.Assert(config.MaxActionResultsAge(), gc.Equals, 72*time.Hour)
+	c.Assert(config.MaxActionResultsSizeMB(), gc.Equals, uint(0))
+}
+
 func (s *ConfigSuite) TestProxyValuesWithFallback(c *gc.C) {
 	s.addJujuFiles(c)
 
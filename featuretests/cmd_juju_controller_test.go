@@ -50,7 +50,7 @@ func (s *cmdControllerSuite) createModelAdminUser(c *gc.C, modelname string, isS
 	model, err := modelManager.CreateModel(
 		modelname, s.AdminUserTag(c).Id(), "", "", names.CloudCredentialTag{}, map[string]interface{}{
 			"controller": isServer,
-		},
+		}, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	return model
@@ -64,7 +64,7 @@ func (s *cmdControllerSuite) createModelNormalUser(c *gc.C, modelname string, is
 		modelname, names.NewLocalUserTag("test").Id(), "", "", names.CloudCredentialTag{}, map[string]interface{}{
 			"authorized-keys": "ssh-key",
 			"controller":      isServer,
-		},
+		}, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 }
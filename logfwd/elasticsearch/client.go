@@ -0,0 +1,229 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// Doer exposes the underlying functionality needed by Client.
+type Doer interface {
+	// Do sends the HTTP request and returns the response.
+	Do(*http.Request) (*http.Response, error)
+}
+
+// requestTimeout is the maximum time allowed for each bulk request.
+const requestTimeout = 30 * time.Second
+
+// Client sends log records to an Elasticsearch cluster.
+type Client struct {
+	// Doer is the HTTP client this client wraps.
+	Doer Doer
+
+	// Config is the configuration of the connection.
+	Config RawConfig
+}
+
+// Open returns a new client which sends records to the Elasticsearch
+// cluster identified by the config.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	doer := &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsCfg,
+		},
+	}
+	client, err := OpenForDoer(cfg, doer)
+	return client, errors.Trace(err)
+}
+
+// OpenForDoer returns a new client which sends records to the
+// Elasticsearch cluster identified by the config using the given
+// HTTP client.
+func OpenForDoer(cfg RawConfig, doer Doer) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := &Client{
+		Doer:   doer,
+		Config: cfg,
+	}
+	return client, nil
+}
+
+// Close implements io.Closer. Connections are made per request,
+// so there is nothing to close.
+func (client Client) Close() error {
+	return nil
+}
+
+// Send sends the records to the Elasticsearch cluster in a single
+// bulk request.
+func (client Client) Send(records []logfwd.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	body, err := client.bulkBody(records)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	url := strings.TrimSuffix(client.Config.URL, "/") + "/_bulk"
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if client.Config.Username != "" {
+		req.SetBasicAuth(client.Config.Username, client.Config.Password)
+	}
+
+	resp, err := client.Doer.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "sending bulk request")
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Annotate(err, "reading bulk response")
+	}
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("bulk request failed: %s: %s", resp.Status, bytes.TrimSpace(respBody))
+	}
+	return errors.Trace(checkBulkResponse(respBody))
+}
+
+// bulkBody returns the newline-delimited JSON body of a bulk request
+// indexing the records.
+func (client Client) bulkBody(records []logfwd.Record) ([]byte, error) {
+	action, err := json.Marshal(bulkAction{
+		Index: bulkIndex{Index: client.Config.index()},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var buf bytes.Buffer
+	for _, rec := range records {
+		doc, err := documentFromRecord(rec)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return nil, errors.Annotate(err, "marshalling record")
+		}
+		buf.Write(action)
+		buf.WriteByte('\n')
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+type bulkAction struct {
+	Index bulkIndex `json:"index"`
+}
+
+type bulkIndex struct {
+	Index string `json:"_index"`
+}
+
+// Document is the representation of a log record stored in
+// Elasticsearch.
+type Document struct {
+	Timestamp       time.Time `json:"@timestamp"`
+	Level           string    `json:"level"`
+	Message         string    `json:"message"`
+	Module          string    `json:"module,omitempty"`
+	Source          string    `json:"source,omitempty"`
+	RecordID        int64     `json:"record-id"`
+	ControllerUUID  string    `json:"controller-uuid"`
+	ModelUUID       string    `json:"model-uuid"`
+	Hostname        string    `json:"hostname,omitempty"`
+	OriginType      string    `json:"origin-type"`
+	OriginName      string    `json:"origin-name,omitempty"`
+	SoftwareName    string    `json:"software-name,omitempty"`
+	SoftwareVersion string    `json:"software-version,omitempty"`
+}
+
+func documentFromRecord(rec logfwd.Record) (Document, error) {
+	switch rec.Level {
+	case loggo.CRITICAL, loggo.ERROR, loggo.WARNING, loggo.INFO, loggo.DEBUG, loggo.TRACE:
+	default:
+		return Document{}, errors.Errorf("unsupported log level %q", rec.Level)
+	}
+	doc := Document{
+		Timestamp:      rec.Timestamp.UTC(),
+		Level:          rec.Level.String(),
+		Message:        rec.Message,
+		Module:         rec.Location.Module,
+		Source:         rec.Location.String(),
+		RecordID:       rec.ID,
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		Hostname:       rec.Origin.Hostname,
+		OriginType:     rec.Origin.Type.String(),
+		OriginName:     rec.Origin.Name,
+		SoftwareName:   rec.Origin.Software.Name,
+	}
+	if rec.Origin.Software.Name != "" {
+		doc.SoftwareVersion = rec.Origin.Software.Version.String()
+	}
+	return doc, nil
+}
+
+type bulkResponse struct {
+	Errors bool                        `json:"errors"`
+	Items  []map[string]bulkItemResult `json:"items"`
+}
+
+type bulkItemResult struct {
+	Status int             `json:"status"`
+	Error  json.RawMessage `json:"error"`
+}
+
+// checkBulkResponse returns an error describing the first record the
+// cluster failed to index, if any.
+func checkBulkResponse(body []byte) error {
+	var resp bulkResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return errors.Annotate(err, "parsing bulk response")
+	}
+	if !resp.Errors {
+		return nil
+	}
+	failed := 0
+	var first string
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Status/100 == 2 {
+				continue
+			}
+			if failed == 0 {
+				first = fmt.Sprintf("status %d: %s", result.Status, result.Error)
+			}
+			failed++
+		}
+	}
+	return errors.Errorf("failed to index %d of %d records (first failure: %s)", failed, len(resp.Items), first)
+}
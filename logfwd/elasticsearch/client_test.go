@@ -0,0 +1,196 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package elasticsearch_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/elasticsearch"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	doer *stubDoer
+	cfg  elasticsearch.RawConfig
+	rec  logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.doer = &stubDoer{
+		stub:       &testing.Stub{},
+		statusCode: http.StatusOK,
+		body:       `{"took":3,"errors":false,"items":[{"index":{"status":201}}]}`,
+	}
+	s.cfg = elasticsearch.RawConfig{
+		Enabled:  true,
+		URL:      "https://es.example.com:9200/",
+		Username: "juju",
+		Password: "sekrit",
+	}
+	tag := names.NewMachineTag("99")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	s.rec = logfwd.Record{
+		ID:        10,
+		Origin:    logfwd.OriginForMachineAgent(tag, cID, mID, version.MustParse("1.2.3")),
+		Timestamp: time.Unix(12345, 0),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: "(╯°□°)╯︵ ┻━┻",
+	}
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := elasticsearch.OpenForDoer(elasticsearch.RawConfig{Enabled: true}, s.doer)
+	c.Assert(err, gc.ErrorMatches, `URL "" not valid`)
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client, err := elasticsearch.OpenForDoer(s.cfg, s.doer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.doer.stub.CheckCallNames(c, "Do")
+	req := s.doer.stub.Calls()[0].Args[0].(*http.Request)
+	c.Check(req.Method, gc.Equals, "POST")
+	c.Check(req.URL.String(), gc.Equals, "https://es.example.com:9200/_bulk")
+	c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/x-ndjson")
+	username, password, ok := req.BasicAuth()
+	c.Check(ok, jc.IsTrue)
+	c.Check(username, gc.Equals, "juju")
+	c.Check(password, gc.Equals, "sekrit")
+
+	lines := strings.Split(strings.TrimSuffix(s.doer.requestBody, "\n"), "\n")
+	c.Assert(lines, gc.HasLen, 2)
+	c.Check(lines[0], gc.Equals, `{"index":{"_index":"juju"}}`)
+	var doc map[string]interface{}
+	err = json.Unmarshal([]byte(lines[1]), &doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc, jc.DeepEquals, map[string]interface{}{
+		"@timestamp":       "1970-01-01T03:25:45Z",
+		"level":            "ERROR",
+		"message":          "(╯°□°)╯︵ ┻━┻",
+		"module":           "juju.x.y",
+		"source":           "x/y/spam.go:42",
+		"record-id":        float64(10),
+		"controller-uuid":  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"model-uuid":       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"hostname":         "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"origin-type":      "machine",
+		"origin-name":      "99",
+		"software-name":    "jujud-machine-agent",
+		"software-version": "1.2.3",
+	})
+}
+
+func (s *ClientSuite) TestSendCustomIndex(c *gc.C) {
+	s.cfg.Index = "juju-logs"
+	s.cfg.Username = ""
+	s.cfg.Password = ""
+	client, err := elasticsearch.OpenForDoer(s.cfg, s.doer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rec1 := s.rec
+	rec1.ID = 11
+	err = client.Send([]logfwd.Record{s.rec, rec1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	req := s.doer.stub.Calls()[0].Args[0].(*http.Request)
+	_, _, ok := req.BasicAuth()
+	c.Check(ok, jc.IsFalse)
+	lines := strings.Split(strings.TrimSuffix(s.doer.requestBody, "\n"), "\n")
+	c.Assert(lines, gc.HasLen, 4)
+	c.Check(lines[0], gc.Equals, `{"index":{"_index":"juju-logs"}}`)
+	c.Check(lines[2], gc.Equals, `{"index":{"_index":"juju-logs"}}`)
+}
+
+func (s *ClientSuite) TestSendNothing(c *gc.C) {
+	client, err := elasticsearch.OpenForDoer(s.cfg, s.doer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.doer.stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestSendHTTPError(c *gc.C) {
+	s.doer.statusCode = http.StatusUnauthorized
+	s.doer.body = `{"error":"unauthorized"}`
+	client, err := elasticsearch.OpenForDoer(s.cfg, s.doer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, `bulk request failed: 401 Unauthorized: {"error":"unauthorized"}`)
+}
+
+func (s *ClientSuite) TestSendItemErrors(c *gc.C) {
+	s.doer.body = `{"errors":true,"items":[` +
+		`{"index":{"status":201}},` +
+		`{"index":{"status":400,"error":{"type":"mapper_parsing_exception"}}}]}`
+	client, err := elasticsearch.OpenForDoer(s.cfg, s.doer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send([]logfwd.Record{s.rec, s.rec})
+	c.Assert(err, gc.ErrorMatches, `failed to index 1 of 2 records \(first failure: status 400: {"type":"mapper_parsing_exception"}\)`)
+}
+
+func (s *ClientSuite) TestSendBadLevel(c *gc.C) {
+	client, err := elasticsearch.OpenForDoer(s.cfg, s.doer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.rec.Level = loggo.UNSPECIFIED
+	err = client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, `unsupported log level "UNSPECIFIED"`)
+	s.doer.stub.CheckNoCalls(c)
+}
+
+type stubDoer struct {
+	stub *testing.Stub
+
+	statusCode  int
+	body        string
+	requestBody string
+}
+
+func (s *stubDoer) Do(req *http.Request) (*http.Response, error) {
+	s.stub.AddCall("Do", req)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	s.requestBody = string(body)
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", s.statusCode, http.StatusText(s.statusCode)),
+		StatusCode: s.statusCode,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(s.body)),
+	}, nil
+}
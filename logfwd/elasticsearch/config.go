@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package elasticsearch
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+)
+
+// DefaultIndex is the index to which records are written if no
+// index is configured.
+const DefaultIndex = "juju"
+
+// RawConfig holds the raw configuration data for a connection to an
+// Elasticsearch forwarding target.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// URL is the base URL of the Elasticsearch cluster, for example
+	// "https://es.example.com:9200". Records are posted to the
+	// "_bulk" endpoint under it.
+	URL string
+
+	// Index is the name of the index to which records are written.
+	// If it is not set then DefaultIndex is used.
+	Index string
+
+	// Username and Password are the credentials used for HTTP basic
+	// authentication, if set.
+	Username string
+	Password string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If it
+	// is not set then the system's root CAs are used.
+	CACert string
+
+	// ClientCert is the TLS certificate (x.509, PEM-encoded) to use
+	// when connecting, if any.
+	ClientCert string
+
+	// ClientKey is the TLS private key (x.509, PEM-encoded) to use
+	// when connecting, if any.
+	ClientKey string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if err := cfg.validateURL(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if cfg.Index != "" && cfg.Index != strings.ToLower(cfg.Index) {
+		return errors.NotValidf("Index %q (must be lower case)", cfg.Index)
+	}
+
+	if cfg.Password != "" && cfg.Username == "" {
+		return errors.New("Password set without Username")
+	}
+
+	if _, err := cfg.tlsConfig(); err != nil {
+		return errors.Annotate(err, "validating TLS config")
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateURL() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("URL %q", cfg.URL)
+		}
+		return nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NotValidf("URL %q", cfg.URL)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL %q (scheme must be http or https)", cfg.URL)
+	}
+	if u.Host == "" {
+		return errors.NotValidf("URL %q (missing host)", cfg.URL)
+	}
+	return nil
+}
+
+// index returns the index to which records are written.
+func (cfg RawConfig) index() string {
+	if cfg.Index == "" {
+		return DefaultIndex
+	}
+	return cfg.Index
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	tlsCfg := &tls.Config{}
+
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing client key pair")
+		}
+		tlsCfg.Certificates = []tls.Certificate{clientCert}
+	}
+
+	if cfg.CACert != "" {
+		caCert, err := cert.ParseCert(cfg.CACert)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing CA certificate")
+		}
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(caCert)
+		tlsCfg.RootCAs = rootCAs
+	}
+
+	return tlsCfg, nil
+}
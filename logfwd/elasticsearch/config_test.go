@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package elasticsearch_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/elasticsearch"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := elasticsearch.RawConfig{
+		Enabled:    true,
+		URL:        "https://es.example.com:9200",
+		Index:      "juju-logs",
+		Username:   "juju",
+		Password:   "sekrit",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateWithoutTLS(c *gc.C) {
+	cfg := elasticsearch.RawConfig{
+		Enabled: true,
+		URL:     "http://10.0.0.1:9200",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg elasticsearch.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingURL(c *gc.C) {
+	cfg := elasticsearch.RawConfig{
+		Enabled: true,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL "" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadURLScheme(c *gc.C) {
+	cfg := elasticsearch.RawConfig{
+		Enabled: true,
+		URL:     "ftp://es.example.com",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL "ftp://es.example.com" \(scheme must be http or https\) not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingURLHost(c *gc.C) {
+	cfg := elasticsearch.RawConfig{
+		Enabled: true,
+		URL:     "https:///foo",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL "https:///foo" \(missing host\) not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadIndex(c *gc.C) {
+	cfg := elasticsearch.RawConfig{
+		Enabled: true,
+		URL:     "https://es.example.com",
+		Index:   "Juju",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `Index "Juju" \(must be lower case\) not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidatePasswordWithoutUsername(c *gc.C) {
+	cfg := elasticsearch.RawConfig{
+		Enabled:  true,
+		URL:      "https://es.example.com",
+		Password: "sekrit",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `Password set without Username`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadCACert(c *gc.C) {
+	cfg := elasticsearch.RawConfig{
+		Enabled: true,
+		URL:     "https://es.example.com",
+		CACert:  invalidCACert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: asn1: syntax error: data truncated`)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingClientKey(c *gc.C) {
+	cfg := elasticsearch.RawConfig{
+		Enabled:    true,
+		URL:        "https://es.example.com",
+		ClientCert: coretesting.ServerCert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing client key pair: (crypto/)?tls: failed to find any PEM data in key input`)
+}
+
+var invalidCACert = `
+-----BEGIN CERTIFICATE-----
+MIIBOgIBAAJAZabKgKInuOxj5vDWLwHHQtK3/45KB+32D15w94Nt83BmuGxo90lw
+-----END CERTIFICATE-----
+`[1:]
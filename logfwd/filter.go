@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+// Filter selects the log records to forward. It has the same
+// semantics as the filtering options of debug-log:
+//
+//   - records below MinLevel are dropped;
+//   - if IncludeEntity is set, only records from matching entities
+//     are kept;
+//   - records from entities matching ExcludeEntity are dropped;
+//   - if IncludeModule is set, only records from matching modules
+//     (or their sub-modules) are kept;
+//   - records from modules matching ExcludeModule (or their
+//     sub-modules) are dropped.
+//
+// Entities are given as tags (e.g. "machine-0" or "unit-mysql-0"),
+// and may include the "*" wildcard (e.g. "unit-mysql-*" matches every
+// unit of the mysql application).
+type Filter struct {
+	// MinLevel is the lowest level of record to forward.
+	MinLevel loggo.Level
+
+	// IncludeEntity lists the entities whose records are forwarded.
+	IncludeEntity []string
+
+	// ExcludeEntity lists the entities whose records are not
+	// forwarded.
+	ExcludeEntity []string
+
+	// IncludeModule lists the modules whose records are forwarded.
+	IncludeModule []string
+
+	// ExcludeModule lists the modules whose records are not
+	// forwarded.
+	ExcludeModule []string
+}
+
+// Validate ensures that the filter is correct.
+func (f Filter) Validate() error {
+	if f.MinLevel != loggo.UNSPECIFIED && (f.MinLevel < loggo.TRACE || f.MinLevel > loggo.ERROR) {
+		return errors.NotValidf("minimum level %q", f.MinLevel)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type FilterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FilterSuite{})
+
+func (s *FilterSuite) TestValidateFull(c *gc.C) {
+	f := logfwd.Filter{
+		MinLevel:      loggo.INFO,
+		IncludeEntity: []string{"unit-mysql-*"},
+		ExcludeEntity: []string{"machine-0"},
+		IncludeModule: []string{"juju.worker"},
+		ExcludeModule: []string{"juju.worker.uniter"},
+	}
+
+	err := f.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *FilterSuite) TestValidateZero(c *gc.C) {
+	var f logfwd.Filter
+
+	err := f.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *FilterSuite) TestValidateBadLevel(c *gc.C) {
+	f := logfwd.Filter{
+		MinLevel: loggo.CRITICAL,
+	}
+
+	err := f.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `minimum level "CRITICAL" not valid`)
+}
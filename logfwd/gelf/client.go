@@ -0,0 +1,295 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// Sender exposes the underlying functionality needed by Client.
+type Sender interface {
+	io.Closer
+
+	// Send sends the encoded GELF message over its connection.
+	Send([]byte) error
+}
+
+// SenderOpener supports opening a GELF connection.
+type SenderOpener interface {
+	// Open connects to the address using the given protocol. If
+	// tlsCfg is not nil then the connection is secured with TLS.
+	Open(protocol, address string, tlsCfg *tls.Config) (Sender, error)
+}
+
+// dialTimeout is the maximum time allowed to connect to the server.
+const dialTimeout = 30 * time.Second
+
+type senderOpener struct{}
+
+func (senderOpener) Open(protocol, address string, tlsCfg *tls.Config) (Sender, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	switch protocol {
+	case ProtocolUDP:
+		conn, err := dialer.Dial("udp", address)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &udpSender{conn: conn}, nil
+	case ProtocolTCP:
+		var conn net.Conn
+		var err error
+		if tlsCfg != nil {
+			conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsCfg)
+		} else {
+			conn, err = dialer.Dial("tcp", address)
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &tcpSender{conn: conn}, nil
+	}
+	return nil, errors.NotValidf("protocol %q", protocol)
+}
+
+// Client is the wrapper around a GELF connection.
+type Client struct {
+	// Sender is the message sender this client wraps.
+	Sender Sender
+}
+
+// Open connects to a remote Graylog server and wraps that connection
+// in a new client.
+func Open(cfg RawConfig) (*Client, error) {
+	client, err := OpenForSender(cfg, &senderOpener{})
+	return client, errors.Trace(err)
+}
+
+// OpenForSender connects to a remote Graylog server and wraps that
+// connection in a new client.
+func OpenForSender(cfg RawConfig, opener SenderOpener) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var tlsCfg *tls.Config
+	if cfg.useTLS() {
+		var err error
+		tlsCfg, err = cfg.tlsConfig()
+		if err != nil {
+			return nil, errors.Annotate(err, "constructing TLS config")
+		}
+	}
+	sender, err := opener.Open(cfg.protocol(), cfg.address(), tlsCfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening client connection")
+	}
+
+	client := &Client{
+		Sender: sender,
+	}
+	return client, nil
+}
+
+// Close closes the client's connection.
+func (client Client) Close() error {
+	err := client.Sender.Close()
+	return errors.Trace(err)
+}
+
+// Send sends the records to the remote Graylog server.
+func (client Client) Send(records []logfwd.Record) error {
+	for _, rec := range records {
+		msg, err := messageFromRecord(rec)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return errors.Annotate(err, "marshalling GELF message")
+		}
+		if err := client.Sender.Send(data); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Message is a GELF (version 1.1) message. Fields prefixed with an
+// underscore are the additional fields describing the record's origin.
+type Message struct {
+	Version      string  `json:"version"`
+	Host         string  `json:"host"`
+	ShortMessage string  `json:"short_message"`
+	FullMessage  string  `json:"full_message,omitempty"`
+	Timestamp    float64 `json:"timestamp"`
+	Level        int     `json:"level"`
+
+	RecordID        int64  `json:"_record_id"`
+	ControllerUUID  string `json:"_controller_uuid"`
+	ModelUUID       string `json:"_model_uuid"`
+	OriginType      string `json:"_origin_type"`
+	OriginName      string `json:"_origin_name,omitempty"`
+	SoftwareName    string `json:"_software_name,omitempty"`
+	SoftwareVersion string `json:"_software_version,omitempty"`
+	Module          string `json:"_module,omitempty"`
+	Source          string `json:"_source,omitempty"`
+}
+
+// Syslog severities, used for the GELF level field.
+const (
+	levelCritical      = 2
+	levelError         = 3
+	levelWarning       = 4
+	levelInformational = 6
+	levelDebug         = 7
+)
+
+// emptyShortMessage replaces empty record messages, which GELF
+// does not allow.
+const emptyShortMessage = "-"
+
+func messageFromRecord(rec logfwd.Record) (Message, error) {
+	msg := Message{
+		Version:        "1.1",
+		Host:           rec.Origin.Hostname,
+		ShortMessage:   rec.Message,
+		Timestamp:      float64(rec.Timestamp.UnixNano()/int64(time.Millisecond)) / 1000,
+		RecordID:       rec.ID,
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		OriginType:     rec.Origin.Type.String(),
+		OriginName:     rec.Origin.Name,
+		SoftwareName:   rec.Origin.Software.Name,
+		Module:         rec.Location.Module,
+		Source:         rec.Location.String(),
+	}
+	if rec.Origin.Software.Name != "" {
+		msg.SoftwareVersion = rec.Origin.Software.Version.String()
+	}
+	// Multi-line messages are summarised by their first line, with
+	// the whole message kept as the full message.
+	if i := strings.IndexByte(rec.Message, '\n'); i >= 0 {
+		msg.ShortMessage = rec.Message[:i]
+		msg.FullMessage = rec.Message
+	}
+	if msg.ShortMessage == "" {
+		msg.ShortMessage = emptyShortMessage
+	}
+
+	switch rec.Level {
+	case loggo.CRITICAL:
+		msg.Level = levelCritical
+	case loggo.ERROR:
+		msg.Level = levelError
+	case loggo.WARNING:
+		msg.Level = levelWarning
+	case loggo.INFO:
+		msg.Level = levelInformational
+	case loggo.DEBUG, loggo.TRACE:
+		msg.Level = levelDebug
+	default:
+		return msg, errors.Errorf("unsupported log level %q", rec.Level)
+	}
+	return msg, nil
+}
+
+type tcpSender struct {
+	conn net.Conn
+}
+
+// Send implements Sender. Messages sent over TCP are delimited by a
+// null byte.
+func (s *tcpSender) Send(msg []byte) error {
+	_, err := s.conn.Write(append(msg, 0))
+	return errors.Trace(err)
+}
+
+// Close implements Sender.
+func (s *tcpSender) Close() error {
+	return errors.Trace(s.conn.Close())
+}
+
+type udpSender struct {
+	conn net.Conn
+}
+
+// Send implements Sender. Messages too large for a single datagram
+// are split into GELF chunks.
+func (s *udpSender) Send(msg []byte) error {
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return errors.Annotate(err, "generating message ID")
+	}
+	chunks, err := chunk(msg, id, maxChunkSize)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, c := range chunks {
+		if _, err := s.conn.Write(c); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Close implements Sender.
+func (s *udpSender) Close() error {
+	return errors.Trace(s.conn.Close())
+}
+
+const (
+	// maxChunkSize is the largest datagram sent, chosen to fit
+	// within a typical network MTU.
+	maxChunkSize = 1420
+
+	// maxChunks is the largest number of chunks a message may be
+	// split into.
+	maxChunks = 128
+
+	// chunkHeaderSize is the size of the header preceding the data in
+	// each chunk: 2 magic bytes, an 8 byte message ID, the sequence
+	// number and the sequence count.
+	chunkHeaderSize = 12
+)
+
+var chunkMagic = []byte{0x1e, 0x0f}
+
+// chunk splits the message into datagrams of at most size bytes. A
+// message which fits in a single datagram is returned as is.
+func chunk(msg []byte, id [8]byte, size int) ([][]byte, error) {
+	if len(msg) <= size {
+		return [][]byte{msg}, nil
+	}
+	dataSize := size - chunkHeaderSize
+	count := (len(msg) + dataSize - 1) / dataSize
+	if count > maxChunks {
+		return nil, errors.Errorf("message too large (%d bytes)", len(msg))
+	}
+	chunks := make([][]byte, 0, count)
+	for seq := 0; seq < count; seq++ {
+		start := seq * dataSize
+		end := start + dataSize
+		if end > len(msg) {
+			end = len(msg)
+		}
+		c := make([]byte, 0, chunkHeaderSize+end-start)
+		c = append(c, chunkMagic...)
+		c = append(c, id[:]...)
+		c = append(c, byte(seq), byte(count))
+		c = append(c, msg[start:end]...)
+		chunks = append(chunks, c)
+	}
+	return chunks, nil
+}
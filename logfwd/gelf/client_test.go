@@ -0,0 +1,246 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/gelf"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	stub   *testing.Stub
+	sender *stubSender
+	rec    logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.sender = &stubSender{stub: s.stub}
+	tag := names.NewMachineTag("99")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	s.rec = logfwd.Record{
+		ID:        10,
+		Origin:    logfwd.OriginForMachineAgent(tag, cID, mID, version.MustParse("1.2.3")),
+		Timestamp: time.Unix(12345, 250000000),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: "(╯°□°)╯︵ ┻━┻",
+	}
+}
+
+func (s *ClientSuite) TestOpenUDP(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Enabled: true,
+		Host:    "a.b.c",
+	}
+	opener := &stubSenderOpener{stub: s.stub, ReturnOpen: s.sender}
+
+	client, err := gelf.OpenForSender(cfg, opener)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"Open", []interface{}{"udp", "a.b.c:12201", (*tls.Config)(nil)}},
+	})
+	c.Check(client.Sender, gc.Equals, s.sender)
+}
+
+func (s *ClientSuite) TestOpenTCPWithTLS(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Enabled:    true,
+		Host:       "a.b.c:9876",
+		Protocol:   "tcp",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	}
+	opener := &stubSenderOpener{stub: s.stub, ReturnOpen: s.sender}
+
+	_, err := gelf.OpenForSender(cfg, opener)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Open")
+	args := s.stub.Calls()[0].Args
+	c.Check(args[0], gc.Equals, "tcp")
+	c.Check(args[1], gc.Equals, "a.b.c:9876")
+	tlsCfg := args[2].(*tls.Config)
+	c.Check(tlsCfg.Certificates, gc.HasLen, 1)
+	c.Check(tlsCfg.RootCAs, gc.NotNil)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	opener := &stubSenderOpener{stub: s.stub, ReturnOpen: s.sender}
+
+	_, err := gelf.OpenForSender(gelf.RawConfig{Enabled: true}, opener)
+	c.Assert(err, gc.ErrorMatches, `Host "" not valid`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client := gelf.Client{Sender: s.sender}
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Send")
+	c.Check(s.sender.decoded(c, 0), jc.DeepEquals, map[string]interface{}{
+		"version":           "1.1",
+		"host":              "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"short_message":     "(╯°□°)╯︵ ┻━┻",
+		"timestamp":         12345.25,
+		"level":             float64(3),
+		"_record_id":        float64(10),
+		"_controller_uuid":  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"_model_uuid":       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"_origin_type":      "machine",
+		"_origin_name":      "99",
+		"_software_name":    "jujud-machine-agent",
+		"_software_version": "1.2.3",
+		"_module":           "juju.x.y",
+		"_source":           "x/y/spam.go:42",
+	})
+}
+
+func (s *ClientSuite) TestSendMultiLineMessage(c *gc.C) {
+	client := gelf.Client{Sender: s.sender}
+	s.rec.Level = loggo.DEBUG
+	s.rec.Message = "summary\nand the details"
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	msg := s.sender.decoded(c, 0)
+	c.Check(msg["short_message"], gc.Equals, "summary")
+	c.Check(msg["full_message"], gc.Equals, "summary\nand the details")
+	c.Check(msg["level"], gc.Equals, float64(7))
+}
+
+func (s *ClientSuite) TestSendEmptyMessage(c *gc.C) {
+	client := gelf.Client{Sender: s.sender}
+	s.rec.Message = ""
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.sender.decoded(c, 0)["short_message"], gc.Equals, "-")
+}
+
+func (s *ClientSuite) TestSendBadLevel(c *gc.C) {
+	client := gelf.Client{Sender: s.sender}
+	s.rec.Level = loggo.UNSPECIFIED
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, `unsupported log level "UNSPECIFIED"`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestClose(c *gc.C) {
+	client := gelf.Client{Sender: s.sender}
+
+	err := client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "Close")
+}
+
+type ChunkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ChunkSuite{})
+
+var chunkID = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+func (s *ChunkSuite) TestSmallMessageNotChunked(c *gc.C) {
+	chunks, err := gelf.Chunk([]byte("hello"), chunkID, 20)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(chunks, jc.DeepEquals, [][]byte{[]byte("hello")})
+}
+
+func (s *ChunkSuite) TestLargeMessageChunked(c *gc.C) {
+	msg := []byte("0123456789abcdefghij")
+	chunks, err := gelf.Chunk(msg, chunkID, 20)
+	c.Assert(err, jc.ErrorIsNil)
+
+	header := func(seq byte) []byte {
+		return append([]byte{0x1e, 0x0f, 1, 2, 3, 4, 5, 6, 7, 8}, seq, 3)
+	}
+	c.Check(chunks, jc.DeepEquals, [][]byte{
+		append(header(0), "01234567"...),
+		append(header(1), "89abcdef"...),
+		append(header(2), "ghij"...),
+	})
+}
+
+func (s *ChunkSuite) TestMessageTooLarge(c *gc.C) {
+	msg := bytes.Repeat([]byte("x"), 129*8)
+	_, err := gelf.Chunk(msg, chunkID, 20)
+	c.Assert(err, gc.ErrorMatches, `message too large \(1032 bytes\)`)
+}
+
+type stubSenderOpener struct {
+	stub *testing.Stub
+
+	ReturnOpen gelf.Sender
+}
+
+func (s *stubSenderOpener) Open(protocol, address string, tlsCfg *tls.Config) (gelf.Sender, error) {
+	s.stub.AddCall("Open", protocol, address, tlsCfg)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+
+	return s.ReturnOpen, nil
+}
+
+type stubSender struct {
+	stub *testing.Stub
+}
+
+func (s *stubSender) Send(msg []byte) error {
+	s.stub.AddCall("Send", msg)
+	if err := s.stub.NextErr(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *stubSender) Close() error {
+	s.stub.AddCall("Close")
+	if err := s.stub.NextErr(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *stubSender) decoded(c *gc.C, call int) map[string]interface{} {
+	var msg map[string]interface{}
+	err := json.Unmarshal(s.stub.Calls()[call].Args[0].([]byte), &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	return msg
+}
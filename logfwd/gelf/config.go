@@ -0,0 +1,136 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+)
+
+const (
+	// ProtocolUDP sends each record as a (possibly chunked) UDP
+	// datagram.
+	ProtocolUDP = "udp"
+
+	// ProtocolTCP sends records as null-delimited messages over a
+	// TCP connection, optionally secured with TLS.
+	ProtocolTCP = "tcp"
+)
+
+// DefaultPort is the port used if the host does not include one.
+const DefaultPort = "12201"
+
+// RawConfig holds the raw configuration data for a connection to a
+// Graylog (GELF) forwarding target.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Host is the host-port of the Graylog GELF input. The format is:
+	//
+	//   [domain-or-ip-addr] or [domain-or-ip-addr][:port]
+	//
+	// If the port is not set then DefaultPort will be used.
+	Host string
+
+	// Protocol is the transport used to send records, either
+	// ProtocolUDP or ProtocolTCP. If it is not set then ProtocolUDP
+	// is used.
+	Protocol string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. Setting
+	// it enables TLS, which is only supported over TCP.
+	CACert string
+
+	// ClientCert is the TLS certificate (x.509, PEM-encoded) to use
+	// when connecting, if any.
+	ClientCert string
+
+	// ClientKey is the TLS private key (x.509, PEM-encoded) to use
+	// when connecting, if any.
+	ClientKey string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if err := cfg.validateHost(); err != nil {
+		return errors.Trace(err)
+	}
+
+	switch cfg.protocol() {
+	case ProtocolUDP:
+		if cfg.useTLS() {
+			return errors.NotValidf("TLS over %s", ProtocolUDP)
+		}
+	case ProtocolTCP:
+	default:
+		return errors.NotValidf("Protocol %q", cfg.Protocol)
+	}
+
+	if cfg.useTLS() {
+		if _, err := cfg.tlsConfig(); err != nil {
+			return errors.Annotate(err, "validating TLS config")
+		}
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateHost() error {
+	host, _, err := net.SplitHostPort(cfg.Host)
+	if err != nil {
+		host = cfg.Host
+	}
+	if host == "" && cfg.Enabled {
+		return errors.NotValidf("Host %q", cfg.Host)
+	}
+	return nil
+}
+
+// address returns the host-port to connect to.
+func (cfg RawConfig) address() string {
+	if _, _, err := net.SplitHostPort(cfg.Host); err == nil {
+		return cfg.Host
+	}
+	return net.JoinHostPort(cfg.Host, DefaultPort)
+}
+
+func (cfg RawConfig) protocol() string {
+	if cfg.Protocol == "" {
+		return ProtocolUDP
+	}
+	return cfg.Protocol
+}
+
+func (cfg RawConfig) useTLS() bool {
+	return cfg.CACert != "" || cfg.ClientCert != "" || cfg.ClientKey != ""
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	tlsCfg := &tls.Config{}
+
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing client key pair")
+		}
+		tlsCfg.Certificates = []tls.Certificate{clientCert}
+	}
+
+	if cfg.CACert != "" {
+		caCert, err := cert.ParseCert(cfg.CACert)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing CA certificate")
+		}
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(caCert)
+		tlsCfg.RootCAs = rootCAs
+	}
+
+	return tlsCfg, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/gelf"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateUDP(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Enabled: true,
+		Host:    "a.b.c:12201",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateTCPWithTLS(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Enabled:    true,
+		Host:       "a.b.c",
+		Protocol:   "tcp",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg gelf.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingHost(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Enabled: true,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `Host "" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadProtocol(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Enabled:  true,
+		Host:     "a.b.c",
+		Protocol: "http",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `Protocol "http" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateTLSOverUDP(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Enabled: true,
+		Host:    "a.b.c",
+		CACert:  coretesting.CACert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `TLS over udp not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingClientKey(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Enabled:    true,
+		Host:       "a.b.c",
+		Protocol:   "tcp",
+		ClientCert: coretesting.ServerCert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing client key pair: (crypto/)?tls: failed to find any PEM data in key input`)
+}
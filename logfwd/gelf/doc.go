@@ -0,0 +1,7 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The gelf package holds the tools needed to perform log forwarding
+// from Juju to a Graylog server, using the Graylog Extended Log Format
+// (GELF) over UDP or TCP.
+package gelf
@@ -123,7 +123,7 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 			Hostname: rfc5424.Hostname{
 				FQDN: rec.Origin.Hostname,
 			},
-			AppName: rfc5424.AppName((rec.Origin.Software.Name + "-" + rec.Origin.ModelUUID)[:48]),
+			AppName: appName(rec.Origin),
 		},
 		StructuredData: rfc5424.StructuredData{
 			&sdelements.Origin{
@@ -177,3 +177,14 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 	}
 	return msg, nil
 }
+
+// maxAppNameLen is the maximum length of the RFC 5424 APP-NAME field.
+const maxAppNameLen = 48
+
+func appName(origin logfwd.Origin) rfc5424.AppName {
+	name := origin.Software.Name + "-" + origin.ModelUUID
+	if len(name) > maxAppNameLen {
+		name = name[:maxAppNameLen]
+	}
+	return rfc5424.AppName(name)
+}
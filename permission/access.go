@@ -59,6 +59,17 @@ func ValidateModelAccess(access Access) error {
 	return errors.NotValidf("%q model access", access)
 }
 
+// ValidateApplicationAccess returns error if the passed access is not a
+// valid application access level. Only write access may be granted on an
+// application; it lets a user with read access to the model make changes
+// to that application alone.
+func ValidateApplicationAccess(access Access) error {
+	if access == WriteAccess {
+		return nil
+	}
+	return errors.NotValidf("%q application access", access)
+}
+
 //ValidateControllerAccess returns error if the passed access is not a valid
 // controller access level.
 func ValidateControllerAccess(access Access) error {
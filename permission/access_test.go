@@ -199,3 +199,17 @@ func (*accessSuite) TestGreaterControllerAccessThan(c *gc.C) {
 	c.Check(superuser.GreaterControllerAccessThan(addmodel), jc.IsTrue)
 	c.Check(superuser.GreaterControllerAccessThan(superuser), jc.IsFalse)
 }
+
+func (*accessSuite) TestValidateApplicationAccess(c *gc.C) {
+	c.Check(permission.ValidateApplicationAccess(permission.WriteAccess), jc.ErrorIsNil)
+	for _, access := range []permission.Access{
+		permission.NoAccess,
+		permission.ReadAccess,
+		permission.AdminAccess,
+		permission.LoginAccess,
+		permission.SuperuserAccess,
+	} {
+		err := permission.ValidateApplicationAccess(access)
+		c.Check(err, gc.ErrorMatches, `".*" application access not valid`)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
@@ -45,6 +46,14 @@ const (
 
 	// ActionRunning indicates that the Action is currently running.
 	ActionRunning ActionStatus = "running"
+
+	// ActionAborting indicates that the Action is running but has been
+	// cancelled, and its receiver should stop it.
+	ActionAborting ActionStatus = "aborting"
+
+	// ActionAborted means that the Action was stopped by its receiver
+	// after being cancelled while running.
+	ActionAborted ActionStatus = "aborted"
 )
 
 type actionNotificationDoc struct {
@@ -207,20 +216,76 @@ func (a *action) Finish(results ActionResults) (Action, error) {
 	return a.removeAndLog(results.Status, results.Results, results.Message)
 }
 
+// Cancel cancels the action. A pending action is removed from the
+// queue and marked as cancelled; a running action is marked as
+// aborting, so that its receiver knows to stop it. Cancelling an
+// action that is already aborting does nothing.
+func (a *action) Cancel(message string) (Action, error) {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		current := a
+		if attempt > 0 {
+			refreshed, err := a.st.Action(a.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			current = refreshed.(*action)
+		}
+		switch status := current.doc.Status; status {
+		case ActionPending:
+			return current.removeAndLogOps(ActionCancelled, nil, message, ActionPending), nil
+		case ActionRunning:
+			return []txn.Op{{
+				C:      actionsC,
+				Id:     current.doc.DocId,
+				Assert: bson.D{{"status", ActionRunning}},
+				Update: bson.D{{"$set", bson.D{
+					{"status", ActionAborting},
+					{"message", message},
+				}}},
+			}}, nil
+		case ActionAborting:
+			return nil, jujutxn.ErrNoOperations
+		default:
+			return nil, errors.Errorf("cannot cancel action %s: action is %s", current.Id(), status)
+		}
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return nil, err
+	}
+	return a.st.Action(a.Id())
+}
+
 // removeAndLog takes the action off of the pending queue, and creates
 // an actionresult to capture the outcome of the action. It asserts that
 // the action is not already completed.
 func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]interface{}, message string) (Action, error) {
-	err := a.st.runTransaction([]txn.Op{
+	err := a.st.runTransaction(a.removeAndLogOps(finalStatus, results, message, ""))
+	if err != nil {
+		return nil, err
+	}
+	return a.st.Action(a.Id())
+}
+
+// removeAndLogOps returns the operations needed to take the action
+// off of the pending queue and record its outcome. If a current status
+// is given the action is asserted to have it; otherwise the action is
+// asserted not to be already completed.
+func (a *action) removeAndLogOps(finalStatus ActionStatus, results map[string]interface{}, message string, current ActionStatus) []txn.Op {
+	assert := bson.D{{"status", bson.D{
+		{"$nin", []interface{}{
+			ActionCompleted,
+			ActionCancelled,
+			ActionFailed,
+			ActionAborted,
+		}}}}}
+	if current != "" {
+		assert = bson.D{{"status", current}}
+	}
+	return []txn.Op{
 		{
-			C:  actionsC,
-			Id: a.doc.DocId,
-			Assert: bson.D{{"status", bson.D{
-				{"$nin", []interface{}{
-					ActionCompleted,
-					ActionCancelled,
-					ActionFailed,
-				}}}}},
+			C:      actionsC,
+			Id:     a.doc.DocId,
+			Assert: assert,
 			Update: bson.D{{"$set", bson.D{
 				{"status", finalStatus},
 				{"message", message},
@@ -231,11 +296,7 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 			C:      actionNotificationsC,
 			Id:     a.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
 			Remove: true,
-		}})
-	if err != nil {
-		return nil, err
-	}
-	return a.st.Action(a.Id())
+		}}
 }
 
 // newAction builds an Action for the given State and actionDoc.
@@ -432,7 +493,10 @@ func (st *State) matchingActionsPending(ar ActionReceiver) ([]Action, error) {
 // matchingActionsRunning finds actions that match ActionReceiver and
 // that are running.
 func (st *State) matchingActionsRunning(ar ActionReceiver) ([]Action, error) {
-	completed := bson.D{{"status", ActionRunning}}
+	completed := bson.D{{"$or", []bson.D{
+		{{"status", ActionRunning}},
+		{{"status", ActionAborting}},
+	}}}
 	return st.matchingActionsByReceiverAndStatus(ar.Tag(), completed)
 }
 
@@ -443,6 +507,7 @@ func (st *State) matchingActionsCompleted(ar ActionReceiver) ([]Action, error) {
 		{{"status", ActionCompleted}},
 		{{"status", ActionCancelled}},
 		{{"status", ActionFailed}},
+		{{"status", ActionAborted}},
 	}}}
 	return st.matchingActionsByReceiverAndStatus(ar.Tag(), completed)
 }
@@ -464,3 +529,88 @@ func (st *State) matchingActionsByReceiverAndStatus(tag names.Tag, statusConditi
 	}
 	return actions, errors.Trace(iter.Close())
 }
+
+// finishedActions returns a query matching the actions in the model
+// which have finished running, and so will not change again, along
+// with any other given conditions.
+func (st *State) finishedActions(conditions ...bson.DocElem) bson.D {
+	query := bson.D{
+		{"model-uuid", st.ModelUUID()},
+		{"status", bson.D{{"$in", []interface{}{
+			ActionCompleted,
+			ActionCancelled,
+			ActionFailed,
+			ActionAborted,
+		}}}},
+	}
+	return append(query, conditions...)
+}
+
+// PruneActions removes finished actions and their results from the
+// model, until only those completed after now - maxHistoryTime remain
+// and the actions collection is no larger than maxHistoryMB. A limit
+// of zero is not applied. Pending and running actions are never
+// removed.
+func PruneActions(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+	if maxHistoryMB < 0 {
+		return errors.NotValidf("non-positive maxHistoryMB")
+	}
+	if maxHistoryTime < 0 {
+		return errors.NotValidf("non-positive maxHistoryTime")
+	}
+
+	// A raw collection is needed to obtain the size of the
+	// collection, so queries must include the model-uuid.
+	actions, closer := st.getRawCollection(actionsC)
+	defer closer()
+
+	if maxHistoryTime > 0 {
+		t := st.clock.Now().Add(-maxHistoryTime)
+		_, err := actions.RemoveAll(st.finishedActions(
+			bson.DocElem{"completed", bson.D{{"$lt", t}}},
+		))
+		if err != nil {
+			return errors.Annotate(err, "pruning actions by age")
+		}
+	}
+	if maxHistoryMB == 0 {
+		return nil
+	}
+
+	collMB, err := getCollectionMB(actions)
+	if err != nil {
+		return errors.Annotate(err, "retrieving actions collection size")
+	}
+	if collMB <= maxHistoryMB {
+		return nil
+	}
+	count, err := actions.Count()
+	if err != nil {
+		return errors.Annotate(err, "counting actions")
+	}
+	if count == 0 {
+		return nil
+	}
+	// Actions are assumed to be of about the same size, so the number
+	// to remove is estimated from the average size.
+	sizePerAction := float64(collMB) / float64(count)
+	toRemove := int(float64(collMB-maxHistoryMB) / sizePerAction)
+	if toRemove < 1 {
+		toRemove = 1
+	}
+	// Remove the oldest actions, up to and including the last one
+	// which needs to go.
+	var doc actionDoc
+	err = actions.Find(st.finishedActions()).Sort("completed").Skip(toRemove - 1).One(&doc)
+	if err == mgo.ErrNotFound {
+		// There are fewer finished actions than need removing.
+		_, err = actions.RemoveAll(st.finishedActions())
+		return errors.Annotate(err, "pruning actions by size")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = actions.RemoveAll(st.finishedActions(
+		bson.DocElem{"completed", bson.D{{"$lte", doc.Completed}}},
+	))
+	return errors.Annotate(err, "pruning actions by size")
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -463,6 +465,152 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestCancelPending(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	action, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := action.Cancel("no longer needed")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionCancelled)
+	_, message := result.Results()
+	c.Assert(message, gc.Equals, "no longer needed")
+
+	actions, err := unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 0)
+	results, err := unit.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+}
+
+func (s *ActionSuite) TestCancelRunning(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	action, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := action.Cancel("taking too long")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionAborting)
+
+	// An aborting action is still running until its receiver
+	// reports that it has been stopped.
+	actions, err := unit.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+
+	// Cancelling it again does nothing.
+	result, err = action.Cancel("taking too long")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionAborting)
+
+	output := map[string]interface{}{"progress": "half"}
+	result, err = result.Finish(state.ActionResults{Status: state.ActionAborted, Results: output})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionAborted)
+
+	results, err := unit.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	res, _ := results[0].Results()
+	c.Assert(res, gc.DeepEquals, output)
+}
+
+func (s *ActionSuite) TestCancelCompleted(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	action, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = action.Cancel("too late")
+	c.Assert(err, gc.ErrorMatches, `cannot cancel action .*: action is completed`)
+}
+
+func (s *ActionSuite) TestPruneActionsByAge(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	old, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = old.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	recent, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = recent.Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Make the first action, and the pending one, look old.
+	actions, closer := state.GetRawCollection(s.State, "actions")
+	defer closer()
+	longAgo := s.Clock.Now().Add(-72 * time.Hour)
+	for _, id := range []string{old.Id(), pending.Id()} {
+		err = actions.UpdateId(s.State.ModelUUID()+":"+id, bson.D{{"$set", bson.D{
+			{"enqueued", longAgo},
+			{"completed", longAgo},
+		}}})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	err = state.PruneActions(s.State, 24*time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Action(old.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.Action(recent.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Action(pending.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionSuite) TestPruneActionsBySize(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	// 30 actions of about 100KB each.
+	const count = 30
+	output := map[string]interface{}{"output": strings.Repeat("x", 100*1024)}
+	for i := 0; i < count; i++ {
+		a, err := unit.AddAction("snapshot", nil)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted, Results: output})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	pending, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneActions(s.State, 0, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	completed, err := unit.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(len(completed) < count, jc.IsTrue, gc.Commentf("%d actions left", len(completed)))
+	_, err = s.State.Action(pending.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionSuite) TestPruneActionsInvalid(c *gc.C) {
+	err := state.PruneActions(s.State, -time.Hour, 0)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	err = state.PruneActions(s.State, 0, -1)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
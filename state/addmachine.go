@@ -15,6 +15,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
@@ -114,21 +115,17 @@ type MachineFilesystemParams struct {
 // of the given type inside another new machine. The two given templates
 // specify the form of the child and parent respectively.
 func (st *State) AddMachineInsideNewMachine(template, parentTemplate MachineTemplate, containerType instance.ContainerType) (*Machine, error) {
-	mdoc, ops, err := st.addMachineInsideNewMachineOps(template, parentTemplate, containerType)
-	if err != nil {
-		return nil, errors.Annotate(err, "cannot add a new machine")
-	}
-	return st.addMachine(mdoc, ops)
+	return st.addMachine(func() (*machineDoc, []txn.Op, error) {
+		return st.addMachineInsideNewMachineOps(template, parentTemplate, containerType)
+	})
 }
 
 // AddMachineInsideMachine adds a machine inside a container of the
 // given type on the existing machine with id=parentId.
 func (st *State) AddMachineInsideMachine(template MachineTemplate, parentId string, containerType instance.ContainerType) (*Machine, error) {
-	mdoc, ops, err := st.addMachineInsideMachineOps(template, parentId, containerType)
-	if err != nil {
-		return nil, errors.Annotate(err, "cannot add a new machine")
-	}
-	return st.addMachine(mdoc, ops)
+	return st.addMachine(func() (*machineDoc, []txn.Op, error) {
+		return st.addMachineInsideMachineOps(template, parentId, containerType)
+	})
 }
 
 // AddMachine adds a machine with the given series and jobs.
@@ -159,42 +156,59 @@ func (st *State) AddOneMachine(template MachineTemplate) (*Machine, error) {
 func (st *State) AddMachines(templates ...MachineTemplate) (_ []*Machine, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add a new machine")
 	var ms []*Machine
-	var ops []txn.Op
-	var mdocs []*machineDoc
-	for _, template := range templates {
-		mdoc, addOps, err := st.addMachineOps(template)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		mdocs = append(mdocs, mdoc)
-		ms = append(ms, newMachine(st, mdoc))
-		ops = append(ops, addOps...)
-	}
-	ssOps, err := st.maintainControllersOps(mdocs, nil)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	ops = append(ops, ssOps...)
-	ops = append(ops, assertModelActiveOp(st.ModelUUID()))
-	if err := st.runTransaction(ops); err != nil {
-		if errors.Cause(err) == txn.ErrAborted {
+	// The transaction is built again if it aborts, as when a
+	// concurrent addition changes the model's quota usage.
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
 			}
 		}
+		ms = nil
+		var ops []txn.Op
+		var mdocs []*machineDoc
+		for _, template := range templates {
+			mdoc, addOps, err := st.addMachineOps(template)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			mdocs = append(mdocs, mdoc)
+			ms = append(ms, newMachine(st, mdoc))
+			ops = append(ops, addOps...)
+		}
+		ssOps, err := st.maintainControllersOps(mdocs, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, ssOps...)
+		ops = append(ops, assertModelActiveOp(st.ModelUUID()))
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return ms, nil
 }
 
-func (st *State) addMachine(mdoc *machineDoc, ops []txn.Op) (*Machine, error) {
-	ops = append([]txn.Op{assertModelActiveOp(st.ModelUUID())}, ops...)
-	if err := st.runTransaction(ops); err != nil {
-		if errors.Cause(err) == txn.ErrAborted {
+// addMachine runs the operations made by machineOps to add a machine,
+// making them again if the transaction aborts.
+func (st *State) addMachine(machineOps func() (*machineDoc, []txn.Op, error)) (*Machine, error) {
+	var mdoc *machineDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
 			}
 		}
+		var ops []txn.Op
+		var err error
+		mdoc, ops, err = machineOps()
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot add a new machine")
+		}
+		return append([]txn.Op{assertModelActiveOp(st.ModelUUID())}, ops...), nil
+	}
+	if err := st.run(buildTxn); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return newMachine(st, mdoc), nil
@@ -262,6 +276,10 @@ func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op,
 	if err != nil {
 		return nil, nil, err
 	}
+	quotaOps, err := st.checkModelQuotas(machineTemplateResources(template))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	if template.InstanceId == "" {
 		if err := st.precheckInstance(template.Series, template.Constraints, template.Placement); err != nil {
 			return nil, nil, err
@@ -277,6 +295,7 @@ func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op,
 		return nil, nil, errors.Trace(err)
 	}
 	prereqOps = append(prereqOps, assertModelActiveOp(st.ModelUUID()))
+	prereqOps = append(prereqOps, quotaOps...)
 	prereqOps = append(prereqOps, st.insertNewContainerRefOp(mdoc.Id))
 	if template.InstanceId != "" {
 		prereqOps = append(prereqOps, txn.Op{
@@ -334,6 +353,12 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 	if containerType == "" {
 		return nil, nil, errors.New("no container type specified")
 	}
+	// Containers use the resources of their host, so only the storage
+	// created with them counts against the model's quotas.
+	quotaOps, err := st.checkModelQuotas(quota.Resources{Storage: machineTemplateStorage(template)})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 
 	// If a parent machine is specified, make sure it exists
 	// and can support the requested container type.
@@ -361,6 +386,7 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 		// Create a containers reference document for the container itself.
 		st.insertNewContainerRefOp(mdoc.Id),
 	)
+	prereqOps = append(prereqOps, quotaOps...)
 	return mdoc, append(prereqOps, machineOp), nil
 }
 
@@ -408,6 +434,12 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	if err != nil {
 		return nil, nil, err
 	}
+	requested := machineTemplateResources(parentTemplate)
+	requested.Storage += machineTemplateStorage(template)
+	quotaOps, err := st.checkModelQuotas(requested)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	mdoc := st.machineDocForTemplate(template, newId)
 	mdoc.ContainerType = string(containerType)
 	parentPrereqOps, parentOp, err := st.insertNewMachineOps(parentDoc, parentTemplate)
@@ -425,6 +457,7 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 		// Create a containers reference document for the container itself.
 		st.insertNewContainerRefOp(parentDoc.Id, mdoc.Id),
 	)
+	prereqOps = append(prereqOps, quotaOps...)
 	return mdoc, append(prereqOps, parentOp, machineOp), nil
 }
 
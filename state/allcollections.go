@@ -146,12 +146,38 @@ func allCollections() collectionSchema {
 			global: true,
 		},
 
+		// This collection holds local groups of users, which may be
+		// granted access like users.
+		userGroupsC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"members"},
+			}},
+		},
+
+		// This collection holds the API tokens of local users. Only the
+		// hashes of the tokens are stored.
+		apiTokensC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"owner"},
+			}},
+		},
+
 		// This collection holds the last time the user connected to the API server.
 		userLastLoginC: {
 			global:    true,
 			rawAccess: true,
 		},
 
+		// This collection holds the failed password logins of local
+		// users, and when they are locked out until. Like the last
+		// login times, it is written outside of transactions.
+		userLoginFailuresC: {
+			global:    true,
+			rawAccess: true,
+		},
+
 		// This collection is used as a unique key restraint. The _id field is
 		// a concatenation of multiple fields that form a compound index,
 		// allowing us to ensure users cannot have the same name for two
@@ -411,6 +437,13 @@ func allCollections() collectionSchema {
 		auditingC: {
 			global:    true,
 			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"time"},
+			}, {
+				Key: []string{"model-uuid", "time"},
+			}, {
+				Key: []string{"origin-name", "time"},
+			}},
 		},
 	}
 	if featureflag.Enabled(feature.CrossModelRelations) {
@@ -512,8 +545,11 @@ const (
 	unitsC                   = "units"
 	upgradeInfoC             = "upgradeInfo"
 	userLastLoginC           = "userLastLogin"
+	userLoginFailuresC       = "userloginfailures"
 	usermodelnameC           = "usermodelname"
 	usersC                   = "users"
+	userGroupsC              = "usergroups"
+	apiTokensC               = "apitokens"
 	volumeAttachmentsC       = "volumeattachments"
 	volumesC                 = "volumes"
 	// "resources" (see resource/persistence/mongo.go)
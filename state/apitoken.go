@@ -0,0 +1,309 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/subtle"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+// apiTokenPrefix starts every API token, so that tokens presented as
+// credentials can be told apart from passwords.
+const apiTokenPrefix = "juju-token:"
+
+var validAPITokenName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// IsAPIToken reports whether the given credentials are an API token
+// rather than a password.
+func IsAPIToken(credentials string) bool {
+	return strings.HasPrefix(credentials, apiTokenPrefix)
+}
+
+// APIToken represents a named, long-lived credential for a local user.
+// A token grants the access of its owner, limited to a set of models
+// and a maximum level of access.
+type APIToken struct {
+	st  *State
+	doc apiTokenDoc
+}
+
+type apiTokenDoc struct {
+	DocID       string    `bson:"_id"`
+	Name        string    `bson:"name"`
+	Owner       string    `bson:"owner"`
+	TokenHash   string    `bson:"tokenhash"`
+	Access      string    `bson:"access"`
+	ModelUUIDs  []string  `bson:"model-uuids"`
+	Expires     time.Time `bson:"expires,omitempty"`
+	DateCreated time.Time `bson:"datecreated"`
+}
+
+func apiTokenDocID(owner names.UserTag, name string) string {
+	return strings.ToLower(owner.Name()) + ":" + name
+}
+
+// Name returns the name of the token, which is unique for its owner.
+func (t *APIToken) Name() string {
+	return t.doc.Name
+}
+
+// Owner returns the tag of the local user that owns the token.
+func (t *APIToken) Owner() names.UserTag {
+	return names.NewLocalUserTag(t.doc.Owner)
+}
+
+// Access returns the maximum model access granted by the token.
+func (t *APIToken) Access() permission.Access {
+	return permission.Access(t.doc.Access)
+}
+
+// ModelUUIDs returns the UUIDs of the models the token grants access to.
+func (t *APIToken) ModelUUIDs() []string {
+	return append([]string(nil), t.doc.ModelUUIDs...)
+}
+
+// Expires returns when the token expires in UTC, or the zero time if it
+// never expires.
+func (t *APIToken) Expires() time.Time {
+	if t.doc.Expires.IsZero() {
+		return time.Time{}
+	}
+	return t.doc.Expires.UTC()
+}
+
+// Expired reports whether the token has expired.
+func (t *APIToken) Expired() bool {
+	return !t.doc.Expires.IsZero() && !t.st.clock.Now().Before(t.doc.Expires)
+}
+
+// DateCreated returns when the token was created in UTC.
+func (t *APIToken) DateCreated() time.Time {
+	return t.doc.DateCreated.UTC()
+}
+
+// MaxAccess returns the most access the token allows on the target.
+// Tokens grant at most login access to the controller, and no access
+// to models other than their own. Applications are taken to be in the
+// model of the State the token was read from.
+func (t *APIToken) MaxAccess(target names.Tag) permission.Access {
+	switch target := target.(type) {
+	case names.ControllerTag:
+		return permission.LoginAccess
+	case names.ModelTag:
+		if set.NewStrings(t.doc.ModelUUIDs...).Contains(target.Id()) {
+			return t.Access()
+		}
+	case names.ApplicationTag:
+		return t.MaxAccess(t.st.ModelTag())
+	}
+	return permission.NoAccess
+}
+
+// AddAPITokenArgs holds the arguments for AddAPIToken.
+type AddAPITokenArgs struct {
+	// Owner is the local user the token acts as.
+	Owner names.UserTag
+
+	// Name is the name of the token, which must be unique for the
+	// owner.
+	Name string
+
+	// Access is the maximum model access the token grants.
+	Access permission.Access
+
+	// ModelUUIDs holds the UUIDs of the models the token grants access
+	// to. A token without models grants only login access to the
+	// controller.
+	ModelUUIDs []string
+
+	// Expires is when the token expires. The token never expires if it
+	// is zero.
+	Expires time.Time
+}
+
+// AddAPIToken adds an API token for a local user. It returns the token
+// along with the secret that must be presented to use it; only a hash of
+// the secret is stored, so it cannot be retrieved again.
+func (st *State) AddAPIToken(args AddAPITokenArgs) (*APIToken, string, error) {
+	if !validAPITokenName.MatchString(args.Name) {
+		return nil, "", errors.NotValidf("token name %q", args.Name)
+	}
+	if !args.Owner.IsLocal() {
+		return nil, "", errors.NotSupportedf("API tokens for external user %q", args.Owner.Id())
+	}
+	if err := permission.ValidateModelAccess(args.Access); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	if !args.Expires.IsZero() && !args.Expires.After(st.clock.Now()) {
+		return nil, "", errors.NotValidf("expiry time %v in the past", args.Expires)
+	}
+	secret, err := utils.RandomPassword()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	credentials := apiTokenPrefix + args.Name + ":" + secret
+
+	ownerID := strings.ToLower(args.Owner.Name())
+	token := &APIToken{
+		st: st,
+		doc: apiTokenDoc{
+			DocID:       apiTokenDocID(args.Owner, args.Name),
+			Name:        args.Name,
+			Owner:       ownerID,
+			TokenHash:   utils.AgentPasswordHash(secret),
+			Access:      string(args.Access),
+			ModelUUIDs:  set.NewStrings(args.ModelUUIDs...).SortedValues(),
+			Expires:     args.Expires,
+			DateCreated: st.NowToTheSecond(),
+		},
+	}
+	ops := []txn.Op{{
+		C:      usersC,
+		Id:     ownerID,
+		Assert: bson.D{{"deleted", bson.D{{"$ne", true}}}},
+	}, {
+		C:      apiTokensC,
+		Id:     token.doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &token.doc,
+	}}
+	for _, uuid := range token.doc.ModelUUIDs {
+		ops = append(ops, txn.Op{
+			C:      modelsC,
+			Id:     uuid,
+			Assert: txn.DocExists,
+		})
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := st.User(args.Owner); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if _, err := st.APIToken(args.Owner, args.Name); err == nil {
+				return nil, errors.AlreadyExistsf("token %q", args.Name)
+			}
+			for _, uuid := range token.doc.ModelUUIDs {
+				if _, err := st.GetModel(names.NewModelTag(uuid)); err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, "", errors.Annotatef(err, "cannot add token %q", args.Name)
+	}
+	return token, credentials, nil
+}
+
+// APIToken returns the API token with the given name owned by the user.
+func (st *State) APIToken(owner names.UserTag, name string) (*APIToken, error) {
+	tokens, closer := st.getCollection(apiTokensC)
+	defer closer()
+
+	token := &APIToken{st: st}
+	err := tokens.FindId(apiTokenDocID(owner, name)).One(&token.doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("token %q", name)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return token, nil
+}
+
+// APITokensForUser returns the API tokens owned by the user, sorted by
+// name.
+func (st *State) APITokensForUser(owner names.UserTag) ([]*APIToken, error) {
+	tokens, closer := st.getCollection(apiTokensC)
+	defer closer()
+
+	var docs []apiTokenDoc
+	query := bson.D{{"owner", strings.ToLower(owner.Name())}}
+	if err := tokens.Find(query).Sort("name").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*APIToken, len(docs))
+	for i, doc := range docs {
+		result[i] = &APIToken{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// RemoveAPIToken revokes the API token with the given name owned by the
+// user.
+func (st *State) RemoveAPIToken(owner names.UserTag, name string) error {
+	ops := []txn.Op{{
+		C:      apiTokensC,
+		Id:     apiTokenDocID(owner, name),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("token %q", name)
+	}
+	return errors.Trace(err)
+}
+
+// removeAPITokensOps returns the operations to remove all the API tokens
+// owned by the user.
+func (st *State) removeAPITokensOps(owner names.UserTag) ([]txn.Op, error) {
+	tokens, err := st.APITokensForUser(owner)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(tokens))
+	for i, token := range tokens {
+		ops[i] = txn.Op{
+			C:      apiTokensC,
+			Id:     token.doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// APITokenValid returns the API token presented as credentials for the
+// user, and whether it is valid. Tokens are not valid once they have
+// expired, or if their owner is disabled or deleted.
+func (u *User) APITokenValid(credentials string) (*APIToken, bool) {
+	if u.IsDisabled() || u.IsDeleted() {
+		return nil, false
+	}
+	if !IsAPIToken(credentials) {
+		return nil, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(credentials, apiTokenPrefix), ":", 2)
+	if len(parts) != 2 {
+		return nil, false
+	}
+	token, err := u.st.APIToken(u.UserTag(), parts[0])
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Warningf("cannot get token %q for %s: %v", parts[0], u.Name(), err)
+		}
+		return nil, false
+	}
+	if token.Expired() {
+		return nil, false
+	}
+	hash := utils.AgentPasswordHash(parts[1])
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(token.doc.TokenHash)) != 1 {
+		return nil, false
+	}
+	return token, true
+}
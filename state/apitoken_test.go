@@ -0,0 +1,176 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type APITokenSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&APITokenSuite{})
+
+func (s *APITokenSuite) addToken(c *gc.C, owner names.UserTag, name string) (*state.APIToken, string) {
+	token, credentials, err := s.State.AddAPIToken(state.AddAPITokenArgs{
+		Owner:      owner,
+		Name:       name,
+		Access:     permission.WriteAccess,
+		ModelUUIDs: []string{s.State.ModelUUID()},
+		Expires:    s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return token, credentials
+}
+
+func (s *APITokenSuite) TestAddAPIToken(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	token, credentials := s.addToken(c, bob.UserTag(), "ci")
+	c.Assert(token.Name(), gc.Equals, "ci")
+	c.Assert(token.Owner(), gc.Equals, bob.UserTag())
+	c.Assert(token.Access(), gc.Equals, permission.WriteAccess)
+	c.Assert(token.ModelUUIDs(), jc.DeepEquals, []string{s.State.ModelUUID()})
+	c.Assert(token.Expires(), gc.Equals, s.Clock.Now().Add(time.Hour).UTC())
+	c.Assert(state.IsAPIToken(credentials), jc.IsTrue)
+	c.Assert(strings.Contains(credentials, "ci:"), jc.IsTrue)
+
+	token, err := s.State.APIToken(bob.UserTag(), "ci")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token.Name(), gc.Equals, "ci")
+}
+
+func (s *APITokenSuite) TestAddAPITokenAlreadyExists(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	s.addToken(c, bob.UserTag(), "ci")
+	_, _, err := s.State.AddAPIToken(state.AddAPITokenArgs{
+		Owner:  bob.UserTag(),
+		Name:   "ci",
+		Access: permission.ReadAccess,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add token "ci": token "ci" already exists`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *APITokenSuite) TestAddAPITokenInvalid(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	for i, test := range []struct {
+		args   state.AddAPITokenArgs
+		expect string
+	}{{
+		args:   state.AddAPITokenArgs{Owner: bob.UserTag(), Name: "no:colons", Access: permission.ReadAccess},
+		expect: `token name "no:colons" not valid`,
+	}, {
+		args:   state.AddAPITokenArgs{Owner: names.NewUserTag("fred@external"), Name: "ci", Access: permission.ReadAccess},
+		expect: `API tokens for external user "fred@external" not supported`,
+	}, {
+		args:   state.AddAPITokenArgs{Owner: bob.UserTag(), Name: "ci", Access: permission.SuperuserAccess},
+		expect: `"superuser" model access not valid`,
+	}, {
+		args: state.AddAPITokenArgs{
+			Owner: bob.UserTag(), Name: "ci", Access: permission.ReadAccess,
+			Expires: s.Clock.Now().Add(-time.Hour),
+		},
+		expect: `expiry time .* in the past not valid`,
+	}, {
+		args: state.AddAPITokenArgs{
+			Owner: bob.UserTag(), Name: "ci", Access: permission.ReadAccess,
+			ModelUUIDs: []string{"deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+		},
+		expect: `cannot add token "ci": model not found`,
+	}, {
+		args:   state.AddAPITokenArgs{Owner: names.NewLocalUserTag("nobody"), Name: "ci", Access: permission.ReadAccess},
+		expect: `cannot add token "ci": user "nobody" not found`,
+	}} {
+		c.Logf("test %d", i)
+		_, _, err := s.State.AddAPIToken(test.args)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *APITokenSuite) TestAPITokensForUser(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	mary := s.Factory.MakeUser(c, &factory.UserParams{Name: "mary"})
+	s.addToken(c, bob.UserTag(), "deploy")
+	s.addToken(c, bob.UserTag(), "ci")
+	s.addToken(c, mary.UserTag(), "ci")
+
+	tokens, err := s.State.APITokensForUser(bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, gc.HasLen, 2)
+	c.Assert(tokens[0].Name(), gc.Equals, "ci")
+	c.Assert(tokens[1].Name(), gc.Equals, "deploy")
+}
+
+func (s *APITokenSuite) TestRemoveAPIToken(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	s.addToken(c, bob.UserTag(), "ci")
+	err := s.State.RemoveAPIToken(bob.UserTag(), "ci")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.APIToken(bob.UserTag(), "ci")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveAPIToken(bob.UserTag(), "ci")
+	c.Assert(err, gc.ErrorMatches, `token "ci" not found`)
+}
+
+func (s *APITokenSuite) TestRemoveUserRemovesAPITokens(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	s.addToken(c, bob.UserTag(), "ci")
+	err := s.State.RemoveUser(bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	tokens, err := s.State.APITokensForUser(bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, gc.HasLen, 0)
+}
+
+func (s *APITokenSuite) TestAPITokenValid(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	_, credentials := s.addToken(c, bob.UserTag(), "ci")
+
+	token, ok := bob.APITokenValid(credentials)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(token.Name(), gc.Equals, "ci")
+
+	_, ok = bob.APITokenValid(credentials + "x")
+	c.Assert(ok, jc.IsFalse)
+	_, ok = bob.APITokenValid("juju-token:other:secret")
+	c.Assert(ok, jc.IsFalse)
+	_, ok = bob.APITokenValid("password")
+	c.Assert(ok, jc.IsFalse)
+
+	// Tokens are not valid once they have expired.
+	s.Clock.Advance(time.Hour)
+	_, ok = bob.APITokenValid(credentials)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *APITokenSuite) TestAPITokenValidDisabledUser(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	_, credentials := s.addToken(c, bob.UserTag(), "ci")
+	err := bob.Disable()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := bob.APITokenValid(credentials)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *APITokenSuite) TestMaxAccess(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	token, _ := s.addToken(c, bob.UserTag(), "ci")
+	c.Assert(token.MaxAccess(s.State.ControllerTag()), gc.Equals, permission.LoginAccess)
+	c.Assert(token.MaxAccess(s.State.ModelTag()), gc.Equals, permission.WriteAccess)
+	otherModel := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	c.Assert(token.MaxAccess(otherModel), gc.Equals, permission.NoAccess)
+	c.Assert(token.MaxAccess(names.NewApplicationTag("mysql")), gc.Equals, permission.WriteAccess)
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/status"
 )
@@ -301,6 +302,11 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(a.st, globalKey),
 		removeModelApplicationRefOp(a.st, name),
 	)
+	accessOps, err := a.st.removeApplicationAccessOps(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, accessOps...)
 	return ops, nil
 }
 
@@ -1086,6 +1092,18 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 	} else if !a.doc.Subordinate && args.principalName != "" {
 		return "", nil, errors.New("application is not a subordinate")
 	}
+	var quotaOps []txn.Op
+	if !a.doc.Subordinate {
+		requested := quota.Resources{
+			Units:   1,
+			Storage: storageConstraintsSize(args.storageCons),
+		}
+		var err error
+		quotaOps, err = a.st.checkModelQuotas(requested)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+	}
 	name, err := a.newUnitName()
 	if err != nil {
 		return "", nil, err
@@ -1161,6 +1179,7 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 	}
 
 	ops = append(ops, storageOps...)
+	ops = append(ops, quotaOps...)
 
 	if a.doc.Subordinate {
 		ops = append(ops, txn.Op{
@@ -1201,19 +1220,24 @@ func (a *Application) incUnitCountOp(asserts bson.D) txn.Op {
 // AddUnit adds a new principal unit to the application.
 func (a *Application) AddUnit() (unit *Unit, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add unit to application %q", a)
-	name, ops, err := a.addUnitOps("", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if err := a.st.runTransaction(ops); err == txn.ErrAborted {
-		if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
-			return nil, err
-		} else if !alive {
-			return nil, errors.New("application is not alive")
+	var name string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
+				return nil, err
+			} else if !alive {
+				return nil, errors.New("application is not alive")
+			}
+			if err := a.Refresh(); err != nil {
+				return nil, err
+			}
 		}
-		return nil, errors.New("inconsistent state")
-	} else if err != nil {
+		var ops []txn.Op
+		var err error
+		name, ops, err = a.addUnitOps("", nil)
+		return ops, err
+	}
+	if err := a.st.run(buildTxn); err != nil {
 		return nil, err
 	}
 	return a.st.Unit(name)
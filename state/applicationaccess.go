@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+// applicationPermissionKey returns the global key used for permissions
+// granted on an application. It extends the key of the model holding
+// the application, so the permissions are removed along with the model.
+// example: e#deadbeef#a#mysql
+func applicationPermissionKey(modelUUID, appName string) string {
+	return modelKey(modelUUID) + "#" + applicationGlobalKey(appName)
+}
+
+// assertApplicationAliveOps returns the operations asserting that the
+// application is alive, so that access cannot be granted on an
+// application that is being removed.
+func (st *State) assertApplicationAliveOps(appName string) ([]txn.Op, error) {
+	app, err := st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if app.Life() != Alive {
+		return nil, errors.Errorf("application %q is not alive", appName)
+	}
+	return []txn.Op{{
+		C:      applicationsC,
+		Id:     app.doc.DocID,
+		Assert: isAliveDoc,
+	}}, nil
+}
+
+// applicationUserAccess returns the access granted to the user on the
+// application in this model.
+func (st *State) applicationUserAccess(subject names.UserTag, target names.Tag) (permission.UserAccess, error) {
+	objectKey := applicationPermissionKey(st.ModelUUID(), target.Id())
+	perm, err := st.userPermission(objectKey, userGlobalKey(userAccessID(subject)))
+	if err != nil {
+		return permission.UserAccess{}, errors.Trace(err)
+	}
+	return permission.UserAccess{
+		UserID:   userAccessID(subject),
+		UserTag:  subject,
+		Object:   target,
+		Access:   perm.access(),
+		UserName: subject.Id(),
+	}, nil
+}
+
+// setApplicationAccess grants the user the given access on the
+// application in this model, replacing any access already granted.
+func (st *State) setApplicationAccess(subject names.UserTag, appName string, access permission.Access) error {
+	if err := permission.ValidateApplicationAccess(access); err != nil {
+		return errors.Trace(err)
+	}
+	objectKey := applicationPermissionKey(st.ModelUUID(), appName)
+	subjectKey := userGlobalKey(userAccessID(subject))
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		ops, err := st.assertApplicationAliveOps(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if subject.IsLocal() {
+			if _, err := st.User(subject); err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, txn.Op{
+				C:      usersC,
+				Id:     userAccessID(subject),
+				Assert: bson.D{{"deleted", bson.D{{"$ne", true}}}},
+			})
+		}
+		existing, err := st.userPermission(objectKey, subjectKey)
+		switch {
+		case errors.IsNotFound(err):
+			ops = append(ops, createPermissionOp(objectKey, subjectKey, access))
+		case err != nil:
+			return nil, errors.Trace(err)
+		case existing.access() == access:
+			return nil, jujutxn.ErrNoOperations
+		default:
+			ops = append(ops, updatePermissionOp(objectKey, subjectKey, access))
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot grant access to application %q", appName)
+	}
+	return nil
+}
+
+// removeApplicationAccess removes the access granted to the user on the
+// application in this model.
+func (st *State) removeApplicationAccess(subject names.UserTag, appName string) error {
+	objectKey := applicationPermissionKey(st.ModelUUID(), appName)
+	ops := []txn.Op{removePermissionOp(objectKey, userGlobalKey(userAccessID(subject)))}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("access to application %q for user %q", appName, subject.Id())
+	}
+	return errors.Trace(err)
+}
+
+// removeApplicationAccessOps returns the operations to remove all the
+// access granted to users and groups on the application.
+func (st *State) removeApplicationAccessOps(appName string) ([]txn.Op, error) {
+	sel := bson.D{{"object-global-key", applicationPermissionKey(st.ModelUUID(), appName)}}
+	return st.removeInCollectionOps(permissionsC, sel)
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ApplicationAccessSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ApplicationAccessSuite{})
+
+func (s *ApplicationAccessSuite) TestSetApplicationAccess(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	sam := s.Factory.MakeUser(c, &factory.UserParams{Name: "sam", NoModelUser: true})
+
+	_, err := s.State.UserAccess(sam.UserTag(), app.ApplicationTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.State.SetUserAccess(sam.UserTag(), app.ApplicationTag(), permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.UserAccess(sam.UserTag(), app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(access.Access, gc.Equals, permission.WriteAccess)
+	c.Check(access.UserTag, gc.Equals, sam.UserTag())
+	c.Check(access.Object, gc.Equals, app.ApplicationTag())
+
+	// Granting the same access again is fine.
+	_, err = s.State.SetUserAccess(sam.UserTag(), app.ApplicationTag(), permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Access to an application does not grant access to the model.
+	_, err = s.State.UserAccess(sam.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	other := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	_, err = s.State.UserAccess(sam.UserTag(), other.ApplicationTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationAccessSuite) TestSetApplicationAccessInvalid(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	sam := s.Factory.MakeUser(c, &factory.UserParams{Name: "sam"})
+
+	_, err := s.State.SetUserAccess(sam.UserTag(), app.ApplicationTag(), permission.ReadAccess)
+	c.Assert(err, gc.ErrorMatches, `"read" application access not valid`)
+	_, err = s.State.SetUserAccess(sam.UserTag(), names.NewApplicationTag("pgsql"), permission.WriteAccess)
+	c.Assert(err, gc.ErrorMatches, `cannot grant access to application "pgsql": application "pgsql" not found`)
+	_, err = s.State.SetUserAccess(names.NewUserTag("bob"), app.ApplicationTag(), permission.WriteAccess)
+	c.Assert(err, gc.ErrorMatches, `cannot grant access to application "mysql": user "bob" not found`)
+}
+
+func (s *ApplicationAccessSuite) TestRemoveApplicationAccess(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	sam := s.Factory.MakeUser(c, &factory.UserParams{Name: "sam"})
+	_, err := s.State.SetUserAccess(sam.UserTag(), app.ApplicationTag(), permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveUserAccess(sam.UserTag(), app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserAccess(sam.UserTag(), app.ApplicationTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveUserAccess(sam.UserTag(), app.ApplicationTag())
+	c.Assert(err, gc.ErrorMatches, `access to application "mysql" for user "sam" not found`)
+}
+
+func (s *ApplicationAccessSuite) TestGroupApplicationAccess(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "sam", NoModelUser: true})
+	group, err := s.State.AddUserGroup("dba", s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMembers(names.NewUserTag("sam"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetUserGroupAccess("dba", app.ApplicationTag(), permission.AdminAccess)
+	c.Assert(err, gc.ErrorMatches, `"admin" application access not valid`)
+	err = s.State.SetUserGroupAccess("dba", app.ApplicationTag(), permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.UserAccessFromGroups(names.NewUserTag("sam"), app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.WriteAccess)
+
+	// Access to an application does not make the model visible to
+	// members of the group.
+	models, err := s.State.ModelsForUser(names.NewUserTag("sam"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(models, gc.HasLen, 0)
+}
+
+func (s *ApplicationAccessSuite) TestRemoveApplicationRemovesAccess(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	sam := s.Factory.MakeUser(c, &factory.UserParams{Name: "sam"})
+	_, err := s.State.SetUserAccess(sam.UserTag(), app.ApplicationTag(), permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserAccess(sam.UserTag(), app.ApplicationTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// An application deployed again with the same name has no access.
+	app = s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	_, err = s.State.UserAccess(sam.UserTag(), app.ApplicationTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type auditSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (s *auditSuite) putEntries(c *gc.C, start time.Time, ops ...string) []audit.AuditEntry {
+	put := s.State.PutAuditEntryFn()
+	entries := make([]audit.AuditEntry, len(ops))
+	for i, op := range ops {
+		entries[i] = audit.AuditEntry{
+			JujuServerVersion: version.MustParse("2.2.0"),
+			ModelUUID:         s.State.ModelUUID(),
+			Timestamp:         start.Add(time.Duration(i) * time.Minute),
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "user",
+			OriginName:        "user-bob",
+			Operation:         op,
+		}
+		if i%2 == 1 {
+			entries[i].OriginName = "user-mary"
+		}
+		c.Assert(put(entries[i]), jc.ErrorIsNil)
+	}
+	return entries
+}
+
+func (s *auditSuite) TestAuditEntriesOldestFirst(c *gc.C) {
+	start := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	entries := s.putEntries(c, start, "a", "b", "c")
+
+	found, err := s.State.AuditEntries(audit.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, entries)
+}
+
+func (s *auditSuite) TestAuditEntriesFiltered(c *gc.C) {
+	start := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	entries := s.putEntries(c, start, "a", "b", "c", "d")
+
+	found, err := s.State.AuditEntries(audit.Query{OriginName: "user-mary"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []audit.AuditEntry{entries[1], entries[3]})
+
+	found, err = s.State.AuditEntries(audit.Query{Operation: "c"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []audit.AuditEntry{entries[2]})
+
+	found, err = s.State.AuditEntries(audit.Query{
+		After:  start.Add(time.Minute),
+		Before: start.Add(3 * time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []audit.AuditEntry{entries[1], entries[2]})
+
+	found, err = s.State.AuditEntries(audit.Query{ModelUUID: "d4e7b2a6-0b0d-4bd8-8d5a-2b6a2b0c9e3f"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, gc.HasLen, 0)
+}
+
+func (s *auditSuite) TestAuditEntriesPaged(c *gc.C) {
+	start := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	entries := s.putEntries(c, start, "a", "b", "c", "d", "e")
+
+	found, err := s.State.AuditEntries(audit.Query{Offset: 1, Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, entries[1:3])
+
+	found, err = s.State.AuditEntries(audit.Query{Offset: 4, Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, entries[4:])
+}
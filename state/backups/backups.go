@@ -43,6 +43,8 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils/filestorage"
 	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/backupcrypt"
 )
 
 const (
@@ -84,8 +86,9 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
 	// Create creates and stores a new juju backup archive. It updates
-	// the provided metadata.
-	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error
+	// the provided metadata. If key is not nil, the archive is
+	// encrypted with it.
+	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, key *backupcrypt.Key) error
 
 	// Add stores the backup archive and returns its new ID.
 	Add(archive io.Reader, meta *Metadata) (string, error)
@@ -119,9 +122,12 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 
 // Create creates and stores a new juju backup archive and updates the
 // provided metadata.
-func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
+func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, key *backupcrypt.Key) error {
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
+	if key != nil {
+		meta.KeyFingerprint = key.Fingerprint()
+	}
 
 	// The metadata file will not contain the ID or the "finished" data.
 	// However, that information is not as critical. The alternatives
@@ -142,7 +148,7 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
 	if err != nil {
 		return errors.Annotate(err, "while preparing for DB dump")
 	}
-	args := createArgs{filesToBackUp, dumper, metadataFile}
+	args := createArgs{filesToBackUp, dumper, metadataFile, key}
 	result, err := runCreate(&args)
 	if err != nil {
 		return errors.Annotate(err, "while creating backup archive")
@@ -212,3 +218,27 @@ func (b *backups) List() ([]*Metadata, error) {
 func (b *backups) Remove(id string) error {
 	return errors.Trace(b.storage.Remove(id))
 }
+
+// DecryptArchive returns a reader for the content of the given backup
+// archive. Archives which are not encrypted are returned unchanged.
+// Encrypted archives need either the secret key they were encrypted
+// with, or the private key corresponding to their public key.
+func DecryptArchive(meta *Metadata, archive io.Reader, key *backupcrypt.Key) (io.Reader, error) {
+	if meta.KeyFingerprint == "" {
+		return archive, nil
+	}
+	if key == nil {
+		return nil, errors.Errorf("backup %q is encrypted with key %s; a key is needed to decrypt it", meta.ID(), meta.KeyFingerprint)
+	}
+	if !key.CanDecrypt() {
+		return nil, errors.Errorf("cannot decrypt backup %q with a %s key", meta.ID(), key.Type())
+	}
+	if fingerprint := key.Fingerprint(); fingerprint != meta.KeyFingerprint {
+		return nil, errors.Errorf("backup %q is encrypted with key %s, not %s", meta.ID(), meta.KeyFingerprint, fingerprint)
+	}
+	plain, err := backupcrypt.NewReader(archive, key)
+	if err != nil {
+		return nil, errors.Annotatef(err, "decrypting backup %q", meta.ID())
+	}
+	return plain, nil
+}
@@ -76,7 +76,11 @@ func (b *backups) Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (na
 
 	defer backupReader.Close()
 
-	workspace, err := NewArchiveWorkspaceReader(backupReader)
+	archive, err := DecryptArchive(meta, backupReader, args.DecryptionKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	workspace, err := NewArchiveWorkspaceReader(archive)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot unpack backup file")
 	}
@@ -87,9 +91,8 @@ func (b *backups) Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (na
 		return nil, errors.Errorf("cannot restore a backup made in a machine with series %q into a machine with series %q, %#v", meta.Origin.Series, args.NewInstSeries, meta)
 	}
 
-	// TODO(perrito666) Create a compatibility table of sorts.
 	vers := meta.Origin.Version
-	if vers.Major != 2 {
+	if !restorableVersion(vers) {
 		return nil, errors.Errorf("Juju version %v cannot restore backups made using Juju version %v", version.Current.Minor, vers)
 	}
 	backupMachine := names.NewMachineTag(meta.Origin.Machine)
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/backupcrypt"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/backups"
 	backupstesting "github.com/juju/juju/state/backups/testing"
@@ -58,7 +59,7 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt}
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
-	err := s.api.Create(meta, &paths, &dbInfo)
+	err := s.api.Create(meta, &paths, &dbInfo, nil)
 
 	c.Check(err, gc.ErrorMatches, expected)
 }
@@ -98,7 +99,7 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
-	err := s.api.Create(meta, &paths, &dbInfo)
+	err := s.api.Create(meta, &paths, &dbInfo, nil)
 
 	// Test the call values.
 	s.Storage.CheckCalled(c, "spam", meta, archiveFile, "Add", "Metadata")
@@ -134,6 +135,29 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	c.Check(string(data), gc.Equals, "<compressed tarball>")
 }
 
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	received, testCreate := backups.NewTestCreate(nil)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		return nil, nil
+	})
+	s.setStored("spam")
+	_, key, err := backupcrypt.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju"), mongo.Mongo32wt}
+	meta := backupstesting.NewMetadataStarted()
+	err = s.api.Create(meta, &paths, &dbInfo, key)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(backups.ExposeCreateKey(received), gc.Equals, key)
+	c.Check(meta.KeyFingerprint, gc.Equals, key.Fingerprint())
+}
+
 func (s *backupsSuite) TestCreateFailToListFiles(c *gc.C) {
 	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
 		return nil, errors.New("failed!")
@@ -188,3 +212,63 @@ func (s *backupsSuite) TestStoreArchive(c *gc.C) {
 	c.Assert(meta.ID(), gc.Equals, "spam")
 	c.Assert(meta.Stored(), jc.DeepEquals, stored)
 }
+
+func (s *backupsSuite) encrypt(c *gc.C, key *backupcrypt.Key, data string) *bytes.Buffer {
+	var buf bytes.Buffer
+	w, err := backupcrypt.NewWriter(&buf, key)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write([]byte(data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return &buf
+}
+
+func (s *backupsSuite) TestDecryptArchive(c *gc.C) {
+	private, public, err := backupcrypt.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	meta := backupstesting.NewMetadataStarted()
+	meta.SetID("spam")
+	meta.KeyFingerprint = public.Fingerprint()
+
+	archive, err := backups.DecryptArchive(meta, s.encrypt(c, public, "<archive>"), private)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+}
+
+func (s *backupsSuite) TestDecryptArchiveNotEncrypted(c *gc.C) {
+	meta := backupstesting.NewMetadataStarted()
+	original := bytes.NewBufferString("<archive>")
+	archive, err := backups.DecryptArchive(meta, original, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archive, gc.Equals, original)
+}
+
+func (s *backupsSuite) TestDecryptArchiveBadKey(c *gc.C) {
+	private, public, err := backupcrypt.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := backupcrypt.GenerateSecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	meta := backupstesting.NewMetadataStarted()
+	meta.SetID("spam")
+	meta.KeyFingerprint = private.Fingerprint()
+
+	for i, test := range []struct {
+		key    *backupcrypt.Key
+		expect string
+	}{{
+		key:    nil,
+		expect: `backup "spam" is encrypted with key .*; a key is needed to decrypt it`,
+	}, {
+		key:    public,
+		expect: `cannot decrypt backup "spam" with a public key`,
+	}, {
+		key:    other,
+		expect: `backup "spam" is encrypted with key .*, not .*`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := backups.DecryptArchive(meta, s.encrypt(c, public, "<archive>"), test.key)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
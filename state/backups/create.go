@@ -16,6 +16,8 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils/hash"
 	"github.com/juju/utils/tar"
+
+	"github.com/juju/juju/core/backupcrypt"
 )
 
 // TODO(ericsnow) One concern is files that get out of date by the time
@@ -31,6 +33,7 @@ type createArgs struct {
 	filesToBackUp  []string
 	db             DBDumper
 	metadataReader io.Reader
+	key            *backupcrypt.Key
 }
 
 type createResult struct {
@@ -47,6 +50,7 @@ func create(args *createArgs) (_ *createResult, err error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	builder.key = args.key
 	defer func() {
 		if cerr := builder.cleanUp(); cerr != nil {
 			cerr.Log(logger)
@@ -96,6 +100,8 @@ type builder struct {
 	filesToBackUp []string
 	// db is the wrapper around the DB dump command and args.
 	db DBDumper
+	// key is the key with which to encrypt the archive, if any.
+	key *backupcrypt.Key
 	// checksum is the checksum of the archive file.
 	checksum string
 	// archiveFile is the backup archive file.
@@ -313,8 +319,23 @@ func (b *builder) buildArchiveAndChecksum() error {
 	// that users can compare the published checksum against the
 	// checksum of the file without having to decompress it first.
 	hasher := hash.NewHashingWriter(b.archiveFile, sha1.New())
-	if err := b.buildArchive(hasher); err != nil {
-		return errors.Trace(err)
+	if b.key == nil {
+		if err := b.buildArchive(hasher); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		// The checksum is of the encrypted archive, since that is
+		// what gets stored and downloaded.
+		encrypter, err := backupcrypt.NewWriter(hasher, b.key)
+		if err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
+		if err := b.buildArchive(encrypter); err != nil {
+			return errors.Trace(err)
+		}
+		if err := encrypter.Close(); err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
 	}
 
 	// Save the SHA1 checksum.
@@ -4,12 +4,14 @@
 package backups_test
 
 import (
+	"compress/gzip"
 	"os"
 	"runtime"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/backupcrypt"
 	"github.com/juju/juju/state/backups"
 	backupstesting "github.com/juju/juju/state/backups/testing"
 )
@@ -56,6 +58,44 @@ func (s *createSuite) TestLegacy(c *gc.C) {
 	s.checkArchive(c, file, expected)
 }
 
+func (s *createSuite) TestEncrypted(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	_, testFiles, expected := s.createTestFiles(c)
+	key, err := backupcrypt.GenerateSecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+
+	dumper := &TestDBDumper{}
+	args := backups.NewTestCreateArgsEncrypted(testFiles, dumper, metadataFile, key)
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	archiveFile, size, checksum := backups.ExposeCreateResult(result)
+	file, ok := archiveFile.(*os.File)
+	c.Assert(ok, jc.IsTrue)
+
+	// The size and checksum are those of the encrypted archive.
+	s.checkSize(c, file, size)
+	s.checkChecksum(c, file, checksum)
+
+	encrypted, err := backupcrypt.IsEncrypted(file)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypted, jc.IsTrue)
+	plain, err := backupcrypt.NewReader(file, key)
+	c.Assert(err, jc.ErrorIsNil)
+	tarFile, err := gzip.NewReader(plain)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkTarContents(c, tarFile, []tarContent{
+		{"juju-backup", "", nil},
+		{"juju-backup/dump", "", nil},
+		{"juju-backup/root.tar", "", expected},
+		{"juju-backup/metadata.json", "", nil},
+	})
+}
+
 func (s *createSuite) TestMetadataFileMissing(c *gc.C) {
 	var testFiles []string
 	dumper := &TestDBDumper{}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/testing"
 	"github.com/juju/utils/filestorage"
 
+	"github.com/juju/juju/core/backupcrypt"
 	"github.com/juju/juju/state"
 )
 
@@ -109,6 +110,19 @@ func ExposeCreateArgs(args *createArgs) ([]string, DBDumper) {
 	return args.filesToBackUp, args.db
 }
 
+// NewTestCreateArgsEncrypted builds a new args value for create() calls
+// which encrypt the archive with the given key.
+func NewTestCreateArgsEncrypted(filesToBackUp []string, db DBDumper, metar io.Reader, key *backupcrypt.Key) *createArgs {
+	args := NewTestCreateArgs(filesToBackUp, db, metar)
+	args.key = key
+	return args
+}
+
+// ExposeCreateKey extracts the encryption key in a create() args value.
+func ExposeCreateKey(args *createArgs) *backupcrypt.Key {
+	return args.key
+}
+
 // NewTestCreateResult builds a new create() result.
 func NewTestCreateResult(file io.ReadCloser, size int64, checksum string) *createResult {
 	result := createResult{
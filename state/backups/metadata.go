@@ -68,6 +68,15 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Scheduled records whether the backup was created by the
+	// controller's backup schedule, rather than on demand. Only
+	// scheduled backups are subject to the retention policy.
+	Scheduled bool
+
+	// KeyFingerprint identifies the key the archive is encrypted
+	// with. It is empty if the archive is not encrypted.
+	KeyFingerprint string
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -159,14 +168,16 @@ type flatMetadata struct {
 
 	// backup
 
-	Started     time.Time
-	Finished    time.Time
-	Notes       string
-	Environment string
-	Machine     string
-	Hostname    string
-	Version     version.Number
-	Series      string
+	Started        time.Time
+	Finished       time.Time
+	Notes          string
+	Scheduled      bool
+	KeyFingerprint string
+	Environment    string
+	Machine        string
+	Hostname       string
+	Version        version.Number
+	Series         string
 
 	CACert       string
 	CAPrivateKey string
@@ -183,15 +194,17 @@ func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
 		ChecksumFormat: m.ChecksumFormat(),
 		Size:           m.Size(),
 
-		Started:      m.Started,
-		Notes:        m.Notes,
-		Environment:  m.Origin.Model,
-		Machine:      m.Origin.Machine,
-		Hostname:     m.Origin.Hostname,
-		Version:      m.Origin.Version,
-		Series:       m.Origin.Series,
-		CACert:       m.CACert,
-		CAPrivateKey: m.CAPrivateKey,
+		Started:        m.Started,
+		Notes:          m.Notes,
+		Scheduled:      m.Scheduled,
+		KeyFingerprint: m.KeyFingerprint,
+		Environment:    m.Origin.Model,
+		Machine:        m.Origin.Machine,
+		Hostname:       m.Origin.Hostname,
+		Version:        m.Origin.Version,
+		Series:         m.Origin.Series,
+		CACert:         m.CACert,
+		CAPrivateKey:   m.CAPrivateKey,
 	}
 
 	stored := m.Stored()
@@ -234,6 +247,8 @@ func NewMetadataJSONReader(in io.Reader) (*Metadata, error) {
 		meta.Finished = &flat.Finished
 	}
 	meta.Notes = flat.Notes
+	meta.Scheduled = flat.Scheduled
+	meta.KeyFingerprint = flat.KeyFingerprint
 	meta.Origin = Origin{
 		Model:    flat.Environment,
 		Machine:  flat.Machine,
@@ -41,6 +41,7 @@ func (s *metadataSuite) TestAsJSONBuffer(c *gc.C) {
 	finished := meta.Started.Add(time.Minute)
 	meta.Finished = &finished
 
+	meta.KeyFingerprint = "de:ad:be:ef"
 	meta.CACert = "ca-cert"
 	meta.CAPrivateKey = "ca-private-key"
 
@@ -56,6 +57,8 @@ func (s *metadataSuite) TestAsJSONBuffer(c *gc.C) {
 		`"Started":"2014-09-09T11:59:34Z",`+
 		`"Finished":"2014-09-09T12:00:34Z",`+
 		`"Notes":"",`+
+		`"Scheduled":false,`+
+		`"KeyFingerprint":"de:ad:be:ef",`+
 		`"Environment":"asdf-zxcv-qwe",`+
 		`"Machine":"0",`+
 		`"Hostname":"myhost",`+
@@ -76,6 +79,7 @@ func (s *metadataSuite) TestNewMetadataJSONReader(c *gc.C) {
 		`"Started":"2014-09-09T11:59:34Z",` +
 		`"Finished":"2014-09-09T12:00:34Z",` +
 		`"Notes":"",` +
+		`"KeyFingerprint":"de:ad:be:ef",` +
 		`"Environment":"asdf-zxcv-qwe",` +
 		`"Machine":"0",` +
 		`"Hostname":"myhost",` +
@@ -92,6 +96,7 @@ func (s *metadataSuite) TestNewMetadataJSONReader(c *gc.C) {
 	c.Check(meta.Started.Unix(), gc.Equals, int64(1410263974))
 	c.Check(meta.Finished.Unix(), gc.Equals, int64(1410264034))
 	c.Check(meta.Notes, gc.Equals, "")
+	c.Check(meta.KeyFingerprint, gc.Equals, "de:ad:be:ef")
 	c.Check(meta.Origin.Model, gc.Equals, "asdf-zxcv-qwe")
 	c.Check(meta.Origin.Machine, gc.Equals, "0")
 	c.Check(meta.Origin.Hostname, gc.Equals, "myhost")
@@ -6,6 +6,7 @@ package backups
 import (
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/backupcrypt"
 	"github.com/juju/juju/instance"
 )
 
@@ -16,4 +17,8 @@ type RestoreArgs struct {
 	NewInstId      instance.Id
 	NewInstTag     names.Tag
 	NewInstSeries  string
+
+	// DecryptionKey is used to decrypt the backup, if it is
+	// encrypted.
+	DecryptionKey *backupcrypt.Key
 }
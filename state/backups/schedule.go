@@ -0,0 +1,104 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+)
+
+const (
+	storageScheduleName = "schedule"
+	scheduleStatusID    = "status"
+)
+
+// ScheduleStatus records the outcome of the most recent run of the
+// controller's backup schedule.
+type ScheduleStatus struct {
+	// Schedule is the cron-style schedule in effect.
+	Schedule string
+
+	// LastRun records when the schedule last ran. It is zero if the
+	// schedule has not yet run.
+	LastRun time.Time
+
+	// LastBackupID is the ID of the backup created by the last run,
+	// if it succeeded.
+	LastBackupID string
+
+	// LastError holds the error which caused the last run to fail,
+	// if it did.
+	LastError string
+
+	// NextRun records when the schedule will next run.
+	NextRun time.Time
+}
+
+// scheduleStatusDoc is a mirror of ScheduleStatus, used just for DB
+// storage.
+type scheduleStatusDoc struct {
+	ID           string `bson:"_id"`
+	Schedule     string `bson:"schedule"`
+	LastRun      int64  `bson:"lastrun,minsize"`
+	LastBackupID string `bson:"lastbackupid,omitempty"`
+	LastError    string `bson:"lasterror,omitempty"`
+	NextRun      int64  `bson:"nextrun,minsize"`
+}
+
+// GetScheduleStatus returns the recorded status of the controller's
+// backup schedule. If the schedule has never been recorded, an error
+// satisfying errors.IsNotFound() is returned.
+func GetScheduleStatus(st DB) (*ScheduleStatus, error) {
+	session := st.MongoSession().Copy()
+	defer session.Close()
+
+	var doc scheduleStatusDoc
+	coll := session.DB(storageDBName).C(storageScheduleName)
+	err := coll.FindId(scheduleStatusID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("backup schedule status")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "while getting backup schedule status")
+	}
+
+	status := ScheduleStatus{
+		Schedule:     doc.Schedule,
+		LastBackupID: doc.LastBackupID,
+		LastError:    doc.LastError,
+	}
+	if doc.LastRun != 0 {
+		status.LastRun = metadocUnixToTime(doc.LastRun)
+	}
+	if doc.NextRun != 0 {
+		status.NextRun = metadocUnixToTime(doc.NextRun)
+	}
+	return &status, nil
+}
+
+// SetScheduleStatus records the status of the controller's backup
+// schedule, replacing any previously recorded status.
+func SetScheduleStatus(st DB, status ScheduleStatus) error {
+	session := st.MongoSession().Copy()
+	defer session.Close()
+
+	doc := scheduleStatusDoc{
+		ID:           scheduleStatusID,
+		Schedule:     status.Schedule,
+		LastBackupID: status.LastBackupID,
+		LastError:    status.LastError,
+	}
+	if !status.LastRun.IsZero() {
+		doc.LastRun = metadocTimeToUnix(status.LastRun)
+	}
+	if !status.NextRun.IsZero() {
+		doc.NextRun = metadocTimeToUnix(status.NextRun)
+	}
+	coll := session.DB(storageDBName).C(storageScheduleName)
+	if _, err := coll.UpsertId(scheduleStatusID, doc); err != nil {
+		return errors.Annotate(err, "while setting backup schedule status")
+	}
+	return nil
+}
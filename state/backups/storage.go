@@ -44,9 +44,11 @@ type storageMetaDoc struct {
 
 	// backup
 
-	Started  int64  `bson:"started,minsize"`
-	Finished int64  `bson:"finished,minsize"`
-	Notes    string `bson:"notes,omitempty"`
+	Started        int64  `bson:"started,minsize"`
+	Finished       int64  `bson:"finished,minsize"`
+	Notes          string `bson:"notes,omitempty"`
+	Scheduled      bool   `bson:"scheduled,omitempty"`
+	KeyFingerprint string `bson:"keyfingerprint,omitempty"`
 
 	// origin
 
@@ -124,6 +126,8 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Scheduled = doc.Scheduled
+	meta.KeyFingerprint = doc.KeyFingerprint
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -175,6 +179,8 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Scheduled = meta.Scheduled
+	doc.KeyFingerprint = meta.KeyFingerprint
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -166,3 +166,52 @@ func (s *storageSuite) TestSetBackupStoredTimeNotFound(c *gc.C) {
 
 	c.Check(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *storageSuite) TestScheduledRoundTrip(c *gc.C) {
+	original := s.metadata(c)
+	original.Scheduled = true
+	id, err := backups.AddBackupMetadata(s.State, original)
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta, err := backups.GetBackupMetadata(s.State, id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(meta.Scheduled, jc.IsTrue)
+}
+
+func (s *storageSuite) TestKeyFingerprintRoundTrip(c *gc.C) {
+	original := s.metadata(c)
+	original.KeyFingerprint = "de:ad:be:ef"
+	id, err := backups.AddBackupMetadata(s.State, original)
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta, err := backups.GetBackupMetadata(s.State, id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(meta.KeyFingerprint, gc.Equals, "de:ad:be:ef")
+}
+
+func (s *storageSuite) TestScheduleStatusNotFound(c *gc.C) {
+	_, err := backups.GetScheduleStatus(s.State)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestSetScheduleStatus(c *gc.C) {
+	lastRun := time.Date(2017, 3, 10, 2, 30, 0, 0, time.UTC)
+	err := backups.SetScheduleStatus(s.State, backups.ScheduleStatus{
+		Schedule:     "@daily",
+		LastRun:      lastRun,
+		LastBackupID: "spam",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := backups.ScheduleStatus{
+		Schedule:  "@daily",
+		LastRun:   lastRun,
+		LastError: "boom",
+		NextRun:   lastRun.Add(24 * time.Hour),
+	}
+	err = backups.SetScheduleStatus(s.State, expected)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := backups.GetScheduleStatus(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*status, jc.DeepEquals, expected)
+}
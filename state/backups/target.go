@@ -0,0 +1,273 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+
+	"github.com/juju/juju/controller"
+)
+
+// Target is a location outside the controller where backups are
+// stored, so that they survive the loss of the controller. A Target
+// need only store named files; the layout, identification and
+// verification of the backups stored in it is handled by the
+// FileStorage returned from NewTargetStorage.
+type Target interface {
+	// Put streams size bytes from r into the named file, replacing
+	// any existing file with that name.
+	Put(name string, r io.Reader, size int64) error
+
+	// Open returns the content of the named file. If there is no
+	// such file, an error satisfying errors.IsNotFound() is
+	// returned.
+	Open(name string) (io.ReadCloser, error)
+
+	// List returns the names of all the stored files.
+	List() ([]string, error)
+
+	// Remove deletes the named file. Removing a file which does not
+	// exist is not an error.
+	Remove(name string) error
+}
+
+const (
+	targetArchiveSuffix  = ".tar.gz"
+	targetMetadataSuffix = ".json"
+)
+
+// OpenTarget returns the backup target configured for the
+// controller. The backup-target setting is a URL, either
+// "file:///some/dir" for a directory on the controller machines
+// (which may be an NFS mount), or "s3://bucket/prefix" for an
+// S3-compatible object store.
+func OpenTarget(cfg controller.Config) (Target, error) {
+	u, err := url.Parse(cfg.BackupTarget())
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing backup target")
+	}
+	switch u.Scheme {
+	case "file":
+		return NewDirTarget(u.Path), nil
+	case "s3":
+		return NewS3Target(S3TargetConfig{
+			Endpoint:  cfg.BackupTargetS3Endpoint(),
+			Region:    cfg.BackupTargetS3Region(),
+			AccessKey: cfg.BackupTargetS3AccessKey(),
+			SecretKey: cfg.BackupTargetS3SecretKey(),
+			Bucket:    u.Host,
+			Prefix:    strings.Trim(u.Path, "/"),
+		})
+	}
+	return nil, errors.NotSupportedf("backup target scheme %q", u.Scheme)
+}
+
+// NewControllerStorage returns the FileStorage in which the
+// controller's backups are kept: the configured backup target if
+// there is one, or the controller's database otherwise.
+func NewControllerStorage(st DB) (filestorage.FileStorage, error) {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "could not get controller config")
+	}
+	if cfg.BackupTarget() == "" {
+		return NewStorage(st), nil
+	}
+	target, err := OpenTarget(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewTargetStorage(target), nil
+}
+
+// NewTargetStorage returns a FileStorage which keeps backups in the
+// given target. Each backup is stored as two files, named for the
+// backup ID: the archive, and its metadata in JSON format. Archives
+// are checked against the checksum in their metadata, both when they
+// are added and when they are read back.
+func NewTargetStorage(target Target) filestorage.FileStorage {
+	return &targetStorage{target: target}
+}
+
+type targetStorage struct {
+	target Target
+}
+
+// Metadata is part of the filestorage.FileStorage interface.
+func (s *targetStorage) Metadata(id string) (filestorage.Metadata, error) {
+	meta, err := s.metadata(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+func (s *targetStorage) metadata(id string) (*Metadata, error) {
+	file, err := s.target.Open(id + targetMetadataSuffix)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("backup metadata %q", id)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer file.Close()
+	meta, err := NewMetadataJSONReader(file)
+	if err != nil {
+		return nil, errors.Annotatef(err, "while reading metadata for backup %q", id)
+	}
+	return meta, nil
+}
+
+// Get is part of the filestorage.FileStorage interface.
+func (s *targetStorage) Get(id string) (filestorage.Metadata, io.ReadCloser, error) {
+	meta, err := s.metadata(id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	archive, err := s.target.Open(id + targetArchiveSuffix)
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "while opening archive for backup %q", id)
+	}
+	return meta, newVerifyingReader(id, archive, meta.Checksum()), nil
+}
+
+// List is part of the filestorage.FileStorage interface.
+func (s *targetStorage) List() ([]filestorage.Metadata, error) {
+	names, err := s.target.List()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var list []filestorage.Metadata
+	for _, name := range names {
+		if !strings.HasSuffix(name, targetMetadataSuffix) {
+			continue
+		}
+		meta, err := s.metadata(strings.TrimSuffix(name, targetMetadataSuffix))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		list = append(list, meta)
+	}
+	return list, nil
+}
+
+// Add is part of the filestorage.FileStorage interface.
+func (s *targetStorage) Add(doc filestorage.Metadata, archive io.Reader) (string, error) {
+	meta, ok := doc.(*Metadata)
+	if !ok {
+		return "", errors.Errorf("doc must be of type *backups.Metadata")
+	}
+	metaDoc := newStorageMetaDoc(meta)
+	id := newStorageID(&metaDoc)
+	if _, err := s.metadata(id); err == nil {
+		return "", errors.AlreadyExistsf("backup metadata %q", id)
+	} else if !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+
+	// The archive is streamed to the target, and checked against
+	// the metadata once it has been sent.
+	archiveName := id + targetArchiveSuffix
+	hasher := sha1.New()
+	if err := s.target.Put(archiveName, io.TeeReader(archive, hasher), meta.Size()); err != nil {
+		return "", errors.Annotate(err, "while storing backup archive")
+	}
+	checksum := base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+	if checksum != meta.Checksum() {
+		if err := s.target.Remove(archiveName); err != nil {
+			logger.Errorf("cannot remove corrupt backup archive %q: %v", archiveName, err)
+		}
+		return "", errors.Errorf("backup archive checksum mismatch: expected %q, got %q", meta.Checksum(), checksum)
+	}
+
+	// The metadata is only written once the archive is safely
+	// stored, so that incomplete backups are never listed.
+	meta.SetID(id)
+	// TODO(fwereade): 2016-03-17 lp:1558657
+	stored := time.Now().UTC()
+	meta.SetStored(&stored)
+	// Like the metadata kept in the database, the metadata kept in
+	// the target leaves out the controller's CA; the copy in the
+	// archive may be encrypted, but this one cannot.
+	targetMeta := *meta
+	targetMeta.CACert = ""
+	targetMeta.CAPrivateKey = ""
+	metaFile, err := targetMeta.AsJSONBuffer()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	data, err := ioutil.ReadAll(metaFile)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	err = s.target.Put(id+targetMetadataSuffix, bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", errors.Annotate(err, "while storing backup metadata")
+	}
+	return id, nil
+}
+
+// SetFile is part of the filestorage.FileStorage interface.
+func (s *targetStorage) SetFile(id string, file io.Reader) error {
+	return errors.NotSupportedf("replacing the archive of a stored backup")
+}
+
+// Remove is part of the filestorage.FileStorage interface.
+func (s *targetStorage) Remove(id string) error {
+	if _, err := s.metadata(id); err != nil {
+		return errors.Trace(err)
+	}
+	// The metadata is removed first, so that a partially removed
+	// backup is no longer listed.
+	if err := s.target.Remove(id + targetMetadataSuffix); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.target.Remove(id + targetArchiveSuffix))
+}
+
+// Close is part of the filestorage.FileStorage interface.
+func (s *targetStorage) Close() error {
+	return nil
+}
+
+// verifyingReader checks the content read from an archive against the
+// expected checksum, failing the final read if they do not match.
+type verifyingReader struct {
+	io.ReadCloser
+	id       string
+	checksum string
+	hasher   hash.Hash
+}
+
+func newVerifyingReader(id string, archive io.ReadCloser, checksum string) io.ReadCloser {
+	return &verifyingReader{
+		ReadCloser: archive,
+		id:         id,
+		checksum:   checksum,
+		hasher:     sha1.New(),
+	}
+}
+
+// Read is part of the io.Reader interface.
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hasher.Write(p[:n])
+	if err == io.EOF {
+		checksum := base64.StdEncoding.EncodeToString(r.hasher.Sum(nil))
+		if checksum != r.checksum {
+			return n, errors.Errorf("backup %q archive checksum mismatch: expected %q, got %q", r.id, r.checksum, checksum)
+		}
+	}
+	return n, err
+}
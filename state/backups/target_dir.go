@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// uploadPrefix marks partially written files in a directory target.
+const uploadPrefix = ".upload-"
+
+// NewDirTarget returns a Target which stores backups in the given
+// directory. The directory is typically a network file system
+// mounted on every controller machine, so that backups survive the
+// loss of the controllers.
+func NewDirTarget(dir string) Target {
+	return &dirTarget{dir: dir}
+}
+
+type dirTarget struct {
+	dir string
+}
+
+func (t *dirTarget) path(name string) (string, error) {
+	if name == "" || strings.ContainsRune(name, filepath.Separator) || strings.HasPrefix(name, ".") {
+		return "", errors.NotValidf("backup file name %q", name)
+	}
+	return filepath.Join(t.dir, name), nil
+}
+
+// Put is part of the Target interface. The file is written under a
+// temporary name and renamed into place once complete, so readers
+// never see a partially written file.
+func (t *dirTarget) Put(name string, r io.Reader, size int64) (err error) {
+	path, err := t.path(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	file, err := ioutil.TempFile(t.dir, uploadPrefix)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(file.Name())
+		}
+	}()
+
+	n, err := io.Copy(file, r)
+	if err != nil {
+		return errors.Annotatef(err, "writing %q", name)
+	}
+	if n != size {
+		return errors.Errorf("writing %q: expected %d bytes, got %d", name, size, n)
+	}
+	if err := file.Sync(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Rename(file.Name(), path))
+}
+
+// Open is part of the Target interface.
+func (t *dirTarget) Open(name string) (io.ReadCloser, error) {
+	path, err := t.path(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("backup file %q", name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return file, nil
+}
+
+// List is part of the Target interface.
+func (t *dirTarget) List() ([]string, error) {
+	infos, err := ioutil.ReadDir(t.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, info := range infos {
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	return names, nil
+}
+
+// Remove is part of the Target interface.
+func (t *dirTarget) Remove(name string) error {
+	path, err := t.path(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
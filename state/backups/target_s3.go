@@ -0,0 +1,153 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+)
+
+// DefaultS3Region is the region used by S3 targets when none is
+// configured.
+const DefaultS3Region = "us-east-1"
+
+// s3ListBatchSize is the maximum number of keys requested in each
+// call when listing the contents of an S3 target.
+const s3ListBatchSize = 1000
+
+// S3TargetConfig holds the configuration of an S3 backup target.
+type S3TargetConfig struct {
+	// Endpoint is the URL of the S3-compatible service. If it is
+	// empty, the Amazon S3 endpoint for the region is used.
+	Endpoint string
+
+	// Region is the region holding the bucket.
+	Region string
+
+	// AccessKey and SecretKey are the credentials used to access
+	// the bucket.
+	AccessKey string
+	SecretKey string
+
+	// Bucket is the name of the bucket holding the backups.
+	Bucket string
+
+	// Prefix is prepended to the names of the stored files.
+	Prefix string
+}
+
+// Validate ensures that the config is correct.
+func (config S3TargetConfig) Validate() error {
+	if config.Bucket == "" {
+		return errors.NotValidf("empty Bucket")
+	}
+	if config.AccessKey == "" {
+		return errors.NotValidf("empty AccessKey")
+	}
+	if config.SecretKey == "" {
+		return errors.NotValidf("empty SecretKey")
+	}
+	return nil
+}
+
+// NewS3Target returns a Target which stores backups in a bucket of
+// Amazon S3, or of an S3-compatible object store.
+func NewS3Target(config S3TargetConfig) (Target, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	regionName := config.Region
+	if regionName == "" {
+		regionName = DefaultS3Region
+	}
+	region, ok := aws.Regions[regionName]
+	if !ok {
+		region = aws.USEast
+		region.Name = regionName
+	}
+	if config.Endpoint != "" {
+		region.S3Endpoint = config.Endpoint
+		region.S3BucketEndpoint = ""
+	}
+	auth := aws.Auth{
+		AccessKey: config.AccessKey,
+		SecretKey: config.SecretKey,
+	}
+	bucket, err := s3.New(auth, region).Bucket(config.Bucket)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &s3Target{
+		bucket: bucket,
+		prefix: config.Prefix,
+	}, nil
+}
+
+type s3Target struct {
+	bucket *s3.Bucket
+	prefix string
+}
+
+func (t *s3Target) key(name string) string {
+	return path.Join(t.prefix, name)
+}
+
+// Put is part of the Target interface.
+func (t *s3Target) Put(name string, r io.Reader, size int64) error {
+	err := t.bucket.PutReader(t.key(name), r, size, "application/octet-stream", s3.Private)
+	return errors.Annotatef(err, "uploading %q", name)
+}
+
+// Open is part of the Target interface.
+func (t *s3Target) Open(name string) (io.ReadCloser, error) {
+	r, err := t.bucket.GetReader(t.key(name))
+	if isS3NotFound(err) {
+		return nil, errors.NotFoundf("backup file %q", name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "downloading %q", name)
+	}
+	return r, nil
+}
+
+// List is part of the Target interface.
+func (t *s3Target) List() ([]string, error) {
+	prefix := ""
+	if t.prefix != "" {
+		prefix = t.prefix + "/"
+	}
+	var names []string
+	marker := ""
+	for {
+		resp, err := t.bucket.List(prefix, "/", marker, s3ListBatchSize)
+		if err != nil {
+			return nil, errors.Annotate(err, "listing backup files")
+		}
+		for _, key := range resp.Contents {
+			names = append(names, strings.TrimPrefix(key.Key, prefix))
+			marker = key.Key
+		}
+		if !resp.IsTruncated {
+			return names, nil
+		}
+	}
+}
+
+// Remove is part of the Target interface.
+func (t *s3Target) Remove(name string) error {
+	err := t.bucket.Del(t.key(name))
+	return errors.Annotatef(err, "removing %q", name)
+}
+
+func isS3NotFound(err error) bool {
+	if s3err, ok := err.(*s3.Error); ok {
+		return s3err.StatusCode == http.StatusNotFound
+	}
+	return false
+}
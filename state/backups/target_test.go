@@ -0,0 +1,213 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type targetSuite struct {
+	testing.BaseSuite
+	dir    string
+	target backups.Target
+}
+
+var _ = gc.Suite(&targetSuite{})
+
+const archiveData = "<compressed archive data>"
+
+func (s *targetSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.dir = filepath.Join(c.MkDir(), "backups")
+	s.target = backups.NewDirTarget(s.dir)
+}
+
+func (s *targetSuite) metadata(c *gc.C, data string) *backups.Metadata {
+	hasher := sha1.New()
+	hasher.Write([]byte(data))
+	checksum := base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+
+	meta := backups.NewMetadata()
+	meta.Started = time.Date(2017, 3, 10, 2, 30, 0, 0, time.UTC)
+	meta.Origin.Model = "some-uuid"
+	meta.Origin.Machine = "0"
+	meta.Origin.Hostname = "localhost"
+	meta.Notes = "nightly"
+	meta.CACert = "ca-cert"
+	meta.CAPrivateKey = "ca-private-key"
+	err := meta.MarkComplete(int64(len(data)), checksum)
+	c.Assert(err, jc.ErrorIsNil)
+	return meta
+}
+
+func (s *targetSuite) TestDirTarget(c *gc.C) {
+	names, err := s.target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names, gc.HasLen, 0)
+
+	err = s.target.Put("spam", bytes.NewBufferString("eggs"), 4)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.target.Put("ham", bytes.NewBufferString("spam"), 4)
+	c.Assert(err, jc.ErrorIsNil)
+
+	names, err = s.target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(names)
+	c.Check(names, jc.DeepEquals, []string{"ham", "spam"})
+
+	file, err := s.target.Open("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(file)
+	file.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "eggs")
+
+	err = s.target.Remove("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.target.Open("spam")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	err = s.target.Remove("spam")
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *targetSuite) TestDirTargetShortWrite(c *gc.C) {
+	err := s.target.Put("spam", bytes.NewBufferString("egg"), 4)
+	c.Assert(err, gc.ErrorMatches, `writing "spam": expected 4 bytes, got 3`)
+
+	names, err := s.target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names, gc.HasLen, 0)
+	infos, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(infos, gc.HasLen, 0)
+}
+
+func (s *targetSuite) TestDirTargetBadName(c *gc.C) {
+	err := s.target.Put("../spam", bytes.NewBufferString("eggs"), 4)
+	c.Check(err, gc.ErrorMatches, `backup file name "../spam" not valid`)
+}
+
+func (s *targetSuite) TestStorageAdd(c *gc.C) {
+	stor := backups.NewTargetStorage(s.target)
+	meta := s.metadata(c, archiveData)
+	id, err := stor.Add(meta, bytes.NewBufferString(archiveData))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "20170310-023000.some-uuid")
+
+	names, err := s.target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(names)
+	c.Check(names, jc.DeepEquals, []string{id + ".json", id + ".tar.gz"})
+
+	list, err := stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 1)
+	stored := list[0].(*backups.Metadata)
+	c.Check(stored.ID(), gc.Equals, id)
+	c.Check(stored.Notes, gc.Equals, "nightly")
+	c.Check(stored.Checksum(), gc.Equals, meta.Checksum())
+	c.Check(stored.Stored(), gc.NotNil)
+	c.Check(stored.CACert, gc.Equals, "")
+	c.Check(stored.CAPrivateKey, gc.Equals, "")
+
+	_, archive, err := stor.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, archiveData)
+}
+
+func (s *targetSuite) TestStorageAddAlreadyExists(c *gc.C) {
+	stor := backups.NewTargetStorage(s.target)
+	_, err := stor.Add(s.metadata(c, archiveData), bytes.NewBufferString(archiveData))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = stor.Add(s.metadata(c, archiveData), bytes.NewBufferString(archiveData))
+	c.Check(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *targetSuite) TestStorageAddChecksumMismatch(c *gc.C) {
+	stor := backups.NewTargetStorage(s.target)
+	meta := s.metadata(c, archiveData)
+	corrupt := "<compressed archive dat!>"
+	_, err := stor.Add(meta, bytes.NewBufferString(corrupt))
+	c.Assert(err, gc.ErrorMatches, `backup archive checksum mismatch: expected ".*", got ".*"`)
+
+	names, err := s.target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names, gc.HasLen, 0)
+}
+
+func (s *targetSuite) TestStorageGetCorrupt(c *gc.C) {
+	stor := backups.NewTargetStorage(s.target)
+	id, err := stor.Add(s.metadata(c, archiveData), bytes.NewBufferString(archiveData))
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(s.dir, id+".tar.gz"), []byte("<compressed archive dat!>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, archive, err := stor.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	_, err = ioutil.ReadAll(archive)
+	c.Check(err, gc.ErrorMatches, `backup "20170310-023000.some-uuid" archive checksum mismatch: .*`)
+}
+
+func (s *targetSuite) TestStorageRemove(c *gc.C) {
+	stor := backups.NewTargetStorage(s.target)
+	id, err := stor.Add(s.metadata(c, archiveData), bytes.NewBufferString(archiveData))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = stor.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+	names, err := s.target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names, gc.HasLen, 0)
+
+	err = stor.Remove(id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, _, err = stor.Get(id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *targetSuite) TestOpenTarget(c *gc.C) {
+	cfg := controller.Config{
+		controller.BackupTarget: "file://" + s.dir,
+	}
+	target, err := backups.OpenTarget(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = target.Put("spam", bytes.NewBufferString("eggs"), 4)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(filepath.Join(s.dir, "spam"))
+	c.Check(err, jc.ErrorIsNil)
+
+	cfg = controller.Config{
+		controller.BackupTarget:            "s3://juju-backups/prod",
+		controller.BackupTargetS3Endpoint:  "https://minio.example.com:9000",
+		controller.BackupTargetS3AccessKey: "access",
+		controller.BackupTargetS3SecretKey: "secret",
+	}
+	_, err = backups.OpenTarget(cfg)
+	c.Check(err, jc.ErrorIsNil)
+
+	cfg = controller.Config{
+		controller.BackupTarget: "ftp://example.com/backups",
+	}
+	_, err = backups.OpenTarget(cfg)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
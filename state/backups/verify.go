@@ -0,0 +1,241 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/backupcrypt"
+)
+
+// maxBSONDocumentSize is the largest document mongod will store, and so
+// the largest which may appear in a database dump.
+const maxBSONDocumentSize = 16 * 1024 * 1024
+
+// VerifyResult describes the outcome of verifying a backup archive.
+type VerifyResult struct {
+	// Checksum and Size are those of the archive as read.
+	Checksum string
+	Size     int64
+
+	// ContentsVerified is false when the archive is encrypted and no
+	// key was given to decrypt it, in which case only its checksum
+	// and size are checked.
+	ContentsVerified bool
+
+	// Collections and Documents count what was found in the
+	// archive's database dump.
+	Collections int
+	Documents   int
+
+	// Version is the juju version which created the backup, and
+	// Compatible reports whether this version of juju can restore it.
+	Version    version.Number
+	Compatible bool
+
+	// Problems lists everything found wrong with the archive. The
+	// archive is sound if it is empty.
+	Problems []string
+}
+
+// restorableVersion reports whether backups made by the given version
+// of juju can be restored.
+func restorableVersion(vers version.Number) bool {
+	// TODO(perrito666) Create a compatibility table of sorts.
+	return vers.Major == 2
+}
+
+// VerifyArchive reads the whole of the given backup archive, checking
+// it against its metadata, and checking that it holds everything
+// needed to restore it: the files bundle, the metadata file and a
+// database dump made of well formed BSON documents. Encrypted archives
+// are decrypted with key if it is not nil.
+//
+// Faults in the archive are reported in the result's Problems; an
+// error is returned only if the archive cannot be verified at all.
+func VerifyArchive(meta *Metadata, archive io.Reader, key *backupcrypt.Key) (*VerifyResult, error) {
+	result := &VerifyResult{
+		Version:    meta.Origin.Version,
+		Compatible: restorableVersion(meta.Origin.Version),
+	}
+	if !result.Compatible {
+		result.Problems = append(result.Problems, fmt.Sprintf(
+			"backups made using Juju version %v cannot be restored", meta.Origin.Version,
+		))
+	}
+
+	hasher := sha1.New()
+	counter := &countingReader{r: io.TeeReader(archive, hasher)}
+	if meta.KeyFingerprint == "" || key != nil {
+		content, err := DecryptArchive(meta, counter, key)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := verifyContent(content, result); err != nil {
+			result.Problems = append(result.Problems, err.Error())
+		} else {
+			result.ContentsVerified = true
+		}
+	}
+	// Whatever is left must still be read to check the archive as
+	// a whole.
+	if _, err := io.Copy(ioutil.Discard, counter); err != nil {
+		result.Problems = append(result.Problems, errors.Annotate(err, "reading archive").Error())
+	}
+
+	result.Checksum = base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+	result.Size = counter.n
+	if result.Checksum != meta.Checksum() {
+		result.Problems = append(result.Problems, fmt.Sprintf(
+			"archive checksum mismatch: expected %q, got %q", meta.Checksum(), result.Checksum,
+		))
+	}
+	if result.Size != meta.Size() {
+		result.Problems = append(result.Problems, fmt.Sprintf(
+			"archive size mismatch: expected %d bytes, got %d", meta.Size(), result.Size,
+		))
+	}
+	return result, nil
+}
+
+// verifyContent checks the layout of the compressed tar file read from
+// r, and the database dump within it, recording what it finds in
+// result. It returns the first fault found.
+func verifyContent(r io.Reader, result *VerifyResult) error {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Annotate(err, "uncompressing archive")
+	}
+	defer gzr.Close()
+
+	paths := NewCanonicalArchivePaths()
+	var foundBundle, foundMetadata, foundDump bool
+	tarFile := tar.NewReader(gzr)
+	for {
+		hdr, err := tarFile.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Annotate(err, "reading archive")
+		}
+		name := path.Clean(hdr.Name)
+		switch {
+		case name == paths.ContentDir:
+		case name == paths.FilesBundle:
+			foundBundle = true
+			if err := verifyBundle(tarFile); err != nil {
+				return errors.Annotatef(err, "reading %s", name)
+			}
+		case name == paths.MetadataFile:
+			foundMetadata = true
+			if _, err := NewMetadataJSONReader(tarFile); err != nil {
+				return errors.Annotatef(err, "reading %s", name)
+			}
+		case name == paths.DBDumpDir:
+			foundDump = true
+		case strings.HasPrefix(name, paths.DBDumpDir+"/"):
+			foundDump = true
+			if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+				continue
+			}
+			if path.Ext(name) != ".bson" {
+				continue
+			}
+			documents, err := verifyBSON(tarFile)
+			if err != nil {
+				return errors.Annotatef(err, "reading %s", name)
+			}
+			result.Collections++
+			result.Documents += documents
+		case strings.HasPrefix(name, paths.ContentDir+"/"):
+		default:
+			return errors.Errorf("unexpected file %q outside %s", hdr.Name, paths.ContentDir)
+		}
+	}
+	// The compressed stream must also be complete.
+	if _, err := io.Copy(ioutil.Discard, gzr); err != nil {
+		return errors.Annotate(err, "reading archive")
+	}
+
+	switch {
+	case !foundBundle:
+		return errors.Errorf("archive has no %s", paths.FilesBundle)
+	case !foundMetadata:
+		return errors.Errorf("archive has no %s", paths.MetadataFile)
+	case !foundDump || result.Collections == 0:
+		return errors.Errorf("archive has no database dump in %s", paths.DBDumpDir)
+	}
+	return nil
+}
+
+// verifyBundle reads the whole of the files bundle, a tar file.
+func verifyBundle(r io.Reader) error {
+	bundle := tar.NewReader(r)
+	for {
+		_, err := bundle.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := io.Copy(ioutil.Discard, bundle); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// verifyBSON parses the sequence of BSON documents written by mongodump
+// for a collection, and returns how many there are.
+func verifyBSON(r io.Reader) (int, error) {
+	br := bufio.NewReader(r)
+	documents := 0
+	for {
+		var length int32
+		if err := binary.Read(br, binary.LittleEndian, &length); err == io.EOF {
+			return documents, nil
+		} else if err != nil {
+			return documents, errors.Annotatef(err, "document %d", documents)
+		}
+		if length < 5 || length > maxBSONDocumentSize {
+			return documents, errors.Errorf("document %d has invalid length %d", documents, length)
+		}
+		data := make([]byte, length)
+		binary.LittleEndian.PutUint32(data, uint32(length))
+		if _, err := io.ReadFull(br, data[4:]); err != nil {
+			return documents, errors.Annotatef(err, "document %d", documents)
+		}
+		var doc bson.D
+		if err := bson.Unmarshal(data, &doc); err != nil {
+			return documents, errors.Annotatef(err, "document %d", documents)
+		}
+		documents++
+	}
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read is part of the io.Reader interface.
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,173 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/backupcrypt"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+type verifySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) bson(c *gc.C, docs ...interface{}) string {
+	var buf bytes.Buffer
+	for _, doc := range docs {
+		data, err := bson.Marshal(doc)
+		c.Assert(err, jc.ErrorIsNil)
+		buf.Write(data)
+	}
+	return buf.String()
+}
+
+func (s *verifySuite) dump(c *gc.C) []backupstesting.File {
+	return []backupstesting.File{{
+		Name:    "juju/machines.bson",
+		Content: s.bson(c, bson.M{"_id": "0"}, bson.M{"_id": "1"}),
+	}, {
+		Name:    "juju/machines.metadata.json",
+		Content: "{}",
+	}, {
+		Name:    "oplog.bson",
+		Content: s.bson(c, bson.M{"ts": 1}),
+	}}
+}
+
+func (s *verifySuite) files() []backupstesting.File {
+	return []backupstesting.File{{
+		Name:    "var/lib/juju/system-identity",
+		Content: "<an ssh key goes here>",
+	}}
+}
+
+// archive returns a new archive with the given dump, and its metadata.
+func (s *verifySuite) archive(c *gc.C, dump []backupstesting.File, key *backupcrypt.Key) (*backups.Metadata, []byte) {
+	meta := backupstesting.NewMetadataStarted()
+	meta.SetID("spam")
+	archive, err := backupstesting.NewArchive(meta, s.files(), dump)
+	c.Assert(err, jc.ErrorIsNil)
+	data := archive.Bytes()
+	if key != nil {
+		var buf bytes.Buffer
+		w, err := backupcrypt.NewWriter(&buf, key)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = w.Write(data)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(w.Close(), jc.ErrorIsNil)
+		data = buf.Bytes()
+		meta.KeyFingerprint = key.Fingerprint()
+	}
+
+	hasher := sha1.New()
+	hasher.Write(data)
+	checksum := base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+	err = meta.MarkComplete(int64(len(data)), checksum)
+	c.Assert(err, jc.ErrorIsNil)
+	return meta, data
+}
+
+func (s *verifySuite) TestVerifyArchive(c *gc.C) {
+	meta, data := s.archive(c, s.dump(c), nil)
+
+	result, err := backups.VerifyArchive(meta, bytes.NewReader(data), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, &backups.VerifyResult{
+		Checksum:         meta.Checksum(),
+		Size:             meta.Size(),
+		ContentsVerified: true,
+		Collections:      2,
+		Documents:        3,
+		Version:          meta.Origin.Version,
+		Compatible:       true,
+	})
+}
+
+func (s *verifySuite) TestVerifyArchiveTruncated(c *gc.C) {
+	meta, data := s.archive(c, s.dump(c), nil)
+
+	result, err := backups.VerifyArchive(meta, bytes.NewReader(data[:len(data)-20]), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ContentsVerified, jc.IsFalse)
+	c.Assert(result.Problems, gc.HasLen, 3)
+	c.Check(result.Problems[0], gc.Matches, ".*unexpected EOF")
+	c.Check(result.Problems[1], gc.Matches, `archive checksum mismatch: expected ".*", got ".*"`)
+	c.Check(result.Problems[2], gc.Equals, fmt.Sprintf(
+		"archive size mismatch: expected %d bytes, got %d", meta.Size(), meta.Size()-20,
+	))
+}
+
+func (s *verifySuite) TestVerifyArchiveBadBSON(c *gc.C) {
+	dump := s.dump(c)
+	dump[0].Content = dump[0].Content[:len(dump[0].Content)-3]
+	meta, data := s.archive(c, dump, nil)
+
+	result, err := backups.VerifyArchive(meta, bytes.NewReader(data), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ContentsVerified, jc.IsFalse)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"reading juju-backup/dump/juju/machines.bson: document 1: unexpected EOF",
+	})
+}
+
+func (s *verifySuite) TestVerifyArchiveNoDump(c *gc.C) {
+	meta, data := s.archive(c, nil, nil)
+
+	result, err := backups.VerifyArchive(meta, bytes.NewReader(data), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"archive has no database dump in juju-backup/dump",
+	})
+}
+
+func (s *verifySuite) TestVerifyArchiveIncompatible(c *gc.C) {
+	meta, data := s.archive(c, s.dump(c), nil)
+	meta.Origin.Version = version.MustParse("1.25.6")
+
+	result, err := backups.VerifyArchive(meta, bytes.NewReader(data), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ContentsVerified, jc.IsTrue)
+	c.Check(result.Compatible, jc.IsFalse)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"backups made using Juju version 1.25.6 cannot be restored",
+	})
+}
+
+func (s *verifySuite) TestVerifyArchiveEncrypted(c *gc.C) {
+	private, public, err := backupcrypt.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	meta, data := s.archive(c, s.dump(c), public)
+
+	// Without the key, only the checksum can be verified.
+	result, err := backups.VerifyArchive(meta, bytes.NewReader(data), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ContentsVerified, jc.IsFalse)
+	c.Check(result.Checksum, gc.Equals, meta.Checksum())
+	c.Check(result.Problems, gc.HasLen, 0)
+
+	result, err = backups.VerifyArchive(meta, bytes.NewReader(data), private)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ContentsVerified, jc.IsTrue)
+	c.Check(result.Documents, gc.Equals, 3)
+	c.Check(result.Problems, gc.HasLen, 0)
+
+	other, err := backupcrypt.GenerateSecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = backups.VerifyArchive(meta, bytes.NewReader(data), other)
+	c.Check(err, gc.ErrorMatches, `backup "spam" is encrypted with key .*, not .*`)
+}
@@ -53,6 +53,7 @@ var (
 	ImageStorageNewStorage               = &imageStorageNewStorage
 	MachineIdLessThan                    = machineIdLessThan
 	ControllerAvailable                  = &controllerAvailable
+	MaxFilteredInitialLogs               = &maxFilteredInitialLogs
 	GetOrCreatePorts                     = getOrCreatePorts
 	GetPorts                             = getPorts
 	AddVolumeOps                         = (*State).addVolumeOps
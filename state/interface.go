@@ -220,6 +220,10 @@ type Action interface {
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
+
+	// Cancel cancels the action: a pending action is marked as
+	// cancelled, and a running action as aborting.
+	Cancel(message string) (Action, error)
 }
 
 // ApplicationEntity represents a local or remote application.
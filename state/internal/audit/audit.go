@@ -4,8 +4,11 @@
 package audit
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/mongo/utils"
@@ -26,6 +29,11 @@ type auditEntryDoc struct {
 	// unmarshaled via time.Time::UnmarshalText.
 	Timestamp string `bson:"timestamp"`
 
+	// Time is when the audit entry was written, in nanoseconds
+	// since the Unix epoch. Unlike Timestamp it sorts correctly,
+	// so it is used for querying.
+	Time int64 `bson:"time"`
+
 	// RemoteAddress is the IP of the machine from which the
 	// audit-event was triggered.
 	RemoteAddress string `bson:"remote-address"`
@@ -75,6 +83,7 @@ func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, er
 		JujuServerVersion: auditEntry.JujuServerVersion,
 		ModelUUID:         auditEntry.ModelUUID,
 		Timestamp:         string(timeAsBlob),
+		Time:              auditEntry.Timestamp.UnixNano(),
 		RemoteAddress:     auditEntry.RemoteAddress,
 		OriginType:        auditEntry.OriginType,
 		OriginName:        auditEntry.OriginName,
@@ -82,3 +91,98 @@ func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, er
 		Data:              utils.EscapeKeys(auditEntry.Data),
 	}, nil
 }
+
+// GetAuditEntriesFn creates a closure which when passed an audit.Query
+// will return the matching entries from the audit collection, oldest
+// first. findDocs must unmarshal the documents matching the given
+// query, sorted by the given fields, into docs.
+func GetAuditEntriesFn(
+	collectionName string,
+	findDocs func(collectionName string, query bson.D, sort []string, skip, limit int, docs interface{}) error,
+) func(audit.Query) ([]audit.AuditEntry, error) {
+	return func(query audit.Query) ([]audit.AuditEntry, error) {
+		if err := query.Validate(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		var docs []auditEntryDoc
+		err := findDocs(
+			collectionName,
+			queryDoc(query),
+			[]string{"time", "_id"},
+			query.Offset,
+			query.Limit,
+			&docs,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries := make([]audit.AuditEntry, len(docs))
+		for i, doc := range docs {
+			entry, err := auditEntryFromAuditEntryDoc(doc)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			entries[i] = entry
+		}
+		return entries, nil
+	}
+}
+
+func queryDoc(query audit.Query) bson.D {
+	doc := bson.D{}
+	if query.ModelUUID != "" {
+		doc = append(doc, bson.DocElem{"model-uuid", query.ModelUUID})
+	}
+	if query.OriginName != "" {
+		doc = append(doc, bson.DocElem{"origin-name", query.OriginName})
+	}
+	if query.Operation != "" {
+		doc = append(doc, bson.DocElem{"operation", query.Operation})
+	}
+	timeRange := bson.D{}
+	if !query.After.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$gte", query.After.UnixNano()})
+	}
+	if !query.Before.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$lt", query.Before.UnixNano()})
+	}
+	if len(timeRange) > 0 {
+		doc = append(doc, bson.DocElem{"time", timeRange})
+	}
+	return doc
+}
+
+func auditEntryFromAuditEntryDoc(doc auditEntryDoc) (audit.AuditEntry, error) {
+	var timestamp time.Time
+	if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+		return audit.AuditEntry{}, errors.Annotatef(err, "parsing timestamp %q", doc.Timestamp)
+	}
+	var data map[string]interface{}
+	if len(doc.Data) > 0 {
+		data = utils.UnescapeKeys(plainMaps(doc.Data))
+	}
+	return audit.AuditEntry{
+		JujuServerVersion: doc.JujuServerVersion,
+		ModelUUID:         doc.ModelUUID,
+		Timestamp:         timestamp.UTC(),
+		RemoteAddress:     doc.RemoteAddress,
+		OriginType:        doc.OriginType,
+		OriginName:        doc.OriginName,
+		Operation:         doc.Operation,
+		Data:              data,
+	}, nil
+}
+
+// plainMaps returns a copy of data with any nested bson.M values,
+// as produced when unmarshalling, converted to plain maps so that
+// their keys can be unescaped.
+func plainMaps(data map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		if submap, ok := value.(bson.M); ok {
+			value = plainMaps(submap)
+		}
+		result[key] = value
+	}
+	return result
+}
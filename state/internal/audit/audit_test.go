@@ -4,6 +4,8 @@
 package audit_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -58,6 +60,7 @@ func (*AuditSuite) TestPutAuditEntry_PersistAuditEntry(c *gc.C) {
 			"juju-server-version": requested.JujuServerVersion,
 			"model-uuid":          requested.ModelUUID,
 			"timestamp":           string(requestedTimeBlob),
+			"time":                requested.Timestamp.UnixNano(),
 			"remote-address":      "8.8.8.8",
 			"origin-type":         requested.OriginType,
 			"origin-name":         requested.OriginName,
@@ -111,3 +114,111 @@ func (*AuditSuite) TestPutAuditEntry_ValidateAuditEntry(c *gc.C) {
 	err := putAuditEntry(auditEntry)
 	c.Check(err, gc.ErrorMatches, validationErr.Error())
 }
+
+func (*AuditSuite) TestGetAuditEntries_BuildsQuery(c *gc.C) {
+	after := time.Date(2017, 5, 1, 0, 0, 0, 0, time.UTC)
+	before := after.Add(24 * time.Hour)
+	query := audit.Query{
+		ModelUUID:  utils.MustNewUUID().String(),
+		OriginName: "user-bob",
+		Operation:  "ApplicationDestroy",
+		After:      after,
+		Before:     before,
+		Offset:     10,
+		Limit:      5,
+	}
+
+	var findDocsCalled bool
+	findDocs := func(collectionName string, q bson.D, sort []string, skip, limit int, docs interface{}) error {
+		findDocsCalled = true
+		c.Check(collectionName, gc.Equals, "audit.log")
+		c.Check(q, jc.DeepEquals, bson.D{
+			{"model-uuid", query.ModelUUID},
+			{"origin-name", "user-bob"},
+			{"operation", "ApplicationDestroy"},
+			{"time", bson.D{
+				{"$gte", after.UnixNano()},
+				{"$lt", before.UnixNano()},
+			}},
+		})
+		c.Check(sort, jc.DeepEquals, []string{"time", "_id"})
+		c.Check(skip, gc.Equals, 10)
+		c.Check(limit, gc.Equals, 5)
+		return nil
+	}
+
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", findDocs)
+	entries, err := getAuditEntries(query)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+	c.Assert(findDocsCalled, jc.IsTrue)
+}
+
+func (*AuditSuite) TestGetAuditEntries_EmptyQueryMatchesAll(c *gc.C) {
+	findDocs := func(_ string, q bson.D, _ []string, skip, limit int, _ interface{}) error {
+		c.Check(q, gc.HasLen, 0)
+		c.Check(skip, gc.Equals, 0)
+		c.Check(limit, gc.Equals, 0)
+		return nil
+	}
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", findDocs)
+	_, err := getAuditEntries(audit.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*AuditSuite) TestGetAuditEntries_RoundTripsAuditEntry(c *gc.C) {
+	stored := audit.AuditEntry{
+		JujuServerVersion: version.MustParse("1.0.0"),
+		ModelUUID:         utils.MustNewUUID().String(),
+		Timestamp:         coretesting.NonZeroTime().UTC(),
+		RemoteAddress:     "8.8.8.8",
+		OriginType:        "user",
+		OriginName:        "bob",
+		Operation:         "status",
+		Data: map[string]interface{}{
+			"a": "b",
+			"$a.b": map[string]interface{}{
+				"b.$a": "c",
+			},
+		},
+	}
+
+	var rawDocs []interface{}
+	insertDocs := func(_ string, docs ...interface{}) error {
+		rawDocs = append(rawDocs, docs...)
+		return nil
+	}
+	err := stateaudit.PutAuditEntryFn("audit.log", insertDocs)(stored)
+	c.Assert(err, jc.ErrorIsNil)
+
+	findDocs := func(_ string, _ bson.D, _ []string, _, _ int, docs interface{}) error {
+		// Round trip the documents through BSON, as the
+		// database would.
+		data, err := bson.Marshal(bson.M{"docs": rawDocs})
+		c.Assert(err, jc.ErrorIsNil)
+		var result struct {
+			Docs bson.Raw `bson:"docs"`
+		}
+		c.Assert(bson.Unmarshal(data, &result), jc.ErrorIsNil)
+		return result.Docs.Unmarshal(docs)
+	}
+	entries, err := stateaudit.GetAuditEntriesFn("audit.log", findDocs)(audit.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0], jc.DeepEquals, stored)
+}
+
+func (*AuditSuite) TestGetAuditEntries_PropagatesReadError(c *gc.C) {
+	findDocs := func(string, bson.D, []string, int, int, interface{}) error {
+		return errors.New("my error")
+	}
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", findDocs)
+	_, err := getAuditEntries(audit.Query{})
+	c.Check(err, gc.ErrorMatches, "my error")
+}
+
+func (*AuditSuite) TestGetAuditEntries_ValidatesQuery(c *gc.C) {
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", nil)
+	_, err := getAuditEntries(audit.Query{Limit: -1})
+	c.Check(err, gc.ErrorMatches, "negative Limit not valid")
+}
@@ -333,6 +333,7 @@ type LogRecord struct {
 type LogTailerParams struct {
 	StartID       int64
 	StartTime     time.Time
+	EndTime       time.Time // Exclusive; implies NoTail when set.
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -340,8 +341,13 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
-	AllModels     bool
+	// IncludeMessage and ExcludeMessage hold regular expressions,
+	// in Go's regexp syntax, which log messages must (or must not)
+	// match.
+	IncludeMessage []string
+	ExcludeMessage []string
+	Oplog          *mgo.Collection // For testing only
+	AllModels      bool
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -361,6 +367,11 @@ const oplogOverlap = time.Minute
 // output of large broken models with logging at DEBUG.
 var maxRecentLogIds = int(oplogOverlap.Minutes() * 150000)
 
+// maxFilteredInitialLogs is the most log records read, newest first,
+// to find the initial lines which match a message filter. Fewer lines
+// than asked for are sent if not enough of those records match.
+var maxFilteredInitialLogs = 100000
+
 // LogTailerState describes the methods on State required for logging to
 // the database.
 type LogTailerState interface {
@@ -377,12 +388,18 @@ func NewLogTailer(st LogTailerState, params *LogTailerParams) (LogTailer, error)
 		return nil, errors.NewNotValid(nil, "not allowed to tail logs from all models: not a controller")
 	}
 
+	messages, err := newMessageFilter(params.IncludeMessage, params.ExcludeMessage)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	session := st.MongoSession().Copy()
 	t := &logTailer{
 		modelUUID: st.ModelUUID(),
 		session:   session,
 		logsColl:  session.DB(logsDB).C(logsC).With(session),
 		params:    params,
+		messages:  messages,
 		logCh:     make(chan *LogRecord),
 		recentIds: newRecentIdTracker(maxRecentLogIds),
 	}
@@ -402,6 +419,7 @@ type logTailer struct {
 	session   *mgo.Session
 	logsColl  *mgo.Collection
 	params    *LogTailerParams
+	messages  messageFilter
 	logCh     chan *LogRecord
 	lastID    int64
 	lastTime  time.Time
@@ -435,7 +453,7 @@ func (t *logTailer) loop() error {
 		return errors.Trace(err)
 	}
 
-	if t.params.NoTail {
+	if t.params.NoTail || !t.params.EndTime.IsZero() {
 		return nil
 	}
 
@@ -448,6 +466,9 @@ func (t *logTailer) processCollection() error {
 	sel := t.paramsToSelector(t.params, "")
 	query := t.logsColl.Find(sel)
 
+	if t.params.InitialLines > 0 && t.messages.active() {
+		return errors.Trace(t.sendInitialMatching(query))
+	}
 	if t.params.InitialLines > 0 {
 		// This is a little racy but it's good enough.
 		count, err := query.Count()
@@ -478,18 +499,61 @@ func (t *logTailer) processCollection() error {
 		if err != nil {
 			return errors.Annotate(err, "deserialization failed (possible DB corruption)")
 		}
-		select {
-		case <-t.tomb.Dying():
-			return errors.Trace(tomb.ErrDying)
-		case t.logCh <- rec:
-			t.lastID = rec.ID
-			t.lastTime = rec.Time
-			t.recentIds.Add(doc.Id)
+		if !t.messages.match(rec.Message) {
+			continue
+		}
+		if err := t.sendRecord(rec, doc.Id); err != nil {
+			return errors.Trace(err)
 		}
 	}
 	return errors.Trace(iter.Close())
 }
 
+// sendInitialMatching sends the last InitialLines records selected by
+// the query which match the message filter. Records filtered out by
+// message can't be counted by the query, so the records are read
+// newest first, using the same index as processCollection, until
+// enough match or maxFilteredInitialLogs have been read.
+func (t *logTailer) sendInitialMatching(query *mgo.Query) error {
+	var initial []*LogRecord
+	var initialIds []interface{}
+	iter := query.Sort("-e", "-t", "-_id").Limit(maxFilteredInitialLogs).Iter()
+	doc := new(logDoc)
+	for len(initial) < t.params.InitialLines && iter.Next(doc) {
+		rec, err := logDocToRecord(doc)
+		if err != nil {
+			return errors.Annotate(err, "deserialization failed (possible DB corruption)")
+		}
+		if !t.messages.match(rec.Message) {
+			continue
+		}
+		initial = append(initial, rec)
+		initialIds = append(initialIds, doc.Id)
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	for i := len(initial) - 1; i >= 0; i-- {
+		if err := t.sendRecord(initial[i], initialIds[i]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// sendRecord sends a record read from the logs collection.
+func (t *logTailer) sendRecord(rec *LogRecord, id interface{}) error {
+	select {
+	case <-t.tomb.Dying():
+		return errors.Trace(tomb.ErrDying)
+	case t.logCh <- rec:
+		t.lastID = rec.ID
+		t.lastTime = rec.Time
+		t.recentIds.Add(id)
+	}
+	return nil
+}
+
 func (t *logTailer) tailOplog() error {
 	recentIds := t.recentIds.AsSet()
 
@@ -539,6 +603,9 @@ func (t *logTailer) tailOplog() error {
 			if err != nil {
 				return errors.Annotate(err, "deserialization failed (possible DB corruption)")
 			}
+			if !t.messages.match(rec.Message) {
+				continue
+			}
 			select {
 			case <-t.tomb.Dying():
 				return errors.Trace(tomb.ErrDying)
@@ -550,8 +617,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
+	timeSel := bson.M{}
 	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+		timeSel["$gte"] = params.StartTime.UnixNano()
+	}
+	if !params.EndTime.IsZero() {
+		timeSel["$lt"] = params.EndTime.UnixNano()
+	}
+	if len(timeSel) > 0 {
+		sel = append(sel, bson.DocElem{"t", timeSel})
 	}
 	if !params.AllModels {
 		sel = append(sel, bson.DocElem{"e", t.modelUUID})
@@ -601,6 +675,52 @@ func makeModulePattern(modules []string) string {
 	return `^(` + strings.Join(patterns, "|") + `)(\..+)?$`
 }
 
+// messageFilter selects log records by message. Messages are matched
+// by the tailer rather than the database, so that user supplied
+// expressions are run by Go's linear time regexp engine rather than
+// MongoDB's backtracking one.
+type messageFilter struct {
+	include *regexp.Regexp
+	exclude *regexp.Regexp
+}
+
+func newMessageFilter(include, exclude []string) (messageFilter, error) {
+	var f messageFilter
+	var err error
+	if len(include) > 0 {
+		if f.include, err = regexp.Compile(makeMessagePattern(include)); err != nil {
+			return messageFilter{}, errors.NewNotValid(err, "include message expression")
+		}
+	}
+	if len(exclude) > 0 {
+		if f.exclude, err = regexp.Compile(makeMessagePattern(exclude)); err != nil {
+			return messageFilter{}, errors.NewNotValid(err, "exclude message expression")
+		}
+	}
+	return f, nil
+}
+
+// active reports whether the filter selects any messages.
+func (f messageFilter) active() bool {
+	return f.include != nil || f.exclude != nil
+}
+
+// match reports whether the message is selected by the filter.
+func (f messageFilter) match(message string) bool {
+	if f.include != nil && !f.include.MatchString(message) {
+		return false
+	}
+	return f.exclude == nil || !f.exclude.MatchString(message)
+}
+
+func makeMessagePattern(expressions []string) string {
+	var patterns []string
+	for _, expr := range expressions {
+		patterns = append(patterns, `(?:`+expr+`)`)
+	}
+	return strings.Join(patterns, "|")
+}
+
 func newRecentIdTracker(maxLen int) *recentIdTracker {
 	return &recentIdTracker{
 		ids: deque.NewWithMaxLen(maxLen),
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
@@ -374,6 +375,38 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestTimeRangeFiltering(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	endT := threshT.Add(5 * time.Second)
+	s.writeLogsT(c,
+		threshT.Add(-5*time.Second), threshT.Add(-time.Millisecond), 5,
+		logTemplate{Message: "too early"},
+	)
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, threshT, endT.Add(-time.Millisecond), 5, want)
+	s.writeLogsT(c, endT, endT.Add(5*time.Second), 5, logTemplate{Message: "too late"})
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		StartTime: threshT,
+		EndTime:   endT,
+		Oplog:     s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+
+	// The tailer stops itself rather than tailing the oplog, as no
+	// later logs can fall within the range.
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -780,6 +813,113 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestIncludeMessage(c *gc.C) {
+	started := logTemplate{Message: "agent started"}
+	failed := logTemplate{Message: "hook failed: config-changed"}
+	other := logTemplate{Message: "nothing to see"}
+	writeLogs := func() {
+		s.writeLogs(c, 1, started)
+		s.writeLogs(c, 1, failed)
+		s.writeLogs(c, 1, other)
+	}
+	params := &state.LogTailerParams{
+		IncludeMessage: []string{"^agent", "hook (failed|error)"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, started)
+		s.assertTailer(c, tailer, 1, failed)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestIncludeExcludeMessage(c *gc.C) {
+	started := logTemplate{Message: "agent started"}
+	stopped := logTemplate{Message: "agent stopped"}
+	other := logTemplate{Message: "nothing to see"}
+	writeLogs := func() {
+		s.writeLogs(c, 1, started)
+		s.writeLogs(c, 1, stopped)
+		s.writeLogs(c, 1, other)
+	}
+	params := &state.LogTailerParams{
+		IncludeMessage: []string{"agent"},
+		ExcludeMessage: []string{"stopped$"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, started)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestInitialLinesWithMessageFilter(c *gc.C) {
+	expected := logTemplate{Message: "want"}
+	s.writeLogs(c, 3, logTemplate{Message: "want but too early"})
+	s.writeLogs(c, 2, expected)
+	s.writeLogs(c, 3, logTemplate{Message: "dont"})
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		InitialLines:   2,
+		IncludeMessage: []string{"^want"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// The last 2 matching lines are seen, not the
+	// matching ones among the last 2 lines.
+	s.assertTailer(c, tailer, 2, expected)
+}
+
+func (s *LogTailerSuite) TestInitialLinesWithMessageFilterBounded(c *gc.C) {
+	s.PatchValue(state.MaxFilteredInitialLogs, 4)
+	s.writeLogs(c, 2, logTemplate{Message: "want but too early"})
+	s.writeLogs(c, 3, logTemplate{Message: "dont"})
+	expected := logTemplate{Message: "want"}
+	s.writeLogs(c, 1, expected)
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		InitialLines:   3,
+		IncludeMessage: []string{"^want"},
+		NoTail:         true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// Only the last 4 records are read, so just one matches.
+	s.assertTailer(c, tailer, 1, expected)
+	select {
+	case log, ok := <-tailer.Logs():
+		c.Assert(ok, jc.IsFalse, gc.Commentf("unexpected log: %+v", log))
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the tailer to finish")
+	}
+}
+
+func (s *LogTailerSuite) TestMessageExpressionBacktracking(c *gc.C) {
+	// Expressions are matched by Go's linear time engine, so
+	// patterns which backtrack catastrophically are harmless.
+	long := logTemplate{Message: strings.Repeat("a", 100) + "!"}
+	match := logTemplate{Message: "aaaa"}
+	writeLogs := func() {
+		s.writeLogs(c, 1, long)
+		s.writeLogs(c, 1, match)
+	}
+	params := &state.LogTailerParams{
+		IncludeMessage: []string{"(a+)+$"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, match)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestInvalidMessageExpression(c *gc.C) {
+	_, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		ExcludeMessage: []string{"(unclosed"},
+	})
+	c.Assert(err, gc.ErrorMatches, "exclude message expression: .*")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -98,9 +98,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
+		userLoginFailuresC,
 		// Controller users contain extra data about users therefore
 		// are not migrated either.
 		controllerUsersC,
+		// User groups are controller global, and not migrated.
+		userGroupsC,
+		// API tokens belong to controller users, and are not migrated.
+		apiTokensC,
 		// userenvnameC is just to provide a unique key constraint.
 		usermodelnameC,
 		// Metrics aren't migrated.
@@ -226,6 +231,9 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		"CloudRegion",
 		"CloudCredential",
 		"LatestAvailableTools",
+		// Quotas are set by the migrationmaster worker
+		// after the model is imported.
+		"Quotas",
 	)
 	s.AssertExportedFields(c, modelDoc{}, fields)
 }
@@ -16,6 +16,7 @@ import (
 
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/permission"
@@ -83,6 +84,10 @@ type modelDoc struct {
 	// LatestAvailableTools is a string representing the newest version
 	// found while checking streams for new versions.
 	LatestAvailableTools string `bson:"available-tools,omitempty"`
+
+	// Quotas holds the quotas on the resources that the model may use.
+	// It is nil if the model's resources are not limited.
+	Quotas *modelQuotasDoc `bson:"quotas,omitempty"`
 }
 
 // modelEntityRefsDoc records references to the top-level entities
@@ -190,6 +195,10 @@ type ModelArgs struct {
 
 	// MigrationMode is the initial migration mode of the model.
 	MigrationMode MigrationMode
+
+	// Quotas holds the initial quotas on the resources that the model
+	// may use.
+	Quotas quota.Resources
 }
 
 // Validate validates the ModelArgs.
@@ -976,6 +985,7 @@ func createModelOp(
 	name, uuid, controllerUUID, cloudName, cloudRegion string,
 	cloudCredential names.CloudCredentialTag,
 	migrationMode MigrationMode,
+	quotas quota.Resources,
 ) txn.Op {
 	doc := &modelDoc{
 		UUID:            uuid,
@@ -987,6 +997,7 @@ func createModelOp(
 		Cloud:           cloudName,
 		CloudRegion:     cloudRegion,
 		CloudCredential: cloudCredential.Id(),
+		Quotas:          newModelQuotasDoc(quotas),
 	}
 	return txn.Op{
 		C:      modelsC,
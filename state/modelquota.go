@@ -0,0 +1,437 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/quota"
+)
+
+// modelQuotasDoc holds the quotas on the resources that a model may use.
+// It is stored in the model document; a zero quota is not limited.
+type modelQuotasDoc struct {
+	Machines int    `bson:"machines,omitempty"`
+	Units    int    `bson:"units,omitempty"`
+	Cores    uint64 `bson:"cores,omitempty"`
+	Memory   uint64 `bson:"memory,omitempty"`
+	Storage  uint64 `bson:"storage,omitempty"`
+}
+
+func newModelQuotasDoc(quotas quota.Resources) *modelQuotasDoc {
+	if quotas == (quota.Resources{}) {
+		return nil
+	}
+	return &modelQuotasDoc{
+		Machines: quotas.Machines,
+		Units:    quotas.Units,
+		Cores:    quotas.Cores,
+		Memory:   quotas.Memory,
+		Storage:  quotas.Storage,
+	}
+}
+
+func (doc *modelQuotasDoc) resources() quota.Resources {
+	if doc == nil {
+		return quota.Resources{}
+	}
+	return quota.Resources{
+		Machines: doc.Machines,
+		Units:    doc.Units,
+		Cores:    doc.Cores,
+		Memory:   doc.Memory,
+		Storage:  doc.Storage,
+	}
+}
+
+// Quotas returns the quotas on the resources that the model may use.
+func (m *Model) Quotas() quota.Resources {
+	return m.doc.Quotas.resources()
+}
+
+// SetQuotas replaces the quotas on the resources that the model may use.
+// Resources already in use beyond the new quotas are not released, but
+// no more will be added.
+func (m *Model) SetQuotas(quotas quota.Resources) error {
+	st, closeState, err := m.getState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closeState()
+
+	var update bson.D
+	if doc := newModelQuotasDoc(quotas); doc != nil {
+		update = bson.D{{"$set", bson.D{{"quotas", doc}}}}
+	} else {
+		update = bson.D{{"$unset", bson.D{{"quotas", nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.doc.UUID,
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	if err := st.runTransaction(ops); err != nil {
+		return errors.Annotate(err, "cannot set model quotas")
+	}
+	return m.Refresh()
+}
+
+// Machines whose cores or memory are neither constrained nor known from
+// their hardware are counted as using the least that providers choose
+// for a machine, so that adding them is still checked against quotas.
+const (
+	defaultMachineCores  = 1
+	defaultMachineMemory = 1024
+)
+
+// machineResources returns the cores and memory, as counted against the
+// model's quotas, of a machine with the given cores and memory.
+func machineResources(cores, mem *uint64) (uint64, uint64) {
+	usedCores, usedMem := uint64(defaultMachineCores), uint64(defaultMachineMemory)
+	if cores != nil {
+		usedCores = *cores
+	}
+	if mem != nil {
+		usedMem = *mem
+	}
+	return usedCores, usedMem
+}
+
+// ModelUsage returns the resources that the model uses, as counted
+// against its quotas. Machines that have been provisioned count the
+// cores and memory of their hardware; others count those of their
+// constraints. Storage that is yet to be created, for units not yet
+// assigned to machines, is counted too. Only the fields counted are
+// read from the database.
+func (st *State) ModelUsage() (quota.Resources, error) {
+	var used quota.Resources
+	if err := st.addMachineUsage(&used); err != nil {
+		return used, errors.Trace(err)
+	}
+
+	units, closer := st.getCollection(unitsC)
+	defer closer()
+	count, err := units.Find(bson.D{{"principal", ""}}).Count()
+	if err != nil {
+		return used, errors.Trace(err)
+	}
+	used.Units = count
+
+	if err := st.addStorageUsage(&used); err != nil {
+		return used, errors.Trace(err)
+	}
+	return used, nil
+}
+
+// addMachineUsage adds the machines, cores and memory that the model's
+// top-level machines use to used.
+func (st *State) addMachineUsage(used *quota.Resources) error {
+	machines, closer := st.getCollection(machinesC)
+	defer closer()
+	var machineDocs []machineDoc
+	err := machines.Find(bson.D{{"containertype", ""}}).Select(bson.D{{"machineid", 1}}).All(&machineDocs)
+	if err != nil {
+		return errors.Annotate(err, "cannot get machines")
+	}
+	used.Machines += len(machineDocs)
+
+	instanceDataCollection, closer := st.getCollection(instanceDataC)
+	defer closer()
+	var instDocs []instanceData
+	err = instanceDataCollection.Find(nil).Select(bson.D{
+		{"machineid", 1}, {"cpucores", 1}, {"mem", 1},
+	}).All(&instDocs)
+	if err != nil {
+		return errors.Annotate(err, "cannot get instance data")
+	}
+	instances := make(map[string]instanceData, len(instDocs))
+	for _, doc := range instDocs {
+		instances[doc.MachineId] = doc
+	}
+
+	var unprovisioned []string
+	for _, doc := range machineDocs {
+		instData, ok := instances[doc.Id]
+		if !ok {
+			unprovisioned = append(unprovisioned, st.docID(machineGlobalKey(doc.Id)))
+			continue
+		}
+		cores, mem := machineResources(instData.CpuCores, instData.Mem)
+		used.Cores += cores
+		used.Memory += mem
+	}
+	if len(unprovisioned) == 0 {
+		return nil
+	}
+
+	constraintsCollection, closer := st.getCollection(constraintsC)
+	defer closer()
+	var consDocs []constraintsDoc
+	err = constraintsCollection.Find(bson.D{{"_id", bson.D{{"$in", unprovisioned}}}}).Select(bson.D{
+		{"cpucores", 1}, {"mem", 1},
+	}).All(&consDocs)
+	if err != nil {
+		return errors.Annotate(err, "cannot get machine constraints")
+	}
+	for _, doc := range consDocs {
+		cores, mem := machineResources(doc.CpuCores, doc.Mem)
+		used.Cores += cores
+		used.Memory += mem
+	}
+	// Machines without a constraints document use the defaults.
+	missing := uint64(len(unprovisioned) - len(consDocs))
+	used.Cores += missing * defaultMachineCores
+	used.Memory += missing * defaultMachineMemory
+	return nil
+}
+
+// addStorageUsage adds the size of the model's volumes and filesystems,
+// and of the storage instances that have neither yet, to used. Those
+// that have been provisioned count their actual size; others count the
+// size requested.
+func (st *State) addStorageUsage(used *quota.Resources) error {
+	sizeFields := bson.D{{"info.size", 1}, {"params.size", 1}, {"storageid", 1}}
+	created := set.NewStrings()
+
+	volumes, closer := st.getCollection(volumesC)
+	defer closer()
+	var volumeDocs []volumeDoc
+	if err := volumes.Find(nil).Select(sizeFields).All(&volumeDocs); err != nil {
+		return errors.Annotate(err, "cannot get volumes")
+	}
+	for _, doc := range volumeDocs {
+		if doc.StorageId != "" {
+			created.Add(doc.StorageId)
+		}
+		if doc.Info != nil {
+			used.Storage += doc.Info.Size
+		} else if doc.Params != nil {
+			used.Storage += doc.Params.Size
+		}
+	}
+
+	filesystems, closer := st.getCollection(filesystemsC)
+	defer closer()
+	var filesystemDocs []filesystemDoc
+	err := filesystems.Find(nil).Select(append(sizeFields, bson.DocElem{"volumeid", 1})).All(&filesystemDocs)
+	if err != nil {
+		return errors.Annotate(err, "cannot get filesystems")
+	}
+	for _, doc := range filesystemDocs {
+		if doc.StorageId != "" {
+			created.Add(doc.StorageId)
+		}
+		if doc.VolumeId != "" {
+			// The size of the backing volume is counted already.
+			continue
+		}
+		if doc.Info != nil {
+			used.Storage += doc.Info.Size
+		} else if doc.Params != nil {
+			used.Storage += doc.Params.Size
+		}
+	}
+	return errors.Trace(st.addPendingStorageUsage(used, created))
+}
+
+// addPendingStorageUsage adds the size of the storage instances whose
+// volumes or filesystems are not yet created, because their units are
+// not yet assigned to machines, to used. They count the size that their
+// units' storage constraints will give them. Storage instances in
+// created already have their volumes or filesystems counted.
+func (st *State) addPendingStorageUsage(used *quota.Resources, created set.Strings) error {
+	storageInstances, closer := st.getCollection(storageInstancesC)
+	defer closer()
+	var storageDocs []storageInstanceDoc
+	err := storageInstances.Find(nil).Select(bson.D{
+		{"id", 1}, {"owner", 1}, {"storagename", 1},
+	}).All(&storageDocs)
+	if err != nil {
+		return errors.Annotate(err, "cannot get storage instances")
+	}
+	pending := make(map[string][]string)
+	for _, doc := range storageDocs {
+		if created.Contains(doc.Id) {
+			continue
+		}
+		// Shared storage is owned by an application, and is
+		// created along with it rather than with a unit.
+		owner, err := names.ParseUnitTag(doc.Owner)
+		if err != nil {
+			continue
+		}
+		pending[owner.Id()] = append(pending[owner.Id()], doc.StorageName)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	unitNames := make([]string, 0, len(pending))
+	for name := range pending {
+		unitNames = append(unitNames, name)
+	}
+	units, closer := st.getCollection(unitsC)
+	defer closer()
+	var unitDocs []unitDoc
+	err = units.Find(bson.D{{"name", bson.D{{"$in", unitNames}}}}).Select(bson.D{
+		{"name", 1}, {"application", 1}, {"charmurl", 1},
+	}).All(&unitDocs)
+	if err != nil {
+		return errors.Annotate(err, "cannot get units")
+	}
+
+	// Units that have not yet set their charm use their
+	// application's, as Unit.StorageConstraints does.
+	applications, closer := st.getCollection(applicationsC)
+	defer closer()
+	var applicationDocs []applicationDoc
+	err = applications.Find(nil).Select(bson.D{{"name", 1}, {"charmurl", 1}}).All(&applicationDocs)
+	if err != nil {
+		return errors.Annotate(err, "cannot get applications")
+	}
+	charmURLs := make(map[string]*charm.URL, len(applicationDocs))
+	for _, doc := range applicationDocs {
+		charmURLs[doc.Name] = doc.CharmURL
+	}
+
+	allCons := make(map[string]map[string]StorageConstraints)
+	for _, doc := range unitDocs {
+		curl := doc.CharmURL
+		if curl == nil {
+			curl = charmURLs[doc.Application]
+		}
+		key := applicationStorageConstraintsKey(doc.Application, curl)
+		cons, ok := allCons[key]
+		if !ok {
+			cons, err = readStorageConstraints(st, key)
+			if err != nil && !errors.IsNotFound(err) {
+				return errors.Trace(err)
+			}
+			allCons[key] = cons
+		}
+		for _, storageName := range pending[doc.Name] {
+			used.Storage += cons[storageName].Size
+		}
+	}
+	return nil
+}
+
+// modelQuotaUsageKey is the key of the refcount document that counts the
+// additions checked against a model's quotas. Each addition asserts the
+// count read before the model's usage and increments it, so that of two
+// concurrent additions checked against the same usage, one aborts and is
+// checked again against the other's.
+const modelQuotaUsageKey = "quotausage"
+
+// checkModelQuotas returns an error satisfying quota.IsExceeded if adding
+// the requested resources to the model would exceed its quotas. Otherwise
+// it returns operations, to be run with those adding the resources, that
+// abort the transaction if the quotas or usage they were checked against
+// have changed.
+func (st *State) checkModelQuotas(requested quota.Resources) ([]txn.Op, error) {
+	if requested == (quota.Resources{}) {
+		return nil, nil
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	quotas := model.Quotas()
+	if quotas == (quota.Resources{}) {
+		return []txn.Op{{
+			C:      modelsC,
+			Id:     model.doc.UUID,
+			Assert: bson.D{{"quotas", bson.D{{"$exists", false}}}},
+		}}, nil
+	}
+	quotasOp := txn.Op{
+		C:      modelsC,
+		Id:     model.doc.UUID,
+		Assert: bson.D{{"quotas", model.doc.Quotas}},
+	}
+
+	refcounts, closer := st.getCollection(refcountsC)
+	defer closer()
+	var usageOp txn.Op
+	count, err := nsRefcounts.read(refcounts, modelQuotaUsageKey)
+	if errors.IsNotFound(err) {
+		usageOp = nsRefcounts.JustCreateOp(refcountsC, modelQuotaUsageKey, 1)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else {
+		usageOp = txn.Op{
+			C:      refcountsC,
+			Id:     modelQuotaUsageKey,
+			Assert: bson.D{{"refcount", count}},
+			Update: bson.D{{"$inc", bson.D{{"refcount", 1}}}},
+		}
+	}
+
+	used, err := st.ModelUsage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := quotas.Check(used, requested); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []txn.Op{quotasOp, usageOp}, nil
+}
+
+// machineTemplateResources returns the resources, as counted against the
+// model's quotas, that a new top-level machine made from the template
+// will use.
+func machineTemplateResources(template MachineTemplate) quota.Resources {
+	r := quota.Resources{
+		Machines: 1,
+		Storage:  machineTemplateStorage(template),
+	}
+	cores, mem := template.Constraints.CpuCores, template.Constraints.Mem
+	if template.InstanceId != "" {
+		cores, mem = template.HardwareCharacteristics.CpuCores, template.HardwareCharacteristics.Mem
+	}
+	r.Cores, r.Memory = machineResources(cores, mem)
+	return r
+}
+
+// machineTemplateStorage returns the size of the volumes and filesystems
+// that will be created along with a machine made from the template, and
+// that are not yet counted against the model's quotas.
+func machineTemplateStorage(template MachineTemplate) uint64 {
+	return machineStorageSize(template.Volumes, template.Filesystems)
+}
+
+// machineStorageSize returns the size of the given volumes and
+// filesystems that are not yet counted against the model's quotas.
+// Those made for storage instances are counted from when the storage
+// instances are added, so only the others count.
+func machineStorageSize(volumes []MachineVolumeParams, filesystems []MachineFilesystemParams) uint64 {
+	var size uint64
+	for _, v := range volumes {
+		if v.Volume.storage == (names.StorageTag{}) {
+			size += v.Volume.Size
+		}
+	}
+	for _, f := range filesystems {
+		if f.Filesystem.storage == (names.StorageTag{}) {
+			size += f.Filesystem.Size
+		}
+	}
+	return size
+}
+
+// storageConstraintsSize returns the total size of the storage instances
+// that the constraints require.
+func storageConstraintsSize(cons map[string]StorageConstraints) uint64 {
+	var size uint64
+	for _, c := range cons {
+		size += c.Size * c.Count
+	}
+	return size
+}
@@ -0,0 +1,283 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/quota"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+)
+
+type ModelQuotaSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&ModelQuotaSuite{})
+
+func (s *ModelQuotaSuite) setQuotas(c *gc.C, quotas quota.Resources) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetQuotas(quotas)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelQuotaSuite) TestSetQuotas(c *gc.C) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Quotas(), gc.Equals, quota.Resources{})
+
+	quotas := quota.Resources{Machines: 2, Memory: 4096}
+	err = model.SetQuotas(quotas)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Quotas(), gc.Equals, quotas)
+	model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Quotas(), gc.Equals, quotas)
+
+	err = model.SetQuotas(quota.Resources{})
+	c.Assert(err, jc.ErrorIsNil)
+	model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Quotas(), gc.Equals, quota.Resources{})
+}
+
+func (s *ModelQuotaSuite) TestNewModelWithQuotas(c *gc.C) {
+	cfg, _ := createTestModelConfig(c, s.State.ControllerUUID())
+	quotas := quota.Resources{Units: 10, Storage: 1024 * 1024}
+	model, st, err := s.State.NewModel(state.ModelArgs{
+		CloudName:               "dummy",
+		CloudRegion:             "dummy-region",
+		Config:                  cfg,
+		Owner:                   names.NewUserTag("test@remote"),
+		StorageProviderRegistry: storage.StaticProviderRegistry{},
+		Quotas:                  quotas,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	c.Assert(model.Quotas(), gc.Equals, quotas)
+}
+
+func (s *ModelQuotaSuite) TestModelUsage(c *gc.C) {
+	// The unit is assigned to a new machine without constraints.
+	s.setupMultipleStorageUnit(c)
+
+	cons := constraints.MustParse("cores=2 mem=2G")
+	_, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: cons,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Provisioned machines count their hardware, not their constraints.
+	cores, mem := uint64(4), uint64(8192)
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: cons,
+		InstanceId:  "inst-1",
+		Nonce:       "nonce",
+		HardwareCharacteristics: instance.HardwareCharacteristics{
+			CpuCores: &cores,
+			Mem:      &mem,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Containers use the resources of their host.
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, m.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	used, err := s.State.ModelUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	// The machine without constraints counts the default size.
+	c.Assert(used, jc.DeepEquals, quota.Resources{
+		Machines: 3,
+		Units:    1,
+		Cores:    1 + 2 + 4,
+		Memory:   1024 + 2048 + 8192,
+		Storage:  3 * 1024,
+	})
+}
+
+func (s *ModelQuotaSuite) TestAddMachineExceedsQuota(c *gc.C) {
+	s.setQuotas(c, quota.Resources{Machines: 1, Memory: 4096})
+	template := state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("mem=3G"),
+	}
+	_, err := s.State.AddOneMachine(template)
+	c.Assert(err, jc.ErrorIsNil)
+
+	template.Constraints = constraints.Value{}
+	_, err = s.State.AddOneMachine(template)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: model machines quota of 1 exceeded \(1 in use\)`)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+
+	s.setQuotas(c, quota.Resources{Memory: 4096})
+	template.Constraints = constraints.MustParse("mem=2G")
+	_, err = s.State.AddOneMachine(template)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: model memory quota of 4096M exceeded \(3072M in use\)`)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+
+	_, err = s.State.AddMachineInsideNewMachine(
+		state.MachineTemplate{Series: "quantal", Jobs: []state.MachineJob{state.JobHostUnits}},
+		template, instance.LXD,
+	)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+}
+
+func (s *ModelQuotaSuite) TestAddMachineWithoutConstraintsExceedsQuota(c *gc.C) {
+	s.setQuotas(c, quota.Resources{Cores: 2, Memory: 4096})
+	template := state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("cores=2 mem=1G"),
+	}
+	_, err := s.State.AddOneMachine(template)
+	c.Assert(err, jc.ErrorIsNil)
+
+	template.Constraints = constraints.Value{}
+	_, err = s.State.AddOneMachine(template)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: model cores quota of 2 exceeded \(2 in use\)`)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+
+	// Model constraints are counted for machines without their own.
+	s.setQuotas(c, quota.Resources{Memory: 4096})
+	err = s.State.SetModelConstraints(constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddOneMachine(template)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: model memory quota of 4096M exceeded \(1024M in use\)`)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+}
+
+func (s *ModelQuotaSuite) TestAddUnitExceedsQuota(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setQuotas(c, quota.Resources{Units: 1})
+	_, err = app.AddUnit()
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "wordpress": model units quota of 1 exceeded \(1 in use\)`)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+}
+
+func (s *ModelQuotaSuite) TestAddUnitConcurrentlyExceedsQuota(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.setQuotas(c, quota.Resources{Units: 2})
+	_, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := app.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err = app.AddUnit()
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "wordpress": model units quota of 2 exceeded \(2 in use\)`)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+}
+
+func (s *ModelQuotaSuite) TestAddMachineQuotaSetConcurrently(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		s.setQuotas(c, quota.Resources{Machines: 1})
+	}).Check()
+
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: model machines quota of 1 exceeded \(1 in use\)`)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+}
+
+func (s *ModelQuotaSuite) TestAddStorageExceedsQuota(c *gc.C) {
+	u := s.setupMultipleStorageUnit(c)
+	s.setQuotas(c, quota.Resources{Storage: 4 * 1024})
+
+	err := s.State.AddStorageForUnit(u.UnitTag(), "multi1to10", makeStorageCons("loop", 1024, 1))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddStorageForUnit(u.UnitTag(), "multi1to10", makeStorageCons("loop", 1024, 1))
+	c.Assert(err, gc.ErrorMatches, `.*model storage quota of 4096M exceeded \(4096M in use\)`)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+}
+
+func (s *ModelQuotaSuite) TestModelUsageCountsPendingStorage(c *gc.C) {
+	app := s.addMultipleStorageApplication(c)
+	_, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit's volumes are not created until it is assigned to a
+	// machine, but the storage they will use is counted already.
+	used, err := s.State.ModelUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(used, jc.DeepEquals, quota.Resources{
+		Units:   1,
+		Storage: 3 * 1024,
+	})
+}
+
+func (s *ModelQuotaSuite) TestAddUnitCountsPendingStorage(c *gc.C) {
+	app := s.addMultipleStorageApplication(c)
+	s.setQuotas(c, quota.Resources{Storage: 4 * 1024})
+	_, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = app.AddUnit()
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "storage-block2": model storage quota of 4096M exceeded \(3072M in use\)`)
+	c.Assert(err, jc.Satisfies, quota.IsExceeded)
+}
+
+func (s *ModelQuotaSuite) TestAssignUnitCountsStorageOnce(c *gc.C) {
+	app := s.addMultipleStorageApplication(c)
+	u, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	s.setQuotas(c, quota.Resources{Storage: 3 * 1024})
+
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	used, err := s.State.ModelUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(used.Storage, gc.Equals, uint64(3*1024))
+}
+
+// addMultipleStorageApplication adds an application whose units each
+// have three 1G loop volumes.
+func (s *ModelQuotaSuite) addMultipleStorageApplication(c *gc.C) *state.Application {
+	ch := s.AddTestingCharm(c, "storage-block2")
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:    "storage-block2",
+		Charm:   ch,
+		Storage: map[string]state.StorageConstraints{"multi1to10": makeStorageCons("loop", 1024, 3)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return app
+}
+
+// setupMultipleStorageUnit adds a unit with three 1G loop volumes,
+// assigned to a new machine so that the volumes are created.
+func (s *ModelQuotaSuite) setupMultipleStorageUnit(c *gc.C) *state.Unit {
+	app := s.addMultipleStorageApplication(c)
+	u, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	return u
+}
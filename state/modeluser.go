@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -220,10 +221,9 @@ func (st *State) ModelsForUser(user names.UserTag) ([]*UserModel, error) {
 	if err != nil && !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
-	// Since there are no groups at this stage, the simplest way to get all
-	// the models that a particular user can see is to look through the
-	// model user collection. A raw collection is required to support
-	// queries across multiple models.
+	// The models that a particular user can see directly are found by
+	// looking through the model user collection. A raw collection is
+	// required to support queries across multiple models.
 	modelUsers, userCloser := st.getRawCollection(modelUsersC)
 	defer userCloser()
 
@@ -232,10 +232,33 @@ func (st *State) ModelsForUser(user names.UserTag) ([]*UserModel, error) {
 	if err != nil {
 		return nil, err
 	}
+	var modelUUIDs []string
+	seen := set.NewStrings()
+	for _, doc := range userSlice {
+		modelUUIDs = append(modelUUIDs, doc.ObjectUUID)
+		seen.Add(doc.ObjectUUID)
+	}
+
+	// The user can also see the models any of their groups have been
+	// granted access to. Those models may have been removed since.
+	groupModelUUIDs, err := st.userGroupModelUUIDs(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, uuid := range groupModelUUIDs {
+		if seen.Contains(uuid) {
+			continue
+		}
+		if _, err := st.GetModel(names.NewModelTag(uuid)); errors.IsNotFound(err) {
+			continue
+		}
+		modelUUIDs = append(modelUUIDs, uuid)
+		seen.Add(uuid)
+	}
 
 	var result []*UserModel
-	for _, doc := range userSlice {
-		modelTag := names.NewModelTag(doc.ObjectUUID)
+	for _, uuid := range modelUUIDs {
+		modelTag := names.NewModelTag(uuid)
 		model, err := st.GetModel(modelTag)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -441,6 +441,7 @@ func (st *State) modelSetupOps(controllerUUID string, args ModelArgs, inherited
 			modelUUID, controllerUUID,
 			args.CloudName, args.CloudRegion, args.CloudCredential,
 			args.MigrationMode,
+			args.Quotas,
 		),
 		createUniqueOwnerModelNameOp(args.Owner, args.Config.Name()),
 	)
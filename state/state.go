@@ -2132,6 +2132,23 @@ func (st *State) PutAuditEntryFn() func(audit.AuditEntry) error {
 	return stateaudit.PutAuditEntryFn(auditingC, insert)
 }
 
+// AuditEntries returns the audit entries matching the query, oldest
+// first.
+func (st *State) AuditEntries(query audit.Query) ([]audit.AuditEntry, error) {
+	find := func(collectionName string, q bson.D, sort []string, skip, limit int, docs interface{}) error {
+		collection, closeCollection := st.getRawCollection(collectionName)
+		defer closeCollection()
+
+		mq := collection.Find(q).Sort(sort...).Skip(skip)
+		if limit > 0 {
+			mq = mq.Limit(limit)
+		}
+		return errors.Trace(mq.All(docs))
+	}
+	entries, err := stateaudit.GetAuditEntriesFn(auditingC, find)(query)
+	return entries, errors.Trace(err)
+}
+
 var tagPrefix = map[byte]string{
 	'm': names.MachineTagKind + "-",
 	'a': names.ApplicationTagKind + "-",
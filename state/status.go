@@ -4,10 +4,14 @@
 package state
 
 import (
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -259,6 +263,232 @@ func statusHistory(args *statusHistoryArgs) ([]status.StatusInfo, error) {
 	return results, nil
 }
 
+const (
+	// DefaultModelStatusHistorySize is the number of entries returned
+	// by ModelStatusHistory when the filter sets neither Size nor Since.
+	DefaultModelStatusHistorySize = 100
+
+	// MaxModelStatusHistorySize is the most entries returned by
+	// ModelStatusHistory, whatever the filter.
+	MaxModelStatusHistorySize = 10000
+)
+
+// ModelStatusHistoryFilter selects the status history entries of many
+// entities in a model.
+type ModelStatusHistoryFilter struct {
+	// Kinds limits the entries to those of the given kinds. All kinds
+	// are included if it is empty.
+	Kinds []status.HistoryKind
+	// Applications limits the entries to those of the units of the
+	// given applications. If it is set, machine entries are not
+	// included.
+	Applications []string
+	// Since and Until, if set, limit the entries to those made at or
+	// after Since, and before Until.
+	Since *time.Time
+	Until *time.Time
+	// Size, if set, limits the entries to the latest Size entries.
+	// It may not be more than MaxModelStatusHistorySize. If it is not
+	// set, the latest DefaultModelStatusHistorySize entries are
+	// returned, or the latest MaxModelStatusHistorySize if Since is
+	// set.
+	Size int
+	// Exclude holds status messages whose entries are not included.
+	Exclude set.Strings
+}
+
+// Validate checks that the filter is usable.
+func (f ModelStatusHistoryFilter) Validate() error {
+	for _, kind := range f.Kinds {
+		if !kind.Valid() {
+			return errors.NotValidf("status history kind %q", kind)
+		}
+	}
+	for _, application := range f.Applications {
+		if !names.IsValidApplication(application) {
+			return errors.NotValidf("application name %q", application)
+		}
+	}
+	if f.Size < 0 {
+		return errors.NotValidf("negative size")
+	}
+	if f.Size > MaxModelStatusHistorySize {
+		return errors.NotValidf("size over %d", MaxModelStatusHistorySize)
+	}
+	if f.Since != nil && f.Until != nil && !f.Until.After(*f.Since) {
+		return errors.NotValidf("until not after since")
+	}
+	return nil
+}
+
+// ModelStatusHistoryEntry is a status history entry of some entity in
+// a model.
+type ModelStatusHistoryEntry struct {
+	status.StatusInfo
+	// Entity is the tag of the unit or machine whose status changed.
+	Entity names.Tag
+	// Kind is the kind of status which changed.
+	Kind status.HistoryKind
+}
+
+// The patterns and suffixes of the global keys under which unit and
+// machine status history is recorded.
+const (
+	unitNamePattern    = `[a-z][a-z0-9-]*/[0-9]+`
+	machineIdPattern   = `[0-9]+`
+	containerIdPattern = `[0-9]+(?:/[a-z]+/[0-9]+)+`
+	instanceKeySuffix  = "#instance"
+	workloadKeySuffix  = "#charm"
+)
+
+// keyPattern returns a regular expression matching the
+// global keys of the status history entries selected by the filter.
+func (f ModelStatusHistoryFilter) keyPattern() string {
+	kinds := set.NewStrings()
+	for _, kind := range f.Kinds {
+		kinds.Add(string(kind))
+	}
+	want := func(kind status.HistoryKind) bool {
+		return kinds.IsEmpty() || kinds.Contains(string(kind))
+	}
+
+	unitPattern := unitNamePattern
+	if len(f.Applications) > 0 {
+		quoted := make([]string, len(f.Applications))
+		for i, application := range f.Applications {
+			quoted[i] = regexp.QuoteMeta(application)
+		}
+		unitPattern = `(?:` + strings.Join(quoted, "|") + `)/[0-9]+`
+	}
+
+	var patterns []string
+	if want(status.KindUnit) || want(status.KindUnitAgent) {
+		patterns = append(patterns, "u#"+unitPattern)
+	}
+	if want(status.KindUnit) || want(status.KindWorkload) {
+		patterns = append(patterns, "u#"+unitPattern+workloadKeySuffix)
+	}
+	if len(f.Applications) == 0 {
+		if want(status.KindMachine) {
+			patterns = append(patterns, "m#"+machineIdPattern)
+		}
+		if want(status.KindMachineInstance) {
+			patterns = append(patterns, "m#"+machineIdPattern+instanceKeySuffix)
+		}
+		if want(status.KindContainer) {
+			patterns = append(patterns, "m#"+containerIdPattern)
+		}
+		if want(status.KindContainerInstance) {
+			patterns = append(patterns, "m#"+containerIdPattern+instanceKeySuffix)
+		}
+	}
+	if len(patterns) == 0 {
+		return ""
+	}
+	return "^(?:" + strings.Join(patterns, "|") + ")$"
+}
+
+// statusHistoryEntity returns the entity and kind of status recorded
+// under the given global key.
+func statusHistoryEntity(globalKey string) (names.Tag, status.HistoryKind, error) {
+	switch {
+	case strings.HasPrefix(globalKey, "u#"):
+		name := strings.TrimPrefix(globalKey, "u#")
+		kind := status.KindUnitAgent
+		if strings.HasSuffix(name, workloadKeySuffix) {
+			name = strings.TrimSuffix(name, workloadKeySuffix)
+			kind = status.KindWorkload
+		}
+		if names.IsValidUnit(name) {
+			return names.NewUnitTag(name), kind, nil
+		}
+	case strings.HasPrefix(globalKey, "m#"):
+		id := strings.TrimPrefix(globalKey, "m#")
+		instance := strings.HasSuffix(id, instanceKeySuffix)
+		id = strings.TrimSuffix(id, instanceKeySuffix)
+		if !names.IsValidMachine(id) {
+			break
+		}
+		container := names.IsContainerMachine(id)
+		switch {
+		case container && instance:
+			return names.NewMachineTag(id), status.KindContainerInstance, nil
+		case container:
+			return names.NewMachineTag(id), status.KindContainer, nil
+		case instance:
+			return names.NewMachineTag(id), status.KindMachineInstance, nil
+		default:
+			return names.NewMachineTag(id), status.KindMachine, nil
+		}
+	}
+	return nil, "", errors.NotValidf("status history key %q", globalKey)
+}
+
+// ModelStatusHistory returns the status history entries of the units
+// and machines in the model selected by the filter, oldest first.
+func (st *State) ModelStatusHistory(filter ModelStatusHistoryFilter) ([]ModelStatusHistoryEntry, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating filter")
+	}
+	keyPattern := filter.keyPattern()
+	if keyPattern == "" {
+		return nil, nil
+	}
+
+	query := bson.D{{"globalkey", bson.RegEx{Pattern: keyPattern}}}
+	updated := bson.M{}
+	if filter.Since != nil {
+		updated["$gte"] = filter.Since.UnixNano()
+	}
+	if filter.Until != nil {
+		updated["$lt"] = filter.Until.UnixNano()
+	}
+	if len(updated) > 0 {
+		query = append(query, bson.DocElem{"updated", updated})
+	}
+	if excludes := filter.Exclude.Values(); len(excludes) > 0 {
+		query = append(query, bson.DocElem{"statusinfo", bson.M{"$nin": excludes}})
+	}
+
+	history, closer := st.getCollection(statusesHistoryC)
+	defer closer()
+	size := filter.Size
+	if size == 0 {
+		size = MaxModelStatusHistorySize
+		if filter.Since == nil {
+			size = DefaultModelStatusHistorySize
+		}
+	}
+	q := history.Find(query).Sort("-updated").Limit(size)
+	var docs []historicalStatusDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get status history")
+	}
+
+	entries := make([]ModelStatusHistoryEntry, 0, len(docs))
+	for i := len(docs) - 1; i >= 0; i-- {
+		doc := docs[i]
+		entity, kind, err := statusHistoryEntity(doc.GlobalKey)
+		if err != nil {
+			// The key pattern should only match keys
+			// which can be parsed.
+			logger.Warningf("skipping status history entry: %v", err)
+			continue
+		}
+		entries = append(entries, ModelStatusHistoryEntry{
+			StatusInfo: status.StatusInfo{
+				Status:  doc.Status,
+				Message: doc.StatusInfo,
+				Data:    utils.UnescapeKeys(doc.StatusData),
+				Since:   unixNanoToTime(doc.Updated),
+			},
+			Entity: entity,
+			Kind:   kind,
+		})
+	}
+	return entries, nil
+}
+
 // PruneStatusHistory removes status history entries until
 // only logs newer than <maxLogTime> remain and also ensures
 // that the collection is smaller than <maxLogsMB> after the
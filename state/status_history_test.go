@@ -266,3 +266,116 @@ func (s *StatusHistorySuite) TestStatusHistoryFiltersByDateAndDelta(c *gc.C) {
 	c.Assert(history[1].Message, gc.Equals, "waiting for machine")
 	c.Assert(history[2].Message, gc.Equals, "2 days ago")
 }
+
+func (s *StatusHistorySuite) TestModelStatusHistory(c *gc.C) {
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	mysql0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql})
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "wordpress",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	wordpress0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+	machineId, err := wordpress0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Set statuses in the future, so that they are the latest.
+	start := time.Now().Add(time.Hour)
+	at := func(seconds int) *time.Time {
+		t := start.Add(time.Duration(seconds) * time.Second)
+		return &t
+	}
+	err = mysql0.SetStatus(status.StatusInfo{Status: status.Active, Message: "ready", Since: at(1)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysql0.SetAgentStatus(status.StatusInfo{Status: status.Idle, Since: at(2)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetStatus(status.StatusInfo{Status: status.Started, Since: at(3)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress0.SetStatus(status.StatusInfo{Status: status.Blocked, Message: "need a db", Since: at(4)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	type entry struct {
+		entity string
+		kind   status.HistoryKind
+		status status.Status
+	}
+	check := func(filter state.ModelStatusHistoryFilter, expected ...entry) {
+		history, err := s.State.ModelStatusHistory(filter)
+		c.Assert(err, jc.ErrorIsNil)
+		var obtained []entry
+		for _, h := range history {
+			obtained = append(obtained, entry{h.Entity.String(), h.Kind, h.Status})
+		}
+		c.Check(obtained, jc.DeepEquals, expected)
+	}
+
+	check(state.ModelStatusHistoryFilter{Since: &start},
+		entry{"unit-mysql-0", status.KindWorkload, status.Active},
+		entry{"unit-mysql-0", status.KindUnitAgent, status.Idle},
+		entry{"machine-" + machineId, status.KindMachine, status.Started},
+		entry{"unit-wordpress-0", status.KindWorkload, status.Blocked},
+	)
+	check(state.ModelStatusHistoryFilter{Since: &start, Until: at(3)},
+		entry{"unit-mysql-0", status.KindWorkload, status.Active},
+		entry{"unit-mysql-0", status.KindUnitAgent, status.Idle},
+	)
+	check(state.ModelStatusHistoryFilter{Since: &start, Applications: []string{"mysql"}},
+		entry{"unit-mysql-0", status.KindWorkload, status.Active},
+		entry{"unit-mysql-0", status.KindUnitAgent, status.Idle},
+	)
+	check(state.ModelStatusHistoryFilter{Since: &start, Kinds: []status.HistoryKind{status.KindWorkload, status.KindMachine}},
+		entry{"unit-mysql-0", status.KindWorkload, status.Active},
+		entry{"machine-" + machineId, status.KindMachine, status.Started},
+		entry{"unit-wordpress-0", status.KindWorkload, status.Blocked},
+	)
+	check(state.ModelStatusHistoryFilter{Size: 2},
+		entry{"machine-" + machineId, status.KindMachine, status.Started},
+		entry{"unit-wordpress-0", status.KindWorkload, status.Blocked},
+	)
+	check(state.ModelStatusHistoryFilter{Since: &start, Exclude: set.NewStrings("ready", "need a db")},
+		entry{"unit-mysql-0", status.KindUnitAgent, status.Idle},
+		entry{"machine-" + machineId, status.KindMachine, status.Started},
+	)
+
+	// Without a time range, earlier history is included.
+	history, err := s.State.ModelStatusHistory(state.ModelStatusHistoryFilter{
+		Kinds: []status.HistoryKind{status.KindUnit},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(len(history), jc.GreaterThan, 3)
+	c.Check(history[0].Since.Before(start), jc.IsTrue)
+	for i := 1; i < len(history); i++ {
+		c.Check(history[i].Since.Before(*history[i-1].Since), jc.IsFalse)
+	}
+}
+
+func (s *StatusHistorySuite) TestModelStatusHistoryInvalidFilter(c *gc.C) {
+	now := time.Now()
+	for i, test := range []struct {
+		filter state.ModelStatusHistoryFilter
+		err    string
+	}{{
+		filter: state.ModelStatusHistoryFilter{Kinds: []status.HistoryKind{"bad"}},
+		err:    `validating filter: status history kind "bad" not valid`,
+	}, {
+		filter: state.ModelStatusHistoryFilter{Applications: []string{"Bad"}},
+		err:    `validating filter: application name "Bad" not valid`,
+	}, {
+		filter: state.ModelStatusHistoryFilter{Size: -1},
+		err:    `validating filter: negative size not valid`,
+	}, {
+		filter: state.ModelStatusHistoryFilter{Size: state.MaxModelStatusHistorySize + 1},
+		err:    `validating filter: size over 10000 not valid`,
+	}, {
+		filter: state.ModelStatusHistoryFilter{Since: &now, Until: &now},
+		err:    `validating filter: until not after since not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.ModelStatusHistory(test.filter)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
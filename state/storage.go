@@ -16,6 +16,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
@@ -1576,6 +1577,12 @@ func (st *State) addStorageForUnitOps(
 	if cons.Count == 0 {
 		return nil, errors.NotValidf("adding storage where instance count is 0")
 	}
+	requested := quota.Resources{Storage: completeCons.Size * completeCons.Count}
+	quotaOps, err := st.checkModelQuotas(requested)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, quotaOps...)
 
 	addUnitStorageOps, err := st.addUnitStorageOps(charmMeta, u, storageName, completeCons, -1)
 	if err != nil {
@@ -22,6 +22,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state/presence"
@@ -1334,6 +1335,16 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	); err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The unit's storage instances are counted against the model's
+	// quotas from when they are added, so only storage created here
+	// for no storage instance is requested.
+	requested := quota.Resources{
+		Storage: machineStorageSize(storageParams.volumes, storageParams.filesystems),
+	}
+	quotaOps, err := u.st.checkModelQuotas(requested)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	storageOps, volumesAttached, filesystemsAttached, err := u.st.machineStorageOps(
 		&m.doc, storageParams,
 	)
@@ -1380,6 +1391,7 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 		removeStagedAssignmentOp(u.doc.DocID),
 	}
 	ops = append(ops, storageOps...)
+	ops = append(ops, quotaOps...)
 	return ops, nil
 }
 
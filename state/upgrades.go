@@ -6,6 +6,7 @@ package state
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -632,3 +633,63 @@ func RemoveNilValueApplicationSettings(st *State) error {
 	}
 	return nil
 }
+
+// AddAuditEntryTimes adds a sortable "time" field, derived from the
+// textual timestamp, to audit entries which are missing one.
+func AddAuditEntryTimes(st *State) error {
+	coll, closer := st.getRawCollection(auditingC)
+	defer closer()
+
+	query := coll.Find(bson.M{"time": bson.M{"$exists": false}})
+	query = query.Select(bson.M{"_id": 1, "timestamp": 1})
+	iter := query.Iter()
+	var doc struct {
+		Id        bson.ObjectId `bson:"_id"`
+		Timestamp string        `bson:"timestamp"`
+	}
+	for iter.Next(&doc) {
+		var t time.Time
+		if err := t.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+			iter.Close()
+			return errors.Annotatef(err, "parsing timestamp of audit entry %q", doc.Id.Hex())
+		}
+		err := coll.UpdateId(doc.Id, bson.M{"$set": bson.M{"time": t.UnixNano()}})
+		if err != nil {
+			iter.Close()
+			return errors.Annotatef(err, "updating audit entry %q", doc.Id.Hex())
+		}
+	}
+	return errors.Trace(iter.Close())
+}
+
+// AddUserPasswordChangedTimes records the upgrade as the time at which
+// the passwords of local users were last changed, for users created
+// before that time was recorded, so that the password-max-age policy
+// applies to them too.
+func AddUserPasswordChangedTimes(st *State) error {
+	coll, closer := st.getRawCollection(usersC)
+	defer closer()
+
+	changed := st.NowToTheSecond()
+	query := coll.Find(bson.M{"passwordchanged": bson.M{"$exists": false}})
+	iter := query.Select(bson.M{"_id": 1}).Iter()
+	var ops []txn.Op
+	var doc struct {
+		DocID string `bson:"_id"`
+	}
+	for iter.Next(&doc) {
+		ops = append(ops, txn.Op{
+			C:      usersC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.M{"$set": bson.M{"passwordchanged": changed}},
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(ops) > 0 {
+		return errors.Trace(st.runRawTransaction(ops))
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 
@@ -918,3 +919,74 @@ func (s *upgradesSuite) TestRemoveNilValueApplicationSettings(c *gc.C) {
 		expectUpgradedData{settingsColl, expectedSettings},
 	)
 }
+
+func (s *upgradesSuite) TestAddAuditEntryTimes(c *gc.C) {
+	coll, closer := s.state.getRawCollection(auditingC)
+	defer closer()
+
+	t1 := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	t2 := t1.Add(1500 * time.Millisecond)
+	id1, id2, id3 := bson.NewObjectId(), bson.NewObjectId(), bson.NewObjectId()
+	err := coll.Insert(bson.M{
+		"_id":       id1,
+		"timestamp": "2017-05-01T12:00:00Z",
+	}, bson.M{
+		"_id":       id2,
+		"timestamp": "2017-05-01T12:00:01.5Z",
+	}, bson.M{
+		// Entries which already have a time should be left alone.
+		"_id":       id3,
+		"timestamp": "2017-05-01T12:00:00Z",
+		"time":      int64(42),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := []bson.M{{
+		"_id":       id1,
+		"timestamp": "2017-05-01T12:00:00Z",
+		"time":      t1.UnixNano(),
+	}, {
+		"_id":       id2,
+		"timestamp": "2017-05-01T12:00:01.5Z",
+		"time":      t2.UnixNano(),
+	}, {
+		"_id":       id3,
+		"timestamp": "2017-05-01T12:00:00Z",
+		"time":      int64(42),
+	}}
+	s.assertUpgradedData(c, AddAuditEntryTimes,
+		expectUpgradedData{coll, expected},
+	)
+}
+
+func (s *upgradesSuite) TestAddUserPasswordChangedTimes(c *gc.C) {
+	users, closer := s.state.getRawCollection(usersC)
+	defer closer()
+
+	_, err := s.state.AddUser("bob", "", "password", s.owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	mary, err := s.state.AddUser("mary", "", "password", s.owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	changed := mary.PasswordChanged()
+
+	// Users created before the time was recorded have none.
+	err = users.UpdateId("bob", bson.M{"$unset": bson.M{"passwordchanged": 1}})
+	c.Assert(err, jc.ErrorIsNil)
+	bob, err := s.state.User(names.NewLocalUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bob.PasswordChanged().IsZero(), jc.IsTrue)
+
+	before := s.state.NowToTheSecond()
+	err = AddUserPasswordChangedTimes(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	// The upgrade step must be idempotent.
+	err = AddUserPasswordChangedTimes(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = bob.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bob.PasswordChanged().Before(before), jc.IsFalse)
+	err = mary.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mary.PasswordChanged(), gc.Equals, changed)
+}
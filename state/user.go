@@ -92,6 +92,7 @@ func (st *State) addUser(name, displayName, password, creator string, secretKey
 		}
 		user.doc.PasswordHash = utils.UserPasswordHash(password, salt)
 		user.doc.PasswordSalt = salt
+		user.doc.PasswordChanged = dateCreated
 	}
 
 	ops := []txn.Op{{
@@ -147,6 +148,25 @@ func (st *State) RemoveUser(tag names.UserTag) error {
 			Assert: txn.DocExists,
 			Update: bson.M{"$set": bson.M{"deleted": true}},
 		}}
+		// Deleted users are no longer members of any groups.
+		groups, err := st.UserGroupsForUser(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, group := range groups {
+			ops = append(ops, txn.Op{
+				C:      userGroupsC,
+				Id:     group.doc.DocID,
+				Assert: txn.DocExists,
+				Update: bson.M{"$pull": bson.M{"members": name}},
+			})
+		}
+		// Nor can they be acted as with their API tokens.
+		tokenOps, err := st.removeAPITokensOps(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, tokenOps...)
 		return ops, nil
 	}
 	return st.run(buildTxn)
@@ -155,13 +175,14 @@ func (st *State) RemoveUser(tag names.UserTag) error {
 func createInitialUserOps(controllerUUID string, user names.UserTag, password, salt string, dateCreated time.Time) []txn.Op {
 	nameToLower := strings.ToLower(user.Name())
 	doc := userDoc{
-		DocID:        nameToLower,
-		Name:         user.Name(),
-		DisplayName:  user.Name(),
-		PasswordHash: utils.UserPasswordHash(password, salt),
-		PasswordSalt: salt,
-		CreatedBy:    user.Name(),
-		DateCreated:  dateCreated,
+		DocID:           nameToLower,
+		Name:            user.Name(),
+		DisplayName:     user.Name(),
+		PasswordHash:    utils.UserPasswordHash(password, salt),
+		PasswordSalt:    salt,
+		PasswordChanged: dateCreated,
+		CreatedBy:       user.Name(),
+		DateCreated:     dateCreated,
 	}
 	ops := []txn.Op{{
 		C:      usersC,
@@ -280,6 +301,9 @@ type userDoc struct {
 	PasswordSalt string    `bson:"passwordsalt"`
 	CreatedBy    string    `bson:"createdby"`
 	DateCreated  time.Time `bson:"datecreated"`
+	// PasswordChanged is when the password was last set. It is
+	// zero for passwords set before it was recorded.
+	PasswordChanged time.Time `bson:"passwordchanged,omitempty"`
 }
 
 type userLastLoginDoc struct {
@@ -425,9 +449,11 @@ func (u *User) SetPasswordHash(pwHash string, pwSalt string) error {
 		// explicit check before login.
 		return errors.Annotate(err, "cannot set password hash")
 	}
+	changed := u.st.NowToTheSecond()
 	update := bson.D{{"$set", bson.D{
 		{"passwordhash", pwHash},
 		{"passwordsalt", pwSalt},
+		{"passwordchanged", changed},
 	}}}
 	if u.doc.SecretKey != nil {
 		update = append(update,
@@ -445,10 +471,20 @@ func (u *User) SetPasswordHash(pwHash string, pwSalt string) error {
 	}
 	u.doc.PasswordHash = pwHash
 	u.doc.PasswordSalt = pwSalt
+	u.doc.PasswordChanged = changed
 	u.doc.SecretKey = nil
 	return nil
 }
 
+// PasswordChanged returns when the User's password was last set in
+// UTC, or the zero time if that is not known.
+func (u *User) PasswordChanged() time.Time {
+	if u.doc.PasswordChanged.IsZero() {
+		return time.Time{}
+	}
+	return u.doc.PasswordChanged.UTC()
+}
+
 // PasswordValid returns whether the given password is valid for the User. The
 // caller should call user.Refresh before calling this.
 func (u *User) PasswordValid(password string) bool {
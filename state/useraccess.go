@@ -177,6 +177,8 @@ func (st *State) UserAccess(subject names.UserTag, target names.Tag) (permission
 		if err == nil {
 			return NewControllerUserAccess(st, userDoc)
 		}
+	case names.ApplicationTagKind:
+		return st.applicationUserAccess(subject, target)
 	default:
 		return permission.UserAccess{}, errors.NotValidf("%q as a target", target.Kind())
 	}
@@ -194,6 +196,8 @@ func (st *State) SetUserAccess(subject names.UserTag, target names.Tag, access p
 		err = st.setModelAccess(access, userGlobalKey(userAccessID(subject)), target.Id())
 	case names.ControllerTagKind:
 		err = st.setControllerAccess(access, userGlobalKey(userAccessID(subject)))
+	case names.ApplicationTagKind:
+		err = st.setApplicationAccess(subject, target.Id(), access)
 	default:
 		return permission.UserAccess{}, errors.NotValidf("%q as a target", target.Kind())
 	}
@@ -210,6 +214,8 @@ func (st *State) RemoveUserAccess(subject names.UserTag, target names.Tag) error
 		return errors.Trace(st.removeModelUser(subject))
 	case names.ControllerTagKind:
 		return errors.Trace(st.removeControllerUser(subject))
+	case names.ApplicationTagKind:
+		return errors.Trace(st.removeApplicationAccess(subject, target.Id()))
 	}
 	return errors.NotValidf("%q as a target", target.Kind())
 }
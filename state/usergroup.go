@@ -0,0 +1,421 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+const groupGlobalKeyPrefix = "gr"
+
+func groupGlobalKey(groupID string) string {
+	return fmt.Sprintf("%s#%s", groupGlobalKeyPrefix, groupID)
+}
+
+// UserGroup represents a local group of users. Access granted to a
+// group is granted to each of its members.
+type UserGroup struct {
+	st  *State
+	doc userGroupDoc
+}
+
+type userGroupDoc struct {
+	DocID       string    `bson:"_id"`
+	Name        string    `bson:"name"`
+	Members     []string  `bson:"members"`
+	CreatedBy   string    `bson:"createdby"`
+	DateCreated time.Time `bson:"datecreated"`
+}
+
+// Name returns the name of the group.
+func (g *UserGroup) Name() string {
+	return g.doc.Name
+}
+
+// Members returns the names of the local users in the group, in
+// alphabetical order.
+func (g *UserGroup) Members() []string {
+	members := append([]string(nil), g.doc.Members...)
+	sort.Strings(members)
+	return members
+}
+
+// CreatedBy returns the name of the user that created the group.
+func (g *UserGroup) CreatedBy() string {
+	return g.doc.CreatedBy
+}
+
+// DateCreated returns when the group was created in UTC.
+func (g *UserGroup) DateCreated() time.Time {
+	return g.doc.DateCreated.UTC()
+}
+
+// Refresh refreshes the contents of the group from the underlying state.
+func (g *UserGroup) Refresh() error {
+	return g.st.getUserGroup(g.doc.Name, &g.doc)
+}
+
+// AddUserGroup adds a local group of users to the database. Group names
+// follow the same rules as user names.
+func (st *State) AddUserGroup(name string, createdBy names.UserTag) (*UserGroup, error) {
+	if !names.IsValidUserName(name) {
+		return nil, errors.Errorf("invalid group name %q", name)
+	}
+	group := &UserGroup{
+		st: st,
+		doc: userGroupDoc{
+			DocID:       strings.ToLower(name),
+			Name:        name,
+			Members:     []string{},
+			CreatedBy:   createdBy.Id(),
+			DateCreated: st.NowToTheSecond(),
+		},
+	}
+	ops := []txn.Op{{
+		C:      userGroupsC,
+		Id:     group.doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &group.doc,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.AlreadyExistsf("group %q", name)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return group, nil
+}
+
+// getUserGroup fetches information about the group with the given name
+// into the provided userGroupDoc.
+func (st *State) getUserGroup(name string, doc *userGroupDoc) error {
+	groups, closer := st.getCollection(userGroupsC)
+	defer closer()
+
+	err := groups.FindId(strings.ToLower(name)).One(doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("group %q", name)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// DateCreated is inserted as UTC, but read out as local time. So we
+	// convert it back to UTC here.
+	doc.DateCreated = doc.DateCreated.UTC()
+	return nil
+}
+
+// UserGroup returns the local group of users with the given name.
+func (st *State) UserGroup(name string) (*UserGroup, error) {
+	group := &UserGroup{st: st}
+	if err := st.getUserGroup(name, &group.doc); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return group, nil
+}
+
+// AllUserGroups returns all the local groups of users, sorted by name.
+func (st *State) AllUserGroups() ([]*UserGroup, error) {
+	return st.findUserGroups(nil)
+}
+
+// UserGroupsForUser returns the local groups the user is a member of,
+// sorted by name. External users are never members of local groups.
+func (st *State) UserGroupsForUser(user names.UserTag) ([]*UserGroup, error) {
+	if !user.IsLocal() {
+		return nil, nil
+	}
+	return st.findUserGroups(bson.D{{"members", strings.ToLower(user.Name())}})
+}
+
+func (st *State) findUserGroups(query bson.D) ([]*UserGroup, error) {
+	groups, closer := st.getCollection(userGroupsC)
+	defer closer()
+
+	var docs []userGroupDoc
+	if err := groups.Find(query).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*UserGroup, len(docs))
+	for i, doc := range docs {
+		doc.DateCreated = doc.DateCreated.UTC()
+		result[i] = &UserGroup{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// AddMembers adds the given local users to the group. Users who are
+// already members are ignored.
+func (g *UserGroup) AddMembers(users ...names.UserTag) error {
+	members := make([]string, len(users))
+	for i, user := range users {
+		if _, err := g.st.User(user); err != nil {
+			return errors.Annotatef(err, "cannot add %q to group %q", user.Id(), g.doc.Name)
+		}
+		members[i] = strings.ToLower(user.Name())
+	}
+	ops := []txn.Op{{
+		C:      userGroupsC,
+		Id:     g.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{{"members", bson.D{{"$each", members}}}}}},
+	}}
+	err := g.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("group %q", g.doc.Name)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return g.Refresh()
+}
+
+// RemoveMembers removes the given users from the group. Users who are
+// not members are ignored.
+func (g *UserGroup) RemoveMembers(users ...names.UserTag) error {
+	members := make([]string, len(users))
+	for i, user := range users {
+		members[i] = strings.ToLower(user.Name())
+	}
+	ops := []txn.Op{{
+		C:      userGroupsC,
+		Id:     g.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$pullAll", bson.D{{"members", members}}}},
+	}}
+	err := g.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("group %q", g.doc.Name)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return g.Refresh()
+}
+
+// RemoveUserGroup removes the group, along with all the access that
+// has been granted to it.
+func (st *State) RemoveUserGroup(name string) error {
+	groupID := strings.ToLower(name)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.UserGroup(name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		permissionIDs, err := st.userGroupPermissionIDs(groupID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      userGroupsC,
+			Id:     groupID,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}
+		for _, id := range permissionIDs {
+			ops = append(ops, txn.Op{
+				C:      permissionsC,
+				Id:     id,
+				Assert: txn.DocExists,
+				Remove: true,
+			})
+		}
+		return ops, nil
+	}
+	return errors.Trace(st.run(buildTxn))
+}
+
+// userGroupPermissionIDs returns the ids of the permission documents
+// granting access to the group.
+func (st *State) userGroupPermissionIDs(groupID string) ([]string, error) {
+	permissions, closer := st.getCollection(permissionsC)
+	defer closer()
+
+	var docs []permissionDoc
+	err := permissions.Find(bson.D{{"subject-global-key", groupGlobalKey(groupID)}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID
+	}
+	return ids, nil
+}
+
+// permissionObjectKey returns the global key used for the target of a
+// permission.
+func (st *State) permissionObjectKey(target names.Tag) (string, error) {
+	switch target.Kind() {
+	case names.ModelTagKind:
+		return modelKey(target.Id()), nil
+	case names.ControllerTagKind:
+		return controllerKey(st.ControllerUUID()), nil
+	case names.ApplicationTagKind:
+		return applicationPermissionKey(st.ModelUUID(), target.Id()), nil
+	}
+	return "", errors.NotValidf("%q as a target", target.Kind())
+}
+
+// UserGroupAccess returns the access granted to the group on the target.
+func (st *State) UserGroupAccess(name string, target names.Tag) (permission.Access, error) {
+	objectKey, err := st.permissionObjectKey(target)
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	perm, err := st.userPermission(objectKey, groupGlobalKey(strings.ToLower(name)))
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	return perm.access(), nil
+}
+
+// SetUserGroupAccess grants the group the given access on the target,
+// replacing any access it had already been granted.
+func (st *State) SetUserGroupAccess(name string, target names.Tag, access permission.Access) error {
+	objectKey, err := st.permissionObjectKey(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch target.Kind() {
+	case names.ModelTagKind:
+		err = permission.ValidateModelAccess(access)
+	case names.ControllerTagKind:
+		err = permission.ValidateControllerAccess(access)
+	case names.ApplicationTagKind:
+		err = permission.ValidateApplicationAccess(access)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	groupID := strings.ToLower(name)
+	subjectKey := groupGlobalKey(groupID)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.UserGroup(name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      userGroupsC,
+			Id:     groupID,
+			Assert: txn.DocExists,
+		}}
+		if target.Kind() == names.ApplicationTagKind {
+			appOps, err := st.assertApplicationAliveOps(target.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, appOps...)
+		}
+		existing, err := st.userPermission(objectKey, subjectKey)
+		switch {
+		case errors.IsNotFound(err):
+			ops = append(ops, createPermissionOp(objectKey, subjectKey, access))
+		case err != nil:
+			return nil, errors.Trace(err)
+		case existing.access() == access:
+			return nil, jujutxn.ErrNoOperations
+		default:
+			ops = append(ops, updatePermissionOp(objectKey, subjectKey, access))
+		}
+		return ops, nil
+	}
+	return errors.Trace(st.run(buildTxn))
+}
+
+// RemoveUserGroupAccess removes the access granted to the group on the
+// target.
+func (st *State) RemoveUserGroupAccess(name string, target names.Tag) error {
+	objectKey, err := st.permissionObjectKey(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{removePermissionOp(objectKey, groupGlobalKey(strings.ToLower(name)))}
+	err = st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("access for group %q", name)
+	}
+	return errors.Trace(err)
+}
+
+// UserAccessFromGroups returns the greatest access granted on the target
+// to the local groups the user is a member of, or permission.NoAccess
+// if none of them have been granted access.
+func (st *State) UserAccessFromGroups(user names.UserTag, target names.Tag) (permission.Access, error) {
+	objectKey, err := st.permissionObjectKey(target)
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	groups, err := st.UserGroupsForUser(user)
+	if err != nil || len(groups) == 0 {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	ids := make([]string, len(groups))
+	for i, group := range groups {
+		ids[i] = permissionID(objectKey, groupGlobalKey(group.doc.DocID))
+	}
+
+	permissions, closer := st.getCollection(permissionsC)
+	defer closer()
+
+	var docs []permissionDoc
+	if err := permissions.Find(bson.D{{"_id", bson.D{{"$in", ids}}}}).All(&docs); err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	access := permission.NoAccess
+	for _, doc := range docs {
+		groupAccess := stringToAccess(doc.Access)
+		greater := groupAccess.EqualOrGreaterModelAccessThan(access)
+		if target.Kind() == names.ControllerTagKind {
+			greater = groupAccess.EqualOrGreaterControllerAccessThan(access)
+		}
+		if greater {
+			access = groupAccess
+		}
+	}
+	return access, nil
+}
+
+// userGroupModelUUIDs returns the UUIDs of the models on which any of
+// the user's local groups have been granted access.
+func (st *State) userGroupModelUUIDs(user names.UserTag) ([]string, error) {
+	groups, err := st.UserGroupsForUser(user)
+	if err != nil || len(groups) == 0 {
+		return nil, errors.Trace(err)
+	}
+	subjectKeys := make([]string, len(groups))
+	for i, group := range groups {
+		subjectKeys[i] = groupGlobalKey(group.doc.DocID)
+	}
+
+	permissions, closer := st.getCollection(permissionsC)
+	defer closer()
+
+	var docs []permissionDoc
+	err = permissions.Find(bson.D{
+		{"subject-global-key", bson.D{{"$in", subjectKeys}}},
+		// Access granted on applications extends the model key, and
+		// does not give access to the model.
+		{"object-global-key", bson.D{{"$regex", "^" + modelGlobalKey + "#[^#]+$"}}},
+	}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var uuids []string
+	for _, doc := range docs {
+		uuids = append(uuids, strings.TrimPrefix(doc.ObjectGlobalKey, modelGlobalKey+"#"))
+	}
+	return uuids, nil
+}
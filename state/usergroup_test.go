@@ -0,0 +1,219 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UserGroupSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&UserGroupSuite{})
+
+func (s *UserGroupSuite) addGroup(c *gc.C, name string, members ...string) *state.UserGroup {
+	group, err := s.State.AddUserGroup(name, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, member := range members {
+		s.Factory.MakeUser(c, &factory.UserParams{Name: member, NoModelUser: true})
+		err := group.AddMembers(names.NewUserTag(member))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return group
+}
+
+func (s *UserGroupSuite) TestAddUserGroup(c *gc.C) {
+	group, err := s.State.AddUserGroup("Ops", s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(group.Name(), gc.Equals, "Ops")
+	c.Check(group.Members(), gc.HasLen, 0)
+	c.Check(group.CreatedBy(), gc.Equals, s.Owner.Id())
+	c.Check(group.DateCreated().IsZero(), jc.IsFalse)
+
+	group, err = s.State.UserGroup("ops")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(group.Name(), gc.Equals, "Ops")
+}
+
+func (s *UserGroupSuite) TestAddUserGroupInvalidName(c *gc.C) {
+	_, err := s.State.AddUserGroup("b^d", s.Owner)
+	c.Assert(err, gc.ErrorMatches, `invalid group name "b\^d"`)
+}
+
+func (s *UserGroupSuite) TestAddUserGroupAlreadyExists(c *gc.C) {
+	s.addGroup(c, "ops")
+	_, err := s.State.AddUserGroup("OPS", s.Owner)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `group "OPS" already exists`)
+}
+
+func (s *UserGroupSuite) TestUserGroupNotFound(c *gc.C) {
+	_, err := s.State.UserGroup("ops")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `group "ops" not found`)
+}
+
+func (s *UserGroupSuite) TestAllUserGroups(c *gc.C) {
+	s.addGroup(c, "ops")
+	s.addGroup(c, "dev")
+	groups, err := s.State.AllUserGroups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 2)
+	c.Check(groups[0].Name(), gc.Equals, "dev")
+	c.Check(groups[1].Name(), gc.Equals, "ops")
+}
+
+func (s *UserGroupSuite) TestMembers(c *gc.C) {
+	group := s.addGroup(c, "ops", "sam", "alex")
+	c.Check(group.Members(), jc.DeepEquals, []string{"alex", "sam"})
+
+	// Adding an existing member again is a no-op.
+	err := group.AddMembers(names.NewUserTag("sam"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(group.Members(), jc.DeepEquals, []string{"alex", "sam"})
+
+	err = group.RemoveMembers(names.NewUserTag("sam"), names.NewUserTag("jo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(group.Members(), jc.DeepEquals, []string{"alex"})
+
+	groups, err := s.State.UserGroupsForUser(names.NewUserTag("alex"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 1)
+	c.Check(groups[0].Name(), gc.Equals, "ops")
+	groups, err = s.State.UserGroupsForUser(names.NewUserTag("sam"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(groups, gc.HasLen, 0)
+}
+
+func (s *UserGroupSuite) TestAddMembersUnknownUser(c *gc.C) {
+	group := s.addGroup(c, "ops")
+	err := group.AddMembers(names.NewUserTag("jo"))
+	c.Assert(err, gc.ErrorMatches, `cannot add "jo" to group "ops": user "jo" not found`)
+	err = group.AddMembers(names.NewUserTag("jo@external"))
+	c.Assert(err, gc.ErrorMatches, `cannot add "jo@external" to group "ops": user "jo@external" not found`)
+}
+
+func (s *UserGroupSuite) TestRemoveUserLeavesGroups(c *gc.C) {
+	group := s.addGroup(c, "ops", "sam", "alex")
+	err := s.State.RemoveUser(names.NewUserTag("sam"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(group.Members(), jc.DeepEquals, []string{"alex"})
+}
+
+func (s *UserGroupSuite) TestUserGroupAccess(c *gc.C) {
+	s.addGroup(c, "ops")
+	modelTag := s.State.ModelTag()
+
+	_, err := s.State.UserGroupAccess("ops", modelTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.SetUserGroupAccess("ops", modelTag, permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.UserGroupAccess("ops", modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.ReadAccess)
+
+	err = s.State.SetUserGroupAccess("ops", modelTag, permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.UserGroupAccess("ops", modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.WriteAccess)
+
+	err = s.State.RemoveUserGroupAccess("ops", modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserGroupAccess("ops", modelTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RemoveUserGroupAccess("ops", modelTag)
+	c.Assert(err, gc.ErrorMatches, `access for group "ops" not found`)
+}
+
+func (s *UserGroupSuite) TestSetUserGroupAccessInvalid(c *gc.C) {
+	s.addGroup(c, "ops")
+	err := s.State.SetUserGroupAccess("ops", s.State.ModelTag(), permission.SuperuserAccess)
+	c.Assert(err, gc.ErrorMatches, `"superuser" model access not valid`)
+	err = s.State.SetUserGroupAccess("ops", s.State.ControllerTag(), permission.WriteAccess)
+	c.Assert(err, gc.ErrorMatches, `"write" controller access not valid`)
+	err = s.State.SetUserGroupAccess("dev", s.State.ModelTag(), permission.ReadAccess)
+	c.Assert(err, gc.ErrorMatches, `group "dev" not found`)
+	err = s.State.SetUserGroupAccess("ops", names.NewUserTag("sam"), permission.ReadAccess)
+	c.Assert(err, gc.ErrorMatches, `"user" as a target not valid`)
+}
+
+func (s *UserGroupSuite) TestUserAccessFromGroups(c *gc.C) {
+	s.addGroup(c, "ops", "sam")
+	group := s.addGroup(c, "dev")
+	err := group.AddMembers(names.NewUserTag("sam"))
+	c.Assert(err, jc.ErrorIsNil)
+	modelTag := s.State.ModelTag()
+	sam := names.NewUserTag("sam")
+
+	access, err := s.State.UserAccessFromGroups(sam, modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.NoAccess)
+
+	err = s.State.SetUserGroupAccess("ops", modelTag, permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUserGroupAccess("dev", modelTag, permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.UserAccessFromGroups(sam, modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.WriteAccess)
+
+	err = s.State.SetUserGroupAccess("dev", s.State.ControllerTag(), permission.AddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.UserAccessFromGroups(sam, s.State.ControllerTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.AddModelAccess)
+
+	access, err = s.State.UserAccessFromGroups(names.NewUserTag("sam@external"), modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.NoAccess)
+}
+
+func (s *UserGroupSuite) TestRemoveUserGroup(c *gc.C) {
+	s.addGroup(c, "ops", "sam")
+	err := s.State.SetUserGroupAccess("ops", s.State.ModelTag(), permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveUserGroup("ops")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserGroup("ops")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	access, err := s.State.UserAccessFromGroups(names.NewUserTag("sam"), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.NoAccess)
+
+	// A group added again with the same name has no access.
+	s.addGroup(c, "ops")
+	_, err = s.State.UserGroupAccess("ops", s.State.ModelTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveUserGroup("dev")
+	c.Assert(err, gc.ErrorMatches, `group "dev" not found`)
+}
+
+func (s *UserGroupSuite) TestModelsForUserIncludesGroupModels(c *gc.C) {
+	s.addGroup(c, "ops", "sam")
+	sam := names.NewUserTag("sam")
+	models, err := s.State.ModelsForUser(sam)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(models, gc.HasLen, 0)
+
+	err = s.State.SetUserGroupAccess("ops", s.State.ModelTag(), permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	models, err = s.State.ModelsForUser(sam)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 1)
+	c.Check(models[0].UUID(), gc.Equals, s.State.ModelUUID())
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// userLoginFailuresDoc records the failed password logins of a local
+// user. Like the last login time, it is updated by the apiserver outside
+// of mgo.txn, so it should NEVER appear in any transaction asserts.
+type userLoginFailuresDoc struct {
+	DocID        string    `bson:"_id"`
+	FailedLogins int       `bson:"failed-logins"`
+	LockedUntil  time.Time `bson:"locked-until,omitempty"`
+}
+
+// RecordFailedLogin records a failed password login for the user. When
+// the user has failed to log in maxAttempts times in a row, the user is
+// locked out for the given duration, and the count starts again. Failed
+// logins are not recorded if maxAttempts is zero.
+func (u *User) RecordFailedLogin(maxAttempts int, lockout time.Duration) error {
+	if maxAttempts <= 0 {
+		return nil
+	}
+	failures, closer := u.st.getRawCollection(userLoginFailuresC)
+	defer closer()
+
+	var doc userLoginFailuresDoc
+	_, err := failures.FindId(u.doc.DocID).Apply(mgo.Change{
+		Update:    bson.D{{"$inc", bson.D{{"failed-logins", 1}}}},
+		Upsert:    true,
+		ReturnNew: true,
+	}, &doc)
+	if err != nil {
+		return errors.Annotate(err, "cannot record failed login")
+	}
+	if doc.FailedLogins < maxAttempts {
+		return nil
+	}
+	err = failures.Update(bson.D{
+		{"_id", u.doc.DocID},
+		{"failed-logins", bson.D{{"$gte", maxAttempts}}},
+	}, bson.D{{"$set", bson.D{
+		{"failed-logins", 0},
+		{"locked-until", u.st.NowToTheSecond().Add(lockout)},
+	}}})
+	if err == mgo.ErrNotFound {
+		// Another failed login has already locked the user out.
+		return nil
+	}
+	return errors.Annotate(err, "cannot lock out user")
+}
+
+// ResetFailedLogins forgets the failed password logins of the user, and
+// lifts any lockout.
+func (u *User) ResetFailedLogins() error {
+	failures, closer := u.st.getRawCollection(userLoginFailuresC)
+	defer closer()
+
+	err := failures.RemoveId(u.doc.DocID)
+	if err != nil && err != mgo.ErrNotFound {
+		return errors.Annotate(err, "cannot reset failed logins")
+	}
+	return nil
+}
+
+// LockedOutUntil returns the time in UTC until which the user is locked
+// out after too many failed logins. The zero time is returned if the user
+// is not locked out.
+func (u *User) LockedOutUntil() (time.Time, error) {
+	failures, closer := u.st.getRawCollection(userLoginFailuresC)
+	defer closer()
+
+	var doc userLoginFailuresDoc
+	err := failures.FindId(u.doc.DocID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	if !doc.LockedUntil.After(u.st.clock.Now()) {
+		return time.Time{}, nil
+	}
+	return doc.LockedUntil.UTC(), nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type UserLoginFailuresSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&UserLoginFailuresSuite{})
+
+func (s *UserLoginFailuresSuite) TestNotLockedOut(c *gc.C) {
+	user := s.Factory.MakeUser(c, nil)
+	until, err := user.LockedOutUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(until.IsZero(), jc.IsTrue)
+}
+
+func (s *UserLoginFailuresSuite) TestRecordFailedLoginLocksOut(c *gc.C) {
+	user := s.Factory.MakeUser(c, nil)
+	for i := 0; i < 2; i++ {
+		err := user.RecordFailedLogin(3, time.Minute)
+		c.Assert(err, jc.ErrorIsNil)
+		until, err := user.LockedOutUntil()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(until.IsZero(), jc.IsTrue)
+	}
+	err := user.RecordFailedLogin(3, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	until, err := user.LockedOutUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(until, gc.Equals, s.State.NowToTheSecond().Add(time.Minute))
+
+	s.Clock.Advance(time.Minute + time.Second)
+	until, err = user.LockedOutUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(until.IsZero(), jc.IsTrue)
+}
+
+func (s *UserLoginFailuresSuite) TestRecordFailedLoginDisabled(c *gc.C) {
+	user := s.Factory.MakeUser(c, nil)
+	for i := 0; i < 5; i++ {
+		err := user.RecordFailedLogin(0, time.Minute)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	until, err := user.LockedOutUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(until.IsZero(), jc.IsTrue)
+}
+
+func (s *UserLoginFailuresSuite) TestResetFailedLogins(c *gc.C) {
+	user := s.Factory.MakeUser(c, nil)
+	err := user.RecordFailedLogin(1, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	until, err := user.LockedOutUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(until.IsZero(), jc.IsFalse)
+
+	err = user.ResetFailedLogins()
+	c.Assert(err, jc.ErrorIsNil)
+	until, err = user.LockedOutUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(until.IsZero(), jc.IsTrue)
+
+	// Resetting again is fine.
+	err = user.ResetFailedLogins()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UserLoginFailuresSuite) TestPasswordChanged(c *gc.C) {
+	user := s.Factory.MakeUser(c, nil)
+	c.Assert(user.PasswordChanged(), gc.Equals, s.State.NowToTheSecond())
+
+	s.Clock.Advance(time.Hour)
+	err := user.SetPassword("new-password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.PasswordChanged(), gc.Equals, s.State.NowToTheSecond())
+
+	user, err = s.State.User(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.PasswordChanged(), gc.Equals, s.State.NowToTheSecond())
+}
@@ -26,6 +26,8 @@ type StateBackend interface {
 	UpgradeNoProxyDefaults() error
 	AddNonDetachableStorageMachineId() error
 	RemoveNilValueApplicationSettings() error
+	AddAuditEntryTimes() error
+	AddUserPasswordChangedTimes() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -92,6 +94,14 @@ func (s stateBackend) RemoveNilValueApplicationSettings() error {
 	return state.RemoveNilValueApplicationSettings(s.st)
 }
 
+func (s stateBackend) AddAuditEntryTimes() error {
+	return state.AddAuditEntryTimes(s.st)
+}
+
+func (s stateBackend) AddUserPasswordChangedTimes() error {
+	return state.AddUserPasswordChangedTimes(s.st)
+}
+
 type modelShim struct {
 	st *state.State
 	m  *state.Model
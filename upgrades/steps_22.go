@@ -20,5 +20,19 @@ func stateStepsFor22() []Step {
 				return context.State().RemoveNilValueApplicationSettings()
 			},
 		},
+		&upgradeStep{
+			description: "add sortable time to audit entries",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().AddAuditEntryTimes()
+			},
+		},
+		&upgradeStep{
+			description: "add password changed time to local users",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().AddUserPasswordChangedTimes()
+			},
+		},
 	}
 }
@@ -25,3 +25,15 @@ func (s *steps22Suite) TestAddNonDetachableStorageMachineId(c *gc.C) {
 	// Logic for step itself is tested in state package.
 	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
 }
+
+func (s *steps22Suite) TestAddAuditEntryTimes(c *gc.C) {
+	step := findStateStep(c, v220, "add sortable time to audit entries")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
+
+func (s *steps22Suite) TestAddUserPasswordChangedTimes(c *gc.C) {
+	step := findStateStep(c, v220, "add password changed time to local users")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
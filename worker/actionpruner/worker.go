@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionpruner")
+
+// Facade represents an API that implements action pruning.
+type Facade interface {
+	Prune(time.Duration, int) error
+	ModelConfig() (*config.Config, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+}
+
+// Config holds all necessary attributes to start a pruner worker.
+type Config struct {
+	Facade        Facade
+	PruneInterval time.Duration
+	Clock         clock.Clock
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c *Config) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.PruneInterval <= 0 {
+		return errors.NotValidf("non-positive PruneInterval")
+	}
+	return nil
+}
+
+// Worker prunes the model's finished actions according to the
+// max-action-results-age and max-action-results-size model config.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New returns a worker.Worker for the action pruner.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *Worker) loop() error {
+	configWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model config")
+	}
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		maxAge    time.Duration
+		maxSizeMB uint
+		timer     <-chan time.Time
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("model config watch closed")
+			}
+			modelConfig, err := w.config.Facade.ModelConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot read model config")
+			}
+			maxAge = modelConfig.MaxActionResultsAge()
+			maxSizeMB = modelConfig.MaxActionResultsSizeMB()
+			logger.Debugf("pruning actions older than %v or beyond %dMB", maxAge, maxSizeMB)
+			if timer == nil {
+				// Prune as soon as the limits are first known.
+				timer = w.config.Clock.After(0)
+			}
+		case <-timer:
+			if maxAge > 0 || maxSizeMB > 0 {
+				if err := w.config.Facade.Prune(maxAge, int(maxSizeMB)); err != nil {
+					return errors.Annotate(err, "cannot prune actions")
+				}
+			}
+			timer = w.config.Clock.After(w.config.PruneInterval)
+		}
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
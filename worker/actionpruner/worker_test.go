@@ -0,0 +1,201 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/actionpruner"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type actionPrunerSuite struct {
+	coretesting.BaseSuite
+
+	clock  *testing.Clock
+	facade *fakeFacade
+}
+
+var _ = gc.Suite(&actionPrunerSuite{})
+
+const pruneInterval = 5 * time.Minute
+
+func (s *actionPrunerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.facade = newFakeFacade()
+}
+
+func (s *actionPrunerSuite) newWorker(c *gc.C) worker.Worker {
+	w, err := actionpruner.New(actionpruner.Config{
+		Facade:        s.facade,
+		PruneInterval: pruneInterval,
+		Clock:         s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *actionPrunerSuite) startWorker(c *gc.C) {
+	w := s.newWorker(c)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+}
+
+func (s *actionPrunerSuite) setModelConfig(c *gc.C, age, size string) {
+	s.facade.setConfig(coretesting.CustomModelConfig(c, coretesting.Attrs{
+		config.MaxActionResultsAge:  age,
+		config.MaxActionResultsSize: size,
+	}))
+}
+
+func (s *actionPrunerSuite) assertPruned(c *gc.C, expected pruneArgs) {
+	select {
+	case args := <-s.facade.prunes:
+		c.Assert(args, gc.Equals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for prune")
+	}
+}
+
+func (s *actionPrunerSuite) assertNotPruned(c *gc.C) {
+	select {
+	case args := <-s.facade.prunes:
+		c.Fatalf("unexpected prune %v", args)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *actionPrunerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		config actionpruner.Config
+		err    string
+	}{{
+		config: actionpruner.Config{PruneInterval: pruneInterval, Clock: s.clock},
+		err:    "nil Facade not valid",
+	}, {
+		config: actionpruner.Config{Facade: s.facade, PruneInterval: pruneInterval},
+		err:    "nil Clock not valid",
+	}, {
+		config: actionpruner.Config{Facade: s.facade, Clock: s.clock},
+		err:    "non-positive PruneInterval not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := actionpruner.New(test.config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *actionPrunerSuite) TestPrunesWithModelConfig(c *gc.C) {
+	s.setModelConfig(c, "24h", "20M")
+	s.startWorker(c)
+	s.facade.watcher.changes <- struct{}{}
+	s.assertPruned(c, pruneArgs{24 * time.Hour, 20})
+
+	err := s.clock.WaitAdvance(pruneInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPruned(c, pruneArgs{24 * time.Hour, 20})
+}
+
+func (s *actionPrunerSuite) TestConfigChange(c *gc.C) {
+	s.setModelConfig(c, "24h", "20M")
+	s.startWorker(c)
+	s.facade.watcher.changes <- struct{}{}
+	s.assertPruned(c, pruneArgs{24 * time.Hour, 20})
+
+	// A config change doesn't trigger pruning, but the new limits
+	// are used next time.
+	s.setModelConfig(c, "1h", "1G")
+	s.facade.watcher.changes <- struct{}{}
+	s.assertNotPruned(c)
+
+	err := s.clock.WaitAdvance(pruneInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPruned(c, pruneArgs{time.Hour, 1024})
+}
+
+func (s *actionPrunerSuite) TestNoLimits(c *gc.C) {
+	s.setModelConfig(c, "0", "0")
+	s.startWorker(c)
+	s.facade.watcher.changes <- struct{}{}
+	s.assertNotPruned(c)
+}
+
+func (s *actionPrunerSuite) TestPruneError(c *gc.C) {
+	s.setModelConfig(c, "24h", "20M")
+	s.facade.pruneErr = errors.New("boom")
+	w := s.newWorker(c)
+	defer workertest.DirtyKill(c, w)
+	s.facade.watcher.changes <- struct{}{}
+	s.assertPruned(c, pruneArgs{24 * time.Hour, 20})
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot prune actions: boom")
+}
+
+type pruneArgs struct {
+	maxHistoryTime time.Duration
+	maxHistoryMB   int
+}
+
+type fakeFacade struct {
+	mu       sync.Mutex
+	config   *config.Config
+	pruneErr error
+	prunes   chan pruneArgs
+	watcher  *notifyWatcher
+}
+
+func newFakeFacade() *fakeFacade {
+	return &fakeFacade{
+		prunes: make(chan pruneArgs, 10),
+		watcher: &notifyWatcher{
+			Worker:  workertest.NewErrorWorker(nil),
+			changes: make(chan struct{}, 1),
+		},
+	}
+}
+
+func (f *fakeFacade) setConfig(cfg *config.Config) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config = cfg
+}
+
+// Prune implements Facade.
+func (f *fakeFacade) Prune(maxHistoryTime time.Duration, maxHistoryMB int) error {
+	f.prunes <- pruneArgs{maxHistoryTime, maxHistoryMB}
+	return f.pruneErr
+}
+
+// ModelConfig implements Facade.
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.config, nil
+}
+
+// WatchForModelConfigChanges implements Facade.
+func (f *fakeFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	return f.watcher, nil
+}
+
+type notifyWatcher struct {
+	worker.Worker
+	changes chan struct{}
+}
+
+// Changes is part of the watcher.NotifyWatcher interface.
+func (w *notifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
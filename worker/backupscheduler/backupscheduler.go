@@ -0,0 +1,204 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker which backs up the
+// controller on a schedule, and removes old scheduled backups
+// according to a retention policy.
+package backupscheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/core/cron"
+	"github.com/juju/juju/state/backups"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// Backend exposes the backup functionality needed by the worker.
+type Backend interface {
+	// CreateBackup creates and stores a new scheduled backup, and
+	// returns its metadata.
+	CreateBackup() (*backups.Metadata, error)
+
+	// ListBackups returns the metadata for all stored backups.
+	ListBackups() ([]*backups.Metadata, error)
+
+	// RemoveBackup removes the identified backup from storage.
+	RemoveBackup(id string) error
+
+	// ScheduleStatus returns the recorded status of the backup
+	// schedule. It returns an error satisfying errors.IsNotFound()
+	// if no status has been recorded.
+	ScheduleStatus() (*backups.ScheduleStatus, error)
+
+	// SetScheduleStatus records the status of the backup schedule.
+	SetScheduleStatus(backups.ScheduleStatus) error
+}
+
+// RetentionPolicy specifies which scheduled backups are kept. A
+// scheduled backup is kept if it is one of the KeepLast most recent
+// scheduled backups, or if it is the last scheduled backup made on
+// one of the last KeepDailyDays days (in UTC). When both are zero,
+// every scheduled backup is kept. Backups created on demand are never
+// removed.
+type RetentionPolicy struct {
+	KeepLast      int
+	KeepDailyDays int
+}
+
+// Expired returns the scheduled backups in the list which are not
+// kept by the policy at the given time.
+func (p RetentionPolicy) Expired(list []*backups.Metadata, now time.Time) []*backups.Metadata {
+	if p.KeepLast <= 0 && p.KeepDailyDays <= 0 {
+		return nil
+	}
+	var scheduled []*backups.Metadata
+	for _, meta := range list {
+		if meta.Scheduled {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	// Newest first.
+	sort.Sort(sort.Reverse(byStarted(scheduled)))
+
+	var firstDay time.Time
+	if p.KeepDailyDays > 0 {
+		today := now.UTC().Truncate(24 * time.Hour)
+		firstDay = today.AddDate(0, 0, 1-p.KeepDailyDays)
+	}
+	var expired []*backups.Metadata
+	keptDays := make(map[time.Time]bool)
+	for i, meta := range scheduled {
+		day := meta.Started.UTC().Truncate(24 * time.Hour)
+		switch {
+		case i < p.KeepLast:
+		case p.KeepDailyDays > 0 && !day.Before(firstDay) && !keptDays[day]:
+		default:
+			expired = append(expired, meta)
+			continue
+		}
+		keptDays[day] = true
+	}
+	return expired
+}
+
+type byStarted []*backups.Metadata
+
+func (b byStarted) Len() int           { return len(b) }
+func (b byStarted) Less(i, j int) bool { return b[i].Started.Before(b[j].Started) }
+func (b byStarted) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+
+// Config holds the configuration and dependencies of the worker.
+type Config struct {
+	Backend   Backend
+	Clock     clock.Clock
+	Schedule  *cron.Schedule
+	Retention RetentionPolicy
+}
+
+// Validate returns an error if the config cannot be used to start
+// the worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Schedule == nil {
+		return errors.NotValidf("nil Schedule")
+	}
+	if config.Retention.KeepLast < 0 {
+		return errors.NotValidf("negative Retention.KeepLast")
+	}
+	if config.Retention.KeepDailyDays < 0 {
+		return errors.NotValidf("negative Retention.KeepDailyDays")
+	}
+	return nil
+}
+
+// New returns a worker which creates a backup of the controller each
+// time the configured schedule fires, then removes the scheduled
+// backups which have expired under the retention policy. The outcome
+// of each run is recorded in the schedule status. This worker is
+// intended to run just once, on the MongoDB master.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &scheduleWorker{config: config}
+	return jworker.NewSimpleWorker(w.loop), nil
+}
+
+type scheduleWorker struct {
+	config Config
+}
+
+func (w *scheduleWorker) loop(stopCh <-chan struct{}) error {
+	status, err := w.config.Backend.ScheduleStatus()
+	if errors.IsNotFound(err) {
+		status = &backups.ScheduleStatus{}
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	status.Schedule = w.config.Schedule.String()
+
+	for {
+		now := w.config.Clock.Now().UTC()
+		status.NextRun = w.config.Schedule.Next(now)
+		if err := w.config.Backend.SetScheduleStatus(*status); err != nil {
+			return errors.Trace(err)
+		}
+		if status.NextRun.IsZero() {
+			logger.Warningf("backup schedule %q never fires", status.Schedule)
+			<-stopCh
+			return tomb.ErrDying
+		}
+		select {
+		case <-stopCh:
+			return tomb.ErrDying
+		case <-w.config.Clock.After(status.NextRun.Sub(now)):
+		}
+
+		status.LastRun = w.config.Clock.Now().UTC()
+		id, err := w.backup()
+		status.LastBackupID = id
+		status.LastError = ""
+		if err != nil {
+			logger.Errorf("scheduled backup failed: %v", err)
+			status.LastError = err.Error()
+		} else {
+			logger.Infof("created scheduled backup %q", id)
+		}
+	}
+}
+
+// backup creates a new backup and removes expired ones. It returns
+// the ID of the new backup, if it was created.
+func (w *scheduleWorker) backup() (string, error) {
+	meta, err := w.config.Backend.CreateBackup()
+	if err != nil {
+		return "", errors.Annotate(err, "creating backup")
+	}
+	list, err := w.config.Backend.ListBackups()
+	if err != nil {
+		return meta.ID(), errors.Annotate(err, "listing backups")
+	}
+	now := w.config.Clock.Now()
+	for _, expired := range w.config.Retention.Expired(list, now) {
+		logger.Infof("removing expired scheduled backup %q", expired.ID())
+		if err := w.config.Backend.RemoveBackup(expired.ID()); err != nil {
+			return meta.ID(), errors.Annotatef(err, "removing expired backup %q", expired.ID())
+		}
+	}
+	return meta.ID(), nil
+}
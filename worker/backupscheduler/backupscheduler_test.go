@@ -0,0 +1,246 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/core/cron"
+	"github.com/juju/juju/state/backups"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type SchedulerSuite struct {
+	testing.IsolationSuite
+	clock   *testing.Clock
+	backend *fakeBackend
+	config  backupscheduler.Config
+}
+
+var _ = gc.Suite(&SchedulerSuite{})
+
+// Friday 2017-03-10 10:20:30 UTC.
+var now = time.Date(2017, 3, 10, 10, 20, 30, 0, time.UTC)
+
+func (s *SchedulerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(now)
+	s.backend = &fakeBackend{
+		statusCh: make(chan backups.ScheduleStatus, 10),
+	}
+	schedule, err := cron.Parse("30 10 * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	s.config = backupscheduler.Config{
+		Backend:   s.backend,
+		Clock:     s.clock,
+		Schedule:  schedule,
+		Retention: backupscheduler.RetentionPolicy{KeepLast: 1},
+	}
+}
+
+func (s *SchedulerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*backupscheduler.Config)
+		expect string
+	}{{
+		mutate: func(config *backupscheduler.Config) { config.Backend = nil },
+		expect: "nil Backend not valid",
+	}, {
+		mutate: func(config *backupscheduler.Config) { config.Clock = nil },
+		expect: "nil Clock not valid",
+	}, {
+		mutate: func(config *backupscheduler.Config) { config.Schedule = nil },
+		expect: "nil Schedule not valid",
+	}, {
+		mutate: func(config *backupscheduler.Config) { config.Retention.KeepLast = -1 },
+		expect: "negative Retention.KeepLast not valid",
+	}, {
+		mutate: func(config *backupscheduler.Config) { config.Retention.KeepDailyDays = -1 },
+		expect: "negative Retention.KeepDailyDays not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := backupscheduler.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *SchedulerSuite) TestScheduledBackup(c *gc.C) {
+	s.backend.list = []*backups.Metadata{
+		newMetadata("manual", now.Add(-48*time.Hour), false),
+		newMetadata("old", now.Add(-24*time.Hour), true),
+	}
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+
+	status := s.nextStatus(c)
+	c.Assert(status, jc.DeepEquals, backups.ScheduleStatus{
+		Schedule: "30 10 * * *",
+		NextRun:  time.Date(2017, 3, 10, 10, 30, 0, 0, time.UTC),
+	})
+	s.backend.CheckCallNames(c, "ScheduleStatus", "SetScheduleStatus")
+	s.backend.ResetCalls()
+
+	err := s.clock.WaitAdvance(10*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	status = s.nextStatus(c)
+	c.Assert(status, jc.DeepEquals, backups.ScheduleStatus{
+		Schedule:     "30 10 * * *",
+		LastRun:      now.Add(10 * time.Minute),
+		LastBackupID: "new",
+		NextRun:      time.Date(2017, 3, 11, 10, 30, 0, 0, time.UTC),
+	})
+	s.backend.CheckCallNames(c, "CreateBackup", "ListBackups", "RemoveBackup", "SetScheduleStatus")
+	s.backend.CheckCall(c, 2, "RemoveBackup", "old")
+}
+
+func (s *SchedulerSuite) TestBackupFailure(c *gc.C) {
+	s.backend.SetErrors(nil, nil, errors.New("boom"))
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+	s.nextStatus(c)
+
+	err := s.clock.WaitAdvance(10*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	status := s.nextStatus(c)
+	c.Assert(status, jc.DeepEquals, backups.ScheduleStatus{
+		Schedule:  "30 10 * * *",
+		LastRun:   now.Add(10 * time.Minute),
+		LastError: "creating backup: boom",
+		NextRun:   time.Date(2017, 3, 11, 10, 30, 0, 0, time.UTC),
+	})
+}
+
+func (s *SchedulerSuite) TestKeepsPreviousStatus(c *gc.C) {
+	s.backend.status = &backups.ScheduleStatus{
+		Schedule:     "@daily",
+		LastRun:      now.Add(-time.Hour),
+		LastBackupID: "previous",
+	}
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+
+	status := s.nextStatus(c)
+	c.Assert(status, jc.DeepEquals, backups.ScheduleStatus{
+		Schedule:     "30 10 * * *",
+		LastRun:      now.Add(-time.Hour),
+		LastBackupID: "previous",
+		NextRun:      time.Date(2017, 3, 10, 10, 30, 0, 0, time.UTC),
+	})
+}
+
+func (s *SchedulerSuite) TestRetentionPolicy(c *gc.C) {
+	day := 24 * time.Hour
+	list := []*backups.Metadata{
+		newMetadata("manual", now.Add(-30*day), false),
+		newMetadata("today-1", now.Add(-2*time.Hour), true),
+		newMetadata("today-2", now.Add(-time.Hour), true),
+		newMetadata("yesterday-1", now.Add(-day-time.Hour), true),
+		newMetadata("yesterday-2", now.Add(-day), true),
+		newMetadata("2-days-ago", now.Add(-2*day), true),
+		newMetadata("10-days-ago", now.Add(-10*day), true),
+	}
+	for i, test := range []struct {
+		policy backupscheduler.RetentionPolicy
+		expect []string
+	}{{
+		expect: nil,
+	}, {
+		policy: backupscheduler.RetentionPolicy{KeepLast: 3},
+		expect: []string{"yesterday-1", "2-days-ago", "10-days-ago"},
+	}, {
+		policy: backupscheduler.RetentionPolicy{KeepDailyDays: 2},
+		expect: []string{"today-1", "yesterday-1", "2-days-ago", "10-days-ago"},
+	}, {
+		policy: backupscheduler.RetentionPolicy{KeepLast: 1, KeepDailyDays: 3},
+		expect: []string{"today-1", "yesterday-1", "10-days-ago"},
+	}, {
+		policy: backupscheduler.RetentionPolicy{KeepLast: 10},
+		expect: nil,
+	}} {
+		c.Logf("test %d: %+v", i, test.policy)
+		var ids []string
+		for _, meta := range test.policy.Expired(list, now) {
+			ids = append(ids, meta.ID())
+		}
+		c.Check(ids, jc.DeepEquals, test.expect)
+	}
+}
+
+func (s *SchedulerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := backupscheduler.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *SchedulerSuite) nextStatus(c *gc.C) backups.ScheduleStatus {
+	select {
+	case status := <-s.backend.statusCh:
+		return status
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for schedule status")
+	}
+	panic("unreachable")
+}
+
+func newMetadata(id string, started time.Time, scheduled bool) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Scheduled = scheduled
+	return meta
+}
+
+type fakeBackend struct {
+	testing.Stub
+	list     []*backups.Metadata
+	status   *backups.ScheduleStatus
+	statusCh chan backups.ScheduleStatus
+}
+
+func (b *fakeBackend) CreateBackup() (*backups.Metadata, error) {
+	b.AddCall("CreateBackup")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	meta := newMetadata("new", now, true)
+	b.list = append(b.list, meta)
+	return meta, nil
+}
+
+func (b *fakeBackend) ListBackups() ([]*backups.Metadata, error) {
+	b.AddCall("ListBackups")
+	return b.list, b.NextErr()
+}
+
+func (b *fakeBackend) RemoveBackup(id string) error {
+	b.AddCall("RemoveBackup", id)
+	return b.NextErr()
+}
+
+func (b *fakeBackend) ScheduleStatus() (*backups.ScheduleStatus, error) {
+	b.AddCall("ScheduleStatus")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	if b.status == nil {
+		return nil, errors.NotFoundf("backup schedule status")
+	}
+	return b.status, nil
+}
+
+func (b *fakeBackend) SetScheduleStatus(status backups.ScheduleStatus) error {
+	b.AddCall("SetScheduleStatus", status)
+	b.statusCh <- status
+	return b.NextErr()
+}
@@ -0,0 +1,138 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/core/backupcrypt"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewStateBackend returns a Backend which backs up the controller
+// from the given machine, using the given paths.
+func NewStateBackend(st *state.State, machineID string, paths backups.Paths) Backend {
+	return &stateBackend{
+		st:        st,
+		machineID: machineID,
+		paths:     paths,
+	}
+}
+
+type stateBackend struct {
+	st        *state.State
+	machineID string
+	paths     backups.Paths
+}
+
+// backups returns the controller's backups, and the storage to close
+// when they are no longer needed.
+func (b *stateBackend) backups() (backups.Backups, io.Closer, error) {
+	stor, err := backups.NewControllerStorage(b.st)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return backups.NewBackups(stor), stor, nil
+}
+
+// CreateBackup is part of the Backend interface.
+func (b *stateBackend) CreateBackup() (*backups.Metadata, error) {
+	backupsMethods, closer, err := b.backups()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer.Close()
+
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotatef(err, "HA not ready")
+	}
+
+	v, err := b.st.MongoVersion()
+	if err != nil {
+		return nil, errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(b.st.MongoConnectionInfo(), session, mongoVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := b.st.Machine(b.machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	meta, err := backups.NewMetadataState(b.st, b.machineID, machine.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Scheduled = true
+	key, err := b.encryptionKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := backupsMethods.Create(meta, &b.paths, dbInfo, key); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// encryptionKey returns the controller's backup encryption key, or nil
+// if backups are not encrypted.
+func (b *stateBackend) encryptionKey() (*backupcrypt.Key, error) {
+	cfg, err := b.st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.BackupEncryptionKey() == "" {
+		return nil, nil
+	}
+	return backupcrypt.ParseKey(cfg.BackupEncryptionKey())
+}
+
+// ListBackups is part of the Backend interface.
+func (b *stateBackend) ListBackups() ([]*backups.Metadata, error) {
+	backupsMethods, closer, err := b.backups()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer.Close()
+	return backupsMethods.List()
+}
+
+// RemoveBackup is part of the Backend interface.
+func (b *stateBackend) RemoveBackup(id string) error {
+	backupsMethods, closer, err := b.backups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer.Close()
+	return backupsMethods.Remove(id)
+}
+
+// ScheduleStatus is part of the Backend interface.
+func (b *stateBackend) ScheduleStatus() (*backups.ScheduleStatus, error) {
+	return backups.GetScheduleStatus(b.st)
+}
+
+// SetScheduleStatus is part of the Backend interface.
+func (b *stateBackend) SetScheduleStatus(status backups.ScheduleStatus) error {
+	return backups.SetScheduleStatus(b.st, status)
+}
@@ -5,6 +5,7 @@ package logforwarder
 
 import (
 	"io"
+	"reflect"
 	"sync"
 
 	"github.com/juju/errors"
@@ -40,9 +41,6 @@ type sender interface {
 	Send([]logfwd.Record) error
 }
 
-// TODO(ericsnow) It is likely that eventually we will want to support
-// multiplexing to multiple senders, each in its own goroutine (or worker).
-
 // LogForwarder is a worker that forwards log records from a source
 // to a sender.
 type LogForwarder struct {
@@ -51,6 +49,13 @@ type LogForwarder struct {
 	enabledCh chan bool
 	mu        sync.Mutex
 	enabled   bool
+	filter    logfwd.Filter
+
+	// stream is the log stream being read, if any. streamGen is
+	// incremented whenever the stream is replaced, so that records
+	// read from an earlier stream are not forwarded.
+	stream    LogStream
+	streamGen int
 }
 
 // OpenLogForwarderArgs holds the info needed to open a LogForwarder.
@@ -70,6 +75,10 @@ type OpenLogForwarderArgs struct {
 	// Name is the name given to the log sink.
 	Name string
 
+	// SinkConfig is the function that extracts the log sink's config
+	// from the model config.
+	SinkConfig LogSinkConfigFn
+
 	// OpenSink is the function that opens the underlying log sink that
 	// will be wrapped.
 	OpenSink LogSinkFn
@@ -79,7 +88,7 @@ type OpenLogForwarderArgs struct {
 	OpenLogStream LogStreamFn
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -94,13 +103,14 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	}
 
 	// Get the new config and set up log forwarding if enabled.
-	cfg, ok, err := lf.args.LogForwardConfig.LogForwardConfig()
+	modelCfg, err := lf.args.LogForwardConfig.ModelConfig()
 	if err != nil {
 		closeExisting()
 		return nil, errors.Trace(err)
 	}
-	if !ok || !cfg.Enabled {
-		logger.Infof("config change - log forwarding not enabled")
+	cfg, ok := lf.args.SinkConfig(modelCfg)
+	if !ok {
+		logger.Infof("config change - log forwarding to %s not enabled", lf.args.Name)
 		return nil, closeExisting()
 	}
 	// If the config is not valid, we don't want to exit with an error
@@ -108,10 +118,23 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	// config change to come through.
 	// We'll continue sending using the current sink.
 	if err := cfg.Validate(); err != nil {
-		logger.Errorf("invalid log forward config change: %v", err)
+		logger.Errorf("invalid %s log forward config change: %v", lf.args.Name, err)
 		return currentSender, nil
 	}
 
+	// The records are filtered by the server, so a new stream is
+	// needed when the filter changes. Closing the current stream
+	// stops any wait for a record matching the old filter.
+	if filter := modelCfg.LogFwdFilter(); !reflect.DeepEqual(filter, lf.filter) {
+		lf.filter = filter
+		if lf.stream != nil {
+			logger.Infof("log forward filter changed, reopening %s log stream", lf.args.Name)
+			closeStream(lf.stream)
+			lf.stream = nil
+		}
+		lf.streamGen++
+	}
+
 	// Shutdown the existing sink since we need to now create a new one.
 	if err := closeExisting(); err != nil {
 		return nil, errors.Trace(err)
@@ -149,7 +172,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		logger.Infof("log forward enabled, starting to stream logs to %s sink", lf.args.Name)
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -183,8 +206,7 @@ func (lf *LogForwarder) loop() error {
 		return errors.Trace(err)
 	}
 
-	records := make(chan []logfwd.Record)
-	var stream LogStream
+	records := make(chan streamRecords)
 	go func() {
 		for {
 			enabled, err := lf.waitForEnabled()
@@ -194,22 +216,18 @@ func (lf *LogForwarder) loop() error {
 			if !enabled {
 				continue
 			}
-			// Lazily create log streamer if needed.
-			if stream == nil {
-				streamCfg := params.LogStreamConfig{
-					AllModels: lf.args.AllModels,
-					Sink:      lf.args.Name,
-					// TODO(wallyworld) - this should be configurable via lf.args.LogForwardConfig
-					MaxLookbackRecords: 100,
-				}
-				stream, err = lf.args.OpenLogStream(lf.args.Caller, streamCfg, lf.args.ControllerUUID)
-				if err != nil {
-					lf.catacomb.Kill(errors.Annotate(err, "creating log stream"))
-					break
-				}
-
+			stream, gen, err := lf.currentStream()
+			if err != nil {
+				lf.catacomb.Kill(errors.Annotate(err, "creating log stream"))
+				break
 			}
 			rec, err := stream.Next()
+			if lf.streamReplaced(gen) {
+				// The filter changed while waiting for the record,
+				// so it may match the old filter. The new stream
+				// resumes after the last record sent.
+				continue
+			}
 			if err != nil {
 				lf.catacomb.Kill(errors.Annotate(err, "getting next log record"))
 				break
@@ -217,7 +235,7 @@ func (lf *LogForwarder) loop() error {
 			select {
 			case <-lf.catacomb.Dying():
 				return
-			case records <- rec: // Wait until the last one is sent.
+			case records <- streamRecords{gen: gen, records: rec}: // Wait until the last one is sent.
 			}
 		}
 	}()
@@ -235,22 +253,77 @@ func (lf *LogForwarder) loop() error {
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
 			}
 		case rec := <-records:
-			if sender == nil {
+			if sender == nil || lf.streamReplaced(rec.gen) {
 				continue
 			}
-			if err := sender.Send(rec); err != nil {
+			if err := sender.Send(rec.records); err != nil {
 				return errors.Trace(err)
 			}
 		}
 	}
 }
 
+// streamRecords holds records read from the stream of the given
+// generation.
+type streamRecords struct {
+	gen     int
+	records []logfwd.Record
+}
+
+// currentStream returns the stream to read records from, opening one
+// with the current filter if needed, along with its generation.
+func (lf *LogForwarder) currentStream() (LogStream, int, error) {
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	if lf.stream != nil {
+		return lf.stream, lf.streamGen, nil
+	}
+	streamCfg := params.LogStreamConfig{
+		AllModels: lf.args.AllModels,
+		Sink:      lf.args.Name,
+		// TODO(wallyworld) - this should be configurable via lf.args.LogForwardConfig
+		MaxLookbackRecords: 100,
+		IncludeEntity:      lf.filter.IncludeEntity,
+		ExcludeEntity:      lf.filter.ExcludeEntity,
+		IncludeModule:      lf.filter.IncludeModule,
+		ExcludeModule:      lf.filter.ExcludeModule,
+	}
+	if lf.filter.MinLevel != loggo.UNSPECIFIED {
+		streamCfg.Level = lf.filter.MinLevel.String()
+	}
+	stream, err := lf.args.OpenLogStream(lf.args.Caller, streamCfg, lf.args.ControllerUUID)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	lf.stream = stream
+	return stream, lf.streamGen, nil
+}
+
+// streamReplaced reports whether the stream of the given generation
+// has been replaced since.
+func (lf *LogForwarder) streamReplaced(gen int) bool {
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	return gen != lf.streamGen
+}
+
+// closeStream closes the log stream, if it can be closed.
+func closeStream(stream LogStream) {
+	closer, ok := stream.(io.Closer)
+	if !ok {
+		return
+	}
+	if err := closer.Close(); err != nil {
+		logger.Debugf("closing log stream: %v", err)
+	}
+}
+
 // Kill implements Worker.Kill()
 func (lf *LogForwarder) Kill() {
 	lf.catacomb.Kill(nil)
@@ -4,6 +4,7 @@
 package logforwarder_test
 
 import (
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
 	coretesting "github.com/juju/juju/testing"
@@ -26,9 +28,10 @@ import (
 type LogForwarderSuite struct {
 	testing.IsolationSuite
 
-	stream *stubStream
-	sender *stubSender
-	rec    logfwd.Record
+	stream     *stubStream
+	sender     *stubSender
+	rec        logfwd.Record
+	streamCfgs []params.LogStreamConfig
 }
 
 var _ = gc.Suite(&LogForwarderSuite{})
@@ -36,6 +39,7 @@ var _ = gc.Suite(&LogForwarderSuite{})
 func (s *LogForwarderSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 
+	s.streamCfgs = nil
 	s.stream = newStubStream()
 	s.sender = newStubSender()
 	s.rec = logfwd.Record{
@@ -77,24 +81,33 @@ func (s *LogForwarderSuite) newLogForwarderArgs(
 
 func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 	c *gc.C,
-	configAPI logforwarder.LogForwardConfig,
+	configAPI *mockLogForwardConfig,
 	stream logforwarder.LogStream,
 	sender *stubSender,
 ) logforwarder.OpenLogForwarderArgs {
+	if configAPI.modelConfig == nil {
+		configAPI.modelConfig = coretesting.ModelConfig(c)
+	}
 	return logforwarder.OpenLogForwarderArgs{
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		AllModels:        true,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		Name:             "juju-log-forward",
+		SinkConfig: func(modelCfg *config.Config) (logforwarder.LogSinkConfig, bool) {
+			c.Assert(modelCfg, gc.Equals, configAPI.modelConfig)
+			return configAPI.sinkConfig()
+		},
+		OpenSink: func(cfg logforwarder.LogSinkConfig) (*logforwarder.LogSink, error) {
+			sender.host = cfg.(*syslog.RawConfig).Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
 			return sink, nil
 		},
-		OpenLogStream: func(_ base.APICaller, _ params.LogStreamConfig, controllerUUID string) (logforwarder.LogStream, error) {
+		OpenLogStream: func(_ base.APICaller, cfg params.LogStreamConfig, controllerUUID string) (logforwarder.LogStream, error) {
 			c.Assert(controllerUUID, gc.Equals, "feebdaed-2f18-4fd2-967d-db9663db7bea")
+			s.streamCfgs = append(s.streamCfgs, cfg)
 			return stream, nil
 		},
 	}
@@ -153,6 +166,79 @@ func (s *LogForwarderSuite) TestConfigChange(c *gc.C) {
 	})
 }
 
+func (s *LogForwarderSuite) TestFilter(c *gc.C) {
+	api := &mockLogForwardConfig{
+		modelConfig: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"logforward-level":          "info",
+			"logforward-exclude":        "machine-0",
+			"logforward-include-module": "juju.worker",
+		}),
+		enabled: true,
+		host:    "10.0.0.1",
+	}
+	s.stream.addRecords(c, s.rec)
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.sender.waitForSend(c)
+	workertest.CleanKill(c, lf)
+	c.Assert(s.streamCfgs, jc.DeepEquals, []params.LogStreamConfig{{
+		AllModels:          true,
+		Sink:               "juju-log-forward",
+		MaxLookbackRecords: 100,
+		Level:              "INFO",
+		ExcludeEntity:      []string{"machine-0"},
+		IncludeModule:      []string{"juju.worker"},
+	}})
+}
+
+func (s *LogForwarderSuite) TestFilterChange(c *gc.C) {
+	rec0 := s.rec
+	rec1 := s.rec
+	rec1.ID = 11
+	rec2 := s.rec
+	rec2.ID = 12
+
+	api := &mockLogForwardConfig{
+		enabled: true,
+		host:    "10.0.0.1",
+	}
+	streams := []*stubStream{s.stream, newStubStream()}
+	args := s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender)
+	args.OpenLogStream = func(_ base.APICaller, cfg params.LogStreamConfig, _ string) (logforwarder.LogStream, error) {
+		stream := streams[len(s.streamCfgs)]
+		s.streamCfgs = append(s.streamCfgs, cfg)
+		return stream, nil
+	}
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	streams[0].addRecords(c, rec0)
+	s.sender.waitForSend(c)
+
+	// Change the filter while the stream is waiting for a record.
+	// The stream is closed and reopened with the new filter, even
+	// though no record matching the old filter arrives.
+	api.modelConfig = coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"logforward-level": "warning",
+	})
+	api.changes <- struct{}{}
+	s.sender.waitForClose(c)
+	streams[1].addRecords(c, rec1, rec2)
+	s.sender.waitForSend(c)
+	s.sender.waitForSend(c)
+
+	workertest.CleanKill(c, lf)
+	c.Assert(s.streamCfgs, gc.HasLen, 2)
+	c.Check(s.streamCfgs[0].Level, gc.Equals, "")
+	c.Check(s.streamCfgs[1].Level, gc.Equals, "WARNING")
+	streams[0].stub.CheckCallNames(c, "Next", "Next", "Close")
+	s.sender.stub.CheckCallNames(c, "Send", "Close", "Send", "Send", "Close")
+	c.Check(s.sender.stub.Calls()[2].Args[0].([]logfwd.Record)[0].ID, gc.Equals, rec1.ID)
+}
+
 func (s *LogForwarderSuite) TestNotEnabled(c *gc.C) {
 	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgs(c, nil, s.sender))
 	c.Assert(err, jc.ErrorIsNil)
@@ -208,9 +294,10 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 }
 
 type mockLogForwardConfig struct {
-	enabled bool
-	host    string
-	changes chan struct{}
+	modelConfig *config.Config
+	enabled     bool
+	host        string
+	changes     chan struct{}
 }
 
 type mockWatcher struct {
@@ -249,25 +336,35 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
+func (c *mockLogForwardConfig) ModelConfig() (*config.Config, error) {
+	return c.modelConfig, nil
+}
+
+func (c *mockLogForwardConfig) sinkConfig() (logforwarder.LogSinkConfig, bool) {
+	if !c.enabled {
+		return nil, false
+	}
 	return &syslog.RawConfig{
 		Enabled:    c.enabled,
 		Host:       c.host,
 		CACert:     coretesting.CACert,
 		ClientCert: coretesting.ServerCert,
 		ClientKey:  coretesting.ServerKey,
-	}, true, nil
+	}, true
 }
 
 type stubStream struct {
-	stub     *testing.Stub
-	nextRecs chan logfwd.Record
+	stub      *testing.Stub
+	nextRecs  chan logfwd.Record
+	closed    chan struct{}
+	closeOnce sync.Once
 }
 
 func newStubStream() *stubStream {
 	return &stubStream{
 		stub:     new(testing.Stub),
 		nextRecs: make(chan logfwd.Record, 16),
+		closed:   make(chan struct{}),
 	}
 }
 
@@ -277,12 +374,23 @@ func (s *stubStream) addRecords(c *gc.C, recs ...logfwd.Record) {
 	}
 }
 
+func (s *stubStream) Close() error {
+	s.stub.AddCall("Close")
+	s.closeOnce.Do(func() { close(s.closed) })
+	return errors.Trace(s.stub.NextErr())
+}
+
 func (s *stubStream) Next() ([]logfwd.Record, error) {
 	s.stub.AddCall("Next")
 	if err := s.stub.NextErr(); err != nil {
 		return []logfwd.Record{}, errors.Trace(err)
 	}
-	return []logfwd.Record{<-s.nextRecs}, nil
+	select {
+	case rec := <-s.nextRecs:
+		return []logfwd.Record{rec}, nil
+	case <-s.closed:
+		return nil, errors.New("stream closed")
+	}
 }
 
 type stubSender struct {
@@ -4,7 +4,7 @@
 package logforwarder
 
 import (
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/watcher"
 )
 
@@ -14,20 +14,38 @@ type LogForwardConfig interface {
 	// log forward configuration to change.
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
-	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
+	// ModelConfig returns the current model configuration, from
+	// which each log sink's configuration is extracted.
+	ModelConfig() (*config.Config, error)
 }
 
+// LogSinkSpec describes a kind of log sink to which records may
+// be forwarded.
 type LogSinkSpec struct {
 	// Name is the name of the log sink.
 	Name string
 
+	// Config is a function that extracts the log sink's config from
+	// the model config.
+	Config LogSinkConfigFn
+
 	// OpenFn is a function that opens a log sink.
 	OpenFn LogSinkFn
 }
 
+// LogSinkConfig is the configuration of a single log sink.
+type LogSinkConfig interface {
+	// Validate ensures that the config is valid.
+	Validate() error
+}
+
+// LogSinkConfigFn is a function that extracts a log sink's config
+// from the model config. It returns false if forwarding to the
+// sink is not enabled.
+type LogSinkConfigFn func(cfg *config.Config) (LogSinkConfig, bool)
+
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg LogSinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
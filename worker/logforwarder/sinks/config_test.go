@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/elasticsearch"
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/logfwd/syslog"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestNotEnabled(c *gc.C) {
+	modelCfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"syslog-host":       "10.0.0.1:6514",
+		"elasticsearch-url": "https://10.0.0.2:9200",
+		"gelf-host":         "10.0.0.3",
+	})
+	_, ok := sinks.SyslogConfig(modelCfg)
+	c.Check(ok, jc.IsFalse)
+	_, ok = sinks.ElasticsearchConfig(modelCfg)
+	c.Check(ok, jc.IsFalse)
+	_, ok = sinks.GELFConfig(modelCfg)
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestOnlyConfiguredSinksEnabled(c *gc.C) {
+	modelCfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"logforward-enabled": true,
+		"elasticsearch-url":  "https://10.0.0.2:9200",
+	})
+	_, ok := sinks.SyslogConfig(modelCfg)
+	c.Check(ok, jc.IsFalse)
+	_, ok = sinks.GELFConfig(modelCfg)
+	c.Check(ok, jc.IsFalse)
+
+	cfg, ok := sinks.ElasticsearchConfig(modelCfg)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(cfg, jc.DeepEquals, &elasticsearch.RawConfig{
+		Enabled: true,
+		URL:     "https://10.0.0.2:9200",
+	})
+}
+
+func (s *ConfigSuite) TestAllSinksEnabled(c *gc.C) {
+	modelCfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"logforward-enabled": true,
+		"syslog-host":        "10.0.0.1:6514",
+		"syslog-ca-cert":     coretesting.CACert,
+		"syslog-client-cert": coretesting.ServerCert,
+		"syslog-client-key":  coretesting.ServerKey,
+		"elasticsearch-url":  "https://10.0.0.2:9200",
+		"gelf-host":          "10.0.0.3",
+		"gelf-protocol":      "tcp",
+	})
+
+	cfg, ok := sinks.SyslogConfig(modelCfg)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(cfg.(*syslog.RawConfig).Host, gc.Equals, "10.0.0.1:6514")
+
+	cfg, ok = sinks.ElasticsearchConfig(modelCfg)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(cfg.(*elasticsearch.RawConfig).URL, gc.Equals, "https://10.0.0.2:9200")
+
+	cfg, ok = sinks.GELFConfig(modelCfg)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(cfg, jc.DeepEquals, &gelf.RawConfig{
+		Enabled:  true,
+		Host:     "10.0.0.3",
+		Protocol: "tcp",
+	})
+}
+
+func (s *ConfigSuite) TestOpenWrongConfig(c *gc.C) {
+	_, err := sinks.OpenGELF(&syslog.RawConfig{})
+	c.Assert(err, gc.ErrorMatches, `expected gelf config, got \*syslog.RawConfig`)
+}
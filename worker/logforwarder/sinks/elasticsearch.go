@@ -0,0 +1,42 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/elasticsearch"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// ElasticsearchConfig returns the Elasticsearch sink's config, and
+// whether forwarding to Elasticsearch is enabled.
+func ElasticsearchConfig(modelCfg *config.Config) (logforwarder.LogSinkConfig, bool) {
+	cfg, ok := modelCfg.LogFwdElasticsearch()
+	if !ok || !cfg.Enabled {
+		return nil, false
+	}
+	return cfg, true
+}
+
+// OpenElasticsearch returns a sink used to receive log messages to be
+// forwarded to an Elasticsearch cluster.
+func OpenElasticsearch(sinkCfg logforwarder.LogSinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*elasticsearch.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected elasticsearch config, got %T", sinkCfg)
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := elasticsearch.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sink := &logforwarder.LogSink{
+		SendCloser: client,
+	}
+	return sink, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// GELFConfig returns the Graylog (GELF) sink's config, and whether
+// forwarding to Graylog is enabled.
+func GELFConfig(modelCfg *config.Config) (logforwarder.LogSinkConfig, bool) {
+	cfg, ok := modelCfg.LogFwdGELF()
+	if !ok || !cfg.Enabled {
+		return nil, false
+	}
+	return cfg, true
+}
+
+// OpenGELF returns a sink used to receive log messages to be forwarded
+// to a Graylog server.
+func OpenGELF(sinkCfg logforwarder.LogSinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*gelf.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected gelf config, got %T", sinkCfg)
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := gelf.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sink := &logforwarder.LogSink{
+		SendCloser: client,
+	}
+	return sink, nil
+}
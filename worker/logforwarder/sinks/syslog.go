@@ -6,13 +6,28 @@ package sinks
 import (
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/worker/logforwarder"
 )
 
+// SyslogConfig returns the syslog sink's config, and whether forwarding
+// to syslog is enabled.
+func SyslogConfig(modelCfg *config.Config) (logforwarder.LogSinkConfig, bool) {
+	cfg, ok := modelCfg.LogFwdSyslog()
+	if !ok || !cfg.Enabled || cfg.Host == "" {
+		return nil, false
+	}
+	return cfg, true
+}
+
 // OpenSyslog returns a sink used to receive log messages to be forwarded.
-func OpenSyslog(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+func OpenSyslog(sinkCfg logforwarder.LogSinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*syslog.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected syslog config, got %T", sinkCfg)
+	}
 	if !cfg.Enabled {
 		return nil, errors.New("log forwarding not enabled")
 	}
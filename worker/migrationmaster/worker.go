@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/tools"
@@ -412,6 +413,15 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 		return errors.Annotate(err, "failed to import model into target controller")
 	}
 
+	// The model's quotas are not part of its description, so they
+	// are set on the imported model separately.
+	if serialized.Quotas != (quota.Resources{}) {
+		err = targetClient.SetQuotas(modelUUID, serialized.Quotas)
+		if err != nil {
+			return errors.Annotate(err, "failed to set model quotas in target controller")
+		}
+	}
+
 	w.setInfoStatus("uploading model binaries into target controller")
 	wrapper := &uploadWrapper{targetClient, modelUUID}
 	err = w.config.UploadBinaries(migration.UploadBinariesConfig{
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/resource/resourcetesting"
 	coretesting "github.com/juju/juju/testing"
@@ -159,6 +160,7 @@ func (s *Suite) SetUpTest(c *gc.C) {
 		stub:          s.stub,
 		controllerTag: targetControllerTag,
 		logStream:     &mockStream{},
+		facadeVersion: 2,
 	}
 	s.connectionErr = nil
 
@@ -468,6 +470,24 @@ func (s *Suite) TestQUIESCETargetChecksFail(c *gc.C) {
 	))
 }
 
+func (s *Suite) TestQUIESCETargetCannotHoldQuotas(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.QUIESCE))
+	s.facade.quotas = quota.Resources{Units: 10}
+	s.connection.facadeVersion = 1
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Prechecks", nil},
+			{"facade.ModelInfo", nil},
+			apiOpenControllerCall,
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
 func (s *Suite) TestExportFailure(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
 	s.facade.exportErr = errors.New("boom")
@@ -516,6 +536,30 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 	))
 }
 
+func (s *Suite) TestSetQuotasFailure(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.facade.quotas = quota.Resources{Units: 10}
+	s.connection.setQuotasErr = errors.New("boom")
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			{"MigrationTarget.SetQuotas", []interface{}{
+				params.SetModelQuotas{
+					ModelTag: modelTag.String(),
+					Quotas:   params.ModelQuotas{Units: 10},
+				},
+			}},
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
 func (s *Suite) TestVALIDATIONMinionWaitWatchError(c *gc.C) {
 	s.checkMinionWaitWatchError(c, coremigration.VALIDATION)
 }
@@ -1054,6 +1098,7 @@ type stubMasterFacade struct {
 	minionReportsErr      error
 
 	exportedResources []coremigration.SerializedModelResource
+	quotas            quota.Resources
 }
 
 func (f *stubMasterFacade) triggerWatcher() {
@@ -1140,6 +1185,7 @@ func (f *stubMasterFacade) ModelInfo() (coremigration.ModelInfo, error) {
 		Name:         modelName,
 		Owner:        ownerTag,
 		AgentVersion: modelVersion,
+		Quotas:       f.quotas,
 	}, nil
 }
 
@@ -1155,6 +1201,7 @@ func (f *stubMasterFacade) Export() (coremigration.SerializedModel, error) {
 			version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
 		},
 		Resources: f.exportedResources,
+		Quotas:    f.quotas,
 	}, nil
 }
 
@@ -1210,7 +1257,9 @@ type stubConnection struct {
 	stub          *jujutesting.Stub
 	prechecksErr  error
 	importErr     error
+	setQuotasErr  error
 	controllerTag names.ControllerTag
+	facadeVersion int
 
 	streamErr error
 	logStream *mockStream
@@ -1220,7 +1269,7 @@ type stubConnection struct {
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
-	return 1
+	return c.facadeVersion
 }
 
 func (c *stubConnection) APICall(objType string, version int, id, request string, params, response interface{}) error {
@@ -1232,6 +1281,8 @@ func (c *stubConnection) APICall(objType string, version int, id, request string
 			return c.prechecksErr
 		case "Import":
 			return c.importErr
+		case "SetQuotas":
+			return c.setQuotasErr
 		case "Activate", "AdoptResources":
 			return nil
 		case "LatestLogTime":
@@ -104,6 +104,14 @@ func (opc *operationCallbacks) FailAction(actionId, message string) error {
 	return err
 }
 
+// ActionStatus is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) ActionStatus(actionId string) (string, error) {
+	if !names.IsValidAction(actionId) {
+		return "", errors.Errorf("invalid action id %q", actionId)
+	}
+	return opc.u.st.ActionStatus(names.NewActionTag(actionId))
+}
+
 // GetArchiveInfo is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) GetArchiveInfo(charmURL *corecharm.URL) (charm.BundleInfo, error) {
 	ch, err := opc.u.st.Charm(charmURL)
@@ -5,6 +5,7 @@ package operation
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -20,11 +21,22 @@ type FactoryParams struct {
 	Callbacks      Callbacks
 	Abort          <-chan struct{}
 	MetricSpoolDir string
+
+	// Metrics, if set, records the hooks and actions run by the
+	// factory's operations, as timed by Clock.
+	Metrics Metrics
+	Clock   clock.Clock
 }
 
 // NewFactory returns a Factory that creates Operations backed by the supplied
 // parameters.
 func NewFactory(params FactoryParams) Factory {
+	if params.Metrics == nil {
+		params.Metrics = NopMetrics{}
+	}
+	if params.Clock == nil {
+		params.Clock = clock.WallClock
+	}
 	return &factory{
 		config: params,
 	}
@@ -81,6 +93,8 @@ func (f *factory) NewRunHook(hookInfo hook.Info) (Operation, error) {
 		info:          hookInfo,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
+		metrics:       f.config.Metrics,
+		clock:         f.config.Clock,
 	}, nil
 }
 
@@ -102,6 +116,8 @@ func (f *factory) NewAction(actionId string) (Operation, error) {
 		actionId:      actionId,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
+		metrics:       f.config.Metrics,
+		clock:         f.config.Clock,
 	}, nil
 }
 
@@ -148,6 +148,11 @@ type Callbacks interface {
 	// RunActions operations.
 	FailAction(actionId, message string) error
 
+	// ActionStatus returns the current status of the supplied action.
+	// It's only used by RunAction operations, to learn whether the
+	// action has been cancelled while running.
+	ActionStatus(actionId string) (string, error)
+
 	// GetArchiveInfo is used to find out how to download a charm archive. It's
 	// only used by Deploy operations.
 	GetArchiveInfo(charmURL *corecharm.URL) (charm.BundleInfo, error)
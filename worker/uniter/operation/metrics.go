@@ -0,0 +1,49 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"time"
+
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+)
+
+// Outcomes of running an action, as reported to Metrics.
+const (
+	// ActionCompleted means the action ran without calling action-fail.
+	ActionCompleted = "completed"
+
+	// ActionFailed means the action called action-fail.
+	ActionFailed = "failed"
+
+	// ActionErrored means the action could not be run to completion.
+	ActionErrored = "error"
+
+	// ActionAborted means the action was cancelled while running,
+	// and stopped.
+	ActionAborted = "aborted"
+)
+
+// Metrics records measurements of the hooks and actions executed by
+// operations.
+type Metrics interface {
+	// HookCompleted records that a hook of the given kind ran for the
+	// given duration. Hooks not implemented by the charm are not
+	// recorded.
+	HookCompleted(kind hooks.Kind, duration time.Duration, failed bool)
+
+	// ActionCompleted records that an action ran for the given
+	// duration with the given outcome.
+	ActionCompleted(duration time.Duration, outcome string)
+}
+
+// NopMetrics is a Metrics that records nothing. It is used when no
+// Metrics are supplied.
+type NopMetrics struct{}
+
+// HookCompleted is part of the Metrics interface.
+func (NopMetrics) HookCompleted(hooks.Kind, time.Duration, bool) {}
+
+// ActionCompleted is part of the Metrics interface.
+func (NopMetrics) ActionCompleted(time.Duration, string) {}
@@ -5,17 +5,26 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
+// actionStatusPollInterval is how often the status of a running action
+// is checked, to find out whether it has been cancelled.
+const actionStatusPollInterval = 5 * time.Second
+
 type runAction struct {
 	actionId string
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
+	metrics       Metrics
+	clock         clock.Clock
 
 	name   string
 	runner runner.Runner
@@ -72,7 +81,17 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
+	started := ra.clock.Now()
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ra.abortIfCancelled(stop)
+	}()
 	err := ra.runner.RunAction(ra.name)
+	close(stop)
+	<-done
+	ra.metrics.ActionCompleted(ra.clock.Now().Sub(started), ra.outcome(err))
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
 		// be handled inside the Runner, and returned as nil.
@@ -86,6 +105,53 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// abortIfCancelled polls the status of the action until stop is closed,
+// and aborts the action if it is cancelled while running.
+func (ra *runAction) abortIfCancelled(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ra.clock.After(actionStatusPollInterval):
+		}
+		status, err := ra.callbacks.ActionStatus(ra.actionId)
+		if errors.IsNotImplemented(err) {
+			// The controller cannot tell us about cancellation.
+			return
+		} else if err != nil {
+			logger.Warningf("cannot get status of action %q: %v", ra.actionId, err)
+			continue
+		}
+		if status != params.ActionAborting {
+			continue
+		}
+		logger.Infof("aborting action %q", ra.actionId)
+		if err := ra.runner.Context().AbortAction(); err != nil {
+			// The action's process may not have started yet, so
+			// try again next time.
+			logger.Warningf("cannot abort action %q: %v", ra.actionId, err)
+			continue
+		}
+		return
+	}
+}
+
+// outcome returns the outcome of an action run which returned err,
+// for reporting to metrics.
+func (ra *runAction) outcome(err error) string {
+	if err != nil {
+		return ActionErrored
+	}
+	actionData, err := ra.runner.Context().ActionData()
+	if err == nil && actionData.Aborted {
+		return ActionAborted
+	}
+	if err == nil && actionData.Failed {
+		return ActionFailed
+	}
+	return ActionCompleted
+}
+
 // Commit preserves the recorded hook, and returns a neutral state.
 // Commit is part of the Operation interface.
 func (ra *runAction) Commit(state State) (*State, error) {
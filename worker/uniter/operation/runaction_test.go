@@ -4,12 +4,16 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -227,6 +231,81 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 	}
 }
 
+func (s *RunActionSuite) TestExecuteMetrics(c *gc.C) {
+	for i, test := range []struct {
+		runErr  error
+		failed  bool
+		outcome string
+	}{{
+		outcome: operation.ActionCompleted,
+	}, {
+		failed:  true,
+		outcome: operation.ActionFailed,
+	}, {
+		runErr:  errors.New("blam"),
+		outcome: operation.ActionErrored,
+	}} {
+		c.Logf("test %d", i)
+		runnerFactory := NewRunActionRunnerFactory(test.runErr)
+		runnerFactory.MockNewActionRunner.runner.context.(*MockContext).actionData.Failed = test.failed
+		metrics := &MockMetrics{}
+		factory := operation.NewFactory(operation.FactoryParams{
+			RunnerFactory: runnerFactory,
+			Callbacks:     &RunActionCallbacks{},
+			Metrics:       metrics,
+			Clock:         &stepClock{step: time.Minute},
+		})
+		op, err := factory.NewAction(someActionId)
+		c.Assert(err, jc.ErrorIsNil)
+		midState, err := op.Prepare(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+
+		op.Execute(*midState)
+		metrics.CheckCalls(c, []testing.StubCall{
+			{"ActionCompleted", []interface{}{time.Minute, test.outcome}},
+		})
+	}
+}
+
+func (s *RunActionSuite) TestExecuteAbort(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	mockRunner := runnerFactory.MockNewActionRunner.runner
+	mockContext := mockRunner.context.(*MockContext)
+	abort := make(chan struct{})
+	mockRunner.MockRunAction.wait = abort
+	mockContext.abort = abort
+	metrics := &MockMetrics{}
+	clock := testing.NewClock(time.Now())
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{actionStatus: params.ActionAborting},
+		Metrics:       metrics,
+		Clock:         clock,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := make(chan error)
+	go func() {
+		_, err := op.Execute(*midState)
+		result <- err
+	}()
+	err = clock.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-result:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for action to be aborted")
+	}
+	mockContext.CheckCallNames(c, "Prepare", "AbortAction")
+	metrics.CheckCalls(c, []testing.StubCall{
+		{"ActionCompleted", []interface{}{5 * time.Second, operation.ActionAborted}},
+	})
+}
+
 func (s *RunActionSuite) TestCommit(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
 	"github.com/juju/juju/status"
@@ -21,6 +22,8 @@ type runHook struct {
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
+	metrics       Metrics
+	clock         clock.Clock
 
 	name   string
 	runner runner.Runner
@@ -91,7 +94,9 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	ranHook := true
 	step := Done
 
+	started := rh.clock.Now()
 	err := rh.runner.RunHook(rh.name)
+	duration := rh.clock.Now().Sub(started)
 	cause := errors.Cause(err)
 	switch {
 	case context.IsMissingHookError(cause):
@@ -105,12 +110,14 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case err == nil:
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
+		rh.metrics.HookCompleted(rh.info.Kind, duration, true)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
 		return nil, ErrHookFailed
 	}
 
 	if ranHook {
 		logger.Infof("ran %q hook", rh.name)
+		rh.metrics.HookCompleted(rh.info.Kind, duration, false)
 		rh.callbacks.NotifyHookCompleted(rh.name, rh.runner.Context())
 	} else {
 		logger.Infof("skipped %q hook (missing)", rh.name)
@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -272,6 +274,40 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
+func (s *RunHookSuite) TestExecuteMetrics(c *gc.C) {
+	for i, test := range []struct {
+		runErr error
+		calls  []testing.StubCall
+	}{{
+		calls: []testing.StubCall{{"HookCompleted", []interface{}{hooks.ConfigChanged, time.Second, false}}},
+	}, {
+		runErr: errors.New("graaargh"),
+		calls:  []testing.StubCall{{"HookCompleted", []interface{}{hooks.ConfigChanged, time.Second, true}}},
+	}, {
+		runErr: context.NewMissingHookError("blah-blah"),
+	}} {
+		c.Logf("test %d", i)
+		metrics := &MockMetrics{}
+		factory := operation.NewFactory(operation.FactoryParams{
+			RunnerFactory: NewRunHookRunnerFactory(test.runErr),
+			Callbacks: &ExecuteHookCallbacks{
+				PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+				MockNotifyHookCompleted: &MockNotify{},
+				MockNotifyHookFailed:    &MockNotify{},
+			},
+			Metrics: metrics,
+			Clock:   &stepClock{step: time.Second},
+		})
+		op, err := factory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = op.Prepare(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+
+		op.Execute(operation.State{})
+		metrics.CheckCalls(c, test.calls)
+	}
+}
+
 func (s *RunHookSuite) testExecuteSuccess(
 	c *gc.C, before, after operation.State, setStatusCalled bool,
 ) {
@@ -4,8 +4,11 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
+	"github.com/juju/utils/clock"
 	utilexec "github.com/juju/utils/exec"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
@@ -116,6 +119,7 @@ type RunActionCallbacks struct {
 	operation.Callbacks
 	*MockFailAction
 	executingMessage string
+	actionStatus     string
 }
 
 func (cb *RunActionCallbacks) FailAction(actionId, message string) error {
@@ -127,6 +131,10 @@ func (cb *RunActionCallbacks) SetExecutingStatus(message string) error {
 	return nil
 }
 
+func (cb *RunActionCallbacks) ActionStatus(actionId string) (string, error) {
+	return cb.actionStatus, nil
+}
+
 type RunCommandsCallbacks struct {
 	operation.Callbacks
 	executingMessage string
@@ -282,6 +290,7 @@ type MockContext struct {
 	actionData      *context.ActionData
 	setStatusCalled bool
 	status          jujuc.StatusInfo
+	abort           chan struct{}
 }
 
 func (mock *MockContext) ActionData() (*context.ActionData, error) {
@@ -318,13 +327,24 @@ func (mock *MockContext) Prepare() error {
 	return mock.NextErr()
 }
 
+func (mock *MockContext) AbortAction() error {
+	mock.MethodCall(mock, "AbortAction")
+	mock.actionData.Aborted = true
+	close(mock.abort)
+	return nil
+}
+
 type MockRunAction struct {
 	gotName *string
 	err     error
+	wait    chan struct{}
 }
 
 func (mock *MockRunAction) Call(actionName string) error {
 	mock.gotName = &actionName
+	if mock.wait != nil {
+		<-mock.wait
+	}
 	return mock.err
 }
 
@@ -436,6 +456,35 @@ func NewRunHookRunnerFactory(runErr error) *MockRunnerFactory {
 	}
 }
 
+type MockMetrics struct {
+	testing.Stub
+}
+
+func (mock *MockMetrics) HookCompleted(kind hooks.Kind, duration time.Duration, failed bool) {
+	mock.AddCall("HookCompleted", kind, duration, failed)
+}
+
+func (mock *MockMetrics) ActionCompleted(duration time.Duration, outcome string) {
+	mock.AddCall("ActionCompleted", duration, outcome)
+}
+
+// stepClock is a clock whose time advances by step each time
+// it is read.
+type stepClock struct {
+	clock.Clock
+	now  time.Time
+	step time.Duration
+}
+
+func (c *stepClock) Now() time.Time {
+	c.now = c.now.Add(c.step)
+	return c.now
+}
+
+func (c *stepClock) After(time.Duration) <-chan time.Time {
+	return nil
+}
+
 type MockSendResponse struct {
 	gotResponse **utilexec.ExecResponse
 	gotErr      *error
@@ -25,6 +25,10 @@ type LoopConfig struct {
 	Abort         <-chan struct{}
 	OnIdle        func() error
 	CharmDirGuard fortress.Guard
+
+	// OnIteration, if set, is called each time the loop consults
+	// the resolver after a remote state change.
+	OnIteration func()
 }
 
 // Loop repeatedly waits for remote state changes, feeding the local and
@@ -56,6 +60,9 @@ func Loop(cfg LoopConfig, localState *LocalState) error {
 	}
 
 	for {
+		if cfg.OnIteration != nil {
+			cfg.OnIteration()
+		}
 		rf.RemoteState = cfg.Watcher.Snapshot()
 		rf.LocalState.State = cfg.Executor.State()
 
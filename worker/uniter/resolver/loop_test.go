@@ -21,13 +21,14 @@ import (
 type LoopSuite struct {
 	testing.BaseSuite
 
-	resolver  resolver.Resolver
-	watcher   *mockRemoteStateWatcher
-	opFactory *mockOpFactory
-	executor  *mockOpExecutor
-	charmURL  *charm.URL
-	abort     chan struct{}
-	onIdle    func() error
+	resolver    resolver.Resolver
+	watcher     *mockRemoteStateWatcher
+	opFactory   *mockOpFactory
+	executor    *mockOpExecutor
+	charmURL    *charm.URL
+	abort       chan struct{}
+	onIdle      func() error
+	onIteration func()
 }
 
 var _ = gc.Suite(&LoopSuite{})
@@ -58,6 +59,7 @@ func (s *LoopSuite) loop() (resolver.LocalState, error) {
 		Abort:         s.abort,
 		OnIdle:        s.onIdle,
 		CharmDirGuard: &mockCharmDirGuard{},
+		OnIteration:   s.onIteration,
 	}, &localState)
 	return localState, err
 }
@@ -124,6 +126,17 @@ func (s *LoopSuite) TestErrWaitingNoOnIdle(c *gc.C) {
 	c.Assert(onIdleCalled, jc.IsFalse)
 }
 
+func (s *LoopSuite) TestOnIteration(c *gc.C) {
+	var iterations int
+	s.onIteration = func() {
+		iterations++
+	}
+	close(s.abort)
+	_, err := s.loop()
+	c.Assert(err, gc.Equals, resolver.ErrLoopAborted)
+	c.Assert(iterations, gc.Equals, 1)
+}
+
 func (s *LoopSuite) TestInitialFinalLocalState(c *gc.C) {
 	var local resolver.LocalState
 	s.resolver = resolver.ResolverFunc(func(
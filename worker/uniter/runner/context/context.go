@@ -724,6 +724,23 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 	return ctxErr
 }
 
+// AbortAction kills the process running the current action, so that
+// the action is recorded as aborted, keeping any results it has already
+// set. It returns ErrNoProcess if the action's process has not been
+// started.
+func (ctx *HookContext) AbortAction() error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	if ctx.GetProcess() == nil {
+		return ErrNoProcess
+	}
+	mutex.Lock()
+	ctx.actionData.Aborted = true
+	mutex.Unlock()
+	return ctx.killCharmHook()
+}
+
 // finalizeAction passes back the final status of an Action hook to state.
 // It wraps any errors which occurred in normal behavior of the Action run;
 // only errors passed in unhandledErr will be returned.
@@ -747,6 +764,16 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 		status = params.ActionFailed
 	}
 
+	// An aborted action's process was killed, so any error from it is
+	// expected and not reported.
+	mutex.Lock()
+	aborted := ctx.actionData.Aborted
+	mutex.Unlock()
+	if aborted {
+		status = params.ActionAborted
+		message = "action aborted"
+	}
+
 	callErr := ctx.state.ActionFinish(tag, status, results, message)
 	if callErr != nil {
 		unhandledErr = errors.Wrap(unhandledErr, callErr)
@@ -305,6 +305,21 @@ func (s *InterfaceSuite) TestSetActionMessage(c *gc.C) {
 	c.Check(actionData.ResultsMessage, gc.Equals, "because reasons")
 }
 
+func (s *InterfaceSuite) TestAbortActionNoProcess(c *gc.C) {
+	hctx := context.GetStubActionContext(nil)
+	err := hctx.AbortAction()
+	c.Assert(err, gc.Equals, context.ErrNoProcess)
+	actionData, err := hctx.ActionData()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actionData.Aborted, jc.IsFalse)
+}
+
+func (s *InterfaceSuite) TestAbortActionNotAction(c *gc.C) {
+	ctx := s.GetContext(c, -1, "").(*context.HookContext)
+	err := ctx.AbortAction()
+	c.Assert(err, gc.ErrorMatches, "not running an action")
+}
+
 func (s *InterfaceSuite) TestRequestRebootAfterHook(c *gc.C) {
 	var killed bool
 	p := &mockProcess{func() error {
@@ -50,6 +50,7 @@ type Context interface {
 	HookVars(paths context.Paths) ([]string, error)
 	ActionData() (*context.ActionData, error)
 	SetProcess(process context.HookProcess)
+	AbortAction() error
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
 
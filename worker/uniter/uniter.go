@@ -52,6 +52,27 @@ type UniterExecutionObserver interface {
 	HookFailed(hookName string)
 }
 
+// Metrics records measurements of the uniter's activity, such as
+// those reported to Prometheus by unitermetrics.Collector.
+type Metrics interface {
+	operation.Metrics
+
+	// ResolverLoopIteration records an iteration of the resolver loop.
+	ResolverLoopIteration()
+
+	// MachineLockAcquired records that the machine lock was acquired
+	// after waiting for the given duration.
+	MachineLockAcquired(wait time.Duration)
+}
+
+// nopMetrics is the Metrics used when none are supplied.
+type nopMetrics struct {
+	operation.NopMetrics
+}
+
+func (nopMetrics) ResolverLoopIteration()            {}
+func (nopMetrics) MachineLockAcquired(time.Duration) {}
+
 // Uniter implements the capabilities of the unit agent. It is not intended to
 // implement the actual *behaviour* of the unit agent; that responsibility is
 // delegated to Mode values, which are expected to react to events and direct
@@ -102,6 +123,10 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// metrics records measurements of the hooks, actions and resolver
+	// loop run by the uniter.
+	metrics Metrics
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -118,6 +143,7 @@ type UniterParams struct {
 	NewOperationExecutor NewExecutorFunc
 	TranslateResolverErr func(error) error
 	Clock                clock.Clock
+	Metrics              Metrics
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
@@ -134,6 +160,10 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 	if translateResolverErr == nil {
 		translateResolverErr = func(err error) error { return err }
 	}
+	metrics := uniterParams.Metrics
+	if metrics == nil {
+		metrics = nopMetrics{}
+	}
 
 	u := &Uniter{
 		st:                   uniterParams.UniterFacade,
@@ -148,6 +178,7 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 		observer:             uniterParams.Observer,
 		clock:                uniterParams.Clock,
 		downloader:           uniterParams.Downloader,
+		metrics:              metrics,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -319,6 +350,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				Abort:         u.catacomb.Dying(),
 				OnIdle:        onIdle,
 				CharmDirGuard: u.charmDirGuard,
+				OnIteration:   u.metrics.ResolverLoopIteration,
 			}, &localState)
 
 			err = u.translateResolverErr(err)
@@ -477,6 +509,8 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		Callbacks:      &operationCallbacks{u},
 		Abort:          u.catacomb.Dying(),
 		MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
+		Metrics:        u.metrics,
+		Clock:          u.clock,
 	})
 
 	operationExecutor, err := u.newOperationExecutor(u.paths.State.OperationsFile, u.getServiceCharmURL, u.acquireExecutionLock)
@@ -550,10 +584,12 @@ func (u *Uniter) acquireExecutionLock() (mutex.Releaser, error) {
 		Cancel: u.catacomb.Dying(),
 	}
 	logger.Debugf("acquire lock %q for uniter hook execution", u.hookLockName)
+	started := u.clock.Now()
 	releaser, err := mutex.Acquire(spec)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	u.metrics.MachineLockAcquired(u.clock.Now().Sub(started))
 	logger.Debugf("lock %q acquired", u.hookLockName)
 	return releaser, nil
 }
@@ -0,0 +1,153 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package unitermetrics provides a Prometheus collector for the hooks,
+// actions and resolver loop of a uniter.
+package unitermetrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+)
+
+const (
+	kindLabel    = "kind"
+	outcomeLabel = "outcome"
+)
+
+// durationBuckets are the histogram buckets used for hook, action and
+// lock wait durations, ranging from 100ms to about 14 minutes.
+var durationBuckets = prometheus.ExponentialBuckets(0.1, 2, 14)
+
+// Collector is a prometheus.Collector that collects metrics about the
+// operations run by a uniter. It implements uniter.Metrics.
+type Collector struct {
+	hooksTotal         *prometheus.CounterVec
+	hookFailuresTotal  *prometheus.CounterVec
+	hookDuration       *prometheus.HistogramVec
+	actionsTotal       *prometheus.CounterVec
+	actionDuration     *prometheus.HistogramVec
+	resolverIterations prometheus.Counter
+	machineLockWait    prometheus.Histogram
+}
+
+// New returns a new Collector.
+func New() *Collector {
+	return &Collector{
+		hooksTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "juju",
+				Subsystem: "uniter",
+				Name:      "hooks_total",
+				Help:      "Total number of hooks executed.",
+			},
+			[]string{kindLabel},
+		),
+		hookFailuresTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "juju",
+				Subsystem: "uniter",
+				Name:      "hook_failures_total",
+				Help:      "Total number of hooks which failed.",
+			},
+			[]string{kindLabel},
+		),
+		hookDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "juju",
+				Subsystem: "uniter",
+				Name:      "hook_duration_seconds",
+				Help:      "Time taken to execute hooks.",
+				Buckets:   durationBuckets,
+			},
+			[]string{kindLabel},
+		),
+		actionsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "juju",
+				Subsystem: "uniter",
+				Name:      "actions_total",
+				Help:      "Total number of actions run, by outcome.",
+			},
+			[]string{outcomeLabel},
+		),
+		actionDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "juju",
+				Subsystem: "uniter",
+				Name:      "action_duration_seconds",
+				Help:      "Time taken to run actions.",
+				Buckets:   durationBuckets,
+			},
+			[]string{outcomeLabel},
+		),
+		resolverIterations: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: "juju",
+				Subsystem: "uniter",
+				Name:      "resolver_loop_iterations_total",
+				Help:      "Total number of iterations of the resolver loop.",
+			},
+		),
+		machineLockWait: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: "juju",
+				Subsystem: "uniter",
+				Name:      "machine_lock_wait_seconds",
+				Help:      "Time spent waiting to acquire the machine lock.",
+				Buckets:   durationBuckets,
+			},
+		),
+	}
+}
+
+// HookCompleted is part of the uniter.Metrics interface.
+func (c *Collector) HookCompleted(kind hooks.Kind, duration time.Duration, failed bool) {
+	labels := prometheus.Labels{kindLabel: string(kind)}
+	c.hooksTotal.With(labels).Inc()
+	if failed {
+		c.hookFailuresTotal.With(labels).Inc()
+	}
+	c.hookDuration.With(labels).Observe(duration.Seconds())
+}
+
+// ActionCompleted is part of the uniter.Metrics interface.
+func (c *Collector) ActionCompleted(duration time.Duration, outcome string) {
+	labels := prometheus.Labels{outcomeLabel: outcome}
+	c.actionsTotal.With(labels).Inc()
+	c.actionDuration.With(labels).Observe(duration.Seconds())
+}
+
+// ResolverLoopIteration is part of the uniter.Metrics interface.
+func (c *Collector) ResolverLoopIteration() {
+	c.resolverIterations.Inc()
+}
+
+// MachineLockAcquired is part of the uniter.Metrics interface.
+func (c *Collector) MachineLockAcquired(wait time.Duration) {
+	c.machineLockWait.Observe(wait.Seconds())
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.hooksTotal.Describe(ch)
+	c.hookFailuresTotal.Describe(ch)
+	c.hookDuration.Describe(ch)
+	c.actionsTotal.Describe(ch)
+	c.actionDuration.Describe(ch)
+	c.resolverIterations.Describe(ch)
+	c.machineLockWait.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.hooksTotal.Collect(ch)
+	c.hookFailuresTotal.Collect(ch)
+	c.hookDuration.Collect(ch)
+	c.actionsTotal.Collect(ch)
+	c.actionDuration.Collect(ch)
+	c.resolverIterations.Collect(ch)
+	c.machineLockWait.Collect(ch)
+}
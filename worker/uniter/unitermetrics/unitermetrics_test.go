@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package unitermetrics_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/unitermetrics"
+)
+
+type collectorSuite struct {
+	testing.IsolationSuite
+	collector *unitermetrics.Collector
+}
+
+var _ = gc.Suite(&collectorSuite{})
+
+func (s *collectorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.collector = unitermetrics.New()
+}
+
+func (s *collectorSuite) TestDescribe(c *gc.C) {
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		defer close(ch)
+		s.collector.Describe(ch)
+	}()
+	var names []string
+	for desc := range ch {
+		names = append(names, desc.String())
+	}
+	c.Assert(names, gc.HasLen, 7)
+	for i, name := range []string{
+		"juju_uniter_hooks_total",
+		"juju_uniter_hook_failures_total",
+		"juju_uniter_hook_duration_seconds",
+		"juju_uniter_actions_total",
+		"juju_uniter_action_duration_seconds",
+		"juju_uniter_resolver_loop_iterations_total",
+		"juju_uniter_machine_lock_wait_seconds",
+	} {
+		c.Check(names[i], gc.Matches, `.*fqName: "`+name+`".*`)
+	}
+}
+
+func (s *collectorSuite) TestCollect(c *gc.C) {
+	s.collector.HookCompleted(hooks.ConfigChanged, 2*time.Second, false)
+	s.collector.HookCompleted(hooks.ConfigChanged, 4*time.Second, true)
+	s.collector.HookCompleted(hooks.Install, time.Second, false)
+	s.collector.ActionCompleted(3*time.Second, operation.ActionFailed)
+	s.collector.ResolverLoopIteration()
+	s.collector.ResolverLoopIteration()
+	s.collector.MachineLockAcquired(500 * time.Millisecond)
+
+	metrics := s.collect(c)
+
+	c.Check(metrics["juju_uniter_hooks_total"], jc.DeepEquals, []sample{
+		{labels: "kind=config-changed", value: 2},
+		{labels: "kind=install", value: 1},
+	})
+	c.Check(metrics["juju_uniter_hook_failures_total"], jc.DeepEquals, []sample{
+		{labels: "kind=config-changed", value: 1},
+	})
+	c.Check(metrics["juju_uniter_hook_duration_seconds"], jc.DeepEquals, []sample{
+		{labels: "kind=config-changed", value: 6, count: 2},
+		{labels: "kind=install", value: 1, count: 1},
+	})
+	c.Check(metrics["juju_uniter_actions_total"], jc.DeepEquals, []sample{
+		{labels: "outcome=failed", value: 1},
+	})
+	c.Check(metrics["juju_uniter_action_duration_seconds"], jc.DeepEquals, []sample{
+		{labels: "outcome=failed", value: 3, count: 1},
+	})
+	c.Check(metrics["juju_uniter_resolver_loop_iterations_total"], jc.DeepEquals, []sample{
+		{value: 2},
+	})
+	c.Check(metrics["juju_uniter_machine_lock_wait_seconds"], jc.DeepEquals, []sample{
+		{value: 0.5, count: 1},
+	})
+}
+
+// sample summarises a collected metric: its labels, and either its
+// counter value or its histogram sum and sample count.
+type sample struct {
+	labels string
+	value  float64
+	count  uint64
+}
+
+func (s *collectorSuite) collect(c *gc.C) map[string][]sample {
+	registry := prometheus.NewPedanticRegistry()
+	err := registry.Register(s.collector)
+	c.Assert(err, jc.ErrorIsNil)
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := make(map[string][]sample)
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			result[family.GetName()] = append(result[family.GetName()], toSample(m))
+		}
+	}
+	return result
+}
+
+func toSample(m *dto.Metric) sample {
+	var s sample
+	for _, label := range m.GetLabel() {
+		s.labels = label.GetName() + "=" + label.GetValue()
+	}
+	if h := m.GetHistogram(); h != nil {
+		s.value = h.GetSampleSum()
+		s.count = h.GetSampleCount()
+	} else {
+		s.value = m.GetCounter().GetValue()
+	}
+	return s
+}